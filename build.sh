@@ -18,13 +18,15 @@
 
 echo -e "\n\033[33m"Start building of Pharos-Anchor"\033[0m"
 export GOPATH=$PWD
+VERSION=$(git describe --tags --always 2>/dev/null || echo dev)
 
 function func_cleanup(){
     rm -rf rm -rf $GOPATH/src/golang.org
 }
 
 function build(){
-    CGO_ENABLED=0 GOOS=linux go build -o pharos-anchor -a -ldflags '-extldflags "-static"'  src/main/main.go
+    CGO_ENABLED=0 GOOS=linux go build -o pharos-anchor -a -ldflags "-extldflags \"-static\" -X controller/health.Version=$VERSION"  src/main/main.go && \
+    CGO_ENABLED=0 GOOS=linux go build -o anchorctl -a -ldflags '-extldflags "-static"'  anchorctl
     if [ $? -ne 0 ]; then
         echo -e "\n\033[31m"build fail"\033[0m"
         func_cleanup
@@ -0,0 +1,317 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package main
+
+import (
+	"client"
+	"commons/models"
+	"context"
+	"flag"
+	"io/ioutil"
+	"strconv"
+)
+
+func init() {
+	resources["apps"] = map[string]command{
+		"list": {
+			usage:       "[--node ID | --group ID] [--image NAME]",
+			description: "List applications of a node or a group, or search all applications.",
+			setup:       listApps,
+		},
+		"get": {
+			usage:       "APP_ID --node ID | --group ID",
+			description: "Show an application of a node or a group.",
+			setup:       getApp,
+		},
+		"deploy": {
+			usage:       "-f FILE --node ID | --group ID [--event URL]",
+			description: "Deploy an application described by a docker-compose file.",
+			setup:       deployAppCommand,
+		},
+		"start": {
+			usage:       "APP_ID --node ID | --group ID",
+			description: "Start an application.",
+			setup:       controlApp("start", (*client.Client).StartNodeApp, (*client.Client).StartGroupApp),
+		},
+		"stop": {
+			usage:       "APP_ID --node ID | --group ID",
+			description: "Stop an application.",
+			setup:       controlApp("stop", (*client.Client).StopNodeApp, (*client.Client).StopGroupApp),
+		},
+		"update": {
+			usage:       "APP_ID --node ID | --group ID",
+			description: "Pull the latest images of an application and restart it.",
+			setup:       controlApp("update", updateNodeApp, (*client.Client).UpdateGroupApp),
+		},
+		"delete": {
+			usage:       "APP_ID --node ID | --group ID",
+			description: "Delete an application.",
+			setup:       controlApp("delete", (*client.Client).DeleteNodeApp, (*client.Client).DeleteGroupApp),
+		},
+	}
+}
+
+// targetFlags select either a node or a group which an application belongs to.
+type targetFlags struct {
+	node  string
+	group string
+}
+
+func (t *targetFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&t.node, "node", "", "id of a node")
+	fs.StringVar(&t.group, "group", "", "id of a group")
+}
+
+func (t *targetFlags) check() error {
+	if len(t.node) == 0 == (len(t.group) == 0) {
+		return usageError{"either --node or --group is required"}
+	}
+	return nil
+}
+
+func appsTable(apps []models.App) table {
+	t := table{headers: []string{"ID", "IMAGES", "SERVICES"}}
+	for _, app := range apps {
+		t.rows = append(t.rows, []string{app.ID, join(app.Images), join(app.Services)})
+	}
+	return t
+}
+
+func appStatesTable(apps []models.AppState) table {
+	t := table{headers: []string{"ID", "STATE"}}
+	for _, app := range apps {
+		t.rows = append(t.rows, []string{app.ID, cell(app.State)})
+	}
+	return t
+}
+
+func groupAppsTable(apps []models.GroupApp) table {
+	t := table{headers: []string{"ID", "MEMBERS"}}
+	for _, app := range apps {
+		t.rows = append(t.rows, []string{app.ID, join(app.Members)})
+	}
+	return t
+}
+
+func servicesTable(node string, info models.AppInfo, t *table) {
+	if len(info.Services) == 0 {
+		t.rows = append(t.rows, []string{node, info.ID, cell(info.State), "-", "-"})
+	}
+	for _, service := range info.Services {
+		t.rows = append(t.rows, []string{node, info.ID, cell(info.State), service.Name, cell(service.State.Status)})
+	}
+}
+
+func appInfoTable(node string, info models.AppInfo) table {
+	t := table{headers: []string{"NODE", "ID", "STATE", "SERVICE", "STATUS"}}
+	servicesTable(node, info, &t)
+	return t
+}
+
+func groupAppInfoTable(info models.GroupAppInfo) table {
+	t := table{headers: []string{"NODE", "ID", "STATE", "SERVICE", "STATUS"}}
+	for _, resp := range info.Responses {
+		servicesTable(cell(resp.ID), resp.AppInfo, &t)
+	}
+	return t
+}
+
+// deploymentTable shows which members of a group succeeded or failed.
+func deploymentTable(resp models.DeploymentResponse) table {
+	t := table{headers: []string{"NODE", "CODE", "MESSAGE"}}
+	for _, node := range resp.Responses {
+		t.rows = append(t.rows, []string{node.ID, strconv.Itoa(int(node.Code)), cell(node.Message)})
+	}
+	return t
+}
+
+func listApps(fs *flag.FlagSet) func(env *environment, args []string) error {
+	filter := &filterFlags{}
+	filter.register(fs, client.QUERY_APP_ID)
+
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 0); err != nil {
+			return err
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		// Applications of a node or a group have their states,
+		// unless other conditions require the search API.
+		switch {
+		case len(filter.node) != 0 && len(filter.group) == 0 && len(filter.image) == 0:
+			apps, err := c.ListNodeApps(env.ctx, filter.node)
+			if err != nil {
+				return err
+			}
+			return env.print(models.NodeAppList{Apps: apps}, func() table { return appStatesTable(apps) })
+		case len(filter.group) != 0 && len(filter.node) == 0 && len(filter.image) == 0:
+			apps, err := c.ListGroupApps(env.ctx, filter.group)
+			if err != nil {
+				return err
+			}
+			return env.print(models.GroupAppList{Apps: apps}, func() table { return groupAppsTable(apps) })
+		}
+
+		apps, err := c.SearchApps(env.ctx, filter.query())
+		if err != nil {
+			return err
+		}
+		return env.print(models.AppList{Apps: apps}, func() table { return appsTable(apps) })
+	}
+}
+
+func getApp(fs *flag.FlagSet) func(env *environment, args []string) error {
+	target := &targetFlags{}
+	target.register(fs)
+
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		if err := target.check(); err != nil {
+			return err
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		if len(target.node) != 0 {
+			info, err := c.GetNodeApp(env.ctx, target.node, args[0])
+			if err != nil {
+				return err
+			}
+			return env.print(info, func() table { return appInfoTable(target.node, info) })
+		}
+
+		info, err := c.GetGroupApp(env.ctx, target.group, args[0])
+		if err != nil {
+			return err
+		}
+		return env.print(info, func() table { return groupAppInfoTable(info) })
+	}
+}
+
+func deployAppCommand(fs *flag.FlagSet) func(env *environment, args []string) error {
+	target := &targetFlags{}
+	target.register(fs)
+	file := fs.String("f", "", "docker-compose file describing the application, or - for stdin")
+	event := fs.String("event", "", "URL which receives deployment events, only for a node")
+
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 0); err != nil {
+			return err
+		}
+		return deployApp(env, target, *file, *event)
+	}
+}
+
+// deployApp deploys an application described by file to the target.
+func deployApp(env *environment, target *targetFlags, file string, event string) error {
+	if err := target.check(); err != nil {
+		return err
+	}
+	if len(file) == 0 {
+		return usageError{"-f is required"}
+	}
+	if len(event) != 0 && len(target.node) == 0 {
+		return usageError{"--event is supported only for a node"}
+	}
+
+	var description []byte
+	var err error
+	if file == "-" {
+		description, err = ioutil.ReadAll(env.stdin)
+	} else {
+		description, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		return err
+	}
+
+	c, err := env.client()
+	if err != nil {
+		return err
+	}
+
+	if len(target.node) != 0 {
+		info, err := c.DeployNodeApp(env.ctx, target.node, description, event)
+		if err != nil {
+			return err
+		}
+		return env.print(info, func() table { return appInfoTable(target.node, info) })
+	}
+
+	resp, err := c.DeployGroupApp(env.ctx, target.group, description)
+	if err != nil {
+		return err
+	}
+	if len(resp.Responses) != 0 {
+		return env.print(resp, func() table { return deploymentTable(resp) })
+	}
+	return env.print(resp, func() table {
+		return table{headers: []string{"ID"}, rows: [][]string{{resp.ID}}}
+	})
+}
+
+func updateNodeApp(c *client.Client, ctx context.Context, nodeId string, appId string) error {
+	return c.UpdateNodeApp(ctx, nodeId, appId, nil)
+}
+
+// controlApp returns a command which calls nodeFn or groupFn for an application.
+func controlApp(verb string,
+	nodeFn func(c *client.Client, ctx context.Context, nodeId string, appId string) error,
+	groupFn func(c *client.Client, ctx context.Context, groupId string, appId string) (models.DeploymentResponse, error),
+) func(fs *flag.FlagSet) func(env *environment, args []string) error {
+
+	return func(fs *flag.FlagSet) func(env *environment, args []string) error {
+		target := &targetFlags{}
+		target.register(fs)
+
+		return func(env *environment, args []string) error {
+			if err := expectArgs(args, 1); err != nil {
+				return err
+			}
+			if err := target.check(); err != nil {
+				return err
+			}
+			c, err := env.client()
+			if err != nil {
+				return err
+			}
+
+			if len(target.node) != 0 {
+				if err = nodeFn(c, env.ctx, target.node, args[0]); err != nil {
+					return err
+				}
+				return env.done("%s of app %s on node %s is done", verb, args[0], target.node)
+			}
+
+			resp, err := groupFn(c, env.ctx, target.group, args[0])
+			if err != nil {
+				return err
+			}
+			if len(resp.Responses) != 0 {
+				return env.print(resp, func() table { return deploymentTable(resp) })
+			}
+			return env.done("%s of app %s on group %s is done", verb, args[0], target.group)
+		}
+	}
+}
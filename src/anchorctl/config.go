@@ -0,0 +1,193 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package main
+
+import (
+	"errors"
+	"flag"
+)
+
+func init() {
+	resources["config"] = map[string]command{
+		"get-contexts": {
+			description: "List contexts in the context file.",
+			setup:       getContexts,
+		},
+		"current-context": {
+			description: "Show the current context.",
+			setup:       currentContext,
+		},
+		"use-context": {
+			usage:       "NAME",
+			description: "Change the current context.",
+			setup:       useContext,
+		},
+		"set-context": {
+			usage:       "NAME [--server URL] [--token TOKEN]",
+			description: "Add a context or change the anchor of a context.",
+			setup:       setContext,
+		},
+		"delete-context": {
+			usage:       "NAME",
+			description: "Delete a context.",
+			setup:       deleteContext,
+		},
+	}
+}
+
+func contextsTable(config *Config) table {
+	t := table{headers: []string{"CURRENT", "NAME", "SERVER"}}
+	for _, context := range config.Contexts {
+		current := ""
+		if context.Name == config.CurrentContext {
+			current = "*"
+		}
+		t.rows = append(t.rows, []string{current, context.Name, context.Server})
+	}
+	return t
+}
+
+func getContexts(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 0); err != nil {
+			return err
+		}
+		config, err := loadConfig(env.options.configPath())
+		if err != nil {
+			return err
+		}
+
+		// Tokens are not shown.
+		masked := &Config{CurrentContext: config.CurrentContext, Contexts: make([]Context, 0)}
+		for _, context := range config.Contexts {
+			masked.Contexts = append(masked.Contexts, Context{Name: context.Name, Server: context.Server})
+		}
+		return env.print(masked.toMap(), func() table { return contextsTable(masked) })
+	}
+}
+
+// toMap converts config into a value whose JSON form names fields as in the context file.
+func (config *Config) toMap() map[string]interface{} {
+	contexts := make([]map[string]interface{}, 0)
+	for _, context := range config.Contexts {
+		contexts = append(contexts, map[string]interface{}{"name": context.Name, "server": context.Server})
+	}
+	return map[string]interface{}{"current-context": config.CurrentContext, "contexts": contexts}
+}
+
+func currentContext(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 0); err != nil {
+			return err
+		}
+		config, err := loadConfig(env.options.configPath())
+		if err != nil {
+			return err
+		}
+		if len(config.CurrentContext) == 0 {
+			return errors.New("current context is not set")
+		}
+
+		return env.print(map[string]interface{}{"current-context": config.CurrentContext}, func() table {
+			return table{headers: []string{"NAME"}, rows: [][]string{{config.CurrentContext}}}
+		})
+	}
+}
+
+func useContext(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		path := env.options.configPath()
+		config, err := loadConfig(path)
+		if err != nil {
+			return err
+		}
+		if config.find(args[0]) < 0 {
+			return errors.New("context not found: " + args[0])
+		}
+
+		config.CurrentContext = args[0]
+		if err = saveConfig(path, config); err != nil {
+			return err
+		}
+		return env.done("switched to context %s", args[0])
+	}
+}
+
+// setContext stores the global --server and --token flags into the context file
+// instead of using them for a request.
+func setContext(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		path := env.options.configPath()
+		config, err := loadConfig(path)
+		if err != nil {
+			return err
+		}
+
+		i := config.find(args[0])
+		if i < 0 {
+			config.Contexts = append(config.Contexts, Context{Name: args[0], Server: DEFAULT_SERVER})
+			i = len(config.Contexts) - 1
+		}
+		if len(env.options.server) != 0 {
+			config.Contexts[i].Server = env.options.server
+		}
+		if len(env.options.token) != 0 {
+			config.Contexts[i].Token = env.options.token
+		}
+		if len(config.CurrentContext) == 0 {
+			config.CurrentContext = args[0]
+		}
+
+		if err = saveConfig(path, config); err != nil {
+			return err
+		}
+		return env.done("context %s is set", args[0])
+	}
+}
+
+func deleteContext(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		path := env.options.configPath()
+		config, err := loadConfig(path)
+		if err != nil {
+			return err
+		}
+
+		i := config.find(args[0])
+		if i < 0 {
+			return errors.New("context not found: " + args[0])
+		}
+		config.Contexts = append(config.Contexts[:i], config.Contexts[i+1:]...)
+		if config.CurrentContext == args[0] {
+			config.CurrentContext = ""
+		}
+
+		if err = saveConfig(path, config); err != nil {
+			return err
+		}
+		return env.done("context %s is deleted", args[0])
+	}
+}
@@ -0,0 +1,154 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package main
+
+import (
+	"client"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	CONFIG_ENV          = "ANCHORCTL_CONFIG"
+	DEFAULT_CONFIG_PATH = ".anchorctl/config"
+	DEFAULT_SERVER      = "http://127.0.0.1:48099"
+)
+
+// Config is the content of a context file, which keeps the addresses of
+// several anchors and the one in use like kubeconfig.
+type Config struct {
+	CurrentContext string    `yaml:"current-context"`
+	Contexts       []Context `yaml:"contexts"`
+}
+
+// Context is an anchor which anchorctl talks to.
+type Context struct {
+	Name   string `yaml:"name"`
+	Server string `yaml:"server"`
+	Token  string `yaml:"token,omitempty"`
+}
+
+// configPath returns the path of the context file.
+// The --config flag is preferred to $ANCHORCTL_CONFIG, which is preferred to the default.
+func (opts *options) configPath() string {
+	if len(opts.config) != 0 {
+		return opts.config
+	}
+	if path := os.Getenv(CONFIG_ENV); len(path) != 0 {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return DEFAULT_CONFIG_PATH
+	}
+	return filepath.Join(home, DEFAULT_CONFIG_PATH)
+}
+
+// loadConfig reads the context file.
+// An empty configuration is returned if the file does not exist.
+func loadConfig(path string) (*Config, error) {
+	config := &Config{}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return nil, errors.New("invalid context file " + path + ": " + err.Error())
+	}
+	return config, nil
+}
+
+// saveConfig writes config into the context file, which may contain tokens
+// and so is readable only by the owner.
+func saveConfig(path string, config *Config) error {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// find returns the index of the context specified by name, or -1.
+func (config *Config) find(name string) int {
+	for i, context := range config.Contexts {
+		if context.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// resolveContext returns the context to use, applying the --server and --token flags.
+// DEFAULT_SERVER is used when neither the flag nor any context is set.
+func (opts *options) resolveContext() (Context, error) {
+	config, err := loadConfig(opts.configPath())
+	if err != nil {
+		return Context{}, err
+	}
+
+	name := opts.context
+	if len(name) == 0 {
+		name = config.CurrentContext
+	}
+
+	context := Context{Server: DEFAULT_SERVER}
+	if len(name) != 0 {
+		i := config.find(name)
+		switch {
+		case i >= 0:
+			context = config.Contexts[i]
+		case len(opts.context) != 0:
+			return Context{}, errors.New("context not found: " + name)
+		}
+	}
+
+	if len(opts.server) != 0 {
+		context.Server = opts.server
+	}
+	if len(opts.token) != 0 {
+		context.Token = opts.token
+	}
+	return context, nil
+}
+
+// client returns a client of the anchor of the resolved context.
+func (env *environment) client() (*client.Client, error) {
+	context, err := env.options.resolveContext()
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]client.Option, 0)
+	if len(context.Token) != 0 {
+		options = append(options, client.WithToken(context.Token))
+	}
+	return client.New(context.Server, options...), nil
+}
@@ -0,0 +1,196 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package main
+
+import (
+	"client"
+	"commons/models"
+	"flag"
+)
+
+func init() {
+	resources["groups"] = map[string]command{
+		"list": {
+			usage:       "[--node ID] [--app ID] [--image NAME]",
+			description: "List groups, optionally filtered.",
+			setup:       listGroups,
+		},
+		"get": {
+			usage:       "GROUP_ID",
+			description: "Show a group.",
+			setup:       getGroup,
+		},
+		"create": {
+			usage:       "NAME",
+			description: "Create a group.",
+			setup:       createGroup,
+		},
+		"delete": {
+			usage:       "GROUP_ID",
+			description: "Delete a group.",
+			setup:       deleteGroup,
+		},
+		"join": {
+			usage:       "GROUP_ID NODE_ID...",
+			description: "Add nodes to a group.",
+			setup:       joinGroup,
+		},
+		"leave": {
+			usage:       "GROUP_ID NODE_ID...",
+			description: "Remove nodes from a group.",
+			setup:       leaveGroup,
+		},
+		"deploy": {
+			usage:       "GROUP_ID -f FILE",
+			description: "Deploy an application to all members of a group.",
+			setup:       deployGroup,
+		},
+	}
+}
+
+func groupsTable(groups []models.Group) table {
+	t := table{headers: []string{"ID", "NAME", "MEMBERS"}}
+	for _, group := range groups {
+		t.rows = append(t.rows, []string{group.ID, cell(group.Name), join(group.Members)})
+	}
+	return t
+}
+
+func listGroups(fs *flag.FlagSet) func(env *environment, args []string) error {
+	filter := &filterFlags{}
+	filter.register(fs, client.QUERY_GROUP_ID)
+
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 0); err != nil {
+			return err
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		var groups []models.Group
+		if query := filter.query(); query != nil {
+			groups, err = c.SearchGroups(env.ctx, query)
+		} else {
+			groups, err = c.ListGroups(env.ctx)
+		}
+		if err != nil {
+			return err
+		}
+		return env.print(models.GroupList{Groups: groups}, func() table { return groupsTable(groups) })
+	}
+}
+
+func getGroup(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		group, err := c.GetGroup(env.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return env.print(group, func() table { return groupsTable([]models.Group{group}) })
+	}
+}
+
+func createGroup(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		group, err := c.CreateGroup(env.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return env.print(group, func() table { return groupsTable([]models.Group{group}) })
+	}
+}
+
+func deleteGroup(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		if err = c.DeleteGroup(env.ctx, args[0]); err != nil {
+			return err
+		}
+		return env.done("group %s is deleted", args[0])
+	}
+}
+
+func joinGroup(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if len(args) < 2 {
+			return usageError{"expected a group and at least one node"}
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		if err = c.JoinGroup(env.ctx, args[0], args[1:]); err != nil {
+			return err
+		}
+		return env.done("%d node(s) joined group %s", len(args)-1, args[0])
+	}
+}
+
+func leaveGroup(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if len(args) < 2 {
+			return usageError{"expected a group and at least one node"}
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		if err = c.LeaveGroup(env.ctx, args[0], args[1:]); err != nil {
+			return err
+		}
+		return env.done("%d node(s) left group %s", len(args)-1, args[0])
+	}
+}
+
+func deployGroup(fs *flag.FlagSet) func(env *environment, args []string) error {
+	file := fs.String("f", "", "docker-compose file describing the application, or - for stdin")
+
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		target := &targetFlags{group: args[0]}
+		return deployApp(env, target, *file, "")
+	}
+}
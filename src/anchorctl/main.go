@@ -0,0 +1,227 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// anchorctl is a command-line tool for operators of Pharos Anchor.
+// Subcommands mirror the REST APIs, and the address of the anchor and
+// the token sent with each request are read from a context file.
+//
+//	anchorctl [global flags] <resource> <verb> [flags] [args]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"text/tabwriter"
+)
+
+const (
+	EXIT_OK    = 0
+	EXIT_ERROR = 1
+	EXIT_USAGE = 2
+)
+
+// options are the flags which are accepted by every command.
+type options struct {
+	output  string
+	context string
+	server  string
+	token   string
+	config  string
+}
+
+// environment is passed to each command and holds what it needs to run.
+type environment struct {
+	ctx     context.Context
+	options *options
+	stdin   io.Reader
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+// command is a verb of a resource, e.g. 'list' of 'nodes'.
+type command struct {
+	usage       string
+	description string
+	setup       func(fs *flag.FlagSet) func(env *environment, args []string) error
+}
+
+// NO_VERB is the verb of a resource which is a command by itself, e.g. 'subscribe'.
+const NO_VERB = ""
+
+// usageError is returned when a command is called with invalid arguments.
+type usageError struct {
+	message string
+}
+
+func (e usageError) Error() string {
+	return e.message
+}
+
+var resources = map[string]map[string]command{}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run parses args and runs the matched command, and returns an exit code.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	opts := &options{}
+	env := &environment{ctx: ctx, options: opts, stdin: stdin, stdout: stdout, stderr: stderr}
+
+	global := newFlagSet("anchorctl", opts, stderr)
+	if err := global.Parse(args); err != nil {
+		return EXIT_USAGE
+	}
+
+	args = global.Args()
+	if len(args) == 0 {
+		printUsage(stderr)
+		return EXIT_USAGE
+	}
+
+	verbs, exists := resources[args[0]]
+	if !exists {
+		fmt.Fprintf(stderr, "unknown resource: %s\n", args[0])
+		printUsage(stderr)
+		return EXIT_USAGE
+	}
+
+	name := args[0]
+	cmd, exists := verbs[NO_VERB]
+	if exists {
+		args = args[1:]
+	} else {
+		if len(args) < 2 {
+			printUsage(stderr)
+			return EXIT_USAGE
+		}
+		name += " " + args[1]
+		if cmd, exists = verbs[args[1]]; !exists {
+			fmt.Fprintf(stderr, "unknown command: %s\n", name)
+			printUsage(stderr)
+			return EXIT_USAGE
+		}
+		args = args[2:]
+	}
+
+	fs := newFlagSet(name, opts, stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: anchorctl %s %s\n\n%s\n\nFlags:\n", name, cmd.usage, cmd.description)
+		fs.PrintDefaults()
+	}
+	action := cmd.setup(fs)
+
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return EXIT_USAGE
+	}
+
+	err = checkOutputFormat(opts.output)
+	if err == nil {
+		err = action(env, positional)
+	}
+
+	switch err.(type) {
+	case nil:
+		return EXIT_OK
+	case usageError:
+		fmt.Fprintf(stderr, "error: %s\n", err.Error())
+		fs.Usage()
+		return EXIT_USAGE
+	default:
+		fmt.Fprintf(stderr, "error: %s\n", err.Error())
+		return EXIT_ERROR
+	}
+}
+
+// newFlagSet creates a flag set which has the global flags bound to opts.
+func newFlagSet(name string, opts *options, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.output, "o", opts.outputOrDefault(), "output format: table, json or yaml")
+	fs.StringVar(&opts.context, "context", opts.context, "name of the context to use instead of the current context")
+	fs.StringVar(&opts.server, "server", opts.server, "address of the anchor, overriding the context")
+	fs.StringVar(&opts.token, "token", opts.token, "bearer token sent to the anchor, overriding the context")
+	fs.StringVar(&opts.config, "config", opts.config, "path of the context file (default $"+CONFIG_ENV+" or ~/"+DEFAULT_CONFIG_PATH+")")
+	fs.Usage = func() { printUsage(stderr) }
+	return fs
+}
+
+func (opts *options) outputOrDefault() string {
+	if len(opts.output) == 0 {
+		return OUTPUT_TABLE
+	}
+	return opts.output
+}
+
+// parseFlags parses args allowing flags to follow positional arguments,
+// and returns the positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// expectArgs returns usageError unless the number of positional arguments is n.
+func expectArgs(args []string, n int) error {
+	if len(args) != n {
+		return usageError{fmt.Sprintf("expected %d argument(s), got %d", n, len(args))}
+	}
+	return nil
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: anchorctl [global flags] <resource> <command> [flags] [args]")
+	fmt.Fprintln(w, "\nCommands:")
+
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 3, ' ', 0)
+	for _, name := range names {
+		verbs := make([]string, 0, len(resources[name]))
+		for verb := range resources[name] {
+			verbs = append(verbs, verb)
+		}
+		sort.Strings(verbs)
+		for _, verb := range verbs {
+			fmt.Fprintf(tw, "  %s %s\t%s\n", name, verb, resources[name][verb].description)
+		}
+	}
+	tw.Flush()
+	fmt.Fprintln(w, "\nRun 'anchorctl <resource> <command> -h' for flags of a command.")
+}
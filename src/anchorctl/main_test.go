@@ -0,0 +1,188 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const nodesBody = `{"nodes":[{"id":"node","ip":"127.0.0.1","apps":["app"],"status":"connected"}]}`
+
+func runCommand(t *testing.T, config string, args ...string) (int, string, string) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run(context.Background(), append([]string{"--config", config}, args...), strings.NewReader(""), stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func newServer(t *testing.T, path string, status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != path {
+			t.Errorf("Expected path: %s, actual path: %s", path, req.URL.Path)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestParseFlagsWithInterspersedArgs_ExpectPositionalArgs(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	node := fs.String("node", "", "")
+
+	args, err := parseFlags(fs, []string{"app", "--node", "id", "other"})
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	if !reflect.DeepEqual(args, []string{"app", "other"}) || *node != "id" {
+		t.Errorf("Unexpected result: %v, %s", args, *node)
+	}
+}
+
+func TestCalledNodesListWithOutputFormats_ExpectFormattedResult(t *testing.T) {
+	server := newServer(t, "/api/v1/management/nodes", http.StatusOK, nodesBody)
+	defer server.Close()
+	config := filepath.Join(t.TempDir(), "config")
+
+	testList := map[string]string{
+		"table": "ID     IP          STATUS      APPS\nnode   127.0.0.1   connected   app\n",
+		"json": "{\n  \"nodes\": [\n    {\n      \"id\": \"node\",\n      \"ip\": \"127.0.0.1\",\n" +
+			"      \"apps\": [\n        \"app\"\n      ],\n      \"status\": \"connected\"\n    }\n  ]\n}\n",
+		"yaml": "nodes:\n- apps:\n  - app\n  id: node\n  ip: 127.0.0.1\n  status: connected\n",
+	}
+
+	for format, expected := range testList {
+		t.Run(format, func(t *testing.T) {
+			code, stdout, stderr := runCommand(t, config, "--server", server.URL, "nodes", "list", "-o", format)
+			if code != EXIT_OK {
+				t.Fatalf("Unexpected exit code: %d, %s", code, stderr)
+			}
+			if stdout != expected {
+				t.Errorf("Expected output:\n%s\nactual output:\n%s", expected, stdout)
+			}
+		})
+	}
+}
+
+func TestCalledWithErrorResponse_ExpectErrorExitCode(t *testing.T) {
+	server := newServer(t, "/api/v1/management/nodes/node", http.StatusNotFound,
+		`{"code":"NotFound","message":"not found target: node"}`)
+	defer server.Close()
+
+	code, _, stderr := runCommand(t, filepath.Join(t.TempDir(), "config"), "nodes", "get", "node", "--server", server.URL)
+	if code != EXIT_ERROR {
+		t.Errorf("Expected exit code: %d, actual exit code: %d", EXIT_ERROR, code)
+	}
+	if !strings.Contains(stderr, "error: not found target: node") {
+		t.Errorf("Unexpected stderr: %s", stderr)
+	}
+}
+
+func TestCalledWithInvalidArgs_ExpectUsageExitCode(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config")
+	testList := map[string][]string{
+		"unknownResource": {"unknown", "list"},
+		"unknownCommand":  {"nodes", "unknown"},
+		"missingTarget":   {"apps", "start", "app"},
+		"bothTargets":     {"apps", "start", "app", "--node", "node", "--group", "group"},
+		"missingArg":      {"nodes", "get"},
+		"unknownOutput":   {"nodes", "list", "-o", "xml"},
+		"invalidProperty": {"nodes", "config", "node", "--set", "property"},
+	}
+
+	for name, args := range testList {
+		t.Run(name, func(t *testing.T) {
+			if code, _, _ := runCommand(t, config, args...); code != EXIT_USAGE {
+				t.Errorf("Expected exit code: %d, actual exit code: %d", EXIT_USAGE, code)
+			}
+		})
+	}
+}
+
+func TestCalledConfigCommands_ExpectContextFileUpdated(t *testing.T) {
+	server := newServer(t, "/api/v1/management/nodes", http.StatusOK, nodesBody)
+	defer server.Close()
+	config := filepath.Join(t.TempDir(), "anchorctl", "config")
+
+	steps := [][]string{
+		{"config", "set-context", "local", "--server", "http://127.0.0.1:1"},
+		{"config", "set-context", "test", "--server", server.URL, "--token", "token"},
+		{"config", "use-context", "test"},
+	}
+	for _, args := range steps {
+		if code, _, stderr := runCommand(t, config, args...); code != EXIT_OK {
+			t.Fatalf("Unexpected exit code of %v: %d, %s", args, code, stderr)
+		}
+	}
+
+	_, stdout, _ := runCommand(t, config, "config", "current-context")
+	if stdout != "NAME\ntest\n" {
+		t.Errorf("Unexpected current context: %s", stdout)
+	}
+
+	opts := &options{config: config}
+	context, err := opts.resolveContext()
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	expected := Context{Name: "test", Server: server.URL, Token: "token"}
+	if !reflect.DeepEqual(expected, context) {
+		t.Errorf("Expected context: %v, actual context: %v", expected, context)
+	}
+
+	if code, _, stderr := runCommand(t, config, "nodes", "list"); code != EXIT_OK {
+		t.Errorf("Unexpected exit code: %d, %s", code, stderr)
+	}
+	if code, _, _ := runCommand(t, config, "--context", "unknown", "nodes", "list"); code != EXIT_ERROR {
+		t.Errorf("Expected exit code: %d, actual exit code: %d", EXIT_ERROR, code)
+	}
+}
+
+func TestResolveContextWithoutConfig_ExpectDefaultServer(t *testing.T) {
+	opts := &options{config: filepath.Join(t.TempDir(), "config"), token: "token"}
+	context, err := opts.resolveContext()
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	if context.Server != DEFAULT_SERVER || context.Token != "token" {
+		t.Errorf("Unexpected context: %v", context)
+	}
+}
+
+func TestPropertyFlags_ExpectTypedValues(t *testing.T) {
+	properties := &propertyFlags{}
+	for _, pair := range []string{"devicename=edge", "pinginterval=10", "reverseproxy={\"enabled\":true}"} {
+		if err := properties.Set(pair); err != nil {
+			t.Fatalf("Unexpected err: %s", err.Error())
+		}
+	}
+
+	expected := propertyFlags{
+		{"devicename": "edge"},
+		{"pinginterval": float64(10)},
+		{"reverseproxy": map[string]interface{}{"enabled": true}},
+	}
+	if !reflect.DeepEqual(expected, *properties) {
+		t.Errorf("Expected properties: %v, actual properties: %v", expected, *properties)
+	}
+}
@@ -0,0 +1,256 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package main
+
+import (
+	"client"
+	"commons/models"
+	"encoding/json"
+	"flag"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const READ_ONLY = "readOnly"
+
+func init() {
+	resources["nodes"] = map[string]command{
+		"list": {
+			usage:       "[--group ID] [--app ID] [--image NAME]",
+			description: "List nodes, optionally filtered.",
+			setup:       listNodes,
+		},
+		"get": {
+			usage:       "NODE_ID",
+			description: "Show a node.",
+			setup:       getNode,
+		},
+		"reboot": {
+			usage:       "NODE_ID",
+			description: "Reboot the device of a node.",
+			setup:       rebootNode,
+		},
+		"restore": {
+			usage:       "NODE_ID",
+			description: "Restore the device of a node to its initial state.",
+			setup:       restoreNode,
+		},
+		"config": {
+			usage:       "NODE_ID [--set PROPERTY=VALUE]...",
+			description: "Show or change the configuration of a node.",
+			setup:       configNode,
+		},
+	}
+}
+
+// filterFlags are the search conditions accepted by list commands.
+type filterFlags struct {
+	node  string
+	group string
+	app   string
+	image string
+}
+
+// register adds flags of the conditions except the one named by exclude,
+// which is the id of the listed resource itself.
+func (f *filterFlags) register(fs *flag.FlagSet, exclude string) {
+	if exclude != client.QUERY_NODE_ID {
+		fs.StringVar(&f.node, "node", "", "id of a node")
+	}
+	if exclude != client.QUERY_GROUP_ID {
+		fs.StringVar(&f.group, "group", "", "id of a group")
+	}
+	if exclude != client.QUERY_APP_ID {
+		fs.StringVar(&f.app, "app", "", "id of an application")
+	}
+	fs.StringVar(&f.image, "image", "", "name of an image used by applications")
+}
+
+// query returns search conditions, or nil if none is given.
+func (f *filterFlags) query() url.Values {
+	query := url.Values{}
+	for key, value := range map[string]string{
+		client.QUERY_NODE_ID:    f.node,
+		client.QUERY_GROUP_ID:   f.group,
+		client.QUERY_APP_ID:     f.app,
+		client.QUERY_IMAGE_NAME: f.image,
+	} {
+		if len(value) != 0 {
+			query.Set(key, value)
+		}
+	}
+	if len(query) == 0 {
+		return nil
+	}
+	return query
+}
+
+func nodesTable(nodes []models.Node) table {
+	t := table{headers: []string{"ID", "IP", "STATUS", "APPS"}}
+	for _, node := range nodes {
+		t.rows = append(t.rows, []string{node.ID, node.IP, cell(node.Status), join(node.Apps)})
+	}
+	return t
+}
+
+func listNodes(fs *flag.FlagSet) func(env *environment, args []string) error {
+	filter := &filterFlags{}
+	filter.register(fs, client.QUERY_NODE_ID)
+
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 0); err != nil {
+			return err
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		var nodes []models.Node
+		if query := filter.query(); query != nil {
+			nodes, err = c.SearchNodes(env.ctx, query)
+		} else {
+			nodes, err = c.ListNodes(env.ctx)
+		}
+		if err != nil {
+			return err
+		}
+		return env.print(models.NodeList{Nodes: nodes}, func() table { return nodesTable(nodes) })
+	}
+}
+
+func getNode(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		node, err := c.GetNode(env.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return env.print(node, func() table { return nodesTable([]models.Node{node}) })
+	}
+}
+
+func rebootNode(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		if err = c.RebootNode(env.ctx, args[0]); err != nil {
+			return err
+		}
+		return env.done("node %s is rebooting", args[0])
+	}
+}
+
+func restoreNode(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		if err = c.RestoreNode(env.ctx, args[0]); err != nil {
+			return err
+		}
+		return env.done("node %s is restoring", args[0])
+	}
+}
+
+// propertyFlags collects PROPERTY=VALUE pairs given by repeated flags.
+type propertyFlags []map[string]interface{}
+
+func (p *propertyFlags) String() string {
+	return ""
+}
+
+// Set parses a pair. A value is decoded as JSON if possible so that
+// numbers and booleans keep their types, or is used as a string otherwise.
+func (p *propertyFlags) Set(pair string) error {
+	i := strings.Index(pair, "=")
+	if i <= 0 {
+		return usageError{"expected PROPERTY=VALUE: " + pair}
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(pair[i+1:]), &value); err != nil {
+		value = pair[i+1:]
+	}
+	*p = append(*p, map[string]interface{}{pair[:i]: value})
+	return nil
+}
+
+func configurationTable(config models.Configuration) table {
+	t := table{headers: []string{"PROPERTY", "VALUE", "READONLY"}}
+	for _, property := range config.Properties {
+		names := make([]string, 0, len(property))
+		for name := range property {
+			if name != READ_ONLY {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			t.rows = append(t.rows, []string{name, cell(property[name]), cell(property[READ_ONLY])})
+		}
+	}
+	return t
+}
+
+func configNode(fs *flag.FlagSet) func(env *environment, args []string) error {
+	properties := &propertyFlags{}
+	fs.Var(properties, "set", "PROPERTY=VALUE to change, can be repeated")
+
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		if len(*properties) != 0 {
+			config := models.Configuration{Properties: *properties}
+			if err = c.SetNodeConfiguration(env.ctx, args[0], config); err != nil {
+				return err
+			}
+			return env.done("configuration of node %s is changed", args[0])
+		}
+
+		config, err := c.GetNodeConfiguration(env.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return env.print(config, func() table { return configurationTable(config) })
+	}
+}
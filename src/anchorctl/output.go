@@ -0,0 +1,123 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	OUTPUT_TABLE = "table"
+	OUTPUT_JSON  = "json"
+	OUTPUT_YAML  = "yaml"
+)
+
+// table is a human readable form of a result.
+type table struct {
+	headers []string
+	rows    [][]string
+}
+
+func checkOutputFormat(format string) error {
+	switch format {
+	case OUTPUT_TABLE, OUTPUT_JSON, OUTPUT_YAML:
+		return nil
+	}
+	return usageError{"unknown output format: " + format}
+}
+
+// print writes value in the output format.
+// toTable is called only for the table format.
+func (env *environment) print(value interface{}, toTable func() table) error {
+	switch env.options.outputOrDefault() {
+	case OUTPUT_JSON:
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(env.stdout, string(data))
+		return err
+	case OUTPUT_YAML:
+		// Values are converted via JSON so that fields are named as in the REST APIs.
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		var object interface{}
+		err = yaml.Unmarshal(data, &object)
+		if err != nil {
+			return err
+		}
+		data, err = yaml.Marshal(object)
+		if err != nil {
+			return err
+		}
+		_, err = env.stdout.Write(data)
+		return err
+	default:
+		return env.printTable(toTable())
+	}
+}
+
+func (env *environment) printTable(t table) error {
+	w := tabwriter.NewWriter(env.stdout, 0, 4, 3, ' ', 0)
+	fmt.Fprintln(w, strings.Join(t.headers, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// done reports the result of a command which does not return anything.
+// Nothing is written unless the output format is table.
+func (env *environment) done(format string, args ...interface{}) error {
+	if env.options.outputOrDefault() != OUTPUT_TABLE {
+		return nil
+	}
+	_, err := fmt.Fprintf(env.stdout, format+"\n", args...)
+	return err
+}
+
+// join converts a list into a table cell.
+func join(list []string) string {
+	if len(list) == 0 {
+		return "-"
+	}
+	return strings.Join(list, ",")
+}
+
+// cell converts a value into a table cell.
+func cell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "-"
+	case string:
+		if len(v) == 0 {
+			return "-"
+		}
+		return v
+	case []interface{}, map[string]interface{}:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,104 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package main
+
+import (
+	"commons/models"
+	"flag"
+)
+
+func init() {
+	resources["registries"] = map[string]command{
+		"list": {
+			description: "List docker registries.",
+			setup:       listRegistries,
+		},
+		"add": {
+			usage:       "ADDRESS",
+			description: "Add a docker registry whose pushed images update applications.",
+			setup:       addRegistry,
+		},
+		"rm": {
+			usage:       "REGISTRY_ID",
+			description: "Remove a docker registry.",
+			setup:       removeRegistry,
+		},
+	}
+}
+
+func registriesTable(registries []models.Registry) table {
+	t := table{headers: []string{"ID", "URL"}}
+	for _, registry := range registries {
+		t.rows = append(t.rows, []string{registry.ID, registry.URL})
+	}
+	return t
+}
+
+func listRegistries(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 0); err != nil {
+			return err
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		registries, err := c.ListRegistries(env.ctx)
+		if err != nil {
+			return err
+		}
+		return env.print(models.RegistryList{Registries: registries}, func() table { return registriesTable(registries) })
+	}
+}
+
+func addRegistry(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		id, err := c.AddRegistry(env.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return env.print(models.IDResponse{ID: id}, func() table {
+			return registriesTable([]models.Registry{{ID: id, URL: args[0]}})
+		})
+	}
+}
+
+func removeRegistry(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		if err = c.DeleteRegistry(env.ctx, args[0]); err != nil {
+			return err
+		}
+		return env.done("registry %s is removed", args[0])
+	}
+}
@@ -0,0 +1,98 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package main
+
+import (
+	"commons/models"
+	"flag"
+	"strings"
+)
+
+func init() {
+	resources["subscribe"] = map[string]command{
+		NO_VERB: {
+			usage:       "--url URL --type node|app|resource [--status S1,S2] [--node ID] [--group ID] [--app ID] [--image NAME]",
+			description: "Subscribe to events of nodes or applications matched with the filters.",
+			setup:       subscribe,
+		},
+	}
+	resources["unsubscribe"] = map[string]command{
+		NO_VERB: {
+			usage:       "SUBSCRIBER_ID",
+			description: "Delete a subscription.",
+			setup:       unsubscribe,
+		},
+	}
+}
+
+func subscribe(fs *flag.FlagSet) func(env *environment, args []string) error {
+	filter := &filterFlags{}
+	filter.register(fs, "")
+	target := fs.String("url", "", "URL which receives events")
+	eventType := fs.String("type", "", "type of events: node, app or resource")
+	status := fs.String("status", "", "comma separated statuses of events, all if empty")
+
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 0); err != nil {
+			return err
+		}
+		if len(*target) == 0 || len(*eventType) == 0 {
+			return usageError{"--url and --type are required"}
+		}
+
+		req := models.SubscriptionRequest{
+			URL:   *target,
+			Event: models.Event{Type: *eventType, Status: make([]string, 0)},
+		}
+		if len(*status) != 0 {
+			req.Event.Status = strings.Split(*status, ",")
+		}
+
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.Subscribe(env.ctx, req, filter.query())
+		if err != nil {
+			return err
+		}
+		return env.print(resp, func() table {
+			if len(resp.Responses) != 0 {
+				return deploymentTable(models.DeploymentResponse{Responses: resp.Responses})
+			}
+			return table{headers: []string{"ID"}, rows: [][]string{{resp.ID}}}
+		})
+	}
+}
+
+func unsubscribe(fs *flag.FlagSet) func(env *environment, args []string) error {
+	return func(env *environment, args []string) error {
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		c, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		if err = c.Unsubscribe(env.ctx, args[0]); err != nil {
+			return err
+		}
+		return env.done("subscriber %s is deleted", args[0])
+	}
+}
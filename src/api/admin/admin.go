@@ -0,0 +1,99 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package admin
+
+import (
+	"api/common"
+	"commons/errors"
+	"commons/logger"
+	"commons/models"
+	"commons/results"
+	URL "commons/url"
+	"net/http"
+)
+
+type Command interface {
+	Handle(w http.ResponseWriter, req *http.Request)
+}
+
+type RequestHandler struct{}
+
+// Handle calls a proper function according to the url and method received from remote device.
+func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
+	logger.With(req.Context()).Logging(logger.DEBUG)
+	defer logger.With(req.Context()).Logging(logger.DEBUG, "OUT")
+
+	if req.URL.Path != URL.Base()+URL.Admin()+URL.Logging() {
+		common.WriteError(w, errors.NotFoundURL{})
+		return
+	}
+
+	switch req.Method {
+	default:
+		common.WriteError(w, errors.InvalidMethod{req.Method})
+	case http.MethodGet:
+		getLogging(w, req)
+	case http.MethodPost:
+		setLogging(w, req)
+	}
+}
+
+// getLogging handles requests to get the level and format of logs.
+//
+//	paths: '/api/v1/admin/logging'
+//	method: GET
+//	responses: if successful, 200 status code will be returned.
+func getLogging(w http.ResponseWriter, req *http.Request) {
+	writeLogConfig(w)
+}
+
+// setLogging handles requests to change the level and format of logs.
+// A field which is omitted in the body is left unchanged.
+//
+//	paths: '/api/v1/admin/logging'
+//	method: POST
+//	body: {"level": "debug", "format": "json"}
+//	responses: if successful, 200 status code will be returned.
+func setLogging(w http.ResponseWriter, req *http.Request) {
+	body, err := common.GetBodyFromReq(req)
+	if err != nil {
+		common.MakeResponse(w, results.ERROR, nil, err)
+		return
+	}
+
+	config := models.LogConfig{}
+	if err = models.Decode(body, &config); err != nil {
+		common.MakeResponse(w, results.ERROR, nil, err)
+		return
+	}
+
+	if err = logger.Configure(config.Level, config.Format); err != nil {
+		common.MakeResponse(w, results.ERROR, nil, err)
+		return
+	}
+	logger.With(req.Context()).Log(logger.INFO, "logging configured", "level", logger.GetLevel(), "format", logger.GetFormat())
+	writeLogConfig(w)
+}
+
+func writeLogConfig(w http.ResponseWriter) {
+	resp, err := models.Encode(models.LogConfig{Level: logger.GetLevel(), Format: logger.GetFormat()})
+	if err != nil {
+		common.MakeResponse(w, results.ERROR, nil, err)
+		return
+	}
+	common.MakeResponse(w, results.OK, []byte(resp), nil)
+}
@@ -0,0 +1,85 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package admin
+
+import (
+	"bytes"
+	"commons/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCalledHandleWithGetRequest_ExpectLogConfigReturned(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/admin/logging", nil)
+	RequestHandler{}.Handle(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusOK, w.Code)
+	}
+	expected := `"level":"` + logger.GetLevel() + `"`
+	if !strings.Contains(w.Body.String(), expected) {
+		t.Errorf("Expected %s in body: %s", expected, w.Body.String())
+	}
+}
+
+func TestCalledHandleWithPostRequest_ExpectLevelChanged(t *testing.T) {
+	defer logger.Configure("info", logger.FORMAT_LOGFMT)
+
+	w := httptest.NewRecorder()
+	body := bytes.NewReader([]byte(`{"level":"debug","format":"json"}`))
+	req, _ := http.NewRequest("POST", "/api/v1/admin/logging", body)
+	RequestHandler{}.Handle(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusOK, w.Code)
+	}
+	if logger.GetLevel() != "debug" || logger.GetFormat() != logger.FORMAT_JSON {
+		t.Errorf("Expected debug and json, actual %s and %s", logger.GetLevel(), logger.GetFormat())
+	}
+}
+
+func TestCalledHandleWithInvalidLevel_ExpectErrorReturn(t *testing.T) {
+	w := httptest.NewRecorder()
+	body := bytes.NewReader([]byte(`{"level":"verbose"}`))
+	req, _ := http.NewRequest("POST", "/api/v1/admin/logging", body)
+	RequestHandler{}.Handle(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCalledHandleWithInvalidMethodOrURL_ExpectErrorReturn(t *testing.T) {
+	testList := map[string]int{
+		"DELETE /api/v1/admin/logging": http.StatusBadRequest,
+		"GET /api/v1/admin/invalid":    http.StatusNotFound,
+	}
+
+	for request, expected := range testList {
+		parts := strings.Split(request, " ")
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(parts[0], parts[1], nil)
+		RequestHandler{}.Handle(w, req)
+
+		if w.Code != expected {
+			t.Errorf("%s: expected code: %d, actual code: %d", request, expected, w.Code)
+		}
+	}
+}
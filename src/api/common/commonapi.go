@@ -20,11 +20,22 @@ package common
 
 import (
 	"commons/errors"
+	"commons/models"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
 )
 
+const (
+	REQUEST_ID_HEADER = "X-Request-Id" // used to indicate a header which identifies a request.
+	ERROR_CODE        = "code"         // used to indicate an error code.
+	ERROR_MESSAGE     = "message"      // used to indicate an error message.
+	ERROR_FIELD       = "field"        // used to indicate an invalid field of request body.
+	REQUEST_ID        = "requestid"    // used to indicate a request id.
+)
+
 // WriteSuccess writes the data to the connection as part of an HTTP reply.
 func WriteSuccess(w http.ResponseWriter, code int, data []byte) {
 	w.Header().Set("Content-Type", "application/json")
@@ -34,17 +45,35 @@ func WriteSuccess(w http.ResponseWriter, code int, data []byte) {
 
 // WriteError writes the data to the connection as part of an HTTP reply.
 // The http status code depend on an error type.
-// An error message will be included as a body.
+// An error envelope will be included as a body, which consists of
+// an error code, an error message, an invalid field if any and a request id.
 func WriteError(w http.ResponseWriter, err error) {
 	code := convertToHttpStatusCode(err)
 	data := make(map[string]interface{})
-	data["message"] = err.Error()
+	data[ERROR_CODE] = convertToErrorCode(err)
+	data[ERROR_MESSAGE] = err.Error()
+	if e, ok := err.(errors.InvalidField); ok {
+		data[ERROR_FIELD] = e.Field
+	}
+	if requestId := w.Header().Get(REQUEST_ID_HEADER); len(requestId) != 0 {
+		data[REQUEST_ID] = requestId
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
 	w.Write(ChangeToJson(data))
 }
 
+// NewRequestId returns a random identifier of a request.
+// It is used when the client does not provide its own request id.
+func NewRequestId() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
 // MakeResponse calls WriteSuccess or MakeResponse function to respond to the request.
 // If err is nil, WriteSuccess will be called.
 // otherwise, WriteError will be called.
@@ -65,6 +94,15 @@ func ChangeToJson(src map[string]interface{}) []byte {
 	return dst
 }
 
+// ChangeModelToJson converts a typed response of controllers to []byte.
+func ChangeModelToJson(src interface{}) []byte {
+	dst, err := json.Marshal(src)
+	if err != nil {
+		return nil
+	}
+	return dst
+}
+
 // GetBodyFromReq reads a body from http request object.
 // A successful call returns the body type of string.
 // If request does not include body, InvalidParam will be returned.
@@ -81,6 +119,52 @@ func GetBodyFromReq(req *http.Request) (string, error) {
 	return string(body), nil
 }
 
+// DecodeBodyFromReq reads a body from http request object and converts it into
+// a typed request of controllers, v.
+// If the body is malformed, InvalidJSON or InvalidField will be returned.
+// In other cases, an appropriate error will be returned.
+func DecodeBodyFromReq(req *http.Request, v interface{}) error {
+	body, err := GetBodyFromReq(req)
+	if err != nil {
+		return err
+	}
+	return models.Decode(body, v)
+}
+
+// convertToErrorCode converts an error object to an error code
+// which is the name of an error type.
+func convertToErrorCode(err error) string {
+	switch err.(type) {
+	case errors.NotFoundURL:
+		return "NotFoundURL"
+	case errors.InvalidMethod:
+		return "InvalidMethod"
+	case errors.InvalidParam:
+		return "InvalidParam"
+	case errors.InvalidJSON:
+		return "InvalidJSON"
+	case errors.InvalidField:
+		return "InvalidField"
+	case errors.InvalidYaml:
+		return "InvalidYaml"
+	case errors.InvalidObjectId:
+		return "InvalidObjectId"
+	case errors.NotFound:
+		return "NotFound"
+	case errors.DBConnectionError:
+		return "DBConnectionError"
+	case errors.DBOperationError:
+		return "DBOperationError"
+	case errors.IOError:
+		return "IOError"
+	case errors.InternalServerError:
+		return "InternalServerError"
+	case errors.NodeError:
+		return "NodeError"
+	}
+	return "Unknown"
+}
+
 // convertToHttpStatusCode converts an error object to http status code.
 // The following codes are used.
 //
@@ -88,11 +172,14 @@ func GetBodyFromReq(req *http.Request) (string, error) {
 //    404 (Not Found)
 // 	  500 (Internal Server Error)
 //    503 (Service Unavailable)
+//
+// An error of a node is converted to the status code of the node.
 func convertToHttpStatusCode(err error) int {
 	code := http.StatusInternalServerError
-	switch err.(type) {
+	switch e := err.(type) {
 	case errors.InvalidParam,
 		errors.InvalidJSON,
+		errors.InvalidField,
 		errors.InvalidMethod,
 		errors.InvalidObjectId:
 		code = http.StatusBadRequest
@@ -105,6 +192,8 @@ func convertToHttpStatusCode(err error) int {
 	case errors.IOError,
 		errors.InternalServerError:
 		code = http.StatusInternalServerError
+	case errors.NodeError:
+		code = e.Code
 	}
 
 	return code
@@ -19,9 +19,12 @@ package common
 import (
 	"bytes"
 	Errors "commons/errors"
+	"commons/models"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 )
 
@@ -42,6 +45,35 @@ func TestWriteError(t *testing.T) {
 	}
 }
 
+func TestWriteErrorWithInvalidField_ExpectErrorEnvelope(t *testing.T) {
+	w := httptest.NewRecorder()
+	w.Header().Set(REQUEST_ID_HEADER, "requestid")
+	WriteError(w, Errors.InvalidField{"nodes[0]", "must be string"})
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusBadRequest, w.Code)
+	}
+
+	expected := map[string]interface{}{
+		ERROR_CODE:    "InvalidField",
+		ERROR_MESSAGE: "invalid field: nodes[0] must be string",
+		ERROR_FIELD:   "nodes[0]",
+		REQUEST_ID:    "requestid",
+	}
+	body := make(map[string]interface{})
+	json.Unmarshal(w.Body.Bytes(), &body)
+	if !reflect.DeepEqual(expected, body) {
+		t.Errorf("Expected body: %v, actual body: %v", expected, body)
+	}
+}
+
+func TestNewRequestId_ExpectUniqueIds(t *testing.T) {
+	first, second := NewRequestId(), NewRequestId()
+	if len(first) == 0 || first == second {
+		t.Errorf("Unexpected request ids: %s, %s", first, second)
+	}
+}
+
 func TestMakeResponse(t *testing.T) {
 	w := httptest.NewRecorder()
 	MakeResponse(w, http.StatusOK, nil, nil)
@@ -76,6 +108,32 @@ func TestGetBodyFromReqWithEmptyBody(t *testing.T) {
 	}
 }
 
+func TestDecodeBodyFromReq_ExpectTypedRequest(t *testing.T) {
+	body := []byte(`{"nodes":["id1"]}`)
+	req, _ := http.NewRequest("POST", "/api/v1/test/url", bytes.NewReader(body))
+	request := models.MembersRequest{}
+	err := DecodeBodyFromReq(req, &request)
+	if err != nil || len(request.Nodes) != 1 || request.Nodes[0] != "id1" {
+		t.Errorf("Unexpected request: %v, err: %v", request, err)
+	}
+}
+
+func TestDecodeBodyFromReqWithInvalidField_ExpectErrorReturn(t *testing.T) {
+	body := []byte(`{"nodes":"id1"}`)
+	req, _ := http.NewRequest("POST", "/api/v1/test/url", bytes.NewReader(body))
+	err := DecodeBodyFromReq(req, &models.MembersRequest{})
+	if _, ok := err.(Errors.InvalidField); !ok {
+		t.Errorf("Expected InvalidField, actual %T", err)
+	}
+}
+
+func TestChangeModelToJson_ExpectFieldsOfModel(t *testing.T) {
+	data := ChangeModelToJson(models.IDResponse{ID: "id"})
+	if string(data) != `{"id":"id"}` {
+		t.Errorf("Unexpected json: %s", data)
+	}
+}
+
 func TestConvertToHttpStatusCodeWithInvalidParam(t *testing.T) {
 	err := Errors.InvalidParam{}
 	code := convertToHttpStatusCode(err)
@@ -92,6 +150,14 @@ func TestConvertToHttpStatusCodeWithInvalidJSON(t *testing.T) {
 	}
 }
 
+func TestConvertToHttpStatusCodeWithInvalidField(t *testing.T) {
+	err := Errors.InvalidField{}
+	code := convertToHttpStatusCode(err)
+	if code != http.StatusBadRequest {
+		t.Error("convertToHttpStatusCode is invalid")
+	}
+}
+
 func TestConvertToHttpStatusCodeWithInvalidMethod(t *testing.T) {
 	err := Errors.InvalidMethod{}
 	code := convertToHttpStatusCode(err)
@@ -155,3 +221,11 @@ func TestConvertToHttpStatusCodeWithInternalServerError(t *testing.T) {
 		t.Error("convertToHttpStatusCode is invalid")
 	}
 }
+
+func TestConvertToHttpStatusCodeWithNodeError(t *testing.T) {
+	err := Errors.NodeError{http.StatusConflict, ""}
+	code := convertToHttpStatusCode(err)
+	if code != http.StatusConflict {
+		t.Error("convertToHttpStatusCode is invalid")
+	}
+}
@@ -18,6 +18,7 @@ package health
 
 import (
 	"api/common"
+	"commons/errors"
 	"commons/logger"
 	"commons/url"
 	"controller/health"
@@ -31,6 +32,7 @@ type Command interface {
 
 type apiInnerCommand interface {
 	ping(w http.ResponseWriter, req *http.Request)
+	ready(w http.ResponseWriter, req *http.Request)
 }
 
 type RequestHandler struct{}
@@ -45,21 +47,58 @@ func init() {
 }
 
 func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG)
-	defer logger.Logging(logger.DEBUG, "OUT")
+	logger.With(req.Context()).Logging(logger.DEBUG)
+	defer logger.With(req.Context()).Logging(logger.DEBUG, "OUT")
 
 	switch reqUrl := req.URL.Path; {
+	case reqUrl == url.Base()+url.Health()+url.Live():
+		if req.Method != http.MethodGet {
+			common.WriteError(w, errors.InvalidMethod{req.Method})
+			return
+		}
+		apiInnerExecutor.ping(w, req)
+
+	case reqUrl == url.Base()+url.Health()+url.Ready():
+		if req.Method != http.MethodGet {
+			common.WriteError(w, errors.InvalidMethod{req.Method})
+			return
+		}
+		apiInnerExecutor.ready(w, req)
+
 	case strings.Contains(reqUrl, url.Ping()):
 		apiInnerExecutor.ping(w, req)
+
+	case strings.Contains(reqUrl, url.Health()):
+		common.WriteError(w, errors.NotFoundURL{})
 	}
 }
 
 // ping handles requests which is used to check whether a pharos-anchor is up.
+//
+//	paths: '/api/v1/ping', '/api/v1/health/live'
+//	method: any for ping, GET for live
+//	responses: if successful, 200 status code will be returned.
 func (innerExecutorImpl) ping(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG)
-	defer logger.Logging(logger.DEBUG, "OUT")
+	logger.With(req.Context()).Logging(logger.DEBUG)
+	defer logger.With(req.Context()).Logging(logger.DEBUG, "OUT")
 
-	result, err := healthExecutor.Ping()
+	result, err := healthExecutor.Ping(req.Context())
 
 	common.MakeResponse(w, result, common.ChangeToJson(nil), err)
 }
+
+// ready handles requests which is used to check whether a pharos-anchor
+// is ready to serve requests, e.g. by a readiness probe.
+//
+//	paths: '/api/v1/health/ready'
+//	method: GET
+//	responses: 200 status code if ready, otherwise 503 status code,
+//	with the status of each component in both cases.
+func (innerExecutorImpl) ready(w http.ResponseWriter, req *http.Request) {
+	logger.With(req.Context()).Logging(logger.DEBUG)
+	defer logger.With(req.Context()).Logging(logger.DEBUG, "OUT")
+
+	result, resp, err := healthExecutor.Ready(req.Context())
+
+	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+}
@@ -18,10 +18,12 @@
 package health
 
 import (
+	"commons/results"
 	healthmocks "controller/health/mocks"
 	"github.com/golang/mock/gomock"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -61,7 +63,7 @@ func TestCalledHandleWithPingRequest_ExpectSuccess(t *testing.T) {
 	healthMockObj := healthmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		healthMockObj.EXPECT().Ping(),
+		healthMockObj.EXPECT().Ping(gomock.Any()).Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -72,3 +74,81 @@ func TestCalledHandleWithPingRequest_ExpectSuccess(t *testing.T) {
 
 	Handler.Handle(w, req)
 }
+
+func TestCalledHandleWithLiveRequest_ExpectCalledPing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	healthMockObj := healthmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		healthMockObj.EXPECT().Ping(gomock.Any()).Return(results.OK, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/health/live", nil)
+
+	// pass mockObj to a real object.
+	healthExecutor = healthMockObj
+
+	Handler.Handle(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestCalledHandleWithReadyRequest_ExpectStatusOfComponentsReturned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	healthMockObj := healthmocks.NewMockCommand(ctrl)
+	resp := map[string]interface{}{"status": "down"}
+
+	gomock.InOrder(
+		healthMockObj.EXPECT().Ready(gomock.Any()).Return(results.UNAVAILABLE, resp, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/health/ready", nil)
+
+	// pass mockObj to a real object.
+	healthExecutor = healthMockObj
+
+	Handler.Handle(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusServiceUnavailable, w.Code)
+	}
+	if w.Body.String() != `{"status":"down"}` {
+		t.Errorf("Unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestCalledHandleWithInvalidHealthRequest_ExpectErrorReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	healthMockObj := healthmocks.NewMockCommand(ctrl)
+
+	// pass mockObj to a real object.
+	healthExecutor = healthMockObj
+
+	testList := map[string]int{
+		"POST /api/v1/health/ready":  http.StatusBadRequest,
+		"DELETE /api/v1/health/live": http.StatusBadRequest,
+		"GET /api/v1/health/invalid": http.StatusNotFound,
+	}
+
+	for request, expected := range testList {
+		parts := strings.Split(request, " ")
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(parts[0], parts[1], nil)
+
+		Handler.Handle(w, req)
+
+		if w.Code != expected {
+			t.Errorf("%s: expected code: %d, actual code: %d", request, expected, w.Code)
+		}
+	}
+}
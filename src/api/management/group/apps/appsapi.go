@@ -22,6 +22,7 @@ import (
 	"api/common"
 	"commons/errors"
 	"commons/logger"
+	"commons/models"
 	"commons/results"
 	URL "commons/url"
 	deployment "controller/deployment/group"
@@ -148,15 +149,15 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) groupDeployApp(w http.ResponseWriter, req *http.Request, groupID string) {
-	logger.Logging(logger.DEBUG, "[GROUP] Deploy App")
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Deploy App")
 	body, err := common.GetBodyFromReq(req)
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
 	}
 
-	result, resp, err := deploymentExecutor.DeployApp(groupID, body)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	result, resp, err := deploymentExecutor.DeployApp(req.Context(), groupID, models.AppDescription(body))
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // groupInfoApps handles requests which is used to get information of all applications
@@ -166,9 +167,9 @@ func (appsAPIExecutor) groupDeployApp(w http.ResponseWriter, req *http.Request,
 //    method: GET
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) groupInfoApps(w http.ResponseWriter, req *http.Request, groupID string) {
-	logger.Logging(logger.DEBUG, "[GROUP] Get Info Apps")
-	result, resp, err := deploymentExecutor.GetApps(groupID)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Get Info Apps")
+	result, resp, err := deploymentExecutor.GetApps(req.Context(), groupID)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // groupInfoApp handles requests which is used to get information of application
@@ -178,9 +179,9 @@ func (appsAPIExecutor) groupInfoApps(w http.ResponseWriter, req *http.Request, g
 //    method: GET
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) groupInfoApp(w http.ResponseWriter, req *http.Request, groupID string, appID string) {
-	logger.Logging(logger.DEBUG, "[GROUP] Get Info App")
-	result, resp, err := deploymentExecutor.GetApp(groupID, appID)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Get Info App")
+	result, resp, err := deploymentExecutor.GetApp(req.Context(), groupID, appID)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // groupUpdateAppInfo handles requests related to updating application installed on group
@@ -190,15 +191,15 @@ func (appsAPIExecutor) groupInfoApp(w http.ResponseWriter, req *http.Request, gr
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) groupUpdateAppInfo(w http.ResponseWriter, req *http.Request, groupID string, appID string) {
-	logger.Logging(logger.DEBUG, "[GROUP] Update App Info")
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Update App Info")
 	body, err := common.GetBodyFromReq(req)
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
 	}
 
-	result, resp, err := deploymentExecutor.UpdateAppInfo(groupID, appID, body)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	result, resp, err := deploymentExecutor.UpdateAppInfo(req.Context(), groupID, appID, models.AppDescription(body))
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // groupDeleteApp handles requests related to delete application installed on group
@@ -208,9 +209,9 @@ func (appsAPIExecutor) groupUpdateAppInfo(w http.ResponseWriter, req *http.Reque
 //    method: DELETE
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) groupDeleteApp(w http.ResponseWriter, req *http.Request, groupID string, appID string) {
-	logger.Logging(logger.DEBUG, "[GROUP] Delete App")
-	result, resp, err := deploymentExecutor.DeleteApp(groupID, appID)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Delete App")
+	result, resp, err := deploymentExecutor.DeleteApp(req.Context(), groupID, appID)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // groupStartApp handles requests related to start application installed on group
@@ -220,9 +221,9 @@ func (appsAPIExecutor) groupDeleteApp(w http.ResponseWriter, req *http.Request,
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) groupStartApp(w http.ResponseWriter, req *http.Request, groupID string, appID string) {
-	logger.Logging(logger.DEBUG, "[GROUP] Start App")
-	result, resp, err := deploymentExecutor.StartApp(groupID, appID)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Start App")
+	result, resp, err := deploymentExecutor.StartApp(req.Context(), groupID, appID)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // groupStopApp handles requests related to stop application installed on group
@@ -232,9 +233,9 @@ func (appsAPIExecutor) groupStartApp(w http.ResponseWriter, req *http.Request, g
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) groupStopApp(w http.ResponseWriter, req *http.Request, groupID string, appID string) {
-	logger.Logging(logger.DEBUG, "[GROUP] Stop App")
-	result, resp, err := deploymentExecutor.StopApp(groupID, appID)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Stop App")
+	result, resp, err := deploymentExecutor.StopApp(req.Context(), groupID, appID)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // groupUpdateApp handles requests related to updating application installed on group
@@ -244,7 +245,7 @@ func (appsAPIExecutor) groupStopApp(w http.ResponseWriter, req *http.Request, gr
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) groupUpdateApp(w http.ResponseWriter, req *http.Request, groupID string, appID string) {
-	logger.Logging(logger.DEBUG, "[GROUP] Update App")
-	result, resp, err := deploymentExecutor.UpdateApp(groupID, appID)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Update App")
+	result, resp, err := deploymentExecutor.UpdateApp(req.Context(), groupID, appID)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
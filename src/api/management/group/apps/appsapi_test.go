@@ -19,6 +19,8 @@ package apps
 
 import (
 	"bytes"
+	"commons/models"
+	"commons/results"
 	deploymentmocks "controller/deployment/group/mocks"
 	"encoding/json"
 	"github.com/golang/mock/gomock"
@@ -78,7 +80,7 @@ func TestCalledHandleWithDeployRequest_ExpectCalledDeployApp(t *testing.T) {
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().DeployApp("groupID", testBodyString),
+		deploymentMockObj.EXPECT().DeployApp(gomock.Any(), "groupID", models.AppDescription(testBodyString)).Return(results.OK, models.DeploymentResponse{}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -98,7 +100,7 @@ func TestCalledHandleWithUpdateAppInfoRequest_ExpectCalledUpdateAppInfo(t *testi
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().UpdateAppInfo("groupID", "appID", testBodyString),
+		deploymentMockObj.EXPECT().UpdateAppInfo(gomock.Any(), "groupID", "appID", models.AppDescription(testBodyString)).Return(results.OK, models.DeploymentResponse{}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -118,7 +120,7 @@ func TestCalledHandleWithGetAppsRequest_ExpectCalledGetApps(t *testing.T) {
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().GetApps("groupID"),
+		deploymentMockObj.EXPECT().GetApps(gomock.Any(), "groupID").Return(results.OK, models.GroupAppList{}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -137,7 +139,7 @@ func TestCalledHandleWithGetAppRequest_ExpectCalledGetApp(t *testing.T) {
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().GetApp("groupID", "appID"),
+		deploymentMockObj.EXPECT().GetApp(gomock.Any(), "groupID", "appID").Return(results.OK, models.GroupAppInfo{}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -156,7 +158,7 @@ func TestCalledHandleWithDeleteAppRequest_ExpectCalledDeleteApp(t *testing.T) {
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().DeleteApp("groupID", "appID"),
+		deploymentMockObj.EXPECT().DeleteApp(gomock.Any(), "groupID", "appID").Return(results.OK, models.DeploymentResponse{}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -175,7 +177,7 @@ func TestCalledHandleWithUpdateAppRequest_ExpectCalledUpdateApp(t *testing.T) {
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().UpdateApp("groupID", "appID"),
+		deploymentMockObj.EXPECT().UpdateApp(gomock.Any(), "groupID", "appID").Return(results.OK, models.DeploymentResponse{}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -194,7 +196,7 @@ func TestCalledHandleWithStartAppRequest_ExpectCalledStartApp(t *testing.T) {
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().StartApp("groupID", "appID"),
+		deploymentMockObj.EXPECT().StartApp(gomock.Any(), "groupID", "appID").Return(results.OK, models.DeploymentResponse{}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -213,7 +215,7 @@ func TestCalledHandleWithStopAppRequest_ExpectCalledStopApp(t *testing.T) {
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().StopApp("groupID", "appID"),
+		deploymentMockObj.EXPECT().StopApp(gomock.Any(), "groupID", "appID").Return(results.OK, models.DeploymentResponse{}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -23,6 +23,7 @@ import (
 	"api/management/group/apps"
 	"commons/errors"
 	"commons/logger"
+	"commons/models"
 	"commons/results"
 	URL "commons/url"
 	groupmanager "controller/management/group"
@@ -126,15 +127,16 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 //    method: GET
 //    responses: if successful, 200 status code will be returned.
 func (groupAPIExecutor) createGroup(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG, "[GROUP] Create Group")
-	body, err := common.GetBodyFromReq(req)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Create Group")
+	request := models.CreateGroupRequest{}
+	err := common.DecodeBodyFromReq(req, &request)
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
 	}
 
-	result, resp, err := managementExecutor.CreateGroup(body)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	result, resp, err := managementExecutor.CreateGroup(req.Context(), request)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // group handles requests which is used to get information of group identified by the given groupID.
@@ -143,19 +145,16 @@ func (groupAPIExecutor) createGroup(w http.ResponseWriter, req *http.Request) {
 //    method: GET
 //    responses: if successful, 200 status code will be returned.
 func (groupAPIExecutor) group(w http.ResponseWriter, req *http.Request, groupID string) {
-	var result int
-	var resp map[string]interface{}
-	var err error
 	switch req.Method {
 	case GET:
-		logger.Logging(logger.DEBUG, "[GROUP] Get Group")
-		result, resp, err = managementExecutor.GetGroup(groupID)
+		logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Get Group")
+		result, resp, err := managementExecutor.GetGroup(req.Context(), groupID)
+		common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 	case DELETE:
-		logger.Logging(logger.DEBUG, "[GROUP] Delete Group")
-		result, resp, err = managementExecutor.DeleteGroup(groupID)
+		logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Delete Group")
+		result, err := managementExecutor.DeleteGroup(req.Context(), groupID)
+		common.MakeResponse(w, result, common.ChangeToJson(nil), err)
 	}
-
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
 }
 
 // groups handles requests which is used to get information of all groups created.
@@ -164,9 +163,9 @@ func (groupAPIExecutor) group(w http.ResponseWriter, req *http.Request, groupID
 //    method: GET
 //    responses: if successful, 200 status code will be returned.
 func (groupAPIExecutor) groups(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG, "[GROUP] Get All Groups")
-	result, resp, err := managementExecutor.GetGroups()
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Get All Groups")
+	result, resp, err := managementExecutor.GetGroups(req.Context())
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // groupJoin handles requests which is used to add an agent to a list of group members
@@ -176,15 +175,16 @@ func (groupAPIExecutor) groups(w http.ResponseWriter, req *http.Request) {
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (groupAPIExecutor) groupJoin(w http.ResponseWriter, req *http.Request, groupID string) {
-	logger.Logging(logger.DEBUG, "[GROUP] Join Group")
-	body, err := common.GetBodyFromReq(req)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Join Group")
+	request := models.MembersRequest{}
+	err := common.DecodeBodyFromReq(req, &request)
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
 	}
 
-	result, resp, err := managementExecutor.JoinGroup(groupID, body)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	result, err := managementExecutor.JoinGroup(req.Context(), groupID, request)
+	common.MakeResponse(w, result, common.ChangeToJson(nil), err)
 }
 
 // groupLeave handles requests which is used to delete an agent from a list of group members
@@ -194,13 +194,14 @@ func (groupAPIExecutor) groupJoin(w http.ResponseWriter, req *http.Request, grou
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (groupAPIExecutor) groupLeave(w http.ResponseWriter, req *http.Request, groupID string) {
-	logger.Logging(logger.DEBUG, "[GROUP] Leave Group")
-	body, err := common.GetBodyFromReq(req)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Leave Group")
+	request := models.MembersRequest{}
+	err := common.DecodeBodyFromReq(req, &request)
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
 	}
 
-	result, resp, err := managementExecutor.LeaveGroup(groupID, body)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	result, err := managementExecutor.LeaveGroup(req.Context(), groupID, request)
+	common.MakeResponse(w, result, common.ChangeToJson(nil), err)
 }
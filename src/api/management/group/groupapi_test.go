@@ -19,6 +19,8 @@ package group
 
 import (
 	"bytes"
+	"commons/models"
+	"commons/results"
 	groupmanagermocks "controller/management/group/mocks"
 	"encoding/json"
 	"github.com/golang/mock/gomock"
@@ -27,14 +29,11 @@ import (
 	"testing"
 )
 
-const (
-	testBodyString = `{"test":"body"}`
+var (
+	createGroupRequest = models.CreateGroupRequest{Name: "groupName"}
+	membersRequest     = models.MembersRequest{Nodes: []string{"nodeID"}}
 )
 
-var testBody = map[string]interface{}{
-	"test": "body",
-}
-
 var Handler Command
 
 func init() {
@@ -78,7 +77,7 @@ func TestCalledHandleWithGroupsRequest_ExpectCalledGetGroups(t *testing.T) {
 	groupmanageMockObj := groupmanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupmanageMockObj.EXPECT().GetGroups(),
+		groupmanageMockObj.EXPECT().GetGroups(gomock.Any()).Return(results.OK, models.GroupList{Groups: []models.Group{}}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -88,6 +87,10 @@ func TestCalledHandleWithGroupsRequest_ExpectCalledGetGroups(t *testing.T) {
 	managementExecutor = groupmanageMockObj
 
 	Handler.Handle(w, req)
+
+	if w.Body.String() != `{"groups":[]}` {
+		t.Errorf("Unexpected body: %s", w.Body.String())
+	}
 }
 
 func TestCalledHandleWithGetGroupRequest_ExpectCalledGetGroup(t *testing.T) {
@@ -97,7 +100,7 @@ func TestCalledHandleWithGetGroupRequest_ExpectCalledGetGroup(t *testing.T) {
 	groupmanageMockObj := groupmanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupmanageMockObj.EXPECT().GetGroup("groupID"),
+		groupmanageMockObj.EXPECT().GetGroup(gomock.Any(), "groupID").Return(results.OK, models.Group{}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -116,7 +119,7 @@ func TestCalledHandleWithDeleteGroupRequest_ExpectCalledDeleteGroup(t *testing.T
 	groupmanageMockObj := groupmanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupmanageMockObj.EXPECT().DeleteGroup("groupID"),
+		groupmanageMockObj.EXPECT().DeleteGroup(gomock.Any(), "groupID").Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -135,11 +138,11 @@ func TestCalledHandleWithCreateGroupRequest_ExpectCalledCreateGroup(t *testing.T
 	groupmanageMockObj := groupmanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupmanageMockObj.EXPECT().CreateGroup(testBodyString),
+		groupmanageMockObj.EXPECT().CreateGroup(gomock.Any(), createGroupRequest).Return(results.OK, models.Group{}, nil),
 	)
 
 	w := httptest.NewRecorder()
-	body, _ := json.Marshal(testBody)
+	body, _ := json.Marshal(createGroupRequest)
 	req, _ := http.NewRequest("POST", "/api/v1/management/groups/create", bytes.NewReader(body))
 
 	// pass mockObj to a real object.
@@ -155,11 +158,11 @@ func TestCalledHandleWithJoinGroupRequest_ExpectCalledJoinGroup(t *testing.T) {
 	groupmanageMockObj := groupmanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupmanageMockObj.EXPECT().JoinGroup("groupID", testBodyString),
+		groupmanageMockObj.EXPECT().JoinGroup(gomock.Any(), "groupID", membersRequest).Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
-	body, _ := json.Marshal(testBody)
+	body, _ := json.Marshal(membersRequest)
 	req, _ := http.NewRequest("POST", "/api/v1/management/groups/groupID/join", bytes.NewReader(body))
 
 	// pass mockObj to a real object.
@@ -175,11 +178,11 @@ func TestCalledHandleWithLeaveGroupRequest_ExpectCalledLeaveGroup(t *testing.T)
 	groupmanageMockObj := groupmanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupmanageMockObj.EXPECT().LeaveGroup("groupID", testBodyString),
+		groupmanageMockObj.EXPECT().LeaveGroup(gomock.Any(), "groupID", membersRequest).Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
-	body, _ := json.Marshal(testBody)
+	body, _ := json.Marshal(membersRequest)
 	req, _ := http.NewRequest("POST", "/api/v1/management/groups/groupID/leave", bytes.NewReader(body))
 
 	// pass mockObj to a real object.
@@ -187,3 +190,23 @@ func TestCalledHandleWithLeaveGroupRequest_ExpectCalledLeaveGroup(t *testing.T)
 
 	Handler.Handle(w, req)
 }
+
+func TestCalledHandleWithInvalidJoinGroupRequest_UnExpectCalledJoinGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	groupmanageMockObj := groupmanagermocks.NewMockCommand(ctrl)
+
+	w := httptest.NewRecorder()
+	body := []byte(`{"nodes":["nodeID",1]}`)
+	req, _ := http.NewRequest("POST", "/api/v1/management/groups/groupID/join", bytes.NewReader(body))
+
+	// pass mockObj to a real object.
+	managementExecutor = groupmanageMockObj
+
+	Handler.Handle(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"api/common"
 	"commons/errors"
 	"commons/logger"
+	"commons/models"
 	"commons/results"
 	URL "commons/url"
 	deployment "controller/deployment/node"
@@ -138,15 +139,15 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) nodeDeployApp(w http.ResponseWriter, req *http.Request, nodeID string) {
-	logger.Logging(logger.DEBUG, "[NODE] Deploy App")
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Deploy App")
 	body, err := common.GetBodyFromReq(req)
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
 	}
 
-	result, resp, err := deploymentExecutor.DeployApp(nodeID, body, parseQuery(req))
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	result, resp, err := deploymentExecutor.DeployApp(req.Context(), nodeID, models.AppDescription(body), parseQuery(req))
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // nodeInfoApps handles requests which is used to get information of all applications
@@ -156,9 +157,9 @@ func (appsAPIExecutor) nodeDeployApp(w http.ResponseWriter, req *http.Request, n
 //    method: GET
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) nodeInfoApps(w http.ResponseWriter, req *http.Request, nodeID string) {
-	logger.Logging(logger.DEBUG, "[NODE] Get Info Apps")
-	result, resp, err := deploymentExecutor.GetApps(nodeID)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Get Info Apps")
+	result, resp, err := deploymentExecutor.GetApps(req.Context(), nodeID)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // nodeInfoApp handles requests which is used to get information of application
@@ -168,9 +169,9 @@ func (appsAPIExecutor) nodeInfoApps(w http.ResponseWriter, req *http.Request, no
 //    method: GET
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) nodeInfoApp(w http.ResponseWriter, req *http.Request, nodeID string, appID string) {
-	logger.Logging(logger.DEBUG, "[NODE] Get Info App")
-	result, resp, err := deploymentExecutor.GetApp(nodeID, appID)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Get Info App")
+	result, resp, err := deploymentExecutor.GetApp(req.Context(), nodeID, appID)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // nodeUpdateAppInfo handles requests related to updating the application with given yaml in body.
@@ -179,15 +180,15 @@ func (appsAPIExecutor) nodeInfoApp(w http.ResponseWriter, req *http.Request, nod
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) nodeUpdateAppInfo(w http.ResponseWriter, req *http.Request, nodeID string, appID string) {
-	logger.Logging(logger.DEBUG, "[NODE] Update App Info")
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Update App Info")
 	body, err := common.GetBodyFromReq(req)
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
 	}
 
-	result, resp, err := deploymentExecutor.UpdateAppInfo(nodeID, appID, body)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	result, err := deploymentExecutor.UpdateAppInfo(req.Context(), nodeID, appID, models.AppDescription(body))
+	common.MakeResponse(w, result, common.ChangeToJson(nil), err)
 }
 
 // nodeDeleteApp handles requests related to delete application installed on node
@@ -197,9 +198,9 @@ func (appsAPIExecutor) nodeUpdateAppInfo(w http.ResponseWriter, req *http.Reques
 //    method: DELETE
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) nodeDeleteApp(w http.ResponseWriter, req *http.Request, nodeID string, appID string) {
-	logger.Logging(logger.DEBUG, "[NODE] Delete App")
-	result, resp, err := deploymentExecutor.DeleteApp(nodeID, appID)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Delete App")
+	result, err := deploymentExecutor.DeleteApp(req.Context(), nodeID, appID)
+	common.MakeResponse(w, result, common.ChangeToJson(nil), err)
 }
 
 // nodeStartApp handles requests related to start application installed on node
@@ -209,9 +210,9 @@ func (appsAPIExecutor) nodeDeleteApp(w http.ResponseWriter, req *http.Request, n
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) nodeStartApp(w http.ResponseWriter, req *http.Request, nodeID string, appID string) {
-	logger.Logging(logger.DEBUG, "[NODE] Start App")
-	result, resp, err := deploymentExecutor.StartApp(nodeID, appID)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Start App")
+	result, err := deploymentExecutor.StartApp(req.Context(), nodeID, appID)
+	common.MakeResponse(w, result, common.ChangeToJson(nil), err)
 }
 
 // nodeStopApp handles requests related to stop application installed on node
@@ -221,9 +222,9 @@ func (appsAPIExecutor) nodeStartApp(w http.ResponseWriter, req *http.Request, no
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) nodeStopApp(w http.ResponseWriter, req *http.Request, nodeID string, appID string) {
-	logger.Logging(logger.DEBUG, "[NODE] Stop App")
-	result, resp, err := deploymentExecutor.StopApp(nodeID, appID)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Stop App")
+	result, err := deploymentExecutor.StopApp(req.Context(), nodeID, appID)
+	common.MakeResponse(w, result, common.ChangeToJson(nil), err)
 }
 
 // nodeUpdateApp handles requests related to updating application installed on node
@@ -233,9 +234,9 @@ func (appsAPIExecutor) nodeStopApp(w http.ResponseWriter, req *http.Request, nod
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (appsAPIExecutor) nodeUpdateApp(w http.ResponseWriter, req *http.Request, nodeID string, appID string) {
-	logger.Logging(logger.DEBUG, "[NODE] Update App")
-	result, resp, err := deploymentExecutor.UpdateApp(nodeID, appID, parseQuery(req))
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Update App")
+	result, err := deploymentExecutor.UpdateApp(req.Context(), nodeID, appID, parseQuery(req))
+	common.MakeResponse(w, result, common.ChangeToJson(nil), err)
 }
 
 func parseQuery(req *http.Request) map[string]interface{} {
@@ -19,6 +19,9 @@ package apps
 
 import (
 	"bytes"
+	"commons/errors"
+	"commons/models"
+	"commons/results"
 	deploymentmocks "controller/deployment/node/mocks"
 	"encoding/json"
 	"github.com/golang/mock/gomock"
@@ -86,7 +89,7 @@ func TestCalledHandleWithDeployRequest_ExpectCalledDeployApp(t *testing.T) {
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().DeployApp("nodeID", testBodyString, nil),
+		deploymentMockObj.EXPECT().DeployApp(gomock.Any(), "nodeID", models.AppDescription(testBodyString), nil).Return(results.OK, models.AppInfo{}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -99,6 +102,30 @@ func TestCalledHandleWithDeployRequest_ExpectCalledDeployApp(t *testing.T) {
 	Handler.Handle(w, req)
 }
 
+func TestCalledHandleWithDeployRequestWhenNodeFailed_ExpectStatusCodeOfNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		deploymentMockObj.EXPECT().DeployApp(gomock.Any(), "nodeID", models.AppDescription(testBodyString), nil).Return(results.ERROR, models.AppInfo{}, errors.NodeError{http.StatusConflict, "conflict"}),
+	)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(testBody)
+	req, _ := http.NewRequest("POST", "/api/v1/management/nodes/nodeID/apps/deploy", bytes.NewReader(body))
+
+	// pass mockObj to a real object.
+	deploymentExecutor = deploymentMockObj
+
+	Handler.Handle(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusConflict, w.Code)
+	}
+}
+
 func TestCalledHandleWithUpdateAppInfoRequest_ExpectCalledUpdateAppInfo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -106,7 +133,7 @@ func TestCalledHandleWithUpdateAppInfoRequest_ExpectCalledUpdateAppInfo(t *testi
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().UpdateAppInfo("nodeID", "appID", testBodyString),
+		deploymentMockObj.EXPECT().UpdateAppInfo(gomock.Any(), "nodeID", "appID", models.AppDescription(testBodyString)).Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -126,7 +153,7 @@ func TestCalledHandleWithGetAppsRequest_ExpectCalledGetApps(t *testing.T) {
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().GetApps("nodeID"),
+		deploymentMockObj.EXPECT().GetApps(gomock.Any(), "nodeID").Return(results.OK, models.NodeAppList{}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -145,7 +172,7 @@ func TestCalledHandleWithGetAppRequest_ExpectCalledGetApp(t *testing.T) {
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().GetApp("nodeID", "appID"),
+		deploymentMockObj.EXPECT().GetApp(gomock.Any(), "nodeID", "appID").Return(results.OK, models.AppInfo{}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -164,7 +191,7 @@ func TestCalledHandleWithDeleteAppRequest_ExpectCalledDeleteApp(t *testing.T) {
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().DeleteApp("nodeID", "appID"),
+		deploymentMockObj.EXPECT().DeleteApp(gomock.Any(), "nodeID", "appID").Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -183,7 +210,7 @@ func TestCalledHandleWithUpdateAppRequestWithoutQuery_ExpectCalledUpdateApp(t *t
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().UpdateApp("nodeID", "appID", nil),
+		deploymentMockObj.EXPECT().UpdateApp(gomock.Any(), "nodeID", "appID", nil).Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -202,7 +229,7 @@ func TestCalledHandleWithUpdateAppRequestWithQuery_ExpectCalledUpdateApp(t *test
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().UpdateApp("nodeID", "appID", testQuery),
+		deploymentMockObj.EXPECT().UpdateApp(gomock.Any(), "nodeID", "appID", testQuery).Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -224,7 +251,7 @@ func TestCalledHandleWithStartAppRequest_ExpectCalledStartApp(t *testing.T) {
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().StartApp("nodeID", "appID"),
+		deploymentMockObj.EXPECT().StartApp(gomock.Any(), "nodeID", "appID").Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -243,7 +270,7 @@ func TestCalledHandleWithStopAppRequest_ExpectCalledStopApp(t *testing.T) {
 	deploymentMockObj := deploymentmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		deploymentMockObj.EXPECT().StopApp("nodeID", "appID"),
+		deploymentMockObj.EXPECT().StopApp(gomock.Any(), "nodeID", "appID").Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
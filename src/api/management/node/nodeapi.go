@@ -23,10 +23,12 @@ import (
 	"api/management/node/apps"
 	"commons/errors"
 	"commons/logger"
+	"commons/models"
 	"commons/results"
 	URL "commons/url"
 	nodemanager "controller/management/node"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -48,6 +50,8 @@ type nodeManagementAPI interface {
 	configuration(w http.ResponseWriter, req *http.Request, nodeID string)
 	reboot(w http.ResponseWriter, req *http.Request)
 	restore(w http.ResponseWriter, req *http.Request)
+	drift(w http.ResponseWriter, req *http.Request, nodeID string)
+	drifts(w http.ResponseWriter, req *http.Request)
 }
 
 type RequestHandler struct{}
@@ -90,6 +94,8 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 		case 2:
 			if "/"+split[1] == URL.Register() {
 				nodeAPI.register(w, req)
+			} else if "/"+split[1] == URL.Drift() {
+				nodeAPI.drifts(w, req)
 			} else {
 				if req.Method == GET {
 					nodeID := split[1]
@@ -112,6 +118,17 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 			} else {
 				common.WriteError(w, errors.NotFoundURL{})
 			}
+
+		case 4:
+			if "/"+split[2] == URL.Configuration() && "/"+split[3] == URL.Drift() {
+				nodeID := split[1]
+				nodeAPI.drift(w, req, nodeID)
+			} else {
+				common.WriteError(w, errors.NotFoundURL{})
+			}
+
+		default:
+			common.WriteError(w, errors.NotFoundURL{})
 		}
 	}
 }
@@ -122,8 +139,8 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (nodeAPIExecutor) reboot(w http.ResponseWriter, req *http.Request, nodeId string) {
-	logger.Logging(logger.DEBUG, "[NODE] Reboot Pharos Nodes")
-	result, err := managementExecutor.Reboot(nodeId)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Reboot Pharos Nodes")
+	result, err := managementExecutor.Reboot(req.Context(), nodeId)
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
@@ -138,8 +155,8 @@ func (nodeAPIExecutor) reboot(w http.ResponseWriter, req *http.Request, nodeId s
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (nodeAPIExecutor) restore(w http.ResponseWriter, req *http.Request, nodeId string) {
-	logger.Logging(logger.DEBUG, "[NODE] Restore Pharos Nodes")
-	result, err := managementExecutor.Restore(nodeId)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Restore Pharos Nodes")
+	result, err := managementExecutor.Restore(req.Context(), nodeId)
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
@@ -154,14 +171,14 @@ func (nodeAPIExecutor) restore(w http.ResponseWriter, req *http.Request, nodeId
 //    method: GET
 //    responses: if successful, 200 status code will be returned.
 func (nodeAPIExecutor) node(w http.ResponseWriter, req *http.Request, nodeID string) {
-	logger.Logging(logger.DEBUG, "[NODE] Get Pharos Nodes")
-	result, resp, err := managementExecutor.GetNode(nodeID)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Get Pharos Nodes")
+	result, resp, err := managementExecutor.GetNode(req.Context(), nodeID)
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
 	}
 
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // nodes handles requests which is used to get information of all nodes registered.
@@ -170,14 +187,14 @@ func (nodeAPIExecutor) node(w http.ResponseWriter, req *http.Request, nodeID str
 //    method: GET
 //    responses: if successful, 200 status code will be returned.
 func (nodeAPIExecutor) nodes(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG, "[NODE] Get All Pharos Nodes")
-	result, resp, err := managementExecutor.GetNodes()
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Get All Pharos Nodes")
+	result, resp, err := managementExecutor.GetNodes(req.Context())
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
 	}
 
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // register handles requests which is used to register node to a list of nodes.
@@ -186,16 +203,17 @@ func (nodeAPIExecutor) nodes(w http.ResponseWriter, req *http.Request) {
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (nodeAPIExecutor) register(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG, "[NODE] Register New Pharos Node")
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Register New Pharos Node")
 
-	body, err := common.GetBodyFromReq(req)
+	request := models.RegisterNodeRequest{}
+	err := common.DecodeBodyFromReq(req, &request)
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
 	}
 
-	result, resp, err := managementExecutor.RegisterNode(body)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	result, resp, err := managementExecutor.RegisterNode(req.Context(), request)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // unregister handles requests which is used to unregister node from a list of nodes.
@@ -204,9 +222,9 @@ func (nodeAPIExecutor) register(w http.ResponseWriter, req *http.Request) {
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (nodeAPIExecutor) unregister(w http.ResponseWriter, req *http.Request, nodeID string) {
-	logger.Logging(logger.DEBUG, "[NODE] Unregister New Pharos Node")
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Unregister New Pharos Node")
 
-	result, err := managementExecutor.UnRegisterNode(nodeID)
+	result, err := managementExecutor.UnRegisterNode(req.Context(), nodeID)
 	common.MakeResponse(w, result, nil, err)
 }
 
@@ -216,15 +234,16 @@ func (nodeAPIExecutor) unregister(w http.ResponseWriter, req *http.Request, node
 //    method: POST
 //    responses: if successful, 200 status code will be returned.
 func (nodeAPIExecutor) ping(w http.ResponseWriter, req *http.Request, nodeID string) {
-	logger.Logging(logger.DEBUG, "[NODE] Ping From Pharos Node")
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Ping From Pharos Node")
 
-	body, err := common.GetBodyFromReq(req)
+	request := models.PingRequest{}
+	err := common.DecodeBodyFromReq(req, &request)
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
 	}
 
-	result, err := managementExecutor.PingNode(nodeID, body)
+	result, err := managementExecutor.PingNode(req.Context(), nodeID, request)
 	common.MakeResponse(w, result, nil, err)
 }
 
@@ -234,27 +253,88 @@ func (nodeAPIExecutor) ping(w http.ResponseWriter, req *http.Request, nodeID str
 //    method: GET, POST
 //    responses: if successful, 200 status code will be returned.
 func (nodeAPIExecutor) configuration(w http.ResponseWriter, req *http.Request, nodeID string) {
-	logger.Logging(logger.DEBUG, "[NODE] Configure Pharos Node")
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Configure Pharos Node")
 
-	response := make(map[string]interface{})
-	var result int
-	var err error
 	switch req.Method {
 	case GET:
-		result, response, err = managementExecutor.GetNodeConfiguration(nodeID)
+		result, resp, err := managementExecutor.GetNodeConfiguration(req.Context(), nodeID)
+		if err != nil {
+			common.MakeResponse(w, results.ERROR, nil, err)
+			return
+		}
+		common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 	case POST:
-		var bodyStr string
-		bodyStr, err = common.GetBodyFromReq(req)
+		request := models.Configuration{}
+		err := common.DecodeBodyFromReq(req, &request)
 		if err != nil {
 			common.MakeResponse(w, results.ERROR, nil, err)
 			return
 		}
-		result, err = managementExecutor.SetNodeConfiguration(nodeID, bodyStr)
+		result, err := managementExecutor.SetNodeConfiguration(req.Context(), nodeID, request)
+		common.MakeResponse(w, result, common.ChangeToJson(make(map[string]interface{})), err)
+	}
+}
+
+// drift handles requests which is used to get/check configuration drift of a node.
+//
+//    paths: '/api/v1/management/nodes/{nodeID}/configuration/drift'
+//    method: GET, POST
+//    query: notify=true sends a 'configdrift' event if the drift has changed. (POST only)
+//    responses: if successful, 200 status code will be returned.
+func (nodeAPIExecutor) drift(w http.ResponseWriter, req *http.Request, nodeID string) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Configuration Drift of Pharos Node")
+
+	var result int
+	var response models.Drift
+	var err error
+	switch req.Method {
+	case GET:
+		result, response, err = managementExecutor.GetConfigurationDrift(req.Context(), nodeID)
+	case POST:
+		result, response, err = managementExecutor.CheckConfigurationDrift(req.Context(), nodeID, isNotifyRequested(req))
+	default:
+		common.WriteError(w, errors.InvalidMethod{req.Method})
+		return
 	}
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
 	}
 
-	common.MakeResponse(w, result, common.ChangeToJson(response), err)
-}
\ No newline at end of file
+	common.MakeResponse(w, result, common.ChangeModelToJson(response), err)
+}
+
+// drifts handles requests which is used to get/check configuration drift of all nodes.
+//
+//    paths: '/api/v1/management/nodes/drift'
+//    method: GET, POST
+//    query: notify=true sends a 'configdrift' event if the drift has changed. (POST only)
+//    responses: if successful, 200 status code will be returned.
+func (nodeAPIExecutor) drifts(w http.ResponseWriter, req *http.Request) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Configuration Drift of All Pharos Nodes")
+
+	var result int
+	var response models.DriftList
+	var err error
+	switch req.Method {
+	case GET:
+		result, response, err = managementExecutor.GetConfigurationDrifts(req.Context())
+	case POST:
+		result, response, err = managementExecutor.CheckConfigurationDrifts(req.Context(), isNotifyRequested(req))
+	default:
+		common.WriteError(w, errors.InvalidMethod{req.Method})
+		return
+	}
+	if err != nil {
+		common.MakeResponse(w, results.ERROR, nil, err)
+		return
+	}
+
+	common.MakeResponse(w, result, common.ChangeModelToJson(response), err)
+}
+
+// isNotifyRequested returns true if 'notify' query of the request is set to true.
+func isNotifyRequested(req *http.Request) bool {
+	notify, err := strconv.ParseBool(req.URL.Query().Get("notify"))
+	return err == nil && notify
+}
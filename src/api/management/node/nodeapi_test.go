@@ -19,6 +19,8 @@ package node
 
 import (
 	"bytes"
+	"commons/models"
+	"commons/results"
 	nodemanagermocks "controller/management/node/mocks"
 	"encoding/json"
 	"github.com/golang/mock/gomock"
@@ -27,14 +29,17 @@ import (
 	"testing"
 )
 
-const (
-	testBodyString = `{"test":"body"}`
+var (
+	registerNodeRequest = models.RegisterNodeRequest{
+		IP:     "127.0.0.1",
+		Config: map[string]interface{}{"properties": []interface{}{}},
+	}
+	pingRequest          = models.PingRequest{Interval: "10"}
+	configurationRequest = models.Configuration{
+		Properties: []map[string]interface{}{{"devicename": "name"}},
+	}
 )
 
-var testBody = map[string]interface{}{
-	"test": "body",
-}
-
 var Handler Command
 
 func init() {
@@ -78,7 +83,7 @@ func TestCalledHandleWithGetNodesRequest_ExpectCalledGetNodes(t *testing.T) {
 	nodemanageMockObj := nodemanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodemanageMockObj.EXPECT().GetNodes(),
+		nodemanageMockObj.EXPECT().GetNodes(gomock.Any()).Return(results.OK, models.NodeList{Nodes: []models.Node{}}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -88,6 +93,10 @@ func TestCalledHandleWithGetNodesRequest_ExpectCalledGetNodes(t *testing.T) {
 	managementExecutor = nodemanageMockObj
 
 	Handler.Handle(w, req)
+
+	if w.Body.String() != `{"nodes":[]}` {
+		t.Errorf("Unexpected body: %s", w.Body.String())
+	}
 }
 
 func TestCalledHandleWithGetNodeRequest_ExpectCalledGetNode(t *testing.T) {
@@ -97,7 +106,7 @@ func TestCalledHandleWithGetNodeRequest_ExpectCalledGetNode(t *testing.T) {
 	nodemanageMockObj := nodemanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodemanageMockObj.EXPECT().GetNode("nodeID"),
+		nodemanageMockObj.EXPECT().GetNode(gomock.Any(), "nodeID").Return(results.OK, models.Node{ID: "nodeID"}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -116,11 +125,11 @@ func TestCalledHandleWithRegisterNodeRequest_ExpectCalledRegisterNode(t *testing
 	nodemanageMockObj := nodemanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodemanageMockObj.EXPECT().RegisterNode(testBodyString),
+		nodemanageMockObj.EXPECT().RegisterNode(gomock.Any(), registerNodeRequest).Return(results.OK, models.IDResponse{ID: "nodeID"}, nil),
 	)
 
 	w := httptest.NewRecorder()
-	body, _ := json.Marshal(testBody)
+	body, _ := json.Marshal(registerNodeRequest)
 	req, _ := http.NewRequest("POST", "/api/v1/management/nodes/register", bytes.NewReader(body))
 
 	// pass mockObj to a real object.
@@ -136,7 +145,7 @@ func TestCalledHandleWithUnregisterNodeRequest_ExpectCalledUnregisterNode(t *tes
 	nodemanageMockObj := nodemanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodemanageMockObj.EXPECT().UnRegisterNode("nodeID"),
+		nodemanageMockObj.EXPECT().UnRegisterNode(gomock.Any(), "nodeID").Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -155,11 +164,11 @@ func TestCalledHandleWithPingNodeRequest_ExpectCalledPingNode(t *testing.T) {
 	nodemanageMockObj := nodemanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodemanageMockObj.EXPECT().PingNode("nodeID", testBodyString),
+		nodemanageMockObj.EXPECT().PingNode(gomock.Any(), "nodeID", pingRequest).Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
-	body, _ := json.Marshal(testBody)
+	body, _ := json.Marshal(pingRequest)
 	req, _ := http.NewRequest("POST", "/api/v1/management/nodes/nodeID/ping", bytes.NewReader(body))
 
 	// pass mockObj to a real object.
@@ -175,7 +184,7 @@ func TestCalledHandleWithConfigurationNodeRequest_ExpectCalledGetNodeConfigurati
 	nodemanageMockObj := nodemanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodemanageMockObj.EXPECT().GetNodeConfiguration("nodeID"),
+		nodemanageMockObj.EXPECT().GetNodeConfiguration(gomock.Any(), "nodeID").Return(results.OK, configurationRequest, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -194,11 +203,11 @@ func TestCalledHandleWithConfigurationNodeRequest_ExpectCalledSetNodeConfigurati
 	nodemanageMockObj := nodemanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodemanageMockObj.EXPECT().SetNodeConfiguration("nodeID", testBodyString),
+		nodemanageMockObj.EXPECT().SetNodeConfiguration(gomock.Any(), "nodeID", configurationRequest).Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
-	body, _ := json.Marshal(testBody)
+	body, _ := json.Marshal(configurationRequest)
 	req, _ := http.NewRequest("POST", "/api/v1/management/nodes/nodeID/configuration", bytes.NewReader(body))
 
 	// pass mockObj to a real object.
@@ -214,7 +223,7 @@ func TestRebootRequest_ExpectRebootCalled(t *testing.T) {
 	nodemanageMockObj := nodemanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodemanageMockObj.EXPECT().Reboot("nodeID"),
+		nodemanageMockObj.EXPECT().Reboot(gomock.Any(), "nodeID").Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -233,7 +242,7 @@ func TestRestoreRequest_ExpectRestoreCalled(t *testing.T) {
 	nodemanageMockObj := nodemanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodemanageMockObj.EXPECT().Restore("nodeID"),
+		nodemanageMockObj.EXPECT().Restore(gomock.Any(), "nodeID").Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -244,3 +253,119 @@ func TestRestoreRequest_ExpectRestoreCalled(t *testing.T) {
 
 	Handler.Handle(w, req)
 }
+
+func TestCalledHandleWithGetDriftsRequest_ExpectCalledGetConfigurationDrifts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nodemanageMockObj := nodemanagermocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		nodemanageMockObj.EXPECT().GetConfigurationDrifts(gomock.Any()).Return(results.OK, models.DriftList{}, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/management/nodes/drift", nil)
+
+	// pass mockObj to a real object.
+	managementExecutor = nodemanageMockObj
+
+	Handler.Handle(w, req)
+}
+
+func TestCalledHandleWithCheckDriftsRequest_ExpectCalledCheckConfigurationDrifts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nodemanageMockObj := nodemanagermocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		nodemanageMockObj.EXPECT().CheckConfigurationDrifts(gomock.Any(), true).Return(results.OK, models.DriftList{}, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/management/nodes/drift?notify=true", nil)
+
+	// pass mockObj to a real object.
+	managementExecutor = nodemanageMockObj
+
+	Handler.Handle(w, req)
+}
+
+func TestCalledHandleWithGetDriftRequest_ExpectCalledGetConfigurationDrift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nodemanageMockObj := nodemanagermocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		nodemanageMockObj.EXPECT().GetConfigurationDrift(gomock.Any(), "nodeID").Return(results.OK, models.Drift{}, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/management/nodes/nodeID/configuration/drift", nil)
+
+	// pass mockObj to a real object.
+	managementExecutor = nodemanageMockObj
+
+	Handler.Handle(w, req)
+}
+
+func TestCalledHandleWithCheckDriftRequest_ExpectCalledCheckConfigurationDrift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nodemanageMockObj := nodemanagermocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		nodemanageMockObj.EXPECT().CheckConfigurationDrift(gomock.Any(), "nodeID", false).Return(results.OK, models.Drift{}, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/management/nodes/nodeID/configuration/drift", nil)
+
+	// pass mockObj to a real object.
+	managementExecutor = nodemanageMockObj
+
+	Handler.Handle(w, req)
+}
+
+func TestCalledHandleWithInvalidRegisterNodeRequest_UnExpectCalledRegisterNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nodemanageMockObj := nodemanagermocks.NewMockCommand(ctrl)
+
+	w := httptest.NewRecorder()
+	body := []byte(`{"ip"}`)
+	req, _ := http.NewRequest("POST", "/api/v1/management/nodes/register", bytes.NewReader(body))
+
+	// pass mockObj to a real object.
+	managementExecutor = nodemanageMockObj
+
+	Handler.Handle(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCalledHandleWithInvalidSetNodeConfigurationRequest_UnExpectCalledSetNodeConfiguration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nodemanageMockObj := nodemanagermocks.NewMockCommand(ctrl)
+
+	w := httptest.NewRecorder()
+	body := []byte(`{"properties":["devicename"]}`)
+	req, _ := http.NewRequest("POST", "/api/v1/management/nodes/nodeID/configuration", bytes.NewReader(body))
+
+	// pass mockObj to a real object.
+	managementExecutor = nodemanageMockObj
+
+	Handler.Handle(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusBadRequest, w.Code)
+	}
+}
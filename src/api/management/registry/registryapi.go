@@ -22,6 +22,7 @@ import (
 	"api/common"
 	"commons/errors"
 	"commons/logger"
+	"commons/models"
 	"commons/results"
 	URL "commons/url"
 	"controller/management/registry"
@@ -62,9 +63,9 @@ func init() {
 
 // Handle calls a proper function according to the url and method received from remote device.
 func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
-	logger.Logging(logger.DEBUG, "receive msg", req.Method, req.URL.Path)
+	logger.With(req.Context()).Logging(logger.DEBUG, "IN")
+	defer logger.With(req.Context()).Logging(logger.DEBUG, "OUT")
+	logger.With(req.Context()).Logging(logger.DEBUG, "receive msg", req.Method, req.URL.Path)
 
 	url := strings.Replace(req.URL.Path, URL.Base()+URL.Management()+URL.Registries(), "", -1)
 	split := strings.Split(url, "/")
@@ -98,41 +99,42 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 }
 
 func (registryAPIExecutor) registerDockerRegistry(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+	logger.With(req.Context()).Logging(logger.DEBUG, "IN")
+	defer logger.With(req.Context()).Logging(logger.DEBUG, "OUT")
 
-	body, err := common.GetBodyFromReq(req)
+	request := models.RegistryRequest{}
+	err := common.DecodeBodyFromReq(req, &request)
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
 	}
 
-	result, resp, err := registryExecutor.AddDockerRegistry(body)
+	result, resp, err := registryExecutor.AddDockerRegistry(req.Context(), request)
 
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 func (registryAPIExecutor) deleteDockerRegistry(w http.ResponseWriter, req *http.Request, registryID string) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+	logger.With(req.Context()).Logging(logger.DEBUG, "IN")
+	defer logger.With(req.Context()).Logging(logger.DEBUG, "OUT")
 
-	result, err := registryExecutor.DeleteDockerRegistry(registryID)
+	result, err := registryExecutor.DeleteDockerRegistry(req.Context(), registryID)
 
 	common.MakeResponse(w, result, common.ChangeToJson(nil), err)
 }
 
 func (registryAPIExecutor) getDockerRegistries(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+	logger.With(req.Context()).Logging(logger.DEBUG, "IN")
+	defer logger.With(req.Context()).Logging(logger.DEBUG, "OUT")
 
-	result, resp, err := registryExecutor.GetDockerRegistries()
+	result, resp, err := registryExecutor.GetDockerRegistries(req.Context())
 
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 func (registryAPIExecutor) handleDockerRegistryEvent(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+	logger.With(req.Context()).Logging(logger.DEBUG, "IN")
+	defer logger.With(req.Context()).Logging(logger.DEBUG, "OUT")
 
 	body, err := common.GetBodyFromReq(req)
 	if err != nil {
@@ -140,7 +142,7 @@ func (registryAPIExecutor) handleDockerRegistryEvent(w http.ResponseWriter, req
 		return
 	}
 
-	result, err := registryExecutor.DockerRegistryEventHandler(body)
+	result, err := registryExecutor.DockerRegistryEventHandler(req.Context(), body)
 
 	common.MakeResponse(w, result, common.ChangeToJson(nil), err)
 }
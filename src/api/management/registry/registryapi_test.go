@@ -19,6 +19,8 @@ package registry
 
 import (
 	"bytes"
+	"commons/models"
+	"commons/results"
 	registrymanagermocks "controller/management/registry/mocks"
 	"encoding/json"
 	"github.com/golang/mock/gomock"
@@ -35,6 +37,8 @@ var testBody = map[string]interface{}{
 	"test": "body",
 }
 
+var registryRequest = models.RegistryRequest{IP: "127.0.0.1"}
+
 var Handler Command
 
 func init() {
@@ -78,7 +82,7 @@ func TestCalledHandleWithGetRegistriesRequest_ExpectCalledGetRegistries(t *testi
 	registrymanageMockObj := registrymanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		registrymanageMockObj.EXPECT().GetDockerRegistries(),
+		registrymanageMockObj.EXPECT().GetDockerRegistries(gomock.Any()).Return(results.OK, models.RegistryList{}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -97,17 +101,40 @@ func TestCalledHandleWithAddRegistryRequest_ExpectCalledAddDockerRegistry(t *tes
 	registrymanageMockObj := registrymanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		registrymanageMockObj.EXPECT().AddDockerRegistry(testBodyString),
+		registrymanageMockObj.EXPECT().AddDockerRegistry(gomock.Any(), registryRequest).Return(results.OK, models.IDResponse{ID: "registryID"}, nil),
 	)
 
 	w := httptest.NewRecorder()
-	body, _ := json.Marshal(testBody)
+	body, _ := json.Marshal(registryRequest)
 	req, _ := http.NewRequest("POST", "/api/v1/management/registries", bytes.NewReader(body))
 
 	// pass mockObj to a real object.
 	registryExecutor = registrymanageMockObj
 
 	Handler.Handle(w, req)
+
+	if w.Body.String() != `{"id":"registryID"}` {
+		t.Errorf("Unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestCalledHandleWithInvalidAddRegistryRequest_UnExpectCalledAddDockerRegistry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	registrymanageMockObj := registrymanagermocks.NewMockCommand(ctrl)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/management/registries", bytes.NewReader([]byte(`{"ip"}`)))
+
+	// pass mockObj to a real object.
+	registryExecutor = registrymanageMockObj
+
+	Handler.Handle(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusBadRequest, w.Code)
+	}
 }
 
 func TestCalledHandleWithDeleteRegistryRequest_ExpectCalledDeleteDockerRegistry(t *testing.T) {
@@ -117,7 +144,7 @@ func TestCalledHandleWithDeleteRegistryRequest_ExpectCalledDeleteDockerRegistry(
 	registrymanageMockObj := registrymanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		registrymanageMockObj.EXPECT().DeleteDockerRegistry("registryID"),
+		registrymanageMockObj.EXPECT().DeleteDockerRegistry(gomock.Any(), "registryID").Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -151,7 +178,7 @@ func TestCalledHandleWithImagesEventReq_ExpectCalledEventHandler(t *testing.T) {
 	registrymanageMockObj := registrymanagermocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		registrymanageMockObj.EXPECT().DockerRegistryEventHandler(testBodyString),
+		registrymanageMockObj.EXPECT().DockerRegistryEventHandler(gomock.Any(), testBodyString).Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
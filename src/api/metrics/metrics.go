@@ -0,0 +1,51 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package metrics
+
+import (
+	"api/common"
+	"commons/errors"
+	"commons/logger"
+	"commons/metrics"
+	"net/http"
+)
+
+const CONTENT_TYPE_HEADER = "Content-Type"
+
+type Command interface {
+	Handle(w http.ResponseWriter, req *http.Request)
+}
+
+type RequestHandler struct{}
+
+// Handle writes metrics collected by pharos-anchor to be scraped by Prometheus.
+func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
+	logger.With(req.Context()).Logging(logger.DEBUG)
+	defer logger.With(req.Context()).Logging(logger.DEBUG, "OUT")
+
+	if req.Method != http.MethodGet {
+		common.WriteError(w, errors.InvalidMethod{req.Method})
+		return
+	}
+
+	w.Header().Set(CONTENT_TYPE_HEADER, metrics.CONTENT_TYPE)
+	w.WriteHeader(http.StatusOK)
+	err := metrics.Write(w)
+	if err != nil {
+		logger.With(req.Context()).Logging(logger.ERROR, err.Error())
+	}
+}
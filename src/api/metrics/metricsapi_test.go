@@ -0,0 +1,55 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package metrics
+
+import (
+	"commons/metrics"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var counter = metrics.NewCounter("metricsapi_test_total", "Counter for the test.")
+
+func TestCalledHandleWithGetRequest_ExpectMetricsWritten(t *testing.T) {
+	counter.Inc()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	RequestHandler{}.Handle(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusOK, w.Code)
+	}
+	if contentType := w.Header().Get(CONTENT_TYPE_HEADER); contentType != metrics.CONTENT_TYPE {
+		t.Errorf("Expected content type: %s, actual content type: %s", metrics.CONTENT_TYPE, contentType)
+	}
+	if !strings.Contains(w.Body.String(), "metricsapi_test_total 1\n") {
+		t.Errorf("Unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestCalledHandleWithInvalidMethod_ExpectErrorReturn(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/metrics", nil)
+	RequestHandler{}.Handle(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,165 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package api/monitoring/alert provides functionality to handle requests
+// related to alert rules on resource usage and their alerts.
+package alert
+
+import (
+	"api/common"
+	"commons/errors"
+	"commons/logger"
+	"commons/results"
+	URL "commons/url"
+	"controller/monitoring/resource/alert"
+	"net/http"
+	"strings"
+)
+
+const (
+	GET    string = "GET"
+	POST   string = "POST"
+	DELETE string = "DELETE"
+)
+
+type Command interface {
+	Handle(w http.ResponseWriter, req *http.Request)
+}
+
+type alertMonitoringAPI interface {
+	addRule(w http.ResponseWriter, req *http.Request)
+	getRules(w http.ResponseWriter, req *http.Request)
+	getRule(w http.ResponseWriter, req *http.Request, ruleId string)
+	deleteRule(w http.ResponseWriter, req *http.Request, ruleId string)
+	getAlerts(w http.ResponseWriter, req *http.Request)
+}
+
+type RequestHandler struct{}
+type alertAPIExecutor struct {
+	alertMonitoringAPI
+}
+
+var alertAPI alertAPIExecutor
+var alertExecutor alert.Command
+
+func init() {
+	alertExecutor = alert.Executor{}
+}
+
+// Handle calls a proper function according to the url and method received from remote device.
+func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "IN")
+	defer logger.With(req.Context()).Logging(logger.DEBUG, "OUT")
+	logger.With(req.Context()).Logging(logger.DEBUG, "receive msg", req.Method, req.URL.Path)
+
+	url := strings.TrimPrefix(req.URL.Path, URL.Base()+URL.Monitoring()+URL.Alerts())
+	split := strings.Split(url, "/")
+
+	switch {
+	default:
+		common.WriteError(w, errors.NotFoundURL{})
+
+	case len(url) == 0:
+		if req.Method == GET {
+			alertAPI.getAlerts(w, req)
+		} else {
+			common.WriteError(w, errors.InvalidMethod{req.Method})
+		}
+
+	case len(split) == 2 && "/"+split[1] == URL.Rules(): // [,rules]
+		if req.Method == GET {
+			alertAPI.getRules(w, req)
+		} else if req.Method == POST {
+			alertAPI.addRule(w, req)
+		} else {
+			common.WriteError(w, errors.InvalidMethod{req.Method})
+		}
+
+	case len(split) == 3 && "/"+split[1] == URL.Rules(): // [,rules,{ruleId}]
+		ruleId := split[2]
+		if req.Method == GET {
+			alertAPI.getRule(w, req, ruleId)
+		} else if req.Method == DELETE {
+			alertAPI.deleteRule(w, req, ruleId)
+		} else {
+			common.WriteError(w, errors.InvalidMethod{req.Method})
+		}
+	}
+}
+
+// addRule handles requests to add a rule which raises alerts on resource usage.
+//
+//	paths: '/api/v1/monitoring/alerts/rules'
+//	method: POST
+//	responses: if successful, 200 status code will be returned.
+func (alertAPIExecutor) addRule(w http.ResponseWriter, req *http.Request) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[ALERT] Add Rule")
+
+	body, err := common.GetBodyFromReq(req)
+	if err != nil {
+		common.MakeResponse(w, results.ERROR, nil, err)
+		return
+	}
+
+	result, resp, err := alertExecutor.AddRule(req.Context(), body)
+	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+}
+
+// getRules handles requests to get all alert rules.
+//
+//	paths: '/api/v1/monitoring/alerts/rules'
+//	method: GET
+//	responses: if successful, 200 status code will be returned.
+func (alertAPIExecutor) getRules(w http.ResponseWriter, req *http.Request) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[ALERT] Get Rules")
+	result, resp, err := alertExecutor.GetRules(req.Context())
+	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+}
+
+// getRule handles requests to get an alert rule identified by the given ruleId.
+//
+//	paths: '/api/v1/monitoring/alerts/rules/{ruleId}'
+//	method: GET
+//	responses: if successful, 200 status code will be returned.
+func (alertAPIExecutor) getRule(w http.ResponseWriter, req *http.Request, ruleId string) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[ALERT] Get Rule")
+	result, resp, err := alertExecutor.GetRule(req.Context(), ruleId)
+	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+}
+
+// deleteRule handles requests to delete an alert rule identified by the given ruleId.
+//
+//	paths: '/api/v1/monitoring/alerts/rules/{ruleId}'
+//	method: DELETE
+//	responses: if successful, 200 status code will be returned.
+func (alertAPIExecutor) deleteRule(w http.ResponseWriter, req *http.Request, ruleId string) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[ALERT] Delete Rule")
+	result, err := alertExecutor.DeleteRule(req.Context(), ruleId)
+	common.MakeResponse(w, result, common.ChangeToJson(nil), err)
+}
+
+// getAlerts handles requests to get alerts fired by rules.
+//
+//	paths: '/api/v1/monitoring/alerts'
+//	method: GET
+//	query: 'status' (firing or resolved), 'nodeId' and 'ruleId'
+//	responses: if successful, 200 status code will be returned.
+func (alertAPIExecutor) getAlerts(w http.ResponseWriter, req *http.Request) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[ALERT] Get Alerts")
+	result, resp, err := alertExecutor.GetAlerts(req.Context(), req.URL.Query())
+	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+}
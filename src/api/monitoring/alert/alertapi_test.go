@@ -0,0 +1,128 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package alert
+
+import (
+	"bytes"
+	"commons/results"
+	alertmocks "controller/monitoring/resource/alert/mocks"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	ruleId = "000000000000000000000001"
+	body   = `{"target":"node","metric":"cpu","operator":">","threshold":90}`
+)
+
+var Handler Command
+
+func init() {
+	Handler = RequestHandler{}
+}
+
+func TestCalledHandleWithAddRuleRequest_ExpectCalledAddRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alertMockObj := alertmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		alertMockObj.EXPECT().AddRule(gomock.Any(), body).Return(results.OK, map[string]interface{}{"id": ruleId}, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/monitoring/alerts/rules", bytes.NewReader([]byte(body)))
+
+	// pass mockObj to a real object.
+	alertExecutor = alertMockObj
+
+	Handler.Handle(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestCalledHandleWithRuleRequest_ExpectCalledProperMethod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alertMockObj := alertmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		alertMockObj.EXPECT().GetRules(gomock.Any()).Return(results.OK, nil, nil),
+		alertMockObj.EXPECT().GetRule(gomock.Any(), ruleId).Return(results.OK, nil, nil),
+		alertMockObj.EXPECT().DeleteRule(gomock.Any(), ruleId).Return(results.OK, nil),
+		alertMockObj.EXPECT().GetAlerts(gomock.Any(), map[string][]string{"status": {"firing"}}).Return(results.OK, nil, nil),
+	)
+
+	// pass mockObj to a real object.
+	alertExecutor = alertMockObj
+
+	for _, request := range []struct {
+		method string
+		url    string
+	}{
+		{"GET", "/api/v1/monitoring/alerts/rules"},
+		{"GET", "/api/v1/monitoring/alerts/rules/" + ruleId},
+		{"DELETE", "/api/v1/monitoring/alerts/rules/" + ruleId},
+		{"GET", "/api/v1/monitoring/alerts?status=firing"},
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(request.method, request.url, nil)
+
+		Handler.Handle(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected code of %s %s: %d, actual code: %d", request.method, request.url, http.StatusOK, w.Code)
+		}
+	}
+}
+
+func TestCalledHandleWithInvalidRequest_ExpectErrorReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alertMockObj := alertmocks.NewMockCommand(ctrl)
+
+	// pass mockObj to a real object.
+	alertExecutor = alertMockObj
+
+	for _, request := range []struct {
+		method string
+		url    string
+		code   int
+	}{
+		{"PUT", "/api/v1/monitoring/alerts/rules", http.StatusBadRequest},
+		{"POST", "/api/v1/monitoring/alerts/rules/" + ruleId, http.StatusBadRequest},
+		{"GET", "/api/v1/monitoring/alerts/unknown", http.StatusNotFound},
+		{"GET", "/api/v1/monitoring/alerts/rules/" + ruleId + "/unknown", http.StatusNotFound},
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(request.method, request.url, nil)
+
+		Handler.Handle(w, req)
+
+		if w.Code != request.code {
+			t.Errorf("Expected code of %s %s: %d, actual code: %d", request.method, request.url, request.code, w.Code)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package monitoring
 
 import (
 	"api/common"
+	"api/monitoring/alert"
 	"api/monitoring/resource"
 	"commons/errors"
 	"commons/logger"
@@ -34,26 +35,32 @@ type Command interface {
 type RequestHandler struct{}
 
 var resourceMonitoringHandler resource.Command
+var alertMonitoringHandler alert.Command
 
 func init() {
 	resourceMonitoringHandler = resource.RequestHandler{}
+	alertMonitoringHandler = alert.RequestHandler{}
 }
 
 func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG, "receive msg", req.Method, req.URL.Path)
-	defer logger.Logging(logger.DEBUG, "OUT")
+	logger.With(req.Context()).Logging(logger.DEBUG, "receive msg", req.Method, req.URL.Path)
+	defer logger.With(req.Context()).Logging(logger.DEBUG, "OUT")
 
 	switch url := req.URL.Path; {
 	default:
-		logger.Logging(logger.DEBUG, "Unknown URL")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Unknown URL")
 		common.WriteError(w, errors.NotFoundURL{})
 
 	case !strings.Contains(url, URL.Base()):
-		logger.Logging(logger.DEBUG, "Unknown URL")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Unknown URL")
 		common.WriteError(w, errors.NotFoundURL{})
 
+	case strings.HasPrefix(url, URL.Base()+URL.Monitoring()+URL.Alerts()):
+		logger.With(req.Context()).Logging(logger.DEBUG, "Request Alert APIs")
+		alertMonitoringHandler.Handle(w, req)
+
 	case strings.Contains(url, URL.Resource()):
-		logger.Logging(logger.DEBUG, "Request Resource APIs")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Request Resource APIs")
 		resourceMonitoringHandler.Handle(w, req)
 	}
 }
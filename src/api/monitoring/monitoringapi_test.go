@@ -18,6 +18,7 @@
 package monitoring
 
 import (
+	alertmocks "api/monitoring/alert/mocks"
 	resourcemocks "api/monitoring/resource/mocks"
 	"github.com/golang/mock/gomock"
 	"net/http"
@@ -98,3 +99,24 @@ func TestCalledHandleWithGetAppResourceNodeRequest_ExpectCalledNodeHandle(t *tes
 
 	Handler.Handle(w, req)
 }
+
+func TestCalledHandleWithAlertRequest_ExpectCalledAlertHandle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceHandlerMockObj := resourcemocks.NewMockCommand(ctrl)
+	alertHandlerMockObj := alertmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		alertHandlerMockObj.EXPECT().Handle(gomock.Any(), gomock.Any()),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/monitoring/alerts/rules", nil)
+
+	// pass mockObj to a real object.
+	resourceMonitoringHandler = resourceHandlerMockObj
+	alertMonitoringHandler = alertHandlerMockObj
+
+	Handler.Handle(w, req)
+}
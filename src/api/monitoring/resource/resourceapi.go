@@ -22,6 +22,8 @@ import (
 	"commons/errors"
 	"commons/logger"
 	URL "commons/url"
+	groupResource "controller/monitoring/resource/group"
+	"controller/monitoring/resource/history"
 	resource "controller/monitoring/resource/node"
 	"net/http"
 	"strings"
@@ -38,6 +40,11 @@ type Command interface {
 type resourceMonitoringAPI interface {
 	getNodeResourceInfo(w http.ResponseWriter, req *http.Request, nodeId string)
 	getAppResourceInfo(w http.ResponseWriter, req *http.Request, nodeId string)
+	getNodeResourceHistory(w http.ResponseWriter, req *http.Request, nodeId string)
+	getGroupResourceHistory(w http.ResponseWriter, req *http.Request, groupId string)
+	getGroupResourceInfo(w http.ResponseWriter, req *http.Request, groupId string)
+	getGroupAppResourceInfo(w http.ResponseWriter, req *http.Request, groupId string, appId string)
+	getTopNodes(w http.ResponseWriter, req *http.Request)
 }
 
 type RequestHandler struct{}
@@ -47,15 +54,38 @@ type resourceAPIExecutor struct {
 
 var resourceAPI resourceAPIExecutor
 var resourceExecutor resource.Command
+var historyExecutor history.Command
+var groupResourceExecutor groupResource.Command
 
 func init() {
 	resourceExecutor = resource.Executor{}
+	historyExecutor = history.Executor{}
+	groupResourceExecutor = groupResource.Executor{}
 }
 
 func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
-	url := strings.Replace(req.URL.Path, URL.Base()+URL.Monitoring()+URL.Nodes(), "", -1)
+	switch url := req.URL.Path; {
+	case url == URL.Base()+URL.Monitoring()+URL.Resource()+URL.Top():
+		if req.Method == GET {
+			resourceAPI.getTopNodes(w, req)
+		} else {
+			common.WriteError(w, errors.InvalidMethod{req.Method})
+		}
+
+	case strings.HasPrefix(url, URL.Base()+URL.Monitoring()+URL.Groups()+"/"):
+		handleGroupResource(w, req, strings.TrimPrefix(url, URL.Base()+URL.Monitoring()+URL.Groups()))
+
+	default:
+		handleNodeResource(w, req, strings.Replace(url, URL.Base()+URL.Monitoring()+URL.Nodes(), "", -1))
+	}
+}
+
+func handleNodeResource(w http.ResponseWriter, req *http.Request, url string) {
 	split := strings.Split(url, "/")
 	switch len(split) {
+	default:
+		common.WriteError(w, errors.NotFoundURL{})
+
 	case 3: // [,{nodeId},resource]
 		nodeId := split[1]
 		if "/"+split[2] == URL.Resource() {
@@ -68,6 +98,18 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 			common.WriteError(w, errors.NotFoundURL{})
 		}
 
+	case 4: // [,{nodeId},resource,history]
+		nodeId := split[1]
+		if "/"+split[2] == URL.Resource() && "/"+split[3] == URL.History() {
+			if req.Method == GET {
+				resourceAPI.getNodeResourceHistory(w, req, nodeId)
+			} else {
+				common.WriteError(w, errors.InvalidMethod{req.Method})
+			}
+		} else {
+			common.WriteError(w, errors.NotFoundURL{})
+		}
+
 	case 5: // [,{nodeId},apps,{appId},resource]
 		if "/"+split[2] == URL.Apps() {
 			nodeId := split[1]
@@ -83,6 +125,38 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+func handleGroupResource(w http.ResponseWriter, req *http.Request, url string) {
+	split := strings.Split(url, "/")
+	switch {
+	case len(split) == 3 && "/"+split[2] == URL.Resource():
+		// [,{groupId},resource]
+		if req.Method == GET {
+			resourceAPI.getGroupResourceInfo(w, req, split[1])
+		} else {
+			common.WriteError(w, errors.InvalidMethod{req.Method})
+		}
+
+	case len(split) == 5 && "/"+split[2] == URL.Apps() && "/"+split[4] == URL.Resource():
+		// [,{groupId},apps,{appId},resource]
+		if req.Method == GET {
+			resourceAPI.getGroupAppResourceInfo(w, req, split[1], split[3])
+		} else {
+			common.WriteError(w, errors.InvalidMethod{req.Method})
+		}
+
+	case len(split) == 4 && "/"+split[2] == URL.Resource() && "/"+split[3] == URL.History():
+		// [,{groupId},resource,history]
+		if req.Method == GET {
+			resourceAPI.getGroupResourceHistory(w, req, split[1])
+		} else {
+			common.WriteError(w, errors.InvalidMethod{req.Method})
+		}
+
+	default:
+		common.WriteError(w, errors.NotFoundURL{})
+	}
+}
+
 // getNodeResourceInfo handles requests related to get node's resource informaion
 // identified by the given nodeId.
 //
@@ -90,9 +164,9 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 //    method: GET
 //    responses: if successful, 200 status code will be returned.
 func (resourceAPIExecutor) getNodeResourceInfo(w http.ResponseWriter, req *http.Request, nodeId string) {
-	logger.Logging(logger.DEBUG, "[NODE] Get Resource Info")
-	result, resp, err := resourceExecutor.GetNodeResourceInfo(nodeId)
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Get Resource Info")
+	result, resp, err := resourceExecutor.GetNodeResourceInfo(req.Context(), nodeId)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 // getAppResourceInfo handles requests related to get app's resource informaion deployed on the specific node.
@@ -102,7 +176,70 @@ func (resourceAPIExecutor) getNodeResourceInfo(w http.ResponseWriter, req *http.
 //    method: GET
 //    responses: if successful, 200 status code will be returned.
 func (resourceAPIExecutor) getAppResourceInfo(w http.ResponseWriter, req *http.Request, nodeId string, appId string) {
-	logger.Logging(logger.DEBUG, "[NODE] Get Performance Info")
-	result, resp, err := resourceExecutor.GetAppResourceInfo(nodeId, appId)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Get Performance Info")
+	result, resp, err := resourceExecutor.GetAppResourceInfo(req.Context(), nodeId, appId)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
+}
+
+// getNodeResourceHistory handles requests to get resource usage of a node over time.
+//
+//    paths: '/api/v1/monitoring/nodes/{nodeId}/resource/history'
+//    method: GET
+//    query: 'resolution' (raw, 5m or 1h), 'from' and 'to' in RFC3339
+//    responses: if successful, 200 status code will be returned.
+func (resourceAPIExecutor) getNodeResourceHistory(w http.ResponseWriter, req *http.Request, nodeId string) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Get Resource History")
+	result, resp, err := historyExecutor.GetNodeResourceHistory(req.Context(), nodeId, req.URL.Query())
+	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+}
+
+// getGroupResourceHistory handles requests to get average and maximum
+// resource usage of members of a group over time.
+//
+//    paths: '/api/v1/monitoring/groups/{groupId}/resource/history'
+//    method: GET
+//    query: 'resolution' (raw, 5m or 1h), 'from' and 'to' in RFC3339
+//    responses: if successful, 200 status code will be returned.
+func (resourceAPIExecutor) getGroupResourceHistory(w http.ResponseWriter, req *http.Request, groupId string) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Get Resource History")
+	result, resp, err := historyExecutor.GetGroupResourceHistory(req.Context(), groupId, req.URL.Query())
+	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+}
+
+// getGroupResourceInfo handles requests to get resource usage of all members of a group
+// with statistics over them.
+//
+//    paths: '/api/v1/monitoring/groups/{groupId}/resource'
+//    method: GET
+//    responses: if successful, 200 status code will be returned.
+//               if some of members fail, 207 status code will be returned.
+func (resourceAPIExecutor) getGroupResourceInfo(w http.ResponseWriter, req *http.Request, groupId string) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Get Resource Info")
+	result, resp, err := groupResourceExecutor.GetGroupResourceInfo(req.Context(), groupId)
+	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+}
+
+// getGroupAppResourceInfo handles requests to get resource usage of an app
+// on members of a group with statistics over them.
+//
+//    paths: '/api/v1/monitoring/groups/{groupId}/apps/{appId}/resource'
+//    method: GET
+//    responses: if successful, 200 status code will be returned.
+//               if some of members fail, 207 status code will be returned.
+func (resourceAPIExecutor) getGroupAppResourceInfo(w http.ResponseWriter, req *http.Request, groupId string, appId string) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Get App Resource Info")
+	result, resp, err := groupResourceExecutor.GetGroupAppResourceInfo(req.Context(), groupId, appId)
+	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+}
+
+// getTopNodes handles requests to get nodes using the most resources.
+//
+//    paths: '/api/v1/monitoring/resource/top'
+//    method: GET
+//    query: 'metric' (cpu, mem or disk), 'limit', 'groupId'
+//    responses: if successful, 200 status code will be returned.
+func (resourceAPIExecutor) getTopNodes(w http.ResponseWriter, req *http.Request) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Get Top Nodes")
+	result, resp, err := historyExecutor.GetTopNodes(req.Context(), req.URL.Query())
 	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
 }
@@ -18,6 +18,10 @@
 package resource
 
 import (
+	"commons/models"
+	"commons/results"
+	groupmocks "controller/monitoring/resource/group/mocks"
+	historymocks "controller/monitoring/resource/history/mocks"
 	resourcemocks "controller/monitoring/resource/node/mocks"
 	"github.com/golang/mock/gomock"
 	"net/http"
@@ -76,7 +80,7 @@ func TestCalledHandleWithGetNodeResourceRequest_ExpectCalledGetResourceInfo(t *t
 	resourceMockObj := resourcemocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		resourceMockObj.EXPECT().GetNodeResourceInfo("nodeId"),
+		resourceMockObj.EXPECT().GetNodeResourceInfo(gomock.Any(), "nodeId").Return(results.OK, models.NodeResource{}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -95,7 +99,7 @@ func TestCalledHandleWithGetAppResourceRequest_ExpectCalledGetResourceInfo(t *te
 	resourceMockObj := resourcemocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		resourceMockObj.EXPECT().GetAppResourceInfo("nodeId", "appId"),
+		resourceMockObj.EXPECT().GetAppResourceInfo(gomock.Any(), "nodeId", "appId").Return(results.OK, models.AppResource{}, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -106,3 +110,108 @@ func TestCalledHandleWithGetAppResourceRequest_ExpectCalledGetResourceInfo(t *te
 
 	Handler.Handle(w, req)
 }
+
+func TestCalledHandleWithGetNodeResourceHistoryRequest_ExpectCalledGetNodeResourceHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	historyMockObj := historymocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		historyMockObj.EXPECT().GetNodeResourceHistory(gomock.Any(), "nodeId", map[string][]string{"resolution": {"5m"}}).Return(results.OK, nil, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/monitoring/nodes/nodeId/resource/history?resolution=5m", nil)
+
+	// pass mockObj to a real object.
+	historyExecutor = historyMockObj
+
+	Handler.Handle(w, req)
+}
+
+func TestCalledHandleWithGetGroupResourceHistoryRequest_ExpectCalledGetGroupResourceHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	historyMockObj := historymocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		historyMockObj.EXPECT().GetGroupResourceHistory(gomock.Any(), "groupId", map[string][]string{}).Return(results.OK, nil, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/monitoring/groups/groupId/resource/history", nil)
+
+	// pass mockObj to a real object.
+	historyExecutor = historyMockObj
+
+	Handler.Handle(w, req)
+}
+
+func TestCalledHandleWithGetTopNodesRequest_ExpectCalledGetTopNodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	historyMockObj := historymocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		historyMockObj.EXPECT().GetTopNodes(gomock.Any(), map[string][]string{"metric": {"mem"}, "limit": {"3"}}).Return(results.OK, nil, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/monitoring/resource/top?metric=mem&limit=3", nil)
+
+	// pass mockObj to a real object.
+	historyExecutor = historyMockObj
+
+	Handler.Handle(w, req)
+}
+
+func TestCalledHandleWithInvalidHistoryMethod_ExpectErrorReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	historyMockObj := historymocks.NewMockCommand(ctrl)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/monitoring/groups/groupId/resource/history", nil)
+
+	// pass mockObj to a real object.
+	historyExecutor = historyMockObj
+
+	Handler.Handle(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCalledHandleWithGroupResourceRequest_ExpectCalledGroupResourceInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	groupMockObj := groupmocks.NewMockCommand(ctrl)
+
+	groupMockObj.EXPECT().GetGroupResourceInfo(gomock.Any(), "groupId").Return(results.OK, nil, nil)
+	groupMockObj.EXPECT().GetGroupAppResourceInfo(gomock.Any(), "groupId", "appId").Return(results.MULTI_STATUS, nil, nil)
+
+	// pass mockObj to a real object.
+	groupResourceExecutor = groupMockObj
+
+	for url, code := range map[string]int{
+		"/api/v1/monitoring/groups/groupId/resource":              results.OK,
+		"/api/v1/monitoring/groups/groupId/apps/appId/resource":   results.MULTI_STATUS,
+		"/api/v1/monitoring/groups/groupId/apps/appId/unknown":    http.StatusNotFound,
+		"/api/v1/monitoring/groups/groupId/apps/appId/resource/x": http.StatusNotFound,
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", url, nil)
+
+		Handler.Handle(w, req)
+
+		if w.Code != code {
+			t.Errorf("Expected code of %s: %d, actual code: %d", url, code, w.Code)
+		}
+	}
+}
@@ -21,15 +21,20 @@ import (
 	"api/common"
 	"commons/errors"
 	"commons/logger"
+	"commons/models"
 	"commons/results"
 	URL "commons/url"
 	noti "controller/notification"
+	"controller/notification/delivery"
+	"controller/notification/history"
 	"net/http"
 	"strings"
 )
 
 const (
+	GET    string = "GET"
 	POST   string = "POST"
+	PUT    string = "PUT"
 	DELETE string = "DELETE"
 )
 
@@ -40,7 +45,19 @@ type Command interface {
 type notificationEventAPI interface {
 	registerNotificationEvent(w http.ResponseWriter, req *http.Request)
 	unRegisterNotificationEvent(w http.ResponseWriter, req *http.Request, eventId string)
+	rotateSecret(w http.ResponseWriter, req *http.Request, subscriberId string)
+	getSubscriptions(w http.ResponseWriter, req *http.Request)
+	getSubscription(w http.ResponseWriter, req *http.Request, subscriberId string)
+	updateSubscription(w http.ResponseWriter, req *http.Request, subscriberId string)
+	getMissedEvents(w http.ResponseWriter, req *http.Request, subscriberId string)
+	replayMissedEvents(w http.ResponseWriter, req *http.Request, subscriberId string)
+	streamEvents(w http.ResponseWriter, req *http.Request)
 	receiveNotificationEvnet(w http.ResponseWriter, req *http.Request)
+	getEvents(w http.ResponseWriter, req *http.Request)
+	getDeliveries(w http.ResponseWriter, req *http.Request)
+	getFailedDeliveries(w http.ResponseWriter, req *http.Request)
+	replayFailedDelivery(w http.ResponseWriter, req *http.Request, deliveryId string)
+	deleteFailedDelivery(w http.ResponseWriter, req *http.Request, deliveryId string)
 }
 
 type RequestHandler struct{}
@@ -49,10 +66,14 @@ type notificationAPIExecutor struct {
 }
 
 var notiExecutor noti.Command
+var deliveryExecutor delivery.Command
+var historyExecutor history.Command
 var notificationAPI notificationAPIExecutor
 
 func init() {
 	notiExecutor = noti.Executor{}
+	deliveryExecutor = delivery.Executor{}
+	historyExecutor = history.Executor{}
 }
 
 func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
@@ -61,11 +82,13 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 
 	switch len(split) {
 	default:
-		logger.Logging(logger.DEBUG, "Unknown URL")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Unknown URL")
 		common.WriteError(w, errors.NotFoundURL{})
 	case 1:
 		if req.Method == POST {
 			notificationAPI.registerNotificationEvent(w, req)
+		} else if req.Method == GET {
+			notificationAPI.getSubscriptions(w, req)
 		} else {
 			common.WriteError(w, errors.InvalidMethod{req.Method})
 		}
@@ -77,6 +100,65 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 			if "/"+split[1] == URL.Events() {
 				notificationAPI.receiveNotificationEvnet(w, req)
 			}
+		} else if req.Method == GET && "/"+split[1] == URL.Stream() {
+			notificationAPI.streamEvents(w, req)
+		} else if req.Method == GET && "/"+split[1] == URL.Deliveries() {
+			notificationAPI.getDeliveries(w, req)
+		} else if req.Method == GET && "/"+split[1] == URL.Events() {
+			notificationAPI.getEvents(w, req)
+		} else if req.Method == GET {
+			subscriberId := split[1]
+			notificationAPI.getSubscription(w, req, subscriberId)
+		} else if req.Method == PUT {
+			subscriberId := split[1]
+			notificationAPI.updateSubscription(w, req, subscriberId)
+		} else {
+			common.WriteError(w, errors.InvalidMethod{req.Method})
+		}
+	case 3:
+		if "/"+split[2] == URL.Secret() {
+			if req.Method == POST {
+				subscriberId := split[1]
+				notificationAPI.rotateSecret(w, req, subscriberId)
+			} else {
+				common.WriteError(w, errors.InvalidMethod{req.Method})
+			}
+		} else if "/"+split[2] == URL.Events() {
+			if req.Method == GET {
+				subscriberId := split[1]
+				notificationAPI.getMissedEvents(w, req, subscriberId)
+			} else {
+				common.WriteError(w, errors.InvalidMethod{req.Method})
+			}
+		} else if "/"+split[1] != URL.Deliveries() || "/"+split[2] != URL.Failed() {
+			common.WriteError(w, errors.NotFoundURL{})
+		} else if req.Method == GET {
+			notificationAPI.getFailedDeliveries(w, req)
+		} else {
+			common.WriteError(w, errors.InvalidMethod{req.Method})
+		}
+	case 4:
+		if "/"+split[2] == URL.Events() && "/"+split[3] == URL.Replay() {
+			if req.Method == POST {
+				subscriberId := split[1]
+				notificationAPI.replayMissedEvents(w, req, subscriberId)
+			} else {
+				common.WriteError(w, errors.InvalidMethod{req.Method})
+			}
+		} else if "/"+split[1] != URL.Deliveries() || "/"+split[2] != URL.Failed() {
+			common.WriteError(w, errors.NotFoundURL{})
+		} else if req.Method == DELETE {
+			deliveryId := split[3]
+			notificationAPI.deleteFailedDelivery(w, req, deliveryId)
+		} else {
+			common.WriteError(w, errors.InvalidMethod{req.Method})
+		}
+	case 5:
+		if "/"+split[1] != URL.Deliveries() || "/"+split[2] != URL.Failed() || "/"+split[4] != URL.Replay() {
+			common.WriteError(w, errors.NotFoundURL{})
+		} else if req.Method == POST {
+			deliveryId := split[3]
+			notificationAPI.replayFailedDelivery(w, req, deliveryId)
 		} else {
 			common.WriteError(w, errors.InvalidMethod{req.Method})
 		}
@@ -84,32 +166,116 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 }
 
 func (notificationAPIExecutor) registerNotificationEvent(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG, "[Notification] registration")
-	body, err := common.GetBodyFromReq(req)
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Notification] registration")
+	subscription := models.SubscriptionRequest{}
+	err := common.DecodeBodyFromReq(req, &subscription)
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
 	}
 
-	result, resp, err := notiExecutor.Register(body, req.URL.Query())
-	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+	result, resp, err := notiExecutor.Register(req.Context(), subscription, req.URL.Query())
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
 }
 
 func (notificationAPIExecutor) unRegisterNotificationEvent(w http.ResponseWriter, req *http.Request, eventId string) {
-	logger.Logging(logger.DEBUG, "[Notification] un-registration")
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Notification] un-registration")
 
-	result, err := notiExecutor.UnRegister(eventId)
+	result, err := notiExecutor.UnRegister(req.Context(), eventId)
 	common.MakeResponse(w, result, nil, err)
 }
 
+func (notificationAPIExecutor) rotateSecret(w http.ResponseWriter, req *http.Request, subscriberId string) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Notification] rotate secret")
+
+	result, resp, err := notiExecutor.RotateSecret(req.Context(), subscriberId)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
+}
+
+func (notificationAPIExecutor) getSubscriptions(w http.ResponseWriter, req *http.Request) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Notification] get subscriptions")
+
+	result, resp, err := notiExecutor.GetSubscriptions(req.Context())
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
+}
+
+func (notificationAPIExecutor) getSubscription(w http.ResponseWriter, req *http.Request, subscriberId string) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Notification] get subscription")
+
+	result, resp, err := notiExecutor.GetSubscription(req.Context(), subscriberId)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
+}
+
+func (notificationAPIExecutor) updateSubscription(w http.ResponseWriter, req *http.Request, subscriberId string) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Notification] update subscription")
+	update := models.SubscriptionUpdate{}
+	err := common.DecodeBodyFromReq(req, &update)
+	if err != nil {
+		common.MakeResponse(w, results.ERROR, nil, err)
+		return
+	}
+
+	result, resp, err := notiExecutor.UpdateSubscription(req.Context(), subscriberId, update)
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
+}
+
+func (notificationAPIExecutor) getMissedEvents(w http.ResponseWriter, req *http.Request, subscriberId string) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Notification] get missed events")
+
+	result, resp, err := notiExecutor.GetMissedEvents(req.Context(), subscriberId, req.URL.Query())
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
+}
+
+func (notificationAPIExecutor) replayMissedEvents(w http.ResponseWriter, req *http.Request, subscriberId string) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Notification] replay missed events")
+
+	result, resp, err := notiExecutor.ReplayMissedEvents(req.Context(), subscriberId, req.URL.Query())
+	common.MakeResponse(w, result, common.ChangeModelToJson(resp), err)
+}
+
 func (notificationAPIExecutor) receiveNotificationEvnet(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG, "[Notification] receive")
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Notification] receive")
 	body, err := common.GetBodyFromReq(req)
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
 	}
 
-	result, err := notiExecutor.NotificationHandler("app", body)
+	result, err := notiExecutor.NotificationHandler(req.Context(), "app", body)
+	common.MakeResponse(w, result, nil, err)
+}
+
+func (notificationAPIExecutor) getEvents(w http.ResponseWriter, req *http.Request) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Notification] get events")
+
+	result, resp, err := historyExecutor.GetEvents(req.Context(), req.URL.Query())
+	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+}
+
+func (notificationAPIExecutor) getDeliveries(w http.ResponseWriter, req *http.Request) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Notification] get deliveries")
+
+	result, resp, err := deliveryExecutor.GetDeliveries(req.Context())
+	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+}
+
+func (notificationAPIExecutor) getFailedDeliveries(w http.ResponseWriter, req *http.Request) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Notification] get failed deliveries")
+
+	result, resp, err := deliveryExecutor.GetFailedDeliveries(req.Context())
+	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
+}
+
+func (notificationAPIExecutor) replayFailedDelivery(w http.ResponseWriter, req *http.Request, deliveryId string) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Notification] replay failed delivery")
+
+	result, err := deliveryExecutor.ReplayFailedDelivery(req.Context(), deliveryId)
+	common.MakeResponse(w, result, nil, err)
+}
+
+func (notificationAPIExecutor) deleteFailedDelivery(w http.ResponseWriter, req *http.Request, deliveryId string) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Notification] delete failed delivery")
+
+	result, err := deliveryExecutor.DeleteFailedDelivery(req.Context(), deliveryId)
 	common.MakeResponse(w, result, nil, err)
 }
@@ -19,6 +19,10 @@ package notification
 
 import (
 	"bytes"
+	"commons/models"
+	"commons/results"
+	deliverymocks "controller/notification/delivery/mocks"
+	historymocks "controller/notification/history/mocks"
 	notificationmocks "controller/notification/mocks"
 	"encoding/json"
 	"github.com/golang/mock/gomock"
@@ -29,8 +33,9 @@ import (
 )
 
 const (
-	EVENT_ID = "eventId"
-	BODY     = `{"test":"body"}`
+	EVENT_ID    = "eventId"
+	DELIVERY_ID = "deliveryId"
+	BODY        = `{"test":"body"}`
 )
 
 var testBody = map[string]interface{}{
@@ -50,7 +55,7 @@ func TestNotificationHandlerWithInvalidMethod_ExpectReturnInvalidMethodMsg(t *te
 	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("GET", "/api/v1/notification", nil)
+	req, _ := http.NewRequest("PUT", "/api/v1/notification", nil)
 	// pass mockObj to a real object.
 	notiExecutor = notiMockObj
 	Handler.Handle(w, req)
@@ -112,12 +117,13 @@ func TestNotificationHandlerWithValidRegisterRequest_ExpectCalledRegister(t *tes
 
 	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
+	subscription := models.SubscriptionRequest{URL: "url", Event: models.Event{Type: "node", Status: []string{"connected"}}}
 	gomock.InOrder(
-		notiMockObj.EXPECT().Register(BODY, gomock.Any()),
+		notiMockObj.EXPECT().Register(gomock.Any(), subscription, gomock.Any()).Return(results.OK, models.SubscriptionResponse{ID: EVENT_ID}, nil),
 	)
 
 	w := httptest.NewRecorder()
-	body, _ := json.Marshal(testBody)
+	body, _ := json.Marshal(subscription)
 	req, _ := http.NewRequest("POST", "/api/v1/notification", bytes.NewReader(body))
 
 	// pass mockObj to a real object.
@@ -126,6 +132,25 @@ func TestNotificationHandlerWithValidRegisterRequest_ExpectCalledRegister(t *tes
 	Handler.Handle(w, req)
 }
 
+func TestNotificationHandlerWithMalformedRegisterRequest_ExpectInvalidFieldReturned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notiMockObj := notificationmocks.NewMockCommand(ctrl)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/notification", bytes.NewBufferString(`{"url":"url","event":{"type":1}}`))
+
+	// pass mockObj to a real object.
+	notiExecutor = notiMockObj
+
+	Handler.Handle(w, req)
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "InvalidField") {
+		t.Errorf("Unexpected response: %d %s", w.Code, w.Body.String())
+	}
+}
+
 func TestNotificationHandlerWithValidUnRegisterRequest_ExpectCalledUnRegister(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -136,7 +161,7 @@ func TestNotificationHandlerWithValidUnRegisterRequest_ExpectCalledUnRegister(t
 	req, _ := http.NewRequest("DELETE", "/api/v1/notification/"+EVENT_ID, nil)
 
 	gomock.InOrder(
-		notiMockObj.EXPECT().UnRegister(EVENT_ID),
+		notiMockObj.EXPECT().UnRegister(gomock.Any(), EVENT_ID).Return(results.OK, nil),
 	)
 
 	// pass mockObj to a real object.
@@ -152,7 +177,7 @@ func TestNotificationHandlerWithValidEventRequest_ExpectCalledHandler(t *testing
 	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		notiMockObj.EXPECT().NotificationHandler(gomock.Any(), BODY),
+		notiMockObj.EXPECT().NotificationHandler(gomock.Any(), gomock.Any(), BODY).Return(results.OK, nil),
 	)
 
 	w := httptest.NewRecorder()
@@ -164,3 +189,237 @@ func TestNotificationHandlerWithValidEventRequest_ExpectCalledHandler(t *testing
 
 	Handler.Handle(w, req)
 }
+
+func TestNotificationHandlerWithDeliveriesRequest_ExpectCalledGetDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deliveryMockObj := deliverymocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		deliveryMockObj.EXPECT().GetDeliveries(gomock.Any()).Return(results.OK, testBody, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/notification/deliveries", nil)
+
+	// pass mockObj to a real object.
+	deliveryExecutor = deliveryMockObj
+
+	Handler.Handle(w, req)
+
+	if w.Code != results.OK || w.Body.String() != BODY {
+		t.Errorf("Unexpected response: %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestNotificationHandlerWithFailedDeliveriesRequest_ExpectCalledGetFailedDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deliveryMockObj := deliverymocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		deliveryMockObj.EXPECT().GetFailedDeliveries(gomock.Any()).Return(results.OK, testBody, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/notification/deliveries/failed", nil)
+
+	// pass mockObj to a real object.
+	deliveryExecutor = deliveryMockObj
+
+	Handler.Handle(w, req)
+}
+
+func TestNotificationHandlerWithReplayRequest_ExpectCalledReplayFailedDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deliveryMockObj := deliverymocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		deliveryMockObj.EXPECT().ReplayFailedDelivery(gomock.Any(), DELIVERY_ID).Return(results.OK, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/notification/deliveries/failed/"+DELIVERY_ID+"/replay", nil)
+
+	// pass mockObj to a real object.
+	deliveryExecutor = deliveryMockObj
+
+	Handler.Handle(w, req)
+}
+
+func TestNotificationHandlerWithDeleteFailedDeliveryRequest_ExpectCalledDeleteFailedDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deliveryMockObj := deliverymocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		deliveryMockObj.EXPECT().DeleteFailedDelivery(gomock.Any(), DELIVERY_ID).Return(results.OK, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/api/v1/notification/deliveries/failed/"+DELIVERY_ID, nil)
+
+	// pass mockObj to a real object.
+	deliveryExecutor = deliveryMockObj
+
+	Handler.Handle(w, req)
+}
+
+func TestNotificationHandlerWithInvalidDeliveriesUrl_ExpectReturnNotFoundURLMsg(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deliveryMockObj := deliverymocks.NewMockCommand(ctrl)
+
+	// pass mockObj to a real object.
+	deliveryExecutor = deliveryMockObj
+
+	for _, url := range []string{
+		"/api/v1/notification/deliveries/invalid",
+		"/api/v1/notification/deliveries/failed/" + DELIVERY_ID + "/invalid",
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", url, nil)
+
+		Handler.Handle(w, req)
+
+		if !strings.Contains(w.Body.String(), "unsupported url") {
+			t.Errorf("Expected results : unsupported url msg, Actual : %s.", w.Body.String())
+		}
+	}
+}
+
+func TestNotificationHandlerWithRotateSecretRequest_ExpectCalledRotateSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notiMockObj := notificationmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		notiMockObj.EXPECT().RotateSecret(gomock.Any(), EVENT_ID).Return(results.OK, models.SubscriptionResponse{ID: EVENT_ID, Secret: "secret"}, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/notification/"+EVENT_ID+"/secret", nil)
+
+	// pass mockObj to a real object.
+	notiExecutor = notiMockObj
+
+	Handler.Handle(w, req)
+
+	if expected := `{"id":"eventId","secret":"secret"}`; w.Code != results.OK || w.Body.String() != expected {
+		t.Errorf("Unexpected response: %d %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/v1/notification/"+EVENT_ID+"/secret", nil)
+
+	Handler.Handle(w, req)
+
+	if !strings.Contains(w.Body.String(), "invalid method") {
+		t.Errorf("Expected results : invalid method msg, Actual : %s.", w.Body.String())
+	}
+}
+
+func TestNotificationHandlerWithSubscriptionRequests_ExpectCalledSubscriptionOperations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notiMockObj := notificationmocks.NewMockCommand(ctrl)
+
+	subscription := models.Subscriber{ID: EVENT_ID, Type: "node", URL: "url", Nodes: []string{"nodeid"}}
+	active := true
+	gomock.InOrder(
+		notiMockObj.EXPECT().GetSubscriptions(gomock.Any()).Return(results.OK, models.SubscriberList{Subscriptions: []models.Subscriber{subscription}}, nil),
+		notiMockObj.EXPECT().GetSubscription(gomock.Any(), EVENT_ID).Return(results.OK, subscription, nil),
+		notiMockObj.EXPECT().UpdateSubscription(gomock.Any(), EVENT_ID, models.SubscriptionUpdate{Active: &active}).Return(results.OK, subscription, nil),
+	)
+
+	// pass mockObj to a real object.
+	notiExecutor = notiMockObj
+
+	expected, _ := json.Marshal(subscription)
+	for i, req := range []*http.Request{
+		httptest.NewRequest("GET", "/api/v1/notification", nil),
+		httptest.NewRequest("GET", "/api/v1/notification/"+EVENT_ID, nil),
+		httptest.NewRequest("PUT", "/api/v1/notification/"+EVENT_ID, bytes.NewBufferString(`{"active":true}`)),
+	} {
+		w := httptest.NewRecorder()
+		Handler.Handle(w, req)
+
+		body := string(expected)
+		if i == 0 {
+			body = `{"subscriptions":[` + body + `]}`
+		}
+		if w.Code != results.OK || w.Body.String() != body {
+			t.Errorf("Unexpected response to %s %s: %d %s", req.Method, req.URL.Path, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestNotificationHandlerWithEventsRequest_ExpectCalledGetEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	historyMockObj := historymocks.NewMockCommand(ctrl)
+
+	query := map[string][]string{"nodeid": []string{"nodeid"}, "status": []string{"disconnected"}}
+	gomock.InOrder(
+		historyMockObj.EXPECT().GetEvents(gomock.Any(), query).Return(results.OK, testBody, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/notification/events?nodeid=nodeid&status=disconnected", nil)
+
+	// pass mockObj to a real object.
+	historyExecutor = historyMockObj
+
+	Handler.Handle(w, req)
+
+	if w.Code != results.OK || w.Body.String() != BODY {
+		t.Errorf("Unexpected response: %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestNotificationHandlerWithMissedEventsRequests_ExpectCalledMissedEventOperations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notiMockObj := notificationmocks.NewMockCommand(ctrl)
+
+	query := map[string][]string{"since": []string{"cursor"}}
+	gomock.InOrder(
+		notiMockObj.EXPECT().GetMissedEvents(gomock.Any(), EVENT_ID, query).Return(results.OK,
+			models.MissedEvents{Events: []map[string]interface{}{testBody}, Cursor: "cursor"}, nil),
+		notiMockObj.EXPECT().ReplayMissedEvents(gomock.Any(), EVENT_ID, query).Return(results.OK,
+			models.ReplayResponse{Replayed: 1, Cursor: "cursor"}, nil),
+	)
+
+	// pass mockObj to a real object.
+	notiExecutor = notiMockObj
+
+	expected := []string{`{"events":[` + BODY + `],"cursor":"cursor"}`, `{"replayed":1,"cursor":"cursor"}`}
+	for i, req := range []*http.Request{
+		httptest.NewRequest("GET", "/api/v1/notification/"+EVENT_ID+"/events?since=cursor", nil),
+		httptest.NewRequest("POST", "/api/v1/notification/"+EVENT_ID+"/events/replay?since=cursor", nil),
+	} {
+		w := httptest.NewRecorder()
+		Handler.Handle(w, req)
+
+		if w.Code != results.OK || w.Body.String() != expected[i] {
+			t.Errorf("Unexpected response to %s %s: %d %s", req.Method, req.URL.Path, w.Code, w.Body.String())
+		}
+	}
+
+	w := httptest.NewRecorder()
+	Handler.Handle(w, httptest.NewRequest("GET", "/api/v1/notification/"+EVENT_ID+"/events/replay", nil))
+
+	if !strings.Contains(w.Body.String(), "invalid method") {
+		t.Errorf("Expected results : invalid method msg, Actual : %s.", w.Body.String())
+	}
+}
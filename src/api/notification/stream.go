@@ -0,0 +1,140 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package notification
+
+import (
+	"api/common"
+	"commons/errors"
+	"commons/logger"
+	"commons/websocket"
+	"controller/notification/stream"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	HEARTBEAT_INTERVAL = 30 * time.Second // a period of messages keeping an idle stream open.
+	EVENT_OPEN         = "open"           // the first message of a stream, with the id of its subscriber.
+)
+
+// streamEvents sends events matched with the query of req as they occur, in
+// WebSocket messages if req asks for an upgrade, otherwise in Server-Sent Events.
+func (notificationAPIExecutor) streamEvents(w http.ResponseWriter, req *http.Request) {
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Notification] stream")
+
+	result, s, err := notiExecutor.OpenStream(req.Context(), req.URL.Query())
+	if err != nil {
+		common.MakeResponse(w, result, nil, err)
+		return
+	}
+	defer notiExecutor.CloseStream(req.Context(), s)
+
+	if websocket.IsUpgrade(req) {
+		serveWebSocket(w, req, s)
+	} else {
+		serveEventStream(w, req, s)
+	}
+}
+
+// serveEventStream writes events as Server-Sent Events named by the type of
+// events, with the same data as sent to webhook subscribers.
+func serveEventStream(w http.ResponseWriter, req *http.Request, s *stream.Stream) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		common.WriteError(w, errors.InternalServerError{"streaming is not supported"})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	open, _ := json.Marshal(map[string]interface{}{"id": s.SubscriberID})
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", EVENT_OPEN, open)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(HEARTBEAT_INTERVAL)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event := <-s.Events():
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, event.Data); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case <-s.Done():
+			return
+		case <-req.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// serveWebSocket sends events in text messages, which are JSON objects with
+// the type and the event.
+func serveWebSocket(w http.ResponseWriter, req *http.Request, s *stream.Stream) {
+	conn, err := websocket.Upgrade(w, req)
+	if err != nil {
+		logger.With(req.Context()).Logging(logger.ERROR, err.Error())
+		return
+	}
+	defer conn.Close()
+
+	open, _ := json.Marshal(map[string]interface{}{"type": EVENT_OPEN, "id": s.SubscriberID})
+	if err = conn.WriteText(open); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(HEARTBEAT_INTERVAL)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event := <-s.Events():
+			err = conn.WriteText(makeMessage(event))
+		case <-heartbeat.C:
+			err = conn.WritePing()
+		case <-s.Done():
+			return
+		case <-conn.Done():
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// makeMessage adds the type of an event to its data.
+func makeMessage(event stream.Event) []byte {
+	message := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(event.Data), &message); err != nil {
+		logger.Logging(logger.ERROR, err.Error())
+	}
+	message["type"] = event.Type
+	data, _ := json.Marshal(message)
+	return data
+}
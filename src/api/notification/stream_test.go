@@ -0,0 +1,178 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package notification
+
+import (
+	"bufio"
+	"commons/errors"
+	"commons/results"
+	"commons/websocket"
+	"context"
+	notificationmocks "controller/notification/mocks"
+	"controller/notification/stream"
+	"github.com/golang/mock/gomock"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const EVENT_DATA = `{"event":{"id":"nodeid","status":"connected"}}`
+
+var streamQuery = map[string][]string{"type": []string{"node"}, "status": []string{"connected"}}
+
+// expectStream makes the mock open a stream for streamQuery and
+// returns the stream with a channel closed when it is closed.
+func expectStream(t *testing.T, notiMockObj *notificationmocks.MockCommand) (*stream.Stream, chan bool) {
+	s, err := stream.New()
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	s.SubscriberID = "subscriberid"
+
+	closed := make(chan bool)
+	gomock.InOrder(
+		notiMockObj.EXPECT().OpenStream(gomock.Any(), streamQuery).Return(results.OK, s, nil),
+		notiMockObj.EXPECT().CloseStream(gomock.Any(), s).Do(func(ctx context.Context, s *stream.Stream) {
+			s.Close()
+			close(closed)
+		}),
+	)
+	return s, closed
+}
+
+func TestStreamWithEventStreamRequest_ExpectServerSentEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notiMockObj := notificationmocks.NewMockCommand(ctrl)
+	s, closed := expectStream(t, notiMockObj)
+
+	// pass mockObj to a real object.
+	notiExecutor = notiMockObj
+
+	server := httptest.NewServer(http.HandlerFunc(Handler.Handle))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/v1/notification/stream?type=node&status=connected")
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	if resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Errorf("Unexpected content type: %s", resp.Header.Get("Content-Type"))
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	expectLines(t, reader, "event: open", `data: {"id":"subscriberid"}`, "")
+
+	stream.Publish(s.URL(), "node", EVENT_DATA)
+	expectLines(t, reader, "event: node", "data: "+EVENT_DATA, "")
+
+	resp.Body.Close()
+	<-closed
+}
+
+func TestStreamWithWebSocketRequest_ExpectEventMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notiMockObj := notificationmocks.NewMockCommand(ctrl)
+	s, closed := expectStream(t, notiMockObj)
+
+	// pass mockObj to a real object.
+	notiExecutor = notiMockObj
+
+	server := httptest.NewServer(http.HandlerFunc(Handler.Handle))
+	defer server.Close()
+
+	client, err := net.Dial("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+
+	key := "dGhlIHNhbXBsZSBub25jZQ=="
+	client.Write([]byte("GET /api/v1/notification/stream?type=node&status=connected HTTP/1.1\r\nHost: test\r\n" +
+		"Upgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Key: " + key + "\r\nSec-WebSocket-Version: 13\r\n\r\n"))
+
+	reader := bufio.NewReader(client)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil || resp.Header.Get("Sec-WebSocket-Accept") != websocket.AcceptKey(key) {
+		t.Fatalf("Unexpected handshake: %v, %v", resp, err)
+	}
+
+	if message := readMessage(t, reader); message != `{"id":"subscriberid","type":"open"}` {
+		t.Errorf("Unexpected message: %s", message)
+	}
+
+	stream.Publish(s.URL(), "node", EVENT_DATA)
+	if message := readMessage(t, reader); message != `{"event":{"id":"nodeid","status":"connected"},"type":"node"}` {
+		t.Errorf("Unexpected message: %s", message)
+	}
+
+	client.Close()
+	<-closed
+}
+
+func TestStreamWithInvalidQuery_ExpectErrorReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notiMockObj := notificationmocks.NewMockCommand(ctrl)
+	notiMockObj.EXPECT().OpenStream(gomock.Any(), gomock.Any()).Return(results.ERROR, nil, errors.InvalidParam{"a type of events is required"})
+
+	// pass mockObj to a real object.
+	notiExecutor = notiMockObj
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/notification/stream", nil)
+	Handler.Handle(w, req)
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "type") {
+		t.Errorf("Unexpected response: %d %s", w.Code, w.Body.String())
+	}
+}
+
+func expectLines(t *testing.T, reader *bufio.Reader, expected ...string) {
+	for _, line := range expected {
+		actual, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Unexpected err: %s", err.Error())
+		}
+		if strings.TrimSuffix(actual, "\n") != line {
+			t.Errorf("Expected line: %s, actual line: %s", line, actual)
+		}
+	}
+}
+
+// readMessage reads a text frame with a payload shorter than 126 bytes.
+func readMessage(t *testing.T, reader *bufio.Reader) string {
+	head := make([]byte, 2)
+	if _, err := io.ReadFull(reader, head); err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	if head[0] != 0x80|websocket.OPCODE_TEXT {
+		t.Fatalf("Unexpected frame: %v", head)
+	}
+	payload := make([]byte, head[1])
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	return string(payload)
+}
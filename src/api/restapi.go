@@ -20,18 +20,26 @@
 package api
 
 import (
+	"api/admin"
 	"api/common"
 	"api/health"
 	"api/management"
+	metricsapi "api/metrics"
 	"api/monitoring"
 	"api/notification"
 	"api/search"
+	"bufio"
 	"commons/errors"
 	"commons/logger"
+	"commons/metrics"
+	"commons/tracing"
 	URL "commons/url"
+	"fmt"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var managementHandler management.Command
@@ -39,6 +47,19 @@ var monitoringHandler monitoring.Command
 var searchHandler search.Command
 var notificationHandler notification.Command
 var healthHandler health.Command
+var metricsHandler metricsapi.Command
+var adminHandler admin.Command
+
+var (
+	requestCount = metrics.NewCounter("anchor_http_requests_total",
+		"Number of HTTP requests handled by the anchor.", "method", "route", "code")
+	requestDuration = metrics.NewHistogram("anchor_http_request_duration_seconds",
+		"Latency of HTTP requests handled by the anchor.", nil, "method", "route")
+)
+
+// routeSegments are the fixed parts of urls. Other parts of a url are ids,
+// which are replaced to keep the number of routes in metrics bounded.
+var routeSegments = map[string]bool{}
 
 func init() {
 	managementHandler = management.RequestHandler{}
@@ -46,6 +67,20 @@ func init() {
 	searchHandler = search.RequestHandler{}
 	notificationHandler = notification.RequestHandler{}
 	healthHandler = health.RequestHandler{}
+	metricsHandler = metricsapi.RequestHandler{}
+	adminHandler = admin.RequestHandler{}
+
+	for _, segment := range []string{URL.Base(), URL.Deploy(), URL.Apps(), URL.Start(), URL.Stop(),
+		URL.Update(), URL.Nodes(), URL.Groups(), URL.Registries(), URL.Management(), URL.Monitoring(),
+		URL.Events(), URL.Create(), URL.Join(), URL.Leave(), URL.Register(), URL.Unregister(), URL.Ping(),
+		URL.Resource(), URL.Search(), URL.Configuration(), URL.Notification(), URL.Reboot(),
+		URL.Restore(), URL.Drift(), URL.Metrics(), URL.History(), URL.Top(), URL.Alerts(),
+		URL.Rules(), URL.Admin(), URL.Logging(), URL.Health(), URL.Live(), URL.Ready(),
+		URL.Deliveries(), URL.Failed(), URL.Replay(), URL.Secret(), URL.Stream()} {
+		for _, part := range strings.Split(strings.Trim(segment, "/"), "/") {
+			routeSegments[part] = true
+		}
+	}
 }
 
 // RunWebServer starts web server service with given address and port number.
@@ -57,37 +92,141 @@ var Handler RequestHandler
 
 type RequestHandler struct{}
 
+// ServeHTTP dispatches a request to a proper handler according to the url.
+// Every response carries a request id, which is taken from the request header
+// or generated if absent, and a panic raised while handling a request is
+// recovered and responded with InternalServerError.
+// A span is started for the request, joining the trace of the traceparent header if given.
+// The request id and span are carried by the context of the request,
+// so that logs and spans of controllers are correlated with it.
+// The count and latency of requests are recorded per route.
 func (RequestHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	logger.Logging(logger.DEBUG, "receive msg", req.Method, req.URL.Path)
 	defer logger.Logging(logger.DEBUG, "OUT")
 
+	requestId := req.Header.Get(common.REQUEST_ID_HEADER)
+	if len(requestId) == 0 {
+		requestId = common.NewRequestId()
+	}
+	w.Header().Set(common.REQUEST_ID_HEADER, requestId)
+
+	span := tracing.StartTrace(req.Header.Get(tracing.TRACEPARENT_HEADER),
+		req.Method+" "+routeOf(req.URL.Path), tracing.KIND_SERVER)
+	span.SetAttribute("http.method", req.Method)
+	span.SetAttribute("http.target", req.URL.Path)
+	span.SetAttribute("request.id", requestId)
+	req = req.WithContext(tracing.NewContext(logger.NewContext(req.Context(), requestId), span))
+
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	defer observeRequest(req, recorder, span, time.Now())
+	w = recorder
+
+	defer recoverFromPanic(w, req)
+
 	switch url := req.URL.Path; {
 	default:
-		logger.Logging(logger.DEBUG, "Unknown URL")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Unknown URL")
 		common.WriteError(w, errors.NotFoundURL{})
 
+	case url == URL.Metrics():
+		logger.With(req.Context()).Logging(logger.DEBUG, "Request Metrics APIs")
+		metricsHandler.Handle(w, req)
+
 	case !strings.Contains(url, URL.Base()):
-		logger.Logging(logger.DEBUG, "Unknown URL")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Unknown URL")
 		common.WriteError(w, errors.NotFoundURL{})
 
 	case strings.Contains(url, URL.Management()):
-		logger.Logging(logger.DEBUG, "Request Management APIs")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Request Management APIs")
 		managementHandler.Handle(w, req)
 
 	case strings.Contains(url, URL.Monitoring()):
-		logger.Logging(logger.DEBUG, "Request Monitoring APIs")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Request Monitoring APIs")
 		monitoringHandler.Handle(w, req)
 
 	case strings.Contains(url, URL.Search()):
-		logger.Logging(logger.DEBUG, "Request Search APIs")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Request Search APIs")
 		searchHandler.Handle(w, req)
 
 	case strings.Contains(url, URL.Notification()):
-		logger.Logging(logger.DEBUG, "Request Notification APIs")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Request Notification APIs")
 		notificationHandler.Handle(w, req)
 
+	case strings.Contains(url, URL.Base()+URL.Admin()):
+		logger.With(req.Context()).Logging(logger.DEBUG, "Request Admin APIs")
+		adminHandler.Handle(w, req)
+
+	case strings.Contains(url, URL.Base()+URL.Health()):
+		logger.With(req.Context()).Logging(logger.DEBUG, "Request Health APIs")
+		healthHandler.Handle(w, req)
+
 	case strings.Contains(url, URL.Ping()):
-		logger.Logging(logger.DEBUG, "Request Ping APIs")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Request Ping APIs")
 		healthHandler.Handle(w, req)
 	}
 }
+
+// recoverFromPanic stops a panic raised while handling a request
+// so that the server keeps running, and responds with InternalServerError.
+func recoverFromPanic(w http.ResponseWriter, req *http.Request) {
+	if r := recover(); r != nil {
+		logger.With(req.Context()).Logging(logger.ERROR, "panic while handling", req.Method, req.URL.Path, fmt.Sprint(r))
+		common.WriteError(w, errors.InternalServerError{"unexpected error while handling the request"})
+	}
+}
+
+// statusRecorder keeps the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Flush sends buffered data to the client, for responses streaming events.
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack takes over the connection, for requests upgraded to WebSocket.
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.InternalServerError{"hijacking is not supported"}
+	}
+	r.status = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
+
+// observeRequest records the count and latency of a handled request,
+// writes an access log of it and ends its span.
+func observeRequest(req *http.Request, recorder *statusRecorder, span *tracing.Span, start time.Time) {
+	route := routeOf(req.URL.Path)
+	span.SetAttribute("http.status_code", recorder.status)
+	if recorder.status >= http.StatusInternalServerError {
+		span.SetError(errors.InternalServerError{http.StatusText(recorder.status)})
+	}
+	span.Finish()
+
+	logger.With(req.Context()).Log(logger.INFO, "request handled", "method", req.Method, "route", route,
+		"code", recorder.status, "duration", time.Since(start).String())
+	requestCount.Inc(req.Method, route, strconv.Itoa(recorder.status))
+	requestDuration.ObserveSince(start, req.Method, route)
+}
+
+// routeOf replaces ids in path with a placeholder,
+// e.g. /api/v1/management/nodes/{id}/apps/{id}/start.
+func routeOf(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range parts {
+		if !routeSegments[part] && len(part) != 0 {
+			parts[i] = "{id}"
+		}
+	}
+	return "/" + strings.Join(parts, "/")
+}
@@ -18,13 +18,21 @@
 package api
 
 import (
+	adminmocks "api/admin/mocks"
+	"api/common"
+	healthmocks "api/health/mocks"
 	managementmocks "api/management/mocks"
+	metricsmocks "api/metrics/mocks"
 	monitoringmocks "api/monitoring/mocks"
 	searchmocks "api/search/mocks"
-	healthmocks "api/health/mocks"
+	"bytes"
+	"commons/logger"
+	"commons/metrics"
+	"commons/tracing"
 	"github.com/golang/mock/gomock"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -137,3 +145,231 @@ func TestCalledServeHTTPWithPingRequest_ExpectCalledPingHandle(t *testing.T) {
 
 	Handler.ServeHTTP(w, req)
 }
+
+func TestCalledServeHTTPWithHealthRequest_ExpectCalledHealthHandle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	healthHandlerMockObj := healthmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		healthHandlerMockObj.EXPECT().Handle(gomock.Any(), gomock.Any()),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/health/ready", nil)
+
+	// pass mockObj to a real object.
+	healthHandler = healthHandlerMockObj
+
+	Handler.ServeHTTP(w, req)
+}
+
+func TestCalledServeHTTPWhenHandlerPanics_ExpectInternalServerErrorWithRequestId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	managementHandlerMockObj := managementmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		managementHandlerMockObj.EXPECT().Handle(gomock.Any(), gomock.Any()).Do(
+			func(w http.ResponseWriter, req *http.Request) {
+				panic("unexpected")
+			}),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/management/nodes", nil)
+	req.Header.Set(common.REQUEST_ID_HEADER, "requestid")
+
+	// pass mockObj to a real object.
+	managementHandler = managementHandlerMockObj
+
+	Handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected code: %d, actual code: %d", http.StatusInternalServerError, w.Code)
+	}
+
+	if requestId := w.Header().Get(common.REQUEST_ID_HEADER); requestId != "requestid" {
+		t.Errorf("Expected request id: %s, actual request id: %s", "requestid", requestId)
+	}
+}
+
+func TestCalledServeHTTPWithoutRequestId_ExpectRequestIdGenerated(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/invalid", nil)
+
+	Handler.ServeHTTP(w, req)
+
+	if len(w.Header().Get(common.REQUEST_ID_HEADER)) == 0 {
+		t.Error("Expected request id, actual request id is empty")
+	}
+}
+
+func TestCalledServeHTTPWithMetricsRequest_ExpectCalledMetricsHandle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metricsHandlerMockObj := metricsmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		metricsHandlerMockObj.EXPECT().Handle(gomock.Any(), gomock.Any()),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+
+	// pass mockObj to a real object.
+	metricsHandler = metricsHandlerMockObj
+
+	Handler.ServeHTTP(w, req)
+}
+
+func TestCalledServeHTTPWithAdminRequest_ExpectCalledAdminHandle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	adminHandlerMockObj := adminmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		adminHandlerMockObj.EXPECT().Handle(gomock.Any(), gomock.Any()),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/admin/logging", nil)
+
+	// pass mockObj to a real object.
+	adminHandler = adminHandlerMockObj
+
+	Handler.ServeHTTP(w, req)
+}
+
+func TestCalledServeHTTP_ExpectRequestIdCarriedByContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	adminHandlerMockObj := adminmocks.NewMockCommand(ctrl)
+
+	var requestId string
+	gomock.InOrder(
+		adminHandlerMockObj.EXPECT().Handle(gomock.Any(), gomock.Any()).Do(
+			func(w http.ResponseWriter, req *http.Request) {
+				requestId = logger.RequestId(req.Context())
+			}),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/admin/logging", nil)
+	req.Header.Set(common.REQUEST_ID_HEADER, "req-1")
+
+	// pass mockObj to a real object.
+	adminHandler = adminHandlerMockObj
+
+	Handler.ServeHTTP(w, req)
+
+	if requestId != "req-1" {
+		t.Errorf("Expected request id: req-1, actual request id: %s", requestId)
+	}
+}
+
+type recordingExporter struct {
+	spans []*tracing.Span
+}
+
+func (e *recordingExporter) Export(spans []*tracing.Span) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func TestCalledServeHTTPWithTraceParent_ExpectSpanJoinedTrace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	exporter := &recordingExporter{}
+	tracing.SetExporters(exporter)
+	defer tracing.SetExporters()
+
+	adminHandlerMockObj := adminmocks.NewMockCommand(ctrl)
+
+	var current *tracing.Span
+	gomock.InOrder(
+		adminHandlerMockObj.EXPECT().Handle(gomock.Any(), gomock.Any()).Do(
+			func(w http.ResponseWriter, req *http.Request) {
+				current = tracing.FromContext(req.Context())
+			}),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/admin/logging", nil)
+	req.Header.Set(tracing.TRACEPARENT_HEADER, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	// pass mockObj to a real object.
+	adminHandler = adminHandlerMockObj
+
+	Handler.ServeHTTP(w, req)
+	tracing.Flush()
+
+	if len(exporter.spans) != 1 || exporter.spans[0] != current {
+		t.Fatalf("Expected the span carried by the context exported, actual %v", exporter.spans)
+	}
+	span := exporter.spans[0]
+	if span.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || span.Name != "GET /api/v1/admin/logging" {
+		t.Errorf("Unexpected span: %v", span)
+	}
+}
+
+func TestCalledServeHTTP_ExpectRequestCountedPerRoute(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/api/v1/invalid/nodeid", nil)
+
+	Handler.ServeHTTP(w, req)
+
+	var buf bytes.Buffer
+	metrics.Write(&buf)
+
+	expected := `anchor_http_requests_total{method="DELETE",route="/api/v1/{id}/{id}",code="404"} 1`
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("Expected metric: %s, actual metrics: %s", expected, buf.String())
+	}
+}
+
+func TestRouteOf_ExpectIdsReplaced(t *testing.T) {
+	testList := map[string]string{
+		"/api/v1/management/nodes/register":                "/api/v1/management/nodes/register",
+		"/api/v1/management/groups/gid/apps/aid/start":     "/api/v1/management/groups/{id}/apps/{id}/start",
+		"/api/v1/monitoring/nodes/nid/resource":            "/api/v1/monitoring/nodes/{id}/resource",
+		"/api/v1/management/nodes/nid/configuration/drift": "/api/v1/management/nodes/{id}/configuration/drift",
+		"/metrics": "/metrics",
+		"/api/v1/notification/deliveries/failed/did/replay": "/api/v1/notification/deliveries/failed/{id}/replay",
+		"/api/v1/notification/sid/secret":                   "/api/v1/notification/{id}/secret",
+		"/api/v1/notification/stream":                       "/api/v1/notification/stream",
+	}
+
+	for path, expected := range testList {
+		if route := routeOf(path); route != expected {
+			t.Errorf("Expected route: %s, actual route: %s", expected, route)
+		}
+	}
+}
+
+func TestCalledFlushOfStatusRecorder_ExpectResponseFlushed(t *testing.T) {
+	w := httptest.NewRecorder()
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+	var writer http.ResponseWriter = recorder
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		t.Fatal("Expected statusRecorder to be a Flusher")
+	}
+	flusher.Flush()
+
+	if !w.Flushed {
+		t.Error("Expected response flushed")
+	}
+
+	// httptest.ResponseRecorder does not support hijacking.
+	if _, _, err := recorder.Hijack(); err == nil {
+		t.Error("Expected error of hijacking")
+	}
+}
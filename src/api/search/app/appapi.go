@@ -59,7 +59,7 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 
 	switch len(split) {
 	default:
-		logger.Logging(logger.DEBUG, "Unknown URL")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Unknown URL")
 		common.WriteError(w, errors.NotFoundURL{})
 	case 1:
 		if req.Method == GET {
@@ -71,9 +71,9 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 }
 
 func (searchAPIExecutor) searchApps(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG, "[Search] Apps")
+	logger.With(req.Context()).Logging(logger.DEBUG, "[Search] Apps")
 
-	result, resp, err := appsSearchExecutor.Search(parseQuery(req))
+	result, resp, err := appsSearchExecutor.Search(req.Context(), parseQuery(req))
 	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
 }
 
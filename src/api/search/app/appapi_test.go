@@ -103,7 +103,7 @@ func TestSearchAppsHandleWithValidAppsSearchRequest_ExpectCalledAppsSearchContro
 	searchMockObj := appsSearchmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		searchMockObj.EXPECT().Search(gomock.Any()),
+		searchMockObj.EXPECT().Search(gomock.Any(), gomock.Any()),
 	)
 
 	w := httptest.NewRecorder()
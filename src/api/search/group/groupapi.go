@@ -69,9 +69,9 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 }
 
 func (groupAPIExecutor) searchGroups(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG, "[GROUP] Search Group")
+	logger.With(req.Context()).Logging(logger.DEBUG, "[GROUP] Search Group")
 
-	result, resp, err := searchExecutor.SearchGroups(parseQuery(req))
+	result, resp, err := searchExecutor.SearchGroups(req.Context(), parseQuery(req))
 	common.MakeResponse(w, result, common.ChangeToJson(resp), err)
 }
 
@@ -79,7 +79,7 @@ func TestSearchGroupsHandleWithValidGroupsSearchRequest_ExpectCalledGroupsSearch
 	searchMockObj := groupsSearchmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		searchMockObj.EXPECT().SearchGroups(gomock.Any()),
+		searchMockObj.EXPECT().SearchGroups(gomock.Any(), gomock.Any()),
 	)
 
 	w := httptest.NewRecorder()
@@ -59,7 +59,7 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 
 	switch len(split) {
 	default:
-		logger.Logging(logger.DEBUG, "Unknown URL")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Unknown URL")
 		common.WriteError(w, errors.NotFoundURL{})
 	case 1:
 		if req.Method == GET {
@@ -71,9 +71,9 @@ func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
 }
 
 func (nodeAPIExecutor) searchNodes(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG, "[NODE] Get Nodes maching the condition")
+	logger.With(req.Context()).Logging(logger.DEBUG, "[NODE] Get Nodes maching the condition")
 
-	result, resp, err := searchExecutor.SearchNodes(req.URL.Query())
+	result, resp, err := searchExecutor.SearchNodes(req.Context(), req.URL.Query())
 	if err != nil {
 		common.MakeResponse(w, results.ERROR, nil, err)
 		return
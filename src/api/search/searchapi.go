@@ -46,28 +46,28 @@ func init() {
 }
 
 func (RequestHandler) Handle(w http.ResponseWriter, req *http.Request) {
-	logger.Logging(logger.DEBUG, "receive msg", req.Method, req.URL.Path)
-	defer logger.Logging(logger.DEBUG, "OUT")
+	logger.With(req.Context()).Logging(logger.DEBUG, "receive msg", req.Method, req.URL.Path)
+	defer logger.With(req.Context()).Logging(logger.DEBUG, "OUT")
 
 	switch url := req.URL.Path; {
 	default:
-		logger.Logging(logger.DEBUG, "Unknown URL")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Unknown URL")
 		common.WriteError(w, errors.NotFoundURL{})
 
 	case !strings.Contains(url, URL.Base()):
-		logger.Logging(logger.DEBUG, "Unknown URL")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Unknown URL")
 		common.WriteError(w, errors.NotFoundURL{})
 
 	case strings.Contains(url, URL.Nodes()):
-		logger.Logging(logger.DEBUG, "Request Nodes APIs")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Request Nodes APIs")
 		nodeSearchHandler.Handle(w, req)
 
 	case strings.Contains(url, URL.Groups()):
-		logger.Logging(logger.DEBUG, "Request Groups APIs")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Request Groups APIs")
 		groupSearchHandler.Handle(w, req)
 
 	case strings.Contains(url, URL.Apps()):
-		logger.Logging(logger.DEBUG, "Request Apps APIs")
+		logger.With(req.Context()).Logging(logger.DEBUG, "Request Apps APIs")
 		appSearchHandler.Handle(w, req)
 	}
 }
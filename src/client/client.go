@@ -0,0 +1,301 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package client provides a Go client of the Pharos Anchor REST APIs.
+// Each API is exposed as a method which takes a context and returns
+// typed models, and errors responded by the anchor are converted back
+// into the types defined in commons/errors.
+package client
+
+import (
+	"bytes"
+	"commons/errors"
+	"commons/models"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_RETRIES      = 2                      // a number of retries of an idempotent request.
+	DEFAULT_BACKOFF      = 500 * time.Millisecond // a delay before the first retry, doubled on each retry.
+	AUTHORIZATION_HEADER = "Authorization"
+	CONTENT_TYPE_HEADER  = "Content-Type"
+	JSON_CONTENT_TYPE    = "application/json"
+	YAML_CONTENT_TYPE    = "application/x-yaml"
+)
+
+// Client sends requests to a single anchor.
+type Client struct {
+	address    string
+	httpClient *http.Client
+	headers    http.Header
+	retries    int
+	backoff    time.Duration
+}
+
+// Option changes the default behavior of a Client.
+type Option func(*Client)
+
+// WithHTTPClient makes a Client use the given http.Client,
+// e.g. to configure TLS or a timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken adds a bearer token to the Authorization header of every request.
+func WithToken(token string) Option {
+	return WithHeader(AUTHORIZATION_HEADER, "Bearer "+token)
+}
+
+// WithHeader adds a header to every request.
+func WithHeader(key string, value string) Option {
+	return func(c *Client) {
+		c.headers.Set(key, value)
+	}
+}
+
+// WithRetry changes how many times an idempotent request is retried
+// and the delay before the first retry.
+func WithRetry(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// New returns a Client of the anchor listening on address,
+// e.g. "http://192.168.0.1:48099". http is used if the scheme is omitted.
+func New(address string, options ...Option) *Client {
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+
+	c := &Client{
+		address:    strings.TrimSuffix(address, "/"),
+		httpClient: http.DefaultClient,
+		headers:    make(http.Header),
+		retries:    DEFAULT_RETRIES,
+		backoff:    DEFAULT_BACKOFF,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// get sends a GET request and decodes the response into out.
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	return c.do(ctx, http.MethodGet, path, query, nil, "", out)
+}
+
+// delete sends a DELETE request and decodes the response into out.
+func (c *Client) delete(ctx context.Context, path string, out interface{}) error {
+	return c.do(ctx, http.MethodDelete, path, nil, nil, "", out)
+}
+
+// post sends a POST request with in encoded as JSON and decodes the response into out.
+// If in is nil, the request is sent without a body.
+func (c *Client) post(ctx context.Context, path string, query url.Values, in interface{}, out interface{}) error {
+	if in == nil {
+		return c.do(ctx, http.MethodPost, path, query, nil, "", out)
+	}
+
+	body, err := json.Marshal(in)
+	if err != nil {
+		return errors.IOError{"json marshalling failed"}
+	}
+	return c.do(ctx, http.MethodPost, path, query, body, JSON_CONTENT_TYPE, out)
+}
+
+// postYaml sends a POST request with a docker-compose document and decodes the response into out.
+func (c *Client) postYaml(ctx context.Context, path string, query url.Values, description []byte, out interface{}) error {
+	return c.do(ctx, http.MethodPost, path, query, description, YAML_CONTENT_TYPE, out)
+}
+
+// do sends a request and decodes the response into out.
+// GET and DELETE requests are retried when the anchor can not be reached
+// or responds with 502, 503 or 504 status code.
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values,
+	body []byte, contentType string, out interface{}) error {
+
+	target := c.address + path
+	if len(query) != 0 {
+		target += "?" + query.Encode()
+	}
+
+	retries := 0
+	if method == http.MethodGet || method == http.MethodDelete {
+		retries = c.retries
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		var status int
+		var respBody []byte
+		status, respBody, err = c.send(ctx, method, target, body, contentType)
+		if err == nil && !isRetryableStatus(status) {
+			return decodeResponse(status, respBody, out)
+		}
+		if err == nil {
+			err = decodeError(status, respBody)
+		}
+
+		if attempt >= retries || ctx.Err() != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.IOError{ctx.Err().Error()}
+		case <-time.After(c.backoff << uint(attempt)):
+		}
+	}
+}
+
+// send sends a single request and returns the status code and body of the response.
+func (c *Client) send(ctx context.Context, method string, target string,
+	body []byte, contentType string) (int, []byte, error) {
+
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, errors.InvalidParam{err.Error()}
+	}
+
+	for key, values := range c.headers {
+		req.Header[key] = values
+	}
+	if len(contentType) != 0 {
+		req.Header.Set(CONTENT_TYPE_HEADER, contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, errors.IOError{err.Error()}
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, errors.IOError{err.Error()}
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// decodeResponse decodes a successful response into out,
+// or converts an unsuccessful response into an error.
+// 207 (Multi-Status) is regarded as success because the body describes
+// which of the target nodes failed.
+func decodeResponse(status int, body []byte, out interface{}) error {
+	if status < 200 || status > 299 {
+		return decodeError(status, body)
+	}
+
+	if out == nil || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	err := models.Decode(string(body), out)
+	if err != nil {
+		return errors.InternalServerError{"unexpected response: " + err.Error()}
+	}
+	return nil
+}
+
+// errorEnvelope is a body of an error response.
+type errorEnvelope struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Field     string `json:"field"`
+	RequestId string `json:"requestid"`
+}
+
+// decodeError converts an error response into one of the types in commons/errors.
+// An error code in the body is preferred, and the status code is used
+// for responses which do not have one.
+func decodeError(status int, body []byte) error {
+	envelope := errorEnvelope{}
+	json.Unmarshal(body, &envelope)
+
+	message := envelope.Message
+	if len(message) == 0 {
+		message = http.StatusText(status)
+	}
+
+	switch envelope.Code {
+	case "NotFoundURL":
+		return errors.NotFoundURL{trimPrefix(message, errors.NotFoundURL{})}
+	case "InvalidMethod":
+		return errors.InvalidMethod{trimPrefix(message, errors.InvalidMethod{})}
+	case "InvalidParam":
+		return errors.InvalidParam{trimPrefix(message, errors.InvalidParam{})}
+	case "InvalidJSON":
+		return errors.InvalidJSON{trimPrefix(message, errors.InvalidJSON{})}
+	case "InvalidField":
+		return errors.InvalidField{envelope.Field, trimPrefix(message, errors.InvalidField{envelope.Field, ""})}
+	case "InvalidYaml":
+		return errors.InvalidYaml{trimPrefix(message, errors.InvalidYaml{})}
+	case "InvalidObjectId":
+		return errors.InvalidObjectId{trimPrefix(message, errors.InvalidObjectId{})}
+	case "NotFound":
+		return errors.NotFound{trimPrefix(message, errors.NotFound{})}
+	case "DBConnectionError":
+		return errors.DBConnectionError{trimPrefix(message, errors.DBConnectionError{})}
+	case "DBOperationError":
+		return errors.DBOperationError{trimPrefix(message, errors.DBOperationError{})}
+	case "IOError":
+		return errors.IOError{trimPrefix(message, errors.IOError{})}
+	case "InternalServerError":
+		return errors.InternalServerError{trimPrefix(message, errors.InternalServerError{})}
+	case "NodeError":
+		return errors.NodeError{status, trimPrefix(message, errors.NodeError{})}
+	case "Unknown":
+		return errors.Unknown{trimPrefix(message, errors.Unknown{})}
+	}
+
+	switch status {
+	case http.StatusBadRequest:
+		return errors.InvalidParam{message}
+	case http.StatusNotFound:
+		return errors.NotFound{message}
+	case http.StatusMethodNotAllowed:
+		return errors.InvalidMethod{message}
+	case http.StatusServiceUnavailable:
+		return errors.DBConnectionError{message}
+	}
+	return errors.InternalServerError{message}
+}
+
+// trimPrefix removes the prefix which is added by Error() of the given error type
+// so that converting the message back into the type does not repeat it.
+func trimPrefix(message string, empty error) string {
+	return strings.TrimPrefix(message, empty.Error())
+}
@@ -0,0 +1,240 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package client
+
+import (
+	"api"
+	"commons/errors"
+	"commons/models"
+	URL "commons/url"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	nodeId  = "000000000000000000000001"
+	groupId = "000000000000000000000002"
+	token   = "token"
+)
+
+func TestCalledWithRealHandler_ExpectErrorConvertedBack(t *testing.T) {
+	server := httptest.NewServer(&api.Handler)
+	defer server.Close()
+
+	c := New(server.URL, WithRetry(0, 0))
+	ctx := context.Background()
+
+	testList := []struct {
+		name     string
+		call     func() error
+		expected error
+	}{
+		{name: "createGroupWithoutName", expected: errors.InvalidJSON{"name field is required"}, call: func() error {
+			_, err := c.CreateGroup(ctx, "")
+			return err
+		}},
+		{name: "joinGroupWithoutNodes", expected: errors.InvalidJSON{"nodes field is required"}, call: func() error {
+			return c.JoinGroup(ctx, groupId, nil)
+		}},
+		{name: "addRegistryWithoutAddress", expected: errors.InvalidJSON{"ip field is required"}, call: func() error {
+			_, err := c.AddRegistry(ctx, "")
+			return err
+		}},
+		{name: "invalidField", expected: errors.InvalidField{"nodes[0]", "has unexpected type number"}, call: func() error {
+			return c.post(ctx, groupsPath("/", groupId, URL.Join()), nil, map[string]interface{}{"nodes": []int{1}}, nil)
+		}},
+		{name: "notFoundURL", call: func() error {
+			return c.get(ctx, URL.Base()+"/unknown", nil, nil)
+		}},
+		{name: "invalidMethod", call: func() error {
+			return c.delete(ctx, groupsPath(URL.Create()), nil)
+		}},
+	}
+
+	for _, test := range testList {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.call()
+			if err == nil {
+				t.Fatalf("Expected error, actual nil")
+			}
+			if test.expected != nil && !reflect.DeepEqual(test.expected, err) {
+				t.Errorf("Expected err: %#v, actual err: %#v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestCalledWithRealHandler_ExpectErrorTypes(t *testing.T) {
+	server := httptest.NewServer(&api.Handler)
+	defer server.Close()
+
+	c := New(server.URL, WithRetry(0, 0))
+	ctx := context.Background()
+
+	err := c.get(ctx, URL.Base()+"/unknown", nil, nil)
+	if _, ok := err.(errors.NotFoundURL); !ok {
+		t.Errorf("Expected err: %s, actual err: %#v", "NotFoundURL", err)
+	}
+
+	err = c.delete(ctx, groupsPath(URL.Create()), nil)
+	if _, ok := err.(errors.InvalidMethod); !ok {
+		t.Errorf("Expected err: %s, actual err: %#v", "InvalidMethod", err)
+	}
+
+	if err = c.Ping(ctx); err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+}
+
+func TestCalledGetNode_ExpectTypedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet || req.URL.Path != nodesPath("/", nodeId) {
+			t.Errorf("Unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+		if auth := req.Header.Get(AUTHORIZATION_HEADER); auth != "Bearer "+token {
+			t.Errorf("Expected auth: %s, actual auth: %s", "Bearer "+token, auth)
+		}
+		w.Write([]byte(`{"id":"` + nodeId + `","ip":"127.0.0.1","status":"connected","apps":["app"]}`))
+	}))
+	defer server.Close()
+
+	node, err := New(server.URL, WithToken(token)).GetNode(context.Background(), nodeId)
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+
+	if node.ID != nodeId || node.IP != "127.0.0.1" || !reflect.DeepEqual(node.Apps, []string{"app"}) {
+		t.Errorf("Unexpected node: %#v", node)
+	}
+}
+
+func TestCalledDeployNodeApp_ExpectYamlBodyAndEventQuery(t *testing.T) {
+	description := "version: '2'\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if contentType := req.Header.Get(CONTENT_TYPE_HEADER); contentType != YAML_CONTENT_TYPE {
+			t.Errorf("Expected content type: %s, actual content type: %s", YAML_CONTENT_TYPE, contentType)
+		}
+		if event := req.URL.Query().Get("event"); event != "http://event" {
+			t.Errorf("Expected event: %s, actual event: %s", "http://event", event)
+		}
+		body, _ := ioutil.ReadAll(req.Body)
+		if string(body) != description {
+			t.Errorf("Expected body: %s, actual body: %s", description, string(body))
+		}
+		w.Write([]byte(`{"id":"app","description":"version: '2'"}`))
+	}))
+	defer server.Close()
+
+	info, err := New(server.URL).DeployNodeApp(context.Background(), nodeId, []byte(description), "http://event")
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	if info.ID != "app" {
+		t.Errorf("Expected id: %s, actual id: %s", "app", info.ID)
+	}
+}
+
+func TestCalledWithMultiStatus_ExpectNodeResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusMultiStatus)
+		w.Write([]byte(`{"responses":[{"id":"node","code":500,"message":"failed"}]}`))
+	}))
+	defer server.Close()
+
+	resp, err := New(server.URL).StartGroupApp(context.Background(), groupId, "app")
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+
+	expected := []models.NodeResponse{{ID: "node", Code: 500, Message: "failed"}}
+	if !reflect.DeepEqual(expected, resp.Responses) {
+		t.Errorf("Expected responses: %v, actual responses: %v", expected, resp.Responses)
+	}
+}
+
+func TestCalledWithNodeError_ExpectStatusCodeOfNode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"code":"NodeError","message":"node error: app is running"}`))
+	}))
+	defer server.Close()
+
+	err := New(server.URL).StartNodeApp(context.Background(), nodeId, "app")
+
+	expected := errors.NodeError{http.StatusConflict, "app is running"}
+	if !reflect.DeepEqual(expected, err) {
+		t.Errorf("Expected err: %#v, actual err: %#v", expected, err)
+	}
+}
+
+func TestCalledWithUnavailableServer_ExpectRetriedOnlyIdempotentRequest(t *testing.T) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&count, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"groups":[]}`))
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithRetry(2, time.Millisecond))
+
+	if _, err := c.ListGroups(context.Background()); err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+	if count != 3 {
+		t.Errorf("Expected requests: %d, actual requests: %d", 3, count)
+	}
+
+	atomic.StoreInt32(&count, 0)
+	err := c.RebootNode(context.Background(), nodeId)
+	if _, ok := err.(errors.DBConnectionError); !ok {
+		t.Errorf("Expected err: %s, actual err: %#v", "DBConnectionError", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected requests: %d, actual requests: %d", 1, count)
+	}
+}
+
+func TestCalledWithCanceledContext_ExpectIOError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := New(server.URL, WithRetry(2, time.Hour)).ListNodes(ctx)
+	if _, ok := err.(errors.IOError); !ok {
+		t.Errorf("Expected err: %s, actual err: %#v", "IOError", err)
+	}
+}
+
+func TestCalledNew_ExpectNormalizedAddress(t *testing.T) {
+	if address := New("127.0.0.1:48099/").address; address != "http://127.0.0.1:48099" {
+		t.Errorf("Expected address: %s, actual address: %s", "http://127.0.0.1:48099", address)
+	}
+}
@@ -0,0 +1,125 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package client
+
+import (
+	"commons/models"
+	URL "commons/url"
+	"context"
+)
+
+func groupsPath(parts ...string) string {
+	path := URL.Base() + URL.Management() + URL.Groups()
+	for _, part := range parts {
+		path += part
+	}
+	return path
+}
+
+// ListGroups returns all groups.
+func (c *Client) ListGroups(ctx context.Context) ([]models.Group, error) {
+	resp := models.GroupList{}
+	err := c.get(ctx, groupsPath(), nil, &resp)
+	return resp.Groups, err
+}
+
+// GetGroup returns the group specified by groupId.
+func (c *Client) GetGroup(ctx context.Context, groupId string) (models.Group, error) {
+	resp := models.Group{}
+	err := c.get(ctx, groupsPath("/", groupId), nil, &resp)
+	return resp, err
+}
+
+// CreateGroup creates a group with the given name.
+func (c *Client) CreateGroup(ctx context.Context, name string) (models.Group, error) {
+	resp := models.Group{}
+	err := c.post(ctx, groupsPath(URL.Create()), nil, models.CreateGroupRequest{Name: name}, &resp)
+	return resp, err
+}
+
+// DeleteGroup deletes the group specified by groupId.
+func (c *Client) DeleteGroup(ctx context.Context, groupId string) error {
+	return c.delete(ctx, groupsPath("/", groupId), nil)
+}
+
+// JoinGroup adds nodes to members of the group.
+func (c *Client) JoinGroup(ctx context.Context, groupId string, nodeIds []string) error {
+	return c.post(ctx, groupsPath("/", groupId, URL.Join()), nil, models.MembersRequest{Nodes: nodeIds}, nil)
+}
+
+// LeaveGroup removes nodes from members of the group.
+func (c *Client) LeaveGroup(ctx context.Context, groupId string, nodeIds []string) error {
+	return c.post(ctx, groupsPath("/", groupId, URL.Leave()), nil, models.MembersRequest{Nodes: nodeIds}, nil)
+}
+
+// DeployGroupApp deploys an application described by a docker-compose document
+// to all members of the group.
+func (c *Client) DeployGroupApp(ctx context.Context, groupId string, description []byte) (models.DeploymentResponse, error) {
+	resp := models.DeploymentResponse{}
+	err := c.postYaml(ctx, groupsPath("/", groupId, URL.Apps(), URL.Deploy()), nil, description, &resp)
+	return resp, err
+}
+
+// ListGroupApps returns applications deployed to members of the group.
+func (c *Client) ListGroupApps(ctx context.Context, groupId string) ([]models.GroupApp, error) {
+	resp := models.GroupAppList{}
+	err := c.get(ctx, groupsPath("/", groupId, URL.Apps()), nil, &resp)
+	return resp.Apps, err
+}
+
+// GetGroupApp returns the information of an application reported by each member of the group.
+func (c *Client) GetGroupApp(ctx context.Context, groupId string, appId string) (models.GroupAppInfo, error) {
+	resp := models.GroupAppInfo{}
+	err := c.get(ctx, groupsPath("/", groupId, URL.Apps(), "/", appId), nil, &resp)
+	return resp, err
+}
+
+// UpdateGroupAppInfo replaces the docker-compose document of an application on all members.
+func (c *Client) UpdateGroupAppInfo(ctx context.Context, groupId string, appId string, description []byte) (models.DeploymentResponse, error) {
+	resp := models.DeploymentResponse{}
+	err := c.postYaml(ctx, groupsPath("/", groupId, URL.Apps(), "/", appId), nil, description, &resp)
+	return resp, err
+}
+
+// DeleteGroupApp deletes an application from all members of the group.
+func (c *Client) DeleteGroupApp(ctx context.Context, groupId string, appId string) (models.DeploymentResponse, error) {
+	resp := models.DeploymentResponse{}
+	err := c.delete(ctx, groupsPath("/", groupId, URL.Apps(), "/", appId), &resp)
+	return resp, err
+}
+
+// StartGroupApp starts an application on all members of the group.
+func (c *Client) StartGroupApp(ctx context.Context, groupId string, appId string) (models.DeploymentResponse, error) {
+	resp := models.DeploymentResponse{}
+	err := c.post(ctx, groupsPath("/", groupId, URL.Apps(), "/", appId, URL.Start()), nil, nil, &resp)
+	return resp, err
+}
+
+// StopGroupApp stops an application on all members of the group.
+func (c *Client) StopGroupApp(ctx context.Context, groupId string, appId string) (models.DeploymentResponse, error) {
+	resp := models.DeploymentResponse{}
+	err := c.post(ctx, groupsPath("/", groupId, URL.Apps(), "/", appId, URL.Stop()), nil, nil, &resp)
+	return resp, err
+}
+
+// UpdateGroupApp pulls the latest images of an application and restarts it on all members.
+func (c *Client) UpdateGroupApp(ctx context.Context, groupId string, appId string) (models.DeploymentResponse, error) {
+	resp := models.DeploymentResponse{}
+	err := c.post(ctx, groupsPath("/", groupId, URL.Apps(), "/", appId, URL.Update()), nil, nil, &resp)
+	return resp, err
+}
@@ -0,0 +1,40 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package client
+
+import (
+	"commons/models"
+	URL "commons/url"
+	"context"
+)
+
+// GetNodeResource returns the resource usage of the device of the node.
+func (c *Client) GetNodeResource(ctx context.Context, nodeId string) (models.NodeResource, error) {
+	resp := models.NodeResource{}
+	path := URL.Base() + URL.Monitoring() + URL.Nodes() + "/" + nodeId + URL.Resource()
+	err := c.get(ctx, path, nil, &resp)
+	return resp, err
+}
+
+// GetAppResource returns the resource usage of an application deployed to the node.
+func (c *Client) GetAppResource(ctx context.Context, nodeId string, appId string) (models.AppResource, error) {
+	resp := models.AppResource{}
+	path := URL.Base() + URL.Monitoring() + URL.Nodes() + "/" + nodeId + URL.Apps() + "/" + appId + URL.Resource()
+	err := c.get(ctx, path, nil, &resp)
+	return resp, err
+}
@@ -0,0 +1,178 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package client
+
+import (
+	"commons/models"
+	URL "commons/url"
+	"context"
+	"net/url"
+	"strconv"
+)
+
+func nodesPath(parts ...string) string {
+	path := URL.Base() + URL.Management() + URL.Nodes()
+	for _, part := range parts {
+		path += part
+	}
+	return path
+}
+
+// ListNodes returns all registered nodes.
+func (c *Client) ListNodes(ctx context.Context) ([]models.Node, error) {
+	resp := models.NodeList{}
+	err := c.get(ctx, nodesPath(), nil, &resp)
+	return resp.Nodes, err
+}
+
+// GetNode returns the node specified by nodeId.
+func (c *Client) GetNode(ctx context.Context, nodeId string) (models.Node, error) {
+	resp := models.Node{}
+	err := c.get(ctx, nodesPath("/", nodeId), nil, &resp)
+	return resp, err
+}
+
+// RegisterNode registers a node and returns its id.
+func (c *Client) RegisterNode(ctx context.Context, req models.RegisterNodeRequest) (string, error) {
+	resp := models.IDResponse{}
+	err := c.post(ctx, nodesPath(URL.Register()), nil, req, &resp)
+	return resp.ID, err
+}
+
+// UnregisterNode unregisters the node specified by nodeId.
+func (c *Client) UnregisterNode(ctx context.Context, nodeId string) error {
+	return c.post(ctx, nodesPath("/", nodeId, URL.Unregister()), nil, nil, nil)
+}
+
+// PingNode notifies the anchor that the node is alive.
+// interval is a period in seconds until the next ping.
+func (c *Client) PingNode(ctx context.Context, nodeId string, interval int) error {
+	req := models.PingRequest{Interval: strconv.Itoa(interval)}
+	return c.post(ctx, nodesPath("/", nodeId, URL.Ping()), nil, req, nil)
+}
+
+// GetNodeConfiguration returns the configuration of the node stored in the anchor.
+func (c *Client) GetNodeConfiguration(ctx context.Context, nodeId string) (models.Configuration, error) {
+	resp := models.Configuration{}
+	err := c.get(ctx, nodesPath("/", nodeId, URL.Configuration()), nil, &resp)
+	return resp, err
+}
+
+// SetNodeConfiguration changes writable properties of the node.
+func (c *Client) SetNodeConfiguration(ctx context.Context, nodeId string, config models.Configuration) error {
+	return c.post(ctx, nodesPath("/", nodeId, URL.Configuration()), nil, config, nil)
+}
+
+// RebootNode reboots the device of the node.
+func (c *Client) RebootNode(ctx context.Context, nodeId string) error {
+	return c.post(ctx, nodesPath("/", nodeId, URL.Reboot()), nil, nil, nil)
+}
+
+// RestoreNode restores the device of the node to its initial state.
+func (c *Client) RestoreNode(ctx context.Context, nodeId string) error {
+	return c.post(ctx, nodesPath("/", nodeId, URL.Restore()), nil, nil, nil)
+}
+
+// GetConfigurationDrift returns the result of the latest drift check of the node.
+func (c *Client) GetConfigurationDrift(ctx context.Context, nodeId string) (models.Drift, error) {
+	resp := models.Drift{}
+	err := c.get(ctx, nodesPath("/", nodeId, URL.Configuration(), URL.Drift()), nil, &resp)
+	return resp, err
+}
+
+// CheckConfigurationDrift checks configuration drift of the node now.
+// If notify is true, subscribers are notified when the drift has changed.
+func (c *Client) CheckConfigurationDrift(ctx context.Context, nodeId string, notify bool) (models.Drift, error) {
+	resp := models.Drift{}
+	err := c.post(ctx, nodesPath("/", nodeId, URL.Configuration(), URL.Drift()), notifyQuery(notify), nil, &resp)
+	return resp, err
+}
+
+// GetConfigurationDrifts returns the results of the latest drift check of all nodes.
+func (c *Client) GetConfigurationDrifts(ctx context.Context) ([]models.Drift, error) {
+	resp := models.DriftList{}
+	err := c.get(ctx, nodesPath(URL.Drift()), nil, &resp)
+	return resp.Drifts, err
+}
+
+// CheckConfigurationDrifts checks configuration drift of all connected nodes now.
+func (c *Client) CheckConfigurationDrifts(ctx context.Context, notify bool) ([]models.Drift, error) {
+	resp := models.DriftList{}
+	err := c.post(ctx, nodesPath(URL.Drift()), notifyQuery(notify), nil, &resp)
+	return resp.Drifts, err
+}
+
+func notifyQuery(notify bool) url.Values {
+	if !notify {
+		return nil
+	}
+	return url.Values{"notify": []string{"true"}}
+}
+
+// DeployNodeApp deploys an application described by a docker-compose document to the node.
+// If eventUrl is not empty, deployment events are sent to it while deploying.
+func (c *Client) DeployNodeApp(ctx context.Context, nodeId string, description []byte, eventUrl string) (models.AppInfo, error) {
+	var query url.Values
+	if len(eventUrl) != 0 {
+		query = url.Values{"event": []string{eventUrl}}
+	}
+
+	resp := models.AppInfo{}
+	err := c.postYaml(ctx, nodesPath("/", nodeId, URL.Apps(), URL.Deploy()), query, description, &resp)
+	return resp, err
+}
+
+// ListNodeApps returns applications deployed to the node.
+func (c *Client) ListNodeApps(ctx context.Context, nodeId string) ([]models.AppState, error) {
+	resp := models.NodeAppList{}
+	err := c.get(ctx, nodesPath("/", nodeId, URL.Apps()), nil, &resp)
+	return resp.Apps, err
+}
+
+// GetNodeApp returns the information of an application deployed to the node.
+func (c *Client) GetNodeApp(ctx context.Context, nodeId string, appId string) (models.AppInfo, error) {
+	resp := models.AppInfo{}
+	err := c.get(ctx, nodesPath("/", nodeId, URL.Apps(), "/", appId), nil, &resp)
+	return resp, err
+}
+
+// UpdateNodeAppInfo replaces the docker-compose document of an application.
+func (c *Client) UpdateNodeAppInfo(ctx context.Context, nodeId string, appId string, description []byte) error {
+	return c.postYaml(ctx, nodesPath("/", nodeId, URL.Apps(), "/", appId), nil, description, nil)
+}
+
+// DeleteNodeApp deletes an application from the node.
+func (c *Client) DeleteNodeApp(ctx context.Context, nodeId string, appId string) error {
+	return c.delete(ctx, nodesPath("/", nodeId, URL.Apps(), "/", appId), nil)
+}
+
+// StartNodeApp starts an application of the node.
+func (c *Client) StartNodeApp(ctx context.Context, nodeId string, appId string) error {
+	return c.post(ctx, nodesPath("/", nodeId, URL.Apps(), "/", appId, URL.Start()), nil, nil, nil)
+}
+
+// StopNodeApp stops an application of the node.
+func (c *Client) StopNodeApp(ctx context.Context, nodeId string, appId string) error {
+	return c.post(ctx, nodesPath("/", nodeId, URL.Apps(), "/", appId, URL.Stop()), nil, nil, nil)
+}
+
+// UpdateNodeApp pulls the latest images of an application and restarts it.
+// query is passed to the node as it is, e.g. to select images to update.
+func (c *Client) UpdateNodeApp(ctx context.Context, nodeId string, appId string, query url.Values) error {
+	return c.post(ctx, nodesPath("/", nodeId, URL.Apps(), "/", appId, URL.Update()), query, nil, nil)
+}
@@ -0,0 +1,43 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package client
+
+import (
+	"commons/models"
+	URL "commons/url"
+	"context"
+	"net/url"
+)
+
+// Subscribe registers a subscriber which receives events of nodes or applications
+// matched with query, which takes the same keys as SearchNodes.
+func (c *Client) Subscribe(ctx context.Context, req models.SubscriptionRequest, query url.Values) (models.SubscriptionResponse, error) {
+	resp := models.SubscriptionResponse{}
+	err := c.post(ctx, URL.Base()+URL.Notification(), query, req, &resp)
+	return resp, err
+}
+
+// Unsubscribe deletes the subscriber specified by subscriberId.
+func (c *Client) Unsubscribe(ctx context.Context, subscriberId string) error {
+	return c.delete(ctx, URL.Base()+URL.Notification()+"/"+subscriberId, nil)
+}
+
+// Ping checks whether the anchor is up.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.get(ctx, URL.Base()+URL.Ping(), nil, nil)
+}
@@ -0,0 +1,52 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package client
+
+import (
+	"commons/models"
+	URL "commons/url"
+	"context"
+)
+
+func registriesPath(parts ...string) string {
+	path := URL.Base() + URL.Management() + URL.Registries()
+	for _, part := range parts {
+		path += part
+	}
+	return path
+}
+
+// ListRegistries returns all docker registries.
+func (c *Client) ListRegistries(ctx context.Context) ([]models.Registry, error) {
+	resp := models.RegistryList{}
+	err := c.get(ctx, registriesPath(), nil, &resp)
+	return resp.Registries, err
+}
+
+// AddRegistry adds a docker registry and returns its id.
+// Applications using images of the registry are updated when an image is pushed.
+func (c *Client) AddRegistry(ctx context.Context, address string) (string, error) {
+	resp := models.IDResponse{}
+	err := c.post(ctx, registriesPath(), nil, models.RegistryRequest{IP: address}, &resp)
+	return resp.ID, err
+}
+
+// DeleteRegistry deletes the docker registry specified by registryId.
+func (c *Client) DeleteRegistry(ctx context.Context, registryId string) error {
+	return c.delete(ctx, registriesPath("/", registryId), nil)
+}
@@ -0,0 +1,54 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package client
+
+import (
+	"commons/models"
+	URL "commons/url"
+	"context"
+	"net/url"
+)
+
+// Keys of search queries.
+const (
+	QUERY_NODE_ID    = "nodeId"
+	QUERY_GROUP_ID   = "groupId"
+	QUERY_APP_ID     = "appId"
+	QUERY_IMAGE_NAME = "imageName"
+)
+
+// SearchNodes returns nodes matched with query.
+func (c *Client) SearchNodes(ctx context.Context, query url.Values) ([]models.Node, error) {
+	resp := models.NodeList{}
+	err := c.get(ctx, URL.Base()+URL.Search()+URL.Nodes(), query, &resp)
+	return resp.Nodes, err
+}
+
+// SearchGroups returns groups matched with query.
+func (c *Client) SearchGroups(ctx context.Context, query url.Values) ([]models.Group, error) {
+	resp := models.GroupList{}
+	err := c.get(ctx, URL.Base()+URL.Search()+URL.Groups(), query, &resp)
+	return resp.Groups, err
+}
+
+// SearchApps returns applications matched with query.
+func (c *Client) SearchApps(ctx context.Context, query url.Values) ([]models.App, error) {
+	resp := models.AppList{}
+	err := c.get(ctx, URL.Base()+URL.Search()+URL.Apps(), query, &resp)
+	return resp.Apps, err
+}
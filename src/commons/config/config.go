@@ -0,0 +1,369 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package commons/config defines a typed model of the configuration
+// reported by pharos-node and validates it against a registry of known properties.
+package config
+
+import (
+	"commons/errors"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+const (
+	PROPERTIES = "properties" // used to indicate a list of properties.
+	READ_ONLY  = "readOnly"   // used to indicate whether a property can be changed.
+
+	DEVICE_ID       = "deviceid"
+	DEVICE_NAME     = "devicename"
+	PING_INTERVAL   = "pinginterval"
+	OS              = "os"
+	PLATFORM        = "platform"
+	PROCESSOR       = "processor"
+	ANCHOR_ADDRESS  = "anchoraddress"
+	ANCHOR_ENDPOINT = "anchorendpoint"
+	NODE_ADDRESS    = "nodeaddress"
+	REVERSE_PROXY   = "reverseproxy"
+	ENABLED         = "enabled"
+)
+
+// Types which can be used in a Schema.
+const (
+	ANY     = ""
+	STRING  = "string"
+	BOOLEAN = "boolean"
+	NUMBER  = "number"
+	OBJECT  = "object"
+	ARRAY   = "array"
+)
+
+// Schema describes the value of a configuration property.
+// It follows the subset of JSON schema keywords which is needed
+// to describe pharos-node properties.
+type Schema struct {
+	Type       string
+	Properties map[string]Schema
+	Required   []string
+	Items      *Schema
+	ReadOnly   bool
+}
+
+// Property is a single configuration item of a node.
+type Property struct {
+	Name     string
+	Value    interface{}
+	ReadOnly bool
+}
+
+// Configuration is a list of properties of a node.
+type Configuration struct {
+	Properties []Property
+}
+
+// Difference describes a property whose value differs between two configurations.
+// A nil Stored or Actual value means that the property is missing on that side.
+type Difference struct {
+	Name   string
+	Stored interface{}
+	Actual interface{}
+}
+
+var registry = struct {
+	sync.RWMutex
+	schemas map[string]Schema
+}{
+	schemas: map[string]Schema{
+		DEVICE_ID:       {Type: STRING, ReadOnly: true},
+		DEVICE_NAME:     {Type: STRING},
+		PING_INTERVAL:   {Type: STRING},
+		OS:              {Type: STRING, ReadOnly: true},
+		PLATFORM:        {Type: STRING, ReadOnly: true},
+		PROCESSOR:       {Type: ANY, ReadOnly: true},
+		ANCHOR_ADDRESS:  {Type: STRING},
+		ANCHOR_ENDPOINT: {Type: STRING},
+		NODE_ADDRESS:    {Type: STRING, ReadOnly: true},
+		REVERSE_PROXY: {
+			Type:       OBJECT,
+			Properties: map[string]Schema{ENABLED: {Type: BOOLEAN}},
+			Required:   []string{ENABLED},
+			ReadOnly:   true,
+		},
+	},
+}
+
+// Register adds or replaces the schema of a property in the registry.
+func Register(name string, schema Schema) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.schemas[name] = schema
+}
+
+// Lookup returns the schema of a property registered with the given name.
+func Lookup(name string) (Schema, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	schema, exists := registry.schemas[name]
+	return schema, exists
+}
+
+// Names returns the names of all registered properties in alphabetical order.
+func Names() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.schemas))
+	for name := range registry.schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse converts a JSON body into a Configuration.
+// If body is malformed, InvalidJSON will be returned.
+func Parse(body string) (Configuration, error) {
+	src := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(body), &src); err != nil {
+		return Configuration{}, errors.InvalidJSON{"Unmarshalling Failed"}
+	}
+	return FromMap(src)
+}
+
+// FromMap converts a configuration stored as a map into a Configuration.
+// Each element of 'properties' must be an object which has exactly one key
+// except 'readOnly', otherwise InvalidJSON will be returned.
+func FromMap(src map[string]interface{}) (Configuration, error) {
+	value, exists := src[PROPERTIES]
+	if !exists {
+		return Configuration{}, errors.InvalidJSON{"properties field is required"}
+	}
+
+	list, ok := value.([]interface{})
+	if !ok {
+		return Configuration{}, errors.InvalidJSON{"properties field must be an array"}
+	}
+
+	config := Configuration{Properties: make([]Property, 0, len(list))}
+	for i, item := range list {
+		field := PROPERTIES + "[" + strconv.Itoa(i) + "]"
+
+		prop, ok := item.(map[string]interface{})
+		if !ok {
+			return Configuration{}, errors.InvalidJSON{field + " must be an object"}
+		}
+
+		property := Property{}
+		for key, value := range prop {
+			if key == READ_ONLY {
+				readOnly, ok := value.(bool)
+				if !ok {
+					return Configuration{}, errors.InvalidJSON{field + "." + READ_ONLY + " must be boolean"}
+				}
+				property.ReadOnly = readOnly
+				continue
+			}
+			if len(property.Name) != 0 {
+				return Configuration{}, errors.InvalidJSON{field + " must have a single property"}
+			}
+			property.Name = key
+			property.Value = value
+		}
+
+		if len(property.Name) == 0 {
+			return Configuration{}, errors.InvalidJSON{field + " has no property"}
+		}
+		config.Properties = append(config.Properties, property)
+	}
+	return config, nil
+}
+
+// FromProperties converts a list of properties, e.g. a request body decoded
+// into models.Configuration, into a Configuration.
+// If the list is missing, InvalidJSON will be returned.
+func FromProperties(props []map[string]interface{}) (Configuration, error) {
+	if props == nil {
+		return Configuration{}, errors.InvalidJSON{"properties field is required"}
+	}
+
+	list := make([]interface{}, len(props))
+	for i, prop := range props {
+		list[i] = prop
+	}
+	return FromMap(map[string]interface{}{PROPERTIES: list})
+}
+
+// ToMap converts a Configuration into the form stored in the database.
+func (config Configuration) ToMap() map[string]interface{} {
+	props := make([]interface{}, len(config.Properties))
+	for i, property := range config.Properties {
+		props[i] = map[string]interface{}{
+			property.Name: property.Value,
+			READ_ONLY:     property.ReadOnly,
+		}
+	}
+	return map[string]interface{}{PROPERTIES: props}
+}
+
+// Get returns the value of a property with the given name.
+func (config Configuration) Get(name string) (interface{}, bool) {
+	for _, property := range config.Properties {
+		if property.Name == name {
+			return property.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Update overwrites the values of properties which exist in both configurations
+// and appends properties which exist only in the updated configuration.
+func (config *Configuration) Update(updated Configuration) {
+	for _, prop := range updated.Properties {
+		found := false
+		for i := range config.Properties {
+			if config.Properties[i].Name == prop.Name {
+				config.Properties[i].Value = prop.Value
+				found = true
+				break
+			}
+		}
+		if !found {
+			config.Properties = append(config.Properties, prop)
+		}
+	}
+}
+
+// Diff returns the properties whose values differ between the configuration
+// stored in the anchor and the actual configuration reported by a node.
+// The order of differences follows stored properties first, then actual ones.
+func Diff(stored Configuration, actual Configuration) []Difference {
+	differences := make([]Difference, 0)
+	for _, property := range stored.Properties {
+		value, exists := actual.Get(property.Name)
+		if !exists {
+			differences = append(differences, Difference{Name: property.Name, Stored: property.Value})
+			continue
+		}
+		if !reflect.DeepEqual(property.Value, value) {
+			differences = append(differences, Difference{Name: property.Name, Stored: property.Value, Actual: value})
+		}
+	}
+	for _, property := range actual.Properties {
+		if _, exists := stored.Get(property.Name); !exists {
+			differences = append(differences, Difference{Name: property.Name, Actual: property.Value})
+		}
+	}
+	return differences
+}
+
+// ToMap converts a Difference into a map.
+func (difference Difference) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"property": difference.Name,
+		"stored":   difference.Stored,
+		"actual":   difference.Actual,
+	}
+}
+
+// Validate checks the value of all registered properties against their schema.
+// Properties which are not registered are accepted as they are,
+// because a node may report properties unknown to this anchor.
+func (config Configuration) Validate() error {
+	for i, property := range config.Properties {
+		schema, exists := Lookup(property.Name)
+		if !exists {
+			continue
+		}
+		field := PROPERTIES + "[" + strconv.Itoa(i) + "]." + property.Name
+		if err := schema.Validate(field, property.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateUpdate checks whether the configuration can be sent to a node.
+// Only registered and writable properties are allowed.
+func (config Configuration) ValidateUpdate() error {
+	if len(config.Properties) == 0 {
+		return errors.InvalidJSON{"properties field is empty"}
+	}
+
+	for _, property := range config.Properties {
+		schema, exists := Lookup(property.Name)
+		if !exists {
+			return errors.InvalidJSON{"unknown property: " + property.Name}
+		}
+		if schema.ReadOnly {
+			return errors.InvalidJSON{"read-only property: " + property.Name}
+		}
+	}
+	return config.Validate()
+}
+
+// Validate checks whether value conforms to the schema.
+// field is used to point out the invalid value in an error message.
+func (schema Schema) Validate(field string, value interface{}) error {
+	switch schema.Type {
+	case ANY:
+		return nil
+	case STRING:
+		if _, ok := value.(string); !ok {
+			return errors.InvalidJSON{field + " must be string"}
+		}
+	case BOOLEAN:
+		if _, ok := value.(bool); !ok {
+			return errors.InvalidJSON{field + " must be boolean"}
+		}
+	case NUMBER:
+		if _, ok := value.(float64); !ok {
+			return errors.InvalidJSON{field + " must be number"}
+		}
+	case ARRAY:
+		items, ok := value.([]interface{})
+		if !ok {
+			return errors.InvalidJSON{field + " must be array"}
+		}
+		if schema.Items != nil {
+			for i, item := range items {
+				if err := schema.Items.Validate(field+"["+strconv.Itoa(i)+"]", item); err != nil {
+					return err
+				}
+			}
+		}
+	case OBJECT:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return errors.InvalidJSON{field + " must be object"}
+		}
+		for _, key := range schema.Required {
+			if _, exists := object[key]; !exists {
+				return errors.InvalidJSON{field + "." + key + " field is required"}
+			}
+		}
+		for key, sub := range schema.Properties {
+			if v, exists := object[key]; exists {
+				if err := sub.Validate(field+"."+key, v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
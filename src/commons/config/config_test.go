@@ -0,0 +1,226 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package config
+
+import (
+	"commons/errors"
+	"reflect"
+	"testing"
+)
+
+const (
+	validConfig = `{"properties":[{"deviceid":"id","readOnly":true},` +
+		`{"devicename":"name","readOnly":false},{"reverseproxy":{"enabled":false}}]}`
+)
+
+func TestParseWithValidConfiguration_ExpectSuccess(t *testing.T) {
+	config, err := Parse(validConfig)
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if len(config.Properties) != 3 {
+		t.Errorf("Expected properties: %d, actual properties: %d", 3, len(config.Properties))
+	}
+
+	if !config.Properties[0].ReadOnly || config.Properties[1].ReadOnly {
+		t.Errorf("Unexpected readOnly flags: %v", config.Properties)
+	}
+
+	if err = config.Validate(); err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+}
+
+func TestParseWithInvalidConfiguration_ExpectErrorReturn(t *testing.T) {
+	testList := map[string]string{
+		"malformed":          `{"properties"}`,
+		"withoutProperties":  `{}`,
+		"propertiesNotArray": `{"properties":{}}`,
+		"propertyNotObject":  `{"properties":[1]}`,
+		"emptyProperty":      `{"properties":[{"readOnly":true}]}`,
+		"multipleProperties": `{"properties":[{"a":1,"b":2}]}`,
+		"readOnlyNotBoolean": `{"properties":[{"a":1,"readOnly":"true"}]}`,
+	}
+
+	for name, body := range testList {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(body)
+			switch err.(type) {
+			default:
+				t.Errorf("Expected err: %s, actual err: %v", "InvalidJSON", err)
+			case errors.InvalidJSON:
+			}
+		})
+	}
+}
+
+func TestFromProperties_ExpectSameConfigurationAsParse(t *testing.T) {
+	props := []map[string]interface{}{
+		{"deviceid": "id", "readOnly": true},
+		{"devicename": "name", "readOnly": false},
+		{"reverseproxy": map[string]interface{}{"enabled": false}},
+	}
+	config, err := FromProperties(props)
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	expected, _ := Parse(validConfig)
+	if !reflect.DeepEqual(expected, config) {
+		t.Errorf("Expected config: %v, actual config: %v", expected, config)
+	}
+
+	_, err = FromProperties(nil)
+	switch err.(type) {
+	default:
+		t.Errorf("Expected err: %s, actual err: %v", "InvalidJSON", err)
+	case errors.InvalidJSON:
+	}
+}
+
+func TestValidateWithInvalidValueType_ExpectErrorReturn(t *testing.T) {
+	testList := map[string]string{
+		"string":         `{"properties":[{"devicename":1}]}`,
+		"object":         `{"properties":[{"reverseproxy":true}]}`,
+		"requiredField":  `{"properties":[{"reverseproxy":{}}]}`,
+		"nestedProperty": `{"properties":[{"reverseproxy":{"enabled":"true"}}]}`,
+	}
+
+	for name, body := range testList {
+		t.Run(name, func(t *testing.T) {
+			config, err := Parse(body)
+			if err != nil {
+				t.Errorf("Unexpected err: %s", err.Error())
+			}
+
+			err = config.Validate()
+			switch err.(type) {
+			default:
+				t.Errorf("Expected err: %s, actual err: %v", "InvalidJSON", err)
+			case errors.InvalidJSON:
+			}
+		})
+	}
+}
+
+func TestValidateWithUnknownProperty_ExpectSuccess(t *testing.T) {
+	config, _ := Parse(`{"properties":[{"unknown":{"any":"value"}}]}`)
+
+	if err := config.Validate(); err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	testList := []struct {
+		name    string
+		body    string
+		success bool
+	}{
+		{name: "writable", body: `{"properties":[{"devicename":"name"},{"pinginterval":"10"}]}`, success: true},
+		{name: "empty", body: `{"properties":[]}`, success: false},
+		{name: "unknown", body: `{"properties":[{"unknown":"value"}]}`, success: false},
+		{name: "readOnly", body: `{"properties":[{"os":"linux"}]}`, success: false},
+		{name: "invalidType", body: `{"properties":[{"anchoraddress":true}]}`, success: false},
+	}
+
+	for _, test := range testList {
+		t.Run(test.name, func(t *testing.T) {
+			config, _ := Parse(test.body)
+			err := config.ValidateUpdate()
+			if test.success && err != nil {
+				t.Errorf("Unexpected err: %s", err.Error())
+			}
+			if !test.success {
+				switch err.(type) {
+				default:
+					t.Errorf("Expected err: %s, actual err: %v", "InvalidJSON", err)
+				case errors.InvalidJSON:
+				}
+			}
+		})
+	}
+}
+
+func TestUpdate_ExpectValuesOverwrittenAndAppended(t *testing.T) {
+	config, _ := Parse(validConfig)
+	updated, _ := Parse(`{"properties":[{"devicename":"new"},{"pinginterval":"10"}]}`)
+
+	config.Update(updated)
+
+	if value, _ := config.Get(DEVICE_NAME); value != "new" {
+		t.Errorf("Expected value: %s, actual value: %v", "new", value)
+	}
+
+	if value, exists := config.Get(PING_INTERVAL); !exists || value != "10" {
+		t.Errorf("Expected value: %s, actual value: %v", "10", value)
+	}
+
+	if len(config.Properties) != 4 {
+		t.Errorf("Expected properties: %d, actual properties: %d", 4, len(config.Properties))
+	}
+}
+
+func TestToMap_ExpectSameConfigurationAfterConversion(t *testing.T) {
+	config, _ := Parse(validConfig)
+
+	converted, err := FromMap(config.ToMap())
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(config, converted) {
+		t.Errorf("Expected config: %v, actual config: %v", config, converted)
+	}
+}
+
+func TestRegister_ExpectLookupReturnsRegisteredSchema(t *testing.T) {
+	schema := Schema{Type: NUMBER}
+	Register("test", schema)
+
+	registered, exists := Lookup("test")
+	if !exists || !reflect.DeepEqual(schema, registered) {
+		t.Errorf("Expected schema: %v, actual schema: %v", schema, registered)
+	}
+}
+
+func TestDiff_ExpectChangedMissingAndAddedProperties(t *testing.T) {
+	stored, _ := Parse(`{"properties":[{"devicename":"old"},{"os":"linux"},{"pinginterval":"10"}]}`)
+	actual, _ := Parse(`{"properties":[{"devicename":"new"},{"pinginterval":"10"},{"platform":"x86"}]}`)
+
+	expected := []Difference{
+		{Name: DEVICE_NAME, Stored: "old", Actual: "new"},
+		{Name: OS, Stored: "linux"},
+		{Name: PLATFORM, Actual: "x86"},
+	}
+
+	differences := Diff(stored, actual)
+	if !reflect.DeepEqual(expected, differences) {
+		t.Errorf("Expected differences: %v, actual differences: %v", expected, differences)
+	}
+}
+
+func TestDiffWithSameConfiguration_ExpectNoDifference(t *testing.T) {
+	stored, _ := Parse(validConfig)
+	actual, _ := Parse(validConfig)
+
+	if differences := Diff(stored, actual); len(differences) != 0 {
+		t.Errorf("Unexpected differences: %v", differences)
+	}
+}
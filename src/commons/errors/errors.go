@@ -73,6 +73,18 @@ func (e InvalidJSON) Error() string {
 	return "invalid json format: " + e.Message
 }
 
+// Struct InvalidField will be used for return case of error
+// which a field of request body has an invalid type or value.
+type InvalidField struct {
+	Field   string
+	Message string
+}
+
+// Error sets an error message of InvalidField.
+func (e InvalidField) Error() string {
+	return "invalid field: " + e.Field + " " + e.Message
+}
+
 // Struct InvalidYaml will be used for return case of error
 // which input yaml form is invalid.
 type InvalidYaml struct {
@@ -150,3 +162,16 @@ type InternalServerError struct {
 func (e InternalServerError) Error() string {
 	return "internal server error: " + e.Message
 }
+
+// Struct NodeError will be used for return case of error
+// which a node responds to a request of the anchor with.
+// Code is the http status code of the response of the node.
+type NodeError struct {
+	Code    int
+	Message string
+}
+
+// Error sets an error message of NodeError.
+func (e NodeError) Error() string {
+	return "node error: " + e.Message
+}
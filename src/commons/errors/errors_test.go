@@ -45,6 +45,8 @@ func TestTError(t *testing.T) {
 			testError: &InvalidParam{msg}},
 		{testName: "InvalidJSON", testPrefix: "invalid json format",
 			testError: &InvalidJSON{msg}},
+		{testName: "InvalidField", testPrefix: "invalid field",
+			testError: &InvalidField{"field", msg}},
 		{testName: "InvalidYaml", testPrefix: "invalid yaml file",
 			testError: &InvalidYaml{msg}},
 		{testName: "InvalidObjectId", testPrefix: "invalid objectId",
@@ -59,6 +61,8 @@ func TestTError(t *testing.T) {
 			testError: &IOError{msg}},
 		{testName: "InternalServerError", testPrefix: "internal server error",
 			testError: &InternalServerError{msg}},
+		{testName: "NodeError", testPrefix: "node error",
+			testError: &NodeError{500, msg}},
 	}
 
 	testFunc := func(err commonsError, prefix string) {
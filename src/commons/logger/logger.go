@@ -16,49 +16,419 @@
  *******************************************************************************/
 
 // Package commons/logger implements log stream.
+// Records are written in logfmt or JSON with the time, level, caller and message
+// of a call, and the request id carried by the context of a request if given.
+// The level and format can be changed at runtime, and records can also be
+// written to a file which is rotated by size.
 package logger
 
 import (
-	"log"
+	"bytes"
+	"commons/errors"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"path"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
-var loggers [3]*log.Logger
-var logFlag int
-
-// init initializes package global value.
-func init() {
-	logFlag = log.Ldate | log.Ltime
-	loggers[0] = log.New(os.Stdout, "[INFO][ANCHOR]", logFlag)
-	loggers[1] = log.New(os.Stdout, "[DEBUG][ANCHOR]", logFlag)
-	loggers[2] = log.New(os.Stdout, "[ERROR][ANCHOR]", logFlag)
-}
-
 const (
 	INFO = iota
 	DEBUG
 	ERROR
 )
 
-// Logging prints log stream on standard output with file name and function name, line.
+const (
+	FORMAT_LOGFMT = "logfmt"
+	FORMAT_JSON   = "json"
+
+	LEVEL_ENV       = "ANCHOR_LOG_LEVEL"       // environment variable of the level.
+	FORMAT_ENV      = "ANCHOR_LOG_FORMAT"      // environment variable of the format.
+	FILE_ENV        = "ANCHOR_LOG_FILE"        // environment variable of the path of a log file.
+	MAX_SIZE_ENV    = "ANCHOR_LOG_MAX_SIZE"    // environment variable of the size of a log file in MB.
+	MAX_BACKUPS_ENV = "ANCHOR_LOG_MAX_BACKUPS" // environment variable of the number of rotated files.
+
+	DEFAULT_MAX_SIZE    = 10 // MB
+	DEFAULT_MAX_BACKUPS = 3
+)
+
+// names and severities of levels. A record is written if its severity
+// is not less than the severity of the current level.
+var names = map[int]string{DEBUG: "debug", INFO: "info", ERROR: "error"}
+var severities = map[int]int{DEBUG: 0, INFO: 1, ERROR: 2}
+
+var (
+	mutex  = &sync.Mutex{}
+	level  = INFO
+	format = FORMAT_LOGFMT
+	stdout io.Writer
+	file   *rotatingFile
+	out    io.Writer
+)
+
+// requestIdKey is the key of a request id in a context.
+type requestIdKey struct{}
+
+// init initializes package global value.
+func init() {
+	stdout = os.Stdout
+	out = stdout
+}
+
+// Logging writes a record with msgs joined by spaces as its message,
+// if level is enabled.
 func Logging(level int, msgs ...string) {
-	pc, file, line, _ := runtime.Caller(1)
-	_, fileName := path.Split(file)
-	parts := strings.Split(runtime.FuncForPC(pc).Name(), ".")
-	pl := len(parts)
-	funcName := parts[pl-1]
-
-	packageName := ""
-	if parts[pl-2][0] == '(' {
-		funcName = parts[pl-2] + "." + funcName
-		packageName = strings.Join(parts[0:pl-2], ".")
+	if !Enabled(level) {
+		return
+	}
+	write(level, "", strings.Join(msgs, " "), nil)
+}
+
+// Log writes a record with msg and fields given as alternating keys and values,
+// if level is enabled.
+func Log(level int, msg string, keyvals ...interface{}) {
+	if !Enabled(level) {
+		return
+	}
+	write(level, "", msg, keyvals)
+}
+
+// Enabled returns whether records of level are written.
+func Enabled(l int) bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return severities[l] >= severities[level]
+}
+
+// NewContext returns a copy of ctx which carries requestId.
+func NewContext(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+// RequestId returns the request id carried by ctx, or an empty string.
+func RequestId(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
+
+// Context writes records with the request id of a context.
+type Context struct {
+	requestId string
+}
+
+// With returns a Context which writes records with the request id carried by ctx,
+// so that records written while handling a request are correlated with it.
+func With(ctx context.Context) Context {
+	return Context{requestId: RequestId(ctx)}
+}
+
+// Logging writes a record as the Logging function does, with the request id.
+func (c Context) Logging(level int, msgs ...string) {
+	if !Enabled(level) {
+		return
+	}
+	write(level, c.requestId, strings.Join(msgs, " "), nil)
+}
+
+// Log writes a record as the Log function does, with the request id.
+func (c Context) Log(level int, msg string, keyvals ...interface{}) {
+	if !Enabled(level) {
+		return
+	}
+	write(level, c.requestId, msg, keyvals)
+}
+
+// GetLevel returns the name of the current level.
+func GetLevel() string {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return names[level]
+}
+
+// SetLevel changes the level to one of debug, info and error.
+func SetLevel(name string) error {
+	return Configure(name, "")
+}
+
+// GetFormat returns the current format.
+func GetFormat() string {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return format
+}
+
+// SetFormat changes the format to logfmt or json.
+func SetFormat(name string) error {
+	return Configure("", name)
+}
+
+// Configure changes the level and the format at once. An empty name leaves
+// the setting unchanged, and nothing is changed if either name is invalid.
+func Configure(levelName, formatName string) error {
+	newLevel, newFormat := -1, strings.ToLower(formatName)
+	if len(levelName) != 0 {
+		for l, n := range names {
+			if n == strings.ToLower(levelName) {
+				newLevel = l
+			}
+		}
+		if newLevel == -1 {
+			return errors.InvalidField{"level", "must be one of debug, info, error"}
+		}
+	}
+	if len(newFormat) != 0 && newFormat != FORMAT_LOGFMT && newFormat != FORMAT_JSON {
+		return errors.InvalidField{"format", "must be " + FORMAT_LOGFMT + " or " + FORMAT_JSON}
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if newLevel != -1 {
+		level = newLevel
+	}
+	if len(newFormat) != 0 {
+		format = newFormat
+	}
+	return nil
+}
+
+// SetOutput replaces the standard output as a sink of records.
+// Records are still written to a file opened by OpenFile.
+func SetOutput(w io.Writer) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	stdout = w
+	resetOutput()
+}
+
+// OpenFile writes records to the file of path as well as the standard output.
+// The file is rotated when it exceeds maxSize bytes, keeping maxBackups files
+// named path.1, path.2 and so on, where path.1 is the latest one.
+func OpenFile(path string, maxSize int64, maxBackups int) error {
+	f, err := openRotatingFile(path, maxSize, maxBackups)
+	if err != nil {
+		return errors.IOError{err.Error()}
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if file != nil {
+		file.Close()
+	}
+	file = f
+	resetOutput()
+	return nil
+}
+
+// ConfigureFromEnv applies the level, format and file given by environment variables.
+// Variables which are not set leave the configuration unchanged.
+func ConfigureFromEnv() error {
+	if err := Configure(os.Getenv(LEVEL_ENV), os.Getenv(FORMAT_ENV)); err != nil {
+		return err
+	}
+
+	path := os.Getenv(FILE_ENV)
+	if len(path) == 0 {
+		return nil
+	}
+
+	maxSize, maxBackups := DEFAULT_MAX_SIZE, DEFAULT_MAX_BACKUPS
+	for _, v := range []struct {
+		key   string
+		value *int
+	}{{MAX_SIZE_ENV, &maxSize}, {MAX_BACKUPS_ENV, &maxBackups}} {
+		if value := os.Getenv(v.key); len(value) != 0 {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return errors.InvalidParam{v.key + " must be a non-negative integer"}
+			}
+			*v.value = n
+		}
+	}
+	return OpenFile(path, int64(maxSize)*1024*1024, maxBackups)
+}
+
+// resetOutput combines sinks. It must be called with mutex held.
+func resetOutput() {
+	if file == nil {
+		out = stdout
+		return
+	}
+	out = io.MultiWriter(stdout, file)
+}
+
+// field is a key and value of a record.
+type field struct {
+	key   string
+	value interface{}
+}
+
+// write formats a record and writes it to the sinks.
+func write(l int, requestId string, msg string, keyvals []interface{}) {
+	packageName, fileName, funcName, line := caller(3)
+
+	fields := []field{
+		{"time", time.Now().Format("2006-01-02T15:04:05.000Z07:00")},
+		{"level", names[l]},
+		{"caller", packageName + "/" + fileName + ":" + strconv.Itoa(line)},
+		{"func", funcName},
+		{"msg", msg},
+	}
+	if len(requestId) != 0 {
+		fields = append(fields, field{"requestid", requestId})
+	}
+	for i := 0; i < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+		var value interface{} = "MISSING"
+		if i+1 < len(keyvals) {
+			value = keyvals[i+1]
+		}
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		fields = append(fields, field{key, value})
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var buf bytes.Buffer
+	if format == FORMAT_JSON {
+		encodeJson(&buf, fields)
 	} else {
-		packageName = strings.Join(parts[0:pl-1], ".")
+		encodeLogfmt(&buf, fields)
+	}
+	buf.WriteByte('\n')
+	out.Write(buf.Bytes())
+}
+
+// caller returns the package, file, function and line of the caller of a logging function.
+func caller(skip int) (string, string, string, int) {
+	pc, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return "unknown", "unknown", "unknown", 0
 	}
+	_, fileName := path.Split(file)
+
+	// e.g. "api/deployment.(*Executor).deploy" or "commons/logger.TestLogger.func1".
+	name := runtime.FuncForPC(pc).Name()
+	dot := strings.LastIndex(name, "/") + 1
+	dot += strings.Index(name[dot:], ".")
+	return name[:dot], fileName, name[dot+1:], line
+}
+
+// encodeLogfmt writes fields as key=value pairs, quoting values if needed.
+func encodeLogfmt(buf *bytes.Buffer, fields []field) {
+	for i, f := range fields {
+		if i != 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(f.key)
+		buf.WriteByte('=')
+
+		value := fmt.Sprint(f.value)
+		if len(value) == 0 || strings.ContainsAny(value, " =\"\t\r\n") {
+			value = strconv.Quote(value)
+		}
+		buf.WriteString(value)
+	}
+}
+
+// encodeJson writes fields as a JSON object keeping their order.
+func encodeJson(buf *bytes.Buffer, fields []field) {
+	buf.WriteByte('{')
+	for i, f := range fields {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(f.key)
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			value, _ = json.Marshal(fmt.Sprint(f.value))
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+}
+
+// rotatingFile is a file which is renamed to a backup and recreated
+// when a write would exceed maxSize bytes.
+type rotatingFile struct {
+	mutex      sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	f := &rotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	f.file, f.size = file, info.Size()
+	return f, nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// rotate shifts backups, moves the current file to path.1 and recreates it.
+// The oldest backup is removed when there are maxBackups backups.
+func (f *rotatingFile) rotate() error {
+	f.file.Close()
+
+	if f.maxBackups > 0 {
+		os.Remove(f.backup(f.maxBackups))
+		for i := f.maxBackups - 1; i >= 1; i-- {
+			os.Rename(f.backup(i), f.backup(i+1))
+		}
+		os.Rename(f.path, f.backup(1))
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	f.file, f.size = file, 0
+	return nil
+}
+
+func (f *rotatingFile) backup(i int) string {
+	return f.path + "." + strconv.Itoa(i)
+}
 
-	loggers[level].Println(packageName, fileName, funcName, ":", strconv.Itoa(line), msgs)
+// Close closes the file.
+func (f *rotatingFile) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.file.Close()
 }
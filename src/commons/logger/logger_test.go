@@ -17,65 +17,215 @@
 package logger
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"io/ioutil"
-	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
 
-var oldStdout *os.File
-
-func setUpLogging() (func(), *os.File, *os.File) {
-	oldStdout = os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
-	loggers[INFO] = log.New(os.Stdout, "[INFO][EM]", logFlag)
-	loggers[DEBUG] = log.New(os.Stdout, "[DEBUG][EM]", logFlag)
-	loggers[ERROR] = log.New(os.Stdout, "[ERROR][EM]", logFlag)
+func setUpLogging() (func(), *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	SetOutput(buf)
+	SetLevel("debug")
 	return func() {
-		os.Stdout = oldStdout
-		loggers[INFO] = log.New(os.Stdout, "[INFO][EM]", logFlag)
-		loggers[DEBUG] = log.New(os.Stdout, "[DEBUG][EM]", logFlag)
-		loggers[ERROR] = log.New(os.Stdout, "[ERROR][EM]", logFlag)
-	}, r, w
-}
-
-func getPrintString(r *os.File, w *os.File) string {
-	w.Close()
-	out, _ := ioutil.ReadAll(r)
-	return string(out)
+		SetOutput(os.Stdout)
+		SetLevel("info")
+		SetFormat(FORMAT_LOGFMT)
+	}, buf
 }
 
 func TestLogger(t *testing.T) {
 	type testInfo struct {
 		name   string
+		level  int
 		prefix string
-		suffix string
 	}
 
 	testStr := "test"
 	testCase := []testInfo{
-		{"INFO", "[INFO][EM]", "[" + testStr + "]\n"},
-		{"DEBUG", "[DEBUG][EM]", "[" + testStr + "]\n"},
-		{"ERROR", "[ERROR][EM]", "[" + testStr + "]\n"},
+		{"INFO", INFO, "level=info"},
+		{"DEBUG", DEBUG, "level=debug"},
+		{"ERROR", ERROR, "level=error"},
 	}
 
-	for i, test := range testCase {
+	for _, test := range testCase {
 		t.Run(test.name, func(t *testing.T) {
-			tearDown, r, w := setUpLogging()
+			tearDown, buf := setUpLogging()
+			defer tearDown()
 
-			Logging(i, testStr)
-			str := getPrintString(r, w)
-			if !strings.HasPrefix(str, test.prefix) {
-				t.Error()
+			Logging(test.level, testStr)
+			str := buf.String()
+			if !strings.Contains(str, test.prefix) {
+				t.Errorf("Expected %s in %s", test.prefix, str)
 			}
-			if !strings.HasSuffix(str, test.suffix) {
-				t.Error()
+			if !strings.Contains(str, "func=TestLogger") {
+				t.Errorf("Expected caller in %s", str)
+			}
+			if !strings.HasSuffix(str, "msg="+testStr+"\n") {
+				t.Errorf("Expected msg at the end of %s", str)
 			}
-
-			tearDown()
 		})
 	}
 }
+
+func TestLoggerWithLevel_ExpectLowerLevelsDiscarded(t *testing.T) {
+	tearDown, buf := setUpLogging()
+	defer tearDown()
+
+	if err := SetLevel("error"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	Logging(DEBUG, "debug")
+	Logging(INFO, "info")
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing written, actual %s", buf.String())
+	}
+
+	Logging(ERROR, "error")
+	if buf.Len() == 0 {
+		t.Error("Expected error written")
+	}
+
+	if GetLevel() != "error" {
+		t.Errorf("Expected level error, actual %s", GetLevel())
+	}
+}
+
+func TestSetInvalidLevelAndFormat_ExpectErrorReturn(t *testing.T) {
+	if err := SetLevel("verbose"); err == nil {
+		t.Error("Expected error for invalid level")
+	}
+	if err := SetFormat("xml"); err == nil {
+		t.Error("Expected error for invalid format")
+	}
+}
+
+func TestLogWithJsonFormat_ExpectFieldsEncoded(t *testing.T) {
+	tearDown, buf := setUpLogging()
+	defer tearDown()
+
+	SetFormat(FORMAT_JSON)
+	Log(INFO, "deployed", "app", "abc", "count", 2)
+
+	record := make(map[string]interface{})
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected JSON record, actual %s", buf.String())
+	}
+	expected := map[string]interface{}{"level": "info", "msg": "deployed", "app": "abc", "count": float64(2)}
+	for key, value := range expected {
+		if record[key] != value {
+			t.Errorf("Expected %s=%v, actual %v", key, value, record[key])
+		}
+	}
+}
+
+func TestLogWithLogfmtFormat_ExpectValuesQuoted(t *testing.T) {
+	tearDown, buf := setUpLogging()
+	defer tearDown()
+
+	Log(INFO, "two words", "key", "a=b", "empty", "")
+	str := buf.String()
+	for _, expected := range []string{`msg="two words"`, `key="a=b"`, `empty=""`} {
+		if !strings.Contains(str, expected) {
+			t.Errorf("Expected %s in %s", expected, str)
+		}
+	}
+}
+
+func TestWith_ExpectRequestIdOfContextInRecords(t *testing.T) {
+	tearDown, buf := setUpLogging()
+	defer tearDown()
+
+	ctx := NewContext(context.Background(), "req-1")
+	With(ctx).Logging(INFO, "correlated")
+	if !strings.Contains(buf.String(), "requestid=req-1") {
+		t.Errorf("Expected request id in %s", buf.String())
+	}
+
+	buf.Reset()
+	With(ctx).Log(INFO, "correlated", "key", "value")
+	if !strings.Contains(buf.String(), "requestid=req-1 key=value") {
+		t.Errorf("Expected request id in %s", buf.String())
+	}
+
+	buf.Reset()
+	Logging(INFO, "uncorrelated")
+	With(context.Background()).Logging(INFO, "uncorrelated")
+	if strings.Contains(buf.String(), "requestid") {
+		t.Errorf("Expected no request id in %s", buf.String())
+	}
+}
+
+func TestOpenFile_ExpectRotatedBySize(t *testing.T) {
+	tearDown, _ := setUpLogging()
+	defer tearDown()
+
+	dir, err := ioutil.TempDir("", "logger")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "anchor.log")
+	if err := OpenFile(path, 200, 2); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	defer func() {
+		mutex.Lock()
+		file.Close()
+		file = nil
+		resetOutput()
+		mutex.Unlock()
+	}()
+
+	for i := 0; i < 10; i++ {
+		Logging(INFO, "rotation")
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		if _, err := os.Stat(name); err != nil {
+			t.Errorf("Expected %s exists", name)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); err == nil {
+		t.Error("Expected at most 2 backups")
+	}
+}
+
+func TestConfigureFromEnv_ExpectLevelAndFormatApplied(t *testing.T) {
+	tearDown, _ := setUpLogging()
+	defer tearDown()
+
+	os.Setenv(LEVEL_ENV, "error")
+	os.Setenv(FORMAT_ENV, "json")
+	defer os.Unsetenv(LEVEL_ENV)
+	defer os.Unsetenv(FORMAT_ENV)
+
+	if err := ConfigureFromEnv(); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if GetLevel() != "error" || GetFormat() != FORMAT_JSON {
+		t.Errorf("Expected error and json, actual %s and %s", GetLevel(), GetFormat())
+	}
+
+	os.Setenv(LEVEL_ENV, "loud")
+	if err := ConfigureFromEnv(); err == nil {
+		t.Error("Expected error for invalid level")
+	}
+}
+
+func TestConfigureWithInvalidFormat_ExpectLevelUnchanged(t *testing.T) {
+	tearDown, _ := setUpLogging()
+	defer tearDown()
+
+	if err := Configure("error", "xml"); err == nil {
+		t.Error("Expected error for invalid format")
+	}
+	if GetLevel() != "debug" {
+		t.Errorf("Expected level debug, actual %s", GetLevel())
+	}
+}
@@ -0,0 +1,329 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package commons/metrics collects metrics of pharos-anchor and writes them
+// in the Prometheus text exposition format.
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const CONTENT_TYPE = "text/plain; version=0.0.4; charset=utf-8"
+
+// DEFAULT_BUCKETS are upper bounds of histogram buckets in seconds.
+var DEFAULT_BUCKETS = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Sample is a value of a gauge collected by GaugeFunc.
+type Sample struct {
+	LabelValues []string
+	Value       float64
+}
+
+// family is a metric which is written as a single block of the exposition format.
+type family interface {
+	name() string
+	write(w io.Writer)
+}
+
+// Registry keeps metrics to be exposed.
+type Registry struct {
+	mutex    sync.Mutex
+	families map[string]family
+}
+
+// DefaultRegistry is the registry used by the package level functions.
+var DefaultRegistry = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]family)}
+}
+
+// register adds f to the registry. It panics if the name is already used
+// because metrics are defined once in package level variables.
+func (r *Registry) register(f family) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.families[f.name()]; exists {
+		panic("duplicated metric: " + f.name())
+	}
+	r.families[f.name()] = f
+}
+
+// Write writes all metrics sorted by name.
+func (r *Registry) Write(w io.Writer) error {
+	r.mutex.Lock()
+	families := make([]family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mutex.Unlock()
+
+	sort.Slice(families, func(i, j int) bool { return families[i].name() < families[j].name() })
+
+	var buf bytes.Buffer
+	for _, f := range families {
+		f.write(&buf)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Write writes all metrics of DefaultRegistry.
+func Write(w io.Writer) error {
+	return DefaultRegistry.Write(w)
+}
+
+// desc is a name, help and label names shared by every kind of metric.
+type desc struct {
+	metricName string
+	help       string
+	labels     []string
+}
+
+func (d desc) name() string {
+	return d.metricName
+}
+
+func (d desc) writeHeader(w io.Writer, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.metricName, strings.Replace(d.help, "\n", " ", -1))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.metricName, kind)
+}
+
+// key joins label values into a key of a series.
+// It panics on a wrong number of values, which is a programming error.
+func (d desc) key(values []string) string {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metric %s expects %d label values, got %d", d.metricName, len(d.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+// labelPairs formats labels and values, with extra pairs appended, as {a="b"}.
+func (d desc) labelPairs(values []string, extra ...string) string {
+	pairs := make([]string, 0, len(values)+len(extra)/2)
+	for i, label := range d.labels {
+		pairs = append(pairs, label+"=\""+escape(values[i])+"\"")
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+"=\""+escape(extra[i+1])+"\"")
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escape(value string) string {
+	value = strings.Replace(value, "\\", "\\\\", -1)
+	value = strings.Replace(value, "\"", "\\\"", -1)
+	return strings.Replace(value, "\n", "\\n", -1)
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// sortedKeys returns keys of series in a stable order.
+func sortedKeys(series map[string][]string) []string {
+	keys := make([]string, 0, len(series))
+	for key := range series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Counter is a monotonically increasing value per combination of labels.
+type Counter struct {
+	desc
+	mutex  sync.Mutex
+	values map[string]float64
+	series map[string][]string
+}
+
+// NewCounter defines a counter in DefaultRegistry.
+func NewCounter(name string, help string, labels ...string) *Counter {
+	return DefaultRegistry.NewCounter(name, help, labels...)
+}
+
+// NewCounter defines a counter in the registry.
+func (r *Registry) NewCounter(name string, help string, labels ...string) *Counter {
+	c := &Counter{
+		desc:   desc{name, help, labels},
+		values: make(map[string]float64),
+		series: make(map[string][]string),
+	}
+	r.register(c)
+	return c
+}
+
+// Inc increases the counter of the given label values by one.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increases the counter of the given label values by value.
+func (c *Counter) Add(value float64, labelValues ...string) {
+	key := c.key(labelValues)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, exists := c.series[key]; !exists {
+		c.series[key] = append([]string{}, labelValues...)
+	}
+	c.values[key] += value
+}
+
+func (c *Counter) write(w io.Writer) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.writeHeader(w, "counter")
+	for _, key := range sortedKeys(c.series) {
+		fmt.Fprintf(w, "%s%s %s\n", c.metricName, c.labelPairs(c.series[key]), formatValue(c.values[key]))
+	}
+}
+
+// Histogram counts observed values into buckets per combination of labels.
+type Histogram struct {
+	desc
+	buckets []float64
+	mutex   sync.Mutex
+	counts  map[string][]uint64
+	sums    map[string]float64
+	series  map[string][]string
+}
+
+// NewHistogram defines a histogram in DefaultRegistry.
+// DEFAULT_BUCKETS is used if buckets is nil.
+func NewHistogram(name string, help string, buckets []float64, labels ...string) *Histogram {
+	return DefaultRegistry.NewHistogram(name, help, buckets, labels...)
+}
+
+// NewHistogram defines a histogram in the registry.
+func (r *Registry) NewHistogram(name string, help string, buckets []float64, labels ...string) *Histogram {
+	if buckets == nil {
+		buckets = DEFAULT_BUCKETS
+	}
+	h := &Histogram{
+		desc:    desc{name, help, labels},
+		buckets: append([]float64{}, buckets...),
+		counts:  make(map[string][]uint64),
+		sums:    make(map[string]float64),
+		series:  make(map[string][]string),
+	}
+	sort.Float64s(h.buckets)
+	r.register(h)
+	return h
+}
+
+// Observe adds value to the histogram of the given label values.
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	key := h.key(labelValues)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	counts, exists := h.counts[key]
+	if !exists {
+		// The last count is for the +Inf bucket.
+		counts = make([]uint64, len(h.buckets)+1)
+		h.counts[key] = counts
+		h.series[key] = append([]string{}, labelValues...)
+	}
+
+	i := sort.SearchFloat64s(h.buckets, value)
+	counts[i]++
+	h.sums[key] += value
+}
+
+// ObserveSince adds the seconds elapsed since start.
+func (h *Histogram) ObserveSince(start time.Time, labelValues ...string) {
+	h.Observe(time.Since(start).Seconds(), labelValues...)
+}
+
+func (h *Histogram) write(w io.Writer) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.writeHeader(w, "histogram")
+	for _, key := range sortedKeys(h.series) {
+		values := h.series[key]
+		counts := h.counts[key]
+
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.metricName, h.labelPairs(values, "le", formatValue(bound)), cumulative)
+		}
+		cumulative += counts[len(h.buckets)]
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.metricName, h.labelPairs(values, "le", "+Inf"), cumulative)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.metricName, h.labelPairs(values), formatValue(h.sums[key]))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.metricName, h.labelPairs(values), cumulative)
+	}
+}
+
+// GaugeFunc is a gauge whose samples are collected by a function
+// each time metrics are written, e.g. the number of nodes in the database.
+type GaugeFunc struct {
+	desc
+	collect func() []Sample
+}
+
+// NewGaugeFunc defines a gauge in DefaultRegistry.
+func NewGaugeFunc(name string, help string, labels []string, collect func() []Sample) *GaugeFunc {
+	return DefaultRegistry.NewGaugeFunc(name, help, labels, collect)
+}
+
+// NewGaugeFunc defines a gauge in the registry.
+func (r *Registry) NewGaugeFunc(name string, help string, labels []string, collect func() []Sample) *GaugeFunc {
+	g := &GaugeFunc{desc: desc{name, help, labels}, collect: collect}
+	r.register(g)
+	return g
+}
+
+func (g *GaugeFunc) write(w io.Writer) {
+	samples := g.collect()
+	sort.Slice(samples, func(i, j int) bool {
+		return strings.Join(samples[i].LabelValues, "\xff") < strings.Join(samples[j].LabelValues, "\xff")
+	})
+
+	g.writeHeader(w, "gauge")
+	for _, sample := range samples {
+		if len(sample.LabelValues) != len(g.labels) {
+			continue
+		}
+		fmt.Fprintf(w, "%s%s %s\n", g.metricName, g.labelPairs(sample.LabelValues), formatValue(sample.Value))
+	}
+}
@@ -0,0 +1,111 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package metrics
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteCounter_ExpectExpositionFormat(t *testing.T) {
+	registry := NewRegistry()
+	counter := registry.NewCounter("test_total", "Test counter.", "method", "path")
+	counter.Inc("GET", "/b")
+	counter.Add(2, "GET", "/a\"")
+	counter.Inc("GET", "/b")
+
+	expected := "# HELP test_total Test counter.\n" +
+		"# TYPE test_total counter\n" +
+		"test_total{method=\"GET\",path=\"/a\\\"\"} 2\n" +
+		"test_total{method=\"GET\",path=\"/b\"} 2\n"
+
+	var buf bytes.Buffer
+	registry.Write(&buf)
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nActual:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteHistogram_ExpectCumulativeBuckets(t *testing.T) {
+	registry := NewRegistry()
+	histogram := registry.NewHistogram("test_seconds", "Test histogram.", []float64{1, 0.1})
+	histogram.Observe(0.05)
+	histogram.Observe(0.1)
+	histogram.Observe(0.5)
+	histogram.Observe(3)
+
+	expected := "# HELP test_seconds Test histogram.\n" +
+		"# TYPE test_seconds histogram\n" +
+		"test_seconds_bucket{le=\"0.1\"} 2\n" +
+		"test_seconds_bucket{le=\"1\"} 3\n" +
+		"test_seconds_bucket{le=\"+Inf\"} 4\n" +
+		"test_seconds_sum 3.65\n" +
+		"test_seconds_count 4\n"
+
+	var buf bytes.Buffer
+	registry.Write(&buf)
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nActual:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteGaugeFunc_ExpectCollectedSamplesSortedByName(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewGaugeFunc("b_gauge", "Second.", nil, func() []Sample {
+		return []Sample{{Value: 1}}
+	})
+	registry.NewGaugeFunc("a_gauge", "First.", []string{"status"}, func() []Sample {
+		return []Sample{{[]string{"registered"}, 2}, {[]string{"connected"}, 1}, {nil, 3}}
+	})
+
+	expected := "# HELP a_gauge First.\n" +
+		"# TYPE a_gauge gauge\n" +
+		"a_gauge{status=\"connected\"} 1\n" +
+		"a_gauge{status=\"registered\"} 2\n" +
+		"# HELP b_gauge Second.\n" +
+		"# TYPE b_gauge gauge\n" +
+		"b_gauge 1\n"
+
+	var buf bytes.Buffer
+	registry.Write(&buf)
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nActual:\n%s", expected, buf.String())
+	}
+}
+
+func TestRegisterDuplicatedName_ExpectPanic(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewCounter("test_total", "Test counter.")
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic, actual nothing")
+		}
+	}()
+	registry.NewCounter("test_total", "Test counter.")
+}
+
+func TestCalledWithWrongNumberOfLabels_ExpectPanic(t *testing.T) {
+	counter := NewRegistry().NewCounter("test_total", "Test counter.", "method")
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic, actual nothing")
+		}
+	}()
+	counter.Inc()
+}
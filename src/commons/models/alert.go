@@ -0,0 +1,60 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package models
+
+// AlertRule is a condition on resource usage which raises an alert when it
+// holds for Duration seconds. Target is either 'node' or 'app'.
+// Metrics of a node are cpu, mem and disk in percent, and those of an app
+// are cpu and mem in percent and memusage in MB. NodeID and GroupID narrow
+// the nodes to evaluate, and AppID is required for an app rule.
+type AlertRule struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Target    string  `json:"target"`
+	Metric    string  `json:"metric"`
+	Operator  string  `json:"operator"`
+	Threshold float64 `json:"threshold"`
+	Duration  int     `json:"duration"`
+	NodeID    string  `json:"nodeId,omitempty"`
+	GroupID   string  `json:"groupId,omitempty"`
+	AppID     string  `json:"appId,omitempty"`
+}
+
+// AlertRuleList is a response of the API which returns all alert rules.
+type AlertRuleList struct {
+	Rules []AlertRule `json:"rules"`
+}
+
+// Alert is the state of a rule on a node, or on an app of the node.
+// Status is either 'firing' or 'resolved', and Value is the usage
+// when the status changed.
+type Alert struct {
+	ID         string  `json:"id"`
+	RuleID     string  `json:"ruleId"`
+	NodeID     string  `json:"nodeId"`
+	AppID      string  `json:"appId,omitempty"`
+	Status     string  `json:"status"`
+	Value      float64 `json:"value"`
+	FiredAt    string  `json:"firedAt"`
+	ResolvedAt string  `json:"resolvedAt,omitempty"`
+}
+
+// AlertList is a response of the API which returns alerts.
+type AlertList struct {
+	Alerts []Alert `json:"alerts"`
+}
@@ -0,0 +1,106 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package models
+
+// App is the information of an application stored in the anchor.
+type App struct {
+	ID       string   `json:"id"`
+	Images   []string `json:"images"`
+	Services []string `json:"services"`
+}
+
+// AppList is a response of APIs which return several applications.
+type AppList struct {
+	Apps []App `json:"apps"`
+}
+
+// GroupApp is an application deployed to members of a group.
+type GroupApp struct {
+	ID      string   `json:"id"`
+	Members []string `json:"members"`
+}
+
+// GroupAppList is a response of the API which returns applications of a group.
+type GroupAppList struct {
+	Apps []GroupApp `json:"apps"`
+}
+
+// AppState is the running state of an application deployed to a node.
+type AppState struct {
+	ID    string `json:"id"`
+	State string `json:"state"`
+}
+
+// NodeAppList is a response of the API which returns applications of a node.
+type NodeAppList struct {
+	Apps []AppState `json:"apps"`
+}
+
+// Image is an image which is used by an application.
+type Image struct {
+	Name string `json:"name"`
+}
+
+// ServiceState is the state of a container.
+type ServiceState struct {
+	ExitCode string `json:"exitcode"`
+	Status   string `json:"status"`
+}
+
+// Service is a container which runs a part of an application.
+type Service struct {
+	Name  string        `json:"name"`
+	CID   string        `json:"cid"`
+	Ports []interface{} `json:"ports"`
+	State ServiceState  `json:"state"`
+}
+
+// AppDescription is a docker-compose document which describes an application.
+// It is the body of requests which deploy or update an application,
+// and is sent to nodes as it is.
+type AppDescription []byte
+
+// AppInfo is the information of an application deployed to a node.
+// Description is a docker-compose document of the application.
+type AppInfo struct {
+	ID          string    `json:"id"`
+	State       string    `json:"state,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Images      []Image   `json:"images,omitempty"`
+	Services    []Service `json:"services,omitempty"`
+}
+
+// NodeAppInfo is the information of an application reported by a member of a group.
+// Code and Message are set when some of the members fail.
+type NodeAppInfo struct {
+	AppInfo
+	Code    StatusCode `json:"code,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
+
+// GroupAppInfo is a response of the API which returns an application of a group.
+type GroupAppInfo struct {
+	Responses []NodeAppInfo `json:"responses"`
+}
+
+// DeploymentResponse is a response of APIs which deploy or control applications.
+// Responses is set when the request is sent to several nodes.
+type DeploymentResponse struct {
+	ID        string         `json:"id,omitempty"`
+	Responses []NodeResponse `json:"responses,omitempty"`
+}
@@ -0,0 +1,40 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package models
+
+// CreateGroupRequest is a body of a request to create a group.
+type CreateGroupRequest struct {
+	Name string `json:"name"`
+}
+
+// MembersRequest is a body of a request to join or leave a group.
+type MembersRequest struct {
+	Nodes []string `json:"nodes"`
+}
+
+// Group is the information of a group.
+type Group struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+// GroupList is a response of APIs which return several groups.
+type GroupList struct {
+	Groups []Group `json:"groups"`
+}
@@ -0,0 +1,120 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package commons/models defines typed request and response payloads of
+// the anchor REST APIs. JSON tags follow the current wire format, so the same
+// types can be used to encode a request on a client and to decode it on the anchor.
+package models
+
+import (
+	"bytes"
+	"commons/errors"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// IDResponse is returned by APIs which create a resource.
+type IDResponse struct {
+	ID string `json:"id"`
+}
+
+// NodeResponse is a result of a request which is sent to each node
+// when a request targets several nodes and some of them fail.
+type NodeResponse struct {
+	ID      string     `json:"id"`
+	Code    StatusCode `json:"code"`
+	Message string     `json:"message,omitempty"`
+}
+
+// StatusCode is a http status code reported per node.
+// It is encoded as a string, and both a number and a string
+// are accepted when decoding.
+type StatusCode int
+
+// MarshalJSON encodes a status code as a string.
+func (code StatusCode) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(strconv.Itoa(int(code)))), nil
+}
+
+// UnmarshalJSON decodes a status code given as a number or a string.
+func (code *StatusCode) UnmarshalJSON(data []byte) error {
+	str := string(bytes.Trim(data, `"`))
+	value, err := strconv.Atoi(str)
+	if err != nil {
+		return errors.InvalidField{"code", "must be integer"}
+	}
+	*code = StatusCode(value)
+	return nil
+}
+
+// Decode converts a JSON body into v.
+// If body is malformed, InvalidJSON will be returned,
+// and if a field has an unexpected type, InvalidField will be returned.
+func Decode(body string, v interface{}) error {
+	err := json.Unmarshal([]byte(body), v)
+	if err == nil {
+		return nil
+	}
+
+	switch e := err.(type) {
+	case *json.UnmarshalTypeError:
+		return errors.InvalidField{convertFieldPath(e.Field), "has unexpected type " + e.Value}
+	case errors.InvalidField:
+		return e
+	}
+	return errors.InvalidJSON{"Unmarshalling Failed"}
+}
+
+// convertFieldPath converts a path reported by encoding/json, e.g. "nodes.0",
+// into the form used in error responses, e.g. "nodes[0]".
+func convertFieldPath(path string) string {
+	if len(path) == 0 {
+		return "body"
+	}
+
+	var field string
+	for _, name := range strings.Split(path, ".") {
+		if _, err := strconv.Atoi(name); err == nil {
+			field += "[" + name + "]"
+		} else if len(field) == 0 {
+			field = name
+		} else {
+			field += "." + name
+		}
+	}
+	return field
+}
+
+// Encode converts v into a JSON body.
+func Encode(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", errors.IOError{"json marshalling failed"}
+	}
+	return string(data), nil
+}
+
+// Convert copies a value decoded as a generic map, e.g. a response of controllers,
+// into a typed model by way of its JSON representation.
+func Convert(src interface{}, dst interface{}) error {
+	body, err := Encode(src)
+	if err != nil {
+		return err
+	}
+	return Decode(body, dst)
+}
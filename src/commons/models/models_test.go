@@ -0,0 +1,153 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package models
+
+import (
+	"commons/errors"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// sameJson returns true if the typed model and the map used on the wire
+// are encoded to the same JSON document.
+func sameJson(t *testing.T, typed interface{}, wire string) {
+	encoded, err := Encode(typed)
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	var expected, actual interface{}
+	json.Unmarshal([]byte(wire), &expected)
+	json.Unmarshal([]byte(encoded), &actual)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected json: %s, actual json: %s", wire, encoded)
+	}
+}
+
+func TestEncode_ExpectCurrentWireFormat(t *testing.T) {
+	testList := []struct {
+		name  string
+		typed interface{}
+		wire  string
+	}{
+		{name: "nodes", typed: NodeList{Nodes: []Node{{ID: "id", IP: "127.0.0.1", Apps: []string{"app"}, Status: "connected"}}},
+			wire: `{"nodes":[{"id":"id","ip":"127.0.0.1","apps":["app"],"status":"connected"}]}`},
+		{name: "group", typed: Group{ID: "id", Name: "name", Members: []string{"node"}},
+			wire: `{"id":"id","name":"name","members":["node"]}`},
+		{name: "registries", typed: RegistryList{Registries: []Registry{{ID: "id", URL: "url"}}},
+			wire: `{"registries":[{"id":"id","url":"url"}]}`},
+		{name: "subscription", typed: SubscriptionRequest{URL: "url", Event: Event{Type: "node", Status: []string{"connected"}}},
+			wire: `{"url":"url","event":{"type":"node","status":["connected"]}}`},
+		{name: "deployment", typed: DeploymentResponse{ID: "app", Responses: []NodeResponse{{ID: "node", Code: 500, Message: "error"}}},
+			wire: `{"id":"app","responses":[{"id":"node","code":"500","message":"error"}]}`},
+		{name: "drift", typed: Drift{ID: "id", Differences: []Difference{{Property: "os", Stored: "linux"}}, CheckedAt: "now"},
+			wire: `{"id":"id","differences":[{"property":"os","stored":"linux","actual":null}],"checkedat":"now"}`},
+	}
+
+	for _, test := range testList {
+		t.Run(test.name, func(t *testing.T) {
+			sameJson(t, test.typed, test.wire)
+		})
+	}
+}
+
+func TestDecodeResourceInfo_ExpectSuccess(t *testing.T) {
+	body := `{"cpu":["8.00%"],"mem":{"free":"1KB","total":"2KB","used":"1KB","usedpercent":"50%"},` +
+		`"disk":[{"free":"1G","path":"/","total":"2G","used":"1G","usedpercent":"50%"}]}`
+
+	resource := NodeResource{}
+	if err := Decode(body, &resource); err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	expected := NodeResource{
+		CPU:  []string{"8.00%"},
+		Mem:  Memory{Free: "1KB", Total: "2KB", Used: "1KB", UsedPercent: "50%"},
+		Disk: []Disk{{Path: "/", Free: "1G", Total: "2G", Used: "1G", UsedPercent: "50%"}},
+	}
+	if !reflect.DeepEqual(expected, resource) {
+		t.Errorf("Expected resource: %v, actual resource: %v", expected, resource)
+	}
+}
+
+func TestUsage_ExpectSummarizedInPercentAndMB(t *testing.T) {
+	node := NodeResource{
+		CPU:  []string{"10.00%", "30.00%"},
+		Mem:  Memory{UsedPercent: "50%"},
+		Disk: []Disk{{UsedPercent: "20%"}, {UsedPercent: "70%"}, {UsedPercent: "invalid"}},
+	}
+	if cpu, mem, disk := node.Usage(); cpu != 20 || mem != 50 || disk != 70 {
+		t.Errorf("Unexpected usage of node: %f, %f, %f", cpu, mem, disk)
+	}
+
+	app := AppResource{Services: []ServiceResource{
+		{CPU: "1.5%", Mem: "2%", MemUsage: "412MiB"},
+		{CPU: "0.5%", Mem: "1%", MemUsage: "0.25GiB"},
+		{CPU: "", Mem: "", MemUsage: "2048KiB"},
+	}}
+	if cpu, mem, memUsage := app.Usage(); cpu != 2 || mem != 3 || memUsage != 670 {
+		t.Errorf("Unexpected usage of app: %f, %f, %f", cpu, mem, memUsage)
+	}
+}
+
+func TestDecodeStatusCode_ExpectNumberAndStringAccepted(t *testing.T) {
+	responses := []NodeResponse{}
+	if err := Decode(`[{"id":"a","code":200},{"id":"b","code":"500"}]`, &responses); err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if responses[0].Code != 200 || responses[1].Code != 500 {
+		t.Errorf("Unexpected codes: %v", responses)
+	}
+}
+
+func TestDecodeWithInvalidBody_ExpectErrorReturn(t *testing.T) {
+	err := Decode(`{"nodes"}`, &MembersRequest{})
+	switch err.(type) {
+	default:
+		t.Errorf("Expected err: %s, actual err: %v", "InvalidJSON", err)
+	case errors.InvalidJSON:
+	}
+
+	err = Decode(`{"nodes":[1]}`, &MembersRequest{})
+	switch e := err.(type) {
+	default:
+		t.Errorf("Expected err: %s, actual err: %v", "InvalidField", err)
+	case errors.InvalidField:
+		if e.Field != "nodes[0]" {
+			t.Errorf("Expected field: %s, actual field: %s", "nodes[0]", e.Field)
+		}
+	}
+}
+
+func TestConvert_ExpectTypedModelFromMap(t *testing.T) {
+	src := map[string]interface{}{
+		"groups": []map[string]interface{}{{"id": "id", "name": "name", "members": []string{"node"}}},
+	}
+
+	groups := GroupList{}
+	if err := Convert(src, &groups); err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	expected := GroupList{Groups: []Group{{ID: "id", Name: "name", Members: []string{"node"}}}}
+	if !reflect.DeepEqual(expected, groups) {
+		t.Errorf("Expected groups: %v, actual groups: %v", expected, groups)
+	}
+}
@@ -0,0 +1,74 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package models
+
+// RegisterNodeRequest is a body of a request to register a node.
+type RegisterNodeRequest struct {
+	IP     string                 `json:"ip"`
+	Config map[string]interface{} `json:"config"`
+	Apps   []string               `json:"apps,omitempty"`
+}
+
+// PingRequest is a body of a ping request sent by a node.
+type PingRequest struct {
+	Interval string `json:"interval"`
+}
+
+// Node is the information of a registered node.
+// Config is omitted when a node is returned by management APIs.
+type Node struct {
+	ID     string                 `json:"id"`
+	IP     string                 `json:"ip"`
+	Apps   []string               `json:"apps"`
+	Status string                 `json:"status"`
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// NodeList is a response of APIs which return several nodes.
+type NodeList struct {
+	Nodes []Node `json:"nodes"`
+}
+
+// Configuration is a configuration of a node, which is a list of
+// objects having a single property and an optional 'readOnly' flag.
+type Configuration struct {
+	Properties []map[string]interface{} `json:"properties"`
+}
+
+// Difference is a property whose value differs between the configuration
+// stored in the anchor and the one reported by a node.
+type Difference struct {
+	Property string      `json:"property"`
+	Stored   interface{} `json:"stored"`
+	Actual   interface{} `json:"actual"`
+}
+
+// Drift is a result of the configuration drift check of a node.
+// Code and Message are set only when several nodes are checked at once.
+type Drift struct {
+	ID          string       `json:"id"`
+	Differences []Difference `json:"differences"`
+	CheckedAt   string       `json:"checkedat"`
+	Code        StatusCode   `json:"code,omitempty"`
+	Message     string       `json:"message,omitempty"`
+}
+
+// DriftList is a response of APIs which return drift of several nodes.
+type DriftList struct {
+	Drifts []Drift `json:"drifts"`
+}
@@ -0,0 +1,34 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package models
+
+// RegistryRequest is a body of a request to add a docker registry.
+type RegistryRequest struct {
+	IP string `json:"ip"`
+}
+
+// Registry is the information of a docker registry.
+type Registry struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// RegistryList is a response of the API which returns docker registries.
+type RegistryList struct {
+	Registries []Registry `json:"registries"`
+}
@@ -0,0 +1,201 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Memory is the memory usage of a device.
+type Memory struct {
+	Free        string `json:"free"`
+	Total       string `json:"total"`
+	Used        string `json:"used"`
+	UsedPercent string `json:"usedpercent"`
+}
+
+// Disk is the usage of a disk partition of a device.
+type Disk struct {
+	Path        string `json:"path"`
+	Free        string `json:"free"`
+	Total       string `json:"total"`
+	Used        string `json:"used"`
+	UsedPercent string `json:"usedpercent"`
+}
+
+// NodeResource is the resource usage of a device.
+// CPU contains the usage of each core.
+type NodeResource struct {
+	CPU     []string                 `json:"cpu"`
+	Mem     Memory                   `json:"mem"`
+	Disk    []Disk                   `json:"disk"`
+	Network []map[string]interface{} `json:"network,omitempty"`
+}
+
+// ServiceResource is the resource usage of a container.
+type ServiceResource struct {
+	CID           string `json:"cid"`
+	CName         string `json:"cname"`
+	CPU           string `json:"cpu"`
+	Mem           string `json:"mem"`
+	MemLimit      string `json:"memlimit"`
+	MemUsage      string `json:"memusage"`
+	BlockInput    string `json:"blockinput"`
+	BlockOutput   string `json:"blockoutput"`
+	NetworkInput  string `json:"networkinput"`
+	NetworkOutput string `json:"networkoutput"`
+	PIDs          int    `json:"pids"`
+}
+
+// AppResource is the resource usage of an application.
+type AppResource struct {
+	Services []ServiceResource `json:"services"`
+}
+
+// Usage returns the average usage of CPU cores, the memory usage and the usage
+// of the fullest disk partition in percent. Values which can not be parsed are regarded as 0.
+func (resource NodeResource) Usage() (cpu float64, mem float64, disk float64) {
+	for _, core := range resource.CPU {
+		cpu += parsePercent(core)
+	}
+	if len(resource.CPU) != 0 {
+		cpu /= float64(len(resource.CPU))
+	}
+
+	for _, partition := range resource.Disk {
+		if used := parsePercent(partition.UsedPercent); used > disk {
+			disk = used
+		}
+	}
+	return cpu, parsePercent(resource.Mem.UsedPercent), disk
+}
+
+// Usage returns the sum of CPU and memory usage of services in percent,
+// and the sum of their memory usage in MB. Values which can not be parsed are regarded as 0.
+func (resource AppResource) Usage() (cpu float64, mem float64, memUsage float64) {
+	for _, service := range resource.Services {
+		cpu += parsePercent(service.CPU)
+		mem += parsePercent(service.Mem)
+		memUsage += parseSize(service.MemUsage)
+	}
+	return cpu, mem, memUsage
+}
+
+// parsePercent parses a value such as '8.00%'.
+func parsePercent(value string) float64 {
+	percent, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(value, "%")), 64)
+	if err != nil {
+		return 0
+	}
+	return percent
+}
+
+// parseSize parses a value such as '12.5MiB' or '1.2GB' into MB, where
+// a KB is 1024 bytes as in usage reported by docker.
+func parseSize(value string) float64 {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	units := []struct {
+		suffix string
+		scale  float64
+	}{
+		{"GIB", 1024}, {"MIB", 1}, {"KIB", 1.0 / 1024},
+		{"GB", 1024}, {"MB", 1}, {"KB", 1.0 / 1024},
+		{"B", 1.0 / 1024 / 1024},
+	}
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			size, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(value, unit.suffix)), 64)
+			if err != nil {
+				return 0
+			}
+			return size * unit.scale
+		}
+	}
+	return 0
+}
+
+// ResourceSample is the resource usage of a device collected at Time.
+// Values are in percent. CPU is the average of all cores and Disk is
+// the usage of the fullest partition.
+type ResourceSample struct {
+	Time string  `json:"time"`
+	CPU  float64 `json:"cpu"`
+	Mem  float64 `json:"mem"`
+	Disk float64 `json:"disk"`
+}
+
+// ResourceHistory is a response of the API which returns resource usage of a node over time.
+// Samples of a resolution other than raw are averages over the period.
+type ResourceHistory struct {
+	ID         string           `json:"id"`
+	Resolution string           `json:"resolution"`
+	Samples    []ResourceSample `json:"samples"`
+}
+
+// Statistic is an aggregate of a value over nodes.
+type Statistic struct {
+	Avg float64 `json:"avg"`
+	Max float64 `json:"max"`
+}
+
+// ResourceAggregate is the resource usage of nodes in a group collected at Time.
+type ResourceAggregate struct {
+	Time  string    `json:"time"`
+	Nodes int       `json:"nodes"`
+	CPU   Statistic `json:"cpu"`
+	Mem   Statistic `json:"mem"`
+	Disk  Statistic `json:"disk"`
+}
+
+// GroupResourceHistory is a response of the API which returns resource usage of a group over time.
+type GroupResourceHistory struct {
+	ID         string              `json:"id"`
+	Resolution string              `json:"resolution"`
+	Samples    []ResourceAggregate `json:"samples"`
+}
+
+// NodeResourceStatistics is an aggregate of resource usage of members of a group
+// which reported it. Values are in percent as in ResourceSample.
+type NodeResourceStatistics struct {
+	Nodes int       `json:"nodes"`
+	CPU   Statistic `json:"cpu"`
+	Mem   Statistic `json:"mem"`
+	Disk  Statistic `json:"disk"`
+}
+
+// AppResourceStatistics is an aggregate of resource usage of an application over
+// members of a group which reported it. CPU and Mem are in percent and MemUsage is in MB.
+type AppResourceStatistics struct {
+	Nodes    int       `json:"nodes"`
+	CPU      Statistic `json:"cpu"`
+	Mem      Statistic `json:"mem"`
+	MemUsage Statistic `json:"memusage"`
+}
+
+// NodeResourceSample is the latest resource usage of a node.
+type NodeResourceSample struct {
+	ID string `json:"id"`
+	ResourceSample
+}
+
+// TopNodes is a response of the API which returns nodes using the most resources.
+type TopNodes struct {
+	Metric string               `json:"metric"`
+	Nodes  []NodeResourceSample `json:"nodes"`
+}
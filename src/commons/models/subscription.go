@@ -0,0 +1,100 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package models
+
+// Event describes events which a subscriber is interested in.
+// Type is one of the types of events, e.g. 'app', 'node' and 'resource',
+// and Filter is an optional expression over fields of events.
+type Event struct {
+	Type   string   `json:"type"`
+	Status []string `json:"status"`
+	Filter string   `json:"filter,omitempty"`
+}
+
+// SubscriptionRequest is a body of a request to subscribe to events.
+// A subscription with TTL is removed the given seconds later.
+type SubscriptionRequest struct {
+	URL   string `json:"url"`
+	Event Event  `json:"event"`
+	TTL   int64  `json:"ttl,omitempty"`
+}
+
+// SubscriptionResponse is a response of the APIs which subscribe to events
+// and rotate the secret of a subscriber.
+// Responses is set when some of the target nodes fail to register the event.
+type SubscriptionResponse struct {
+	ID        string         `json:"id"`
+	Secret    string         `json:"secret,omitempty"`
+	Filter    string         `json:"filter,omitempty"`
+	ExpiresAt int64          `json:"expiresat,omitempty"`
+	Responses []NodeResponse `json:"response,omitempty"`
+}
+
+// EventUpdate is the part of a subscription update about events.
+// The type of events can not be changed, and an empty Filter removes the filter.
+type EventUpdate struct {
+	Type   string   `json:"type,omitempty"`
+	Status []string `json:"status,omitempty"`
+	Filter *string  `json:"filter,omitempty"`
+}
+
+// SubscriptionUpdate is a body of a request to update a subscription.
+// Fields which are not given are kept.
+type SubscriptionUpdate struct {
+	URL    *string      `json:"url,omitempty"`
+	Event  *EventUpdate `json:"event,omitempty"`
+	TTL    *int64       `json:"ttl,omitempty"`
+	Active *bool        `json:"active,omitempty"`
+}
+
+// Subscriber is the information of a subscription with the nodes it receives events of.
+// Message is set when the nodes can not be found.
+type Subscriber struct {
+	ID          string              `json:"id"`
+	Type        string              `json:"type"`
+	URL         string              `json:"url"`
+	Status      []string            `json:"status"`
+	EventID     []string            `json:"eventid"`
+	Query       map[string][]string `json:"query"`
+	ExpiresAt   int64               `json:"expiresat"`
+	Failures    int                 `json:"failures"`
+	Deactivated bool                `json:"deactivated"`
+	Cursor      string              `json:"cursor"`
+	Filter      string              `json:"filter"`
+	Nodes       []string            `json:"nodes"`
+	Message     string              `json:"message,omitempty"`
+}
+
+// SubscriberList is a response of the API which returns all subscriptions.
+type SubscriberList struct {
+	Subscriptions []Subscriber `json:"subscriptions"`
+}
+
+// MissedEvents is a response of the API which returns events a subscriber missed.
+// Events are stored events as the event history returns them, and Cursor
+// is the id of the last of them.
+type MissedEvents struct {
+	Events []map[string]interface{} `json:"events"`
+	Cursor string                   `json:"cursor"`
+}
+
+// ReplayResponse is a response of the API which delivers missed events again.
+type ReplayResponse struct {
+	Replayed int    `json:"replayed"`
+	Cursor   string `json:"cursor"`
+}
@@ -0,0 +1,170 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package mqtt
+
+import (
+	"bufio"
+	"net"
+	"sync"
+)
+
+const BROKER_BUFFER_SIZE = 64 // messages kept by a broker until they are read.
+
+// Message is a message published to a broker.
+type Message struct {
+	Topic   string
+	Payload []byte
+	QoS     byte
+}
+
+// Broker is a broker embedded in a process, which accepts connections from
+// clients and keeps messages they publish to be read from Messages.
+// Subscriptions of clients are not supported.
+type Broker struct {
+	// Username and Password are required from clients if Username is not empty.
+	Username string
+	Password string
+
+	listener  net.Listener
+	messages  chan Message
+	closeOnce sync.Once
+}
+
+// NewBroker starts a broker listening on address, e.g. "127.0.0.1:0" for a free port.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func NewBroker(address string) (*Broker, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Broker{listener: listener, messages: make(chan Message, BROKER_BUFFER_SIZE)}
+	go b.accept()
+	return b, nil
+}
+
+// Address returns host:port the broker is listening on.
+func (b *Broker) Address() string {
+	return b.listener.Addr().String()
+}
+
+// Messages returns a channel of messages published to the broker.
+func (b *Broker) Messages() <-chan Message {
+	return b.messages
+}
+
+// Close stops the broker from accepting connections.
+func (b *Broker) Close() error {
+	var err error
+	b.closeOnce.Do(func() {
+		err = b.listener.Close()
+	})
+	return err
+}
+
+func (b *Broker) accept() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go b.serve(conn)
+	}
+}
+
+// serve handles packets from a client until it disconnects or sends a malformed packet.
+func (b *Broker) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	header, body, err := readPacket(reader)
+	if err != nil || header>>4 != PACKET_CONNECT {
+		return
+	}
+	code := b.authorize(body)
+	if writePacket(conn, PACKET_CONNACK<<4, []byte{0, code}) != nil || code != CONNACK_ACCEPTED {
+		return
+	}
+
+	for {
+		header, body, err := readPacket(reader)
+		if err != nil {
+			return
+		}
+
+		switch header >> 4 {
+		case PACKET_PUBLISH:
+			qos := header >> 1 & 0x3
+			topic, rest, err := readString(body)
+			if err != nil || qos > MAX_QOS || (qos > 0 && len(rest) < 2) {
+				return
+			}
+			var packetId []byte
+			if qos > 0 {
+				packetId, rest = rest[:2], rest[2:]
+			}
+			b.messages <- Message{Topic: topic, Payload: rest, QoS: qos}
+			if qos > 0 && writePacket(conn, PACKET_PUBACK<<4, packetId) != nil {
+				return
+			}
+		case PACKET_PINGREQ:
+			if writePacket(conn, PACKET_PINGRESP<<4, nil) != nil {
+				return
+			}
+		default:
+			// DISCONNECT, or a packet of subscriptions which are not supported.
+			return
+		}
+	}
+}
+
+// authorize returns the return code of CONNACK for the body of a CONNECT packet.
+func (b *Broker) authorize(body []byte) byte {
+	name, rest, err := readString(body)
+	if err != nil || name != PROTOCOL_NAME || len(rest) < 4 {
+		return CONNACK_NOT_AUTHORIZED
+	}
+	flags := rest[1]
+	rest = rest[4:]
+
+	// The payload has the client id, and the username and the password if flagged.
+	values := make([]string, 0, 3)
+	for len(rest) != 0 {
+		var value string
+		if value, rest, err = readString(rest); err != nil {
+			return CONNACK_NOT_AUTHORIZED
+		}
+		values = append(values, value)
+	}
+	if len(b.Username) == 0 {
+		return CONNACK_ACCEPTED
+	}
+
+	var username, password string
+	if flags&FLAG_USERNAME != 0 && len(values) > 1 {
+		username = values[1]
+	}
+	if flags&FLAG_PASSWORD != 0 && len(values) > 2 {
+		password = values[2]
+	}
+	if username != b.Username || password != b.Password {
+		return CONNACK_BAD_CREDENTIALS
+	}
+	return CONNACK_ACCEPTED
+}
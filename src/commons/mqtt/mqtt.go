@@ -0,0 +1,286 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package commons/mqtt implements MQTT 3.1.1 as far as needed to publish messages
+// to a broker with QoS 0 or 1, and a broker embedded for tests and local development
+// which keeps messages published to it.
+package mqtt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	PACKET_CONNECT    = 0x1
+	PACKET_CONNACK    = 0x2
+	PACKET_PUBLISH    = 0x3
+	PACKET_PUBACK     = 0x4
+	PACKET_PINGREQ    = 0xC
+	PACKET_PINGRESP   = 0xD
+	PACKET_DISCONNECT = 0xE
+
+	PROTOCOL_NAME  = "MQTT"
+	PROTOCOL_LEVEL = 4 // MQTT 3.1.1.
+
+	FLAG_CLEAN_SESSION = 0x02
+	FLAG_PASSWORD      = 0x40
+	FLAG_USERNAME      = 0x80
+
+	CONNACK_ACCEPTED        = 0
+	CONNACK_BAD_CREDENTIALS = 4
+	CONNACK_NOT_AUTHORIZED  = 5
+	MAX_QOS                 = 1                // the highest QoS messages are published with.
+	MAX_PACKET_SIZE         = 268435455        // the largest remaining length of a packet.
+	DEFAULT_KEEP_ALIVE      = 60 * time.Second // interval the broker expects packets from a client in.
+	DEFAULT_TIMEOUT         = 10 * time.Second // time to wait for a broker to acknowledge a packet.
+	DEFAULT_PORT            = "1883"
+)
+
+// ErrClosed is returned when publishing to a closed connection.
+var ErrClosed = errors.New("mqtt: connection closed")
+
+// Options are the options to connect to a broker with.
+type Options struct {
+	Address   string // host:port of the broker.
+	ClientID  string
+	Username  string
+	Password  string
+	KeepAlive time.Duration
+	Timeout   time.Duration
+}
+
+// Client is a connection to a broker to publish messages with.
+type Client struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	timeout time.Duration
+
+	mutex    sync.Mutex
+	packetId uint16
+	closed   bool
+}
+
+// Dial connects to a broker with a clean session.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func Dial(options Options) (*Client, error) {
+	if options.Timeout == 0 {
+		options.Timeout = DEFAULT_TIMEOUT
+	}
+	if options.KeepAlive == 0 {
+		options.KeepAlive = DEFAULT_KEEP_ALIVE
+	}
+
+	conn, err := net.DialTimeout("tcp", options.Address, options.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{conn: conn, reader: bufio.NewReader(conn), timeout: options.Timeout}
+
+	flags := byte(FLAG_CLEAN_SESSION)
+	payload := appendString(nil, options.ClientID)
+	if len(options.Username) != 0 {
+		flags |= FLAG_USERNAME
+		payload = appendString(payload, options.Username)
+		if len(options.Password) != 0 {
+			flags |= FLAG_PASSWORD
+			payload = appendString(payload, options.Password)
+		}
+	}
+
+	body := appendString(nil, PROTOCOL_NAME)
+	body = append(body, PROTOCOL_LEVEL, flags)
+	body = appendUint16(body, uint16(options.KeepAlive/time.Second))
+	body = append(body, payload...)
+
+	if err = c.write(PACKET_CONNECT<<4, body); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	packetType, body, err := c.read()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if packetType>>4 != PACKET_CONNACK || len(body) != 2 {
+		conn.Close()
+		return nil, errors.New("mqtt: unexpected packet instead of CONNACK")
+	}
+	if body[1] != CONNACK_ACCEPTED {
+		conn.Close()
+		return nil, errors.New("mqtt: connection refused with code " + strconv.Itoa(int(body[1])))
+	}
+	return c, nil
+}
+
+// Publish publishes payload to topic with qos, which is 0 or 1. A message with
+// QoS 1 is published when the broker acknowledges it.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (c *Client) Publish(topic string, payload []byte, qos byte) error {
+	if qos > MAX_QOS {
+		return errors.New("mqtt: qos " + strconv.Itoa(int(qos)) + " is not supported")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+
+	body := appendString(nil, topic)
+	var packetId uint16
+	if qos > 0 {
+		c.packetId++
+		if c.packetId == 0 {
+			c.packetId = 1
+		}
+		packetId = c.packetId
+		body = appendUint16(body, packetId)
+	}
+	body = append(body, payload...)
+
+	if err := c.write(PACKET_PUBLISH<<4|qos<<1, body); err != nil {
+		c.shutdown()
+		return err
+	}
+	if qos == 0 {
+		return nil
+	}
+
+	for {
+		packetType, body, err := c.read()
+		if err != nil {
+			c.shutdown()
+			return err
+		}
+		if packetType>>4 == PACKET_PUBACK && len(body) == 2 && binary.BigEndian.Uint16(body) == packetId {
+			return nil
+		}
+	}
+}
+
+// Close disconnects from the broker.
+func (c *Client) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.write(PACKET_DISCONNECT<<4, nil)
+	c.shutdown()
+	return nil
+}
+
+func (c *Client) shutdown() {
+	c.closed = true
+	c.conn.Close()
+}
+
+func (c *Client) write(header byte, body []byte) error {
+	c.conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	return writePacket(c.conn, header, body)
+}
+
+func (c *Client) read() (byte, []byte, error) {
+	c.conn.SetReadDeadline(time.Now().Add(c.timeout))
+	return readPacket(c.reader)
+}
+
+// writePacket writes a packet of a fixed header byte and body to w.
+func writePacket(w io.Writer, header byte, body []byte) error {
+	if len(body) > MAX_PACKET_SIZE {
+		return errors.New("mqtt: packet too large")
+	}
+	packet := append([]byte{header}, encodeLength(len(body))...)
+	_, err := w.Write(append(packet, body...))
+	return err
+}
+
+// readPacket reads a packet and returns the fixed header byte and the body.
+func readPacket(r *bufio.Reader) (byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, nil, errors.New("mqtt: malformed remaining length")
+		}
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(b&0x7F) * multiplier
+		if b&0x80 == 0 {
+			break
+		}
+		multiplier *= 128
+	}
+
+	body := make([]byte, length)
+	if _, err = io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return header, body, nil
+}
+
+// encodeLength encodes the remaining length of a packet in 7 bits per byte.
+func encodeLength(length int) []byte {
+	encoded := make([]byte, 0, 4)
+	for {
+		b := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			b |= 0x80
+		}
+		encoded = append(encoded, b)
+		if length == 0 {
+			return encoded
+		}
+	}
+}
+
+func appendUint16(b []byte, value uint16) []byte {
+	return append(b, byte(value>>8), byte(value))
+}
+
+func appendString(b []byte, value string) []byte {
+	return append(appendUint16(b, uint16(len(value))), value...)
+}
+
+// readString reads a length-prefixed string at the start of b and returns the rest of b.
+func readString(b []byte) (string, []byte, error) {
+	if len(b) < 2 {
+		return "", nil, errors.New("mqtt: malformed string")
+	}
+	length := int(binary.BigEndian.Uint16(b))
+	if len(b) < 2+length {
+		return "", nil, errors.New("mqtt: malformed string")
+	}
+	return string(b[2 : 2+length]), b[2+length:], nil
+}
@@ -0,0 +1,127 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package mqtt
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func receive(t *testing.T, broker *Broker) Message {
+	select {
+	case message := <-broker.Messages():
+		return message
+	case <-time.After(time.Second):
+		t.Fatal("Expected message published to broker")
+	}
+	return Message{}
+}
+
+func TestCalledPublish_ExpectMessagesReceivedByBroker(t *testing.T) {
+	broker, err := NewBroker("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	defer broker.Close()
+
+	client, err := Dial(Options{Address: broker.Address(), ClientID: "anchor"})
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	defer client.Close()
+
+	payload := bytes.Repeat([]byte("x"), 300) // remaining length of two bytes.
+	for _, qos := range []byte{0, 1, 1} {
+		if err = client.Publish("anchor/node", payload, qos); err != nil {
+			t.Fatalf("Unexpected err: %s", err.Error())
+		}
+		message := receive(t, broker)
+		if message.Topic != "anchor/node" || message.QoS != qos || !bytes.Equal(message.Payload, payload) {
+			t.Errorf("Unexpected message: %s, %d, %d bytes", message.Topic, message.QoS, len(message.Payload))
+		}
+	}
+}
+
+func TestCalledPublishWithQoS2_ExpectErrorReturn(t *testing.T) {
+	broker, _ := NewBroker("127.0.0.1:0")
+	defer broker.Close()
+
+	client, err := Dial(Options{Address: broker.Address()})
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	defer client.Close()
+
+	if err = client.Publish("anchor/node", nil, 2); err == nil {
+		t.Error("Expected err, actual err: nil")
+	}
+}
+
+func TestCalledPublishAfterClose_ExpectErrClosed(t *testing.T) {
+	broker, _ := NewBroker("127.0.0.1:0")
+	defer broker.Close()
+
+	client, err := Dial(Options{Address: broker.Address()})
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	client.Close()
+
+	if err = client.Publish("anchor/node", nil, 0); err != ErrClosed {
+		t.Errorf("Expected err: %v, actual err: %v", ErrClosed, err)
+	}
+}
+
+func TestCalledDialWithCredentials_ExpectOnlyValidOnesAccepted(t *testing.T) {
+	broker, _ := NewBroker("127.0.0.1:0")
+	broker.Username, broker.Password = "anchor", "secret"
+	defer broker.Close()
+
+	_, err := Dial(Options{Address: broker.Address(), Username: "anchor", Password: "wrong"})
+	if err == nil || !strings.Contains(err.Error(), "code 4") {
+		t.Errorf("Expected connection refused with code 4, actual err: %v", err)
+	}
+
+	client, err := Dial(Options{Address: broker.Address(), Username: "anchor", Password: "secret"})
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	client.Close()
+}
+
+func TestCalledReadPacket_ExpectRemainingLengthDecoded(t *testing.T) {
+	for _, length := range []int{0, 127, 128, 16383, 16384} {
+		var buf bytes.Buffer
+		if err := writePacket(&buf, PACKET_PUBLISH<<4, make([]byte, length)); err != nil {
+			t.Fatalf("Unexpected err: %s", err.Error())
+		}
+
+		header, body, err := readPacket(bufio.NewReader(&buf))
+		if err != nil || header != PACKET_PUBLISH<<4 || len(body) != length {
+			t.Errorf("Unexpected packet of length %d: %x, %d, %v", length, header, len(body), err)
+		}
+	}
+
+	_, _, err := readPacket(bufio.NewReader(bytes.NewReader([]byte{0x30, 0xFF, 0xFF, 0xFF, 0xFF})))
+	if err == nil {
+		t.Error("Expected err of malformed remaining length, actual err: nil")
+	}
+}
@@ -22,4 +22,5 @@ const (
 	OK           = 200 /* Returned for a successful response. */
 	MULTI_STATUS = 207 /* Partial success for multiple requests. Some requests succeeded, but at least one failed */
 	ERROR        = 500 /* Returned for an error response. */
+	UNAVAILABLE  = 503 /* Returned when pharos-anchor is not ready to serve requests. */
 )
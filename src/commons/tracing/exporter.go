@@ -0,0 +1,207 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package tracing
+
+import (
+	"bytes"
+	"commons/errors"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	OTLP_ENDPOINT_ENV = "ANCHOR_TRACING_OTLP_ENDPOINT" // environment variable of an OTLP/HTTP traces url.
+	FILE_ENV          = "ANCHOR_TRACING_FILE"          // environment variable of the path of a file exporter.
+
+	SERVICE_NAME = "pharos-anchor"
+	SCOPE_NAME   = "commons/tracing"
+	OTLP_TIMEOUT = 10 // seconds
+)
+
+// ConfigureFromEnv sets an OTLP exporter if the endpoint is given,
+// e.g. http://collector:4318/v1/traces, and a file exporter if the path is given.
+// Tracing stays disabled if neither is given.
+func ConfigureFromEnv() error {
+	exporters := make([]Exporter, 0)
+	if endpoint := os.Getenv(OTLP_ENDPOINT_ENV); len(endpoint) != 0 {
+		exporters = append(exporters, NewOTLPExporter(endpoint))
+	}
+	if path := os.Getenv(FILE_ENV); len(path) != 0 {
+		exporter, err := NewFileExporter(path)
+		if err != nil {
+			return err
+		}
+		exporters = append(exporters, exporter)
+	}
+	SetExporters(exporters...)
+	return nil
+}
+
+// FileExporter writes a span per line in JSON.
+type FileExporter struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+// NewFileExporter opens the file of path to append spans to.
+func NewFileExporter(path string) (*FileExporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, errors.IOError{err.Error()}
+	}
+	return &FileExporter{file: file}, nil
+}
+
+func (e *FileExporter) Export(spans []*Span) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, span := range spans {
+		span.mutex.Lock()
+		err := encoder.Encode(span)
+		span.mutex.Unlock()
+		if err != nil {
+			return errors.IOError{"json marshalling failed"}
+		}
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if _, err := e.file.Write(buf.Bytes()); err != nil {
+		return errors.IOError{err.Error()}
+	}
+	return nil
+}
+
+// OTLPExporter posts spans to an OTLP/HTTP endpoint in the JSON encoding.
+type OTLPExporter struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewOTLPExporter(endpoint string) *OTLPExporter {
+	return &OTLPExporter{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: OTLP_TIMEOUT * time.Second},
+	}
+}
+
+func (e *OTLPExporter) Export(spans []*Span) error {
+	data, err := json.Marshal(toOTLP(spans))
+	if err != nil {
+		return errors.IOError{"json marshalling failed"}
+	}
+
+	resp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return errors.IOError{err.Error()}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.IOError{"OTLP endpoint responded with " + strconv.Itoa(resp.StatusCode)}
+	}
+	return nil
+}
+
+// Kinds and status codes of spans in OTLP.
+var otlpKinds = map[string]int{KIND_INTERNAL: 1, KIND_SERVER: 2, KIND_CLIENT: 3}
+var otlpStatus = map[string]int{STATUS_OK: 0, STATUS_ERROR: 2}
+
+type otlpValue map[string]interface{}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpSpan struct {
+	TraceID      string          `json:"traceId"`
+	SpanID       string          `json:"spanId"`
+	ParentSpanID string          `json:"parentSpanId,omitempty"`
+	Name         string          `json:"name"`
+	Kind         int             `json:"kind"`
+	Start        string          `json:"startTimeUnixNano"`
+	End          string          `json:"endTimeUnixNano"`
+	Attributes   []otlpAttribute `json:"attributes,omitempty"`
+	Links        []Link          `json:"links,omitempty"`
+	Status       otlpValue       `json:"status"`
+}
+
+// toOTLP converts spans into an ExportTraceServiceRequest.
+func toOTLP(spans []*Span) map[string]interface{} {
+	converted := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		span.mutex.Lock()
+		s := otlpSpan{
+			TraceID:      span.TraceID,
+			SpanID:       span.SpanID,
+			ParentSpanID: span.ParentID,
+			Name:         span.Name,
+			Kind:         otlpKinds[span.Kind],
+			Start:        strconv.FormatInt(span.Start.UnixNano(), 10),
+			End:          strconv.FormatInt(span.End.UnixNano(), 10),
+			Links:        span.Links,
+			Status:       otlpValue{"code": otlpStatus[span.Status]},
+		}
+		if len(span.Message) != 0 {
+			s.Status["message"] = span.Message
+		}
+		for key, value := range span.Attributes {
+			s.Attributes = append(s.Attributes, otlpAttribute{key, toOTLPValue(value)})
+		}
+		span.mutex.Unlock()
+		converted = append(converted, s)
+	}
+
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": []otlpAttribute{{"service.name", toOTLPValue(SERVICE_NAME)}},
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{"name": SCOPE_NAME},
+						"spans": converted,
+					},
+				},
+			},
+		},
+	}
+}
+
+func toOTLPValue(value interface{}) otlpValue {
+	switch v := value.(type) {
+	case bool:
+		return otlpValue{"boolValue": v}
+	case int:
+		return otlpValue{"intValue": strconv.Itoa(v)}
+	case int64:
+		return otlpValue{"intValue": strconv.FormatInt(v, 10)}
+	case float64:
+		return otlpValue{"doubleValue": v}
+	case string:
+		return otlpValue{"stringValue": v}
+	default:
+		data, _ := json.Marshal(v)
+		return otlpValue{"stringValue": string(data)}
+	}
+}
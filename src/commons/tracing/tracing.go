@@ -0,0 +1,316 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package commons/tracing records spans of work done by pharos-anchor and
+// exports them to an OTLP endpoint or a local file.
+// A span of an incoming request is carried by the context of the request,
+// so that spans of database operations and requests to nodes made with the
+// context become its children. The trace is propagated to nodes in the
+// W3C traceparent header.
+// No span is recorded unless an exporter is set.
+package tracing
+
+import (
+	"commons/logger"
+	"commons/workers"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	TRACEPARENT_HEADER = "traceparent" // W3C header carrying a trace id and the id of the parent span.
+
+	KIND_INTERNAL = "internal"
+	KIND_SERVER   = "server"
+	KIND_CLIENT   = "client"
+
+	STATUS_OK    = "ok"
+	STATUS_ERROR = "error"
+
+	EXPORTER        = "spanexporter" // name of the worker exporting spans.
+	EXPORT_INTERVAL = 5              // seconds
+	MAX_QUEUED      = 4096           // spans kept until exported, newer spans are dropped if exceeded.
+)
+
+// Span is a unit of work in a trace.
+type Span struct {
+	TraceID    string                 `json:"traceId"`
+	SpanID     string                 `json:"spanId"`
+	ParentID   string                 `json:"parentSpanId,omitempty"`
+	Name       string                 `json:"name"`
+	Kind       string                 `json:"kind"`
+	Start      time.Time              `json:"start"`
+	End        time.Time              `json:"end"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Status     string                 `json:"status"`
+	Message    string                 `json:"message,omitempty"`
+	Links      []Link                 `json:"links,omitempty"`
+
+	mutex sync.Mutex
+	ended bool
+}
+
+// Link points to a span of another trace which caused the work of a span.
+type Link struct {
+	TraceID string `json:"traceId"`
+	SpanID  string `json:"spanId"`
+}
+
+// Exporter sends ended spans to a backend.
+type Exporter interface {
+	Export(spans []*Span) error
+}
+
+var (
+	mutex     = &sync.Mutex{}
+	exporters []Exporter
+	queue     []*Span
+	dropped   int
+)
+
+// spanKey is the key of a span in a context.
+type spanKey struct{}
+
+// SetExporters replaces exporters. Tracing is disabled if none is given.
+func SetExporters(e ...Exporter) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	exporters = e
+	if len(e) == 0 {
+		queue = nil
+	}
+}
+
+// Enabled returns whether spans are recorded.
+func Enabled() bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return len(exporters) != 0
+}
+
+// StartTrace starts a span of kind which has no parent in this process.
+// If traceparent is a valid W3C traceparent, the span joins the trace of it.
+// It returns nil if tracing is disabled.
+func StartTrace(traceparent string, name string, kind string) *Span {
+	if !Enabled() {
+		return nil
+	}
+
+	span := newSpan(name, kind)
+	if traceId, parentId, ok := ParseTraceParent(traceparent); ok {
+		span.TraceID, span.ParentID = traceId, parentId
+	} else {
+		span.TraceID = newId(16)
+	}
+	return span
+}
+
+// Start starts a child span of parent.
+// It returns nil if parent is nil, so that work done outside of a traced
+// request is not recorded.
+func Start(parent *Span, name string, kind string) *Span {
+	if parent == nil || !Enabled() {
+		return nil
+	}
+
+	span := newSpan(name, kind)
+	span.TraceID, span.ParentID = parent.TraceID, parent.SpanID
+	return span
+}
+
+// StartLinked starts a span of kind in a new trace, linked to origin.
+// It is used for work which outlives the request of origin, so that the work
+// is not recorded as a part of the request but can still be found from it.
+// The span is not linked if origin is nil, and nil is returned if tracing
+// is disabled.
+func StartLinked(origin *Span, name string, kind string) *Span {
+	span := StartTrace("", name, kind)
+	if span != nil && origin != nil {
+		span.Links = []Link{{TraceID: origin.TraceID, SpanID: origin.SpanID}}
+	}
+	return span
+}
+
+func newSpan(name string, kind string) *Span {
+	return &Span{
+		SpanID:     newId(8),
+		Name:       name,
+		Kind:       kind,
+		Start:      time.Now(),
+		Attributes: make(map[string]interface{}),
+		Status:     STATUS_OK,
+	}
+}
+
+// NewContext returns a copy of ctx which carries span, so that spans started
+// with the context become its children. ctx is returned as is if span is nil.
+func NewContext(ctx context.Context, span *Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// FromContext returns the span carried by ctx, or nil.
+func FromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// SetAttribute adds a key and value describing the work of the span.
+// Values are expected to be strings, numbers or booleans.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.Attributes[key] = value
+}
+
+// SetError marks the span as failed with the message of err.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.Status, s.Message = STATUS_ERROR, err.Error()
+}
+
+// TraceParent returns the W3C traceparent header value to propagate the span.
+func (s *Span) TraceParent() string {
+	if s == nil {
+		return ""
+	}
+	return "00-" + s.TraceID + "-" + s.SpanID + "-01"
+}
+
+// Finish ends the span and queues it to be exported.
+// A span is queued only once even if Finish is called again.
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	if s.ended {
+		s.mutex.Unlock()
+		return
+	}
+	s.ended, s.End = true, time.Now()
+	s.mutex.Unlock()
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(exporters) == 0 {
+		return
+	}
+	if len(queue) >= MAX_QUEUED {
+		dropped++
+		return
+	}
+	queue = append(queue, s)
+}
+
+// Flush exports queued spans.
+func Flush() {
+	mutex.Lock()
+	spans, targets, lost := queue, exporters, dropped
+	queue, dropped = nil, 0
+	mutex.Unlock()
+
+	if lost != 0 {
+		logger.Log(logger.ERROR, "spans dropped", "count", lost)
+	}
+	if len(spans) == 0 {
+		return
+	}
+	for _, exporter := range targets {
+		if err := exporter.Export(spans); err != nil {
+			logger.Log(logger.ERROR, "exporting spans failed", "error", err)
+		}
+	}
+}
+
+// StartExporter exports queued spans every interval seconds.
+// Sending a value to the returned channel stops exporting.
+func StartExporter(interval int) chan bool {
+	quit := make(chan bool)
+	worker := workers.Register(EXPORTER, time.Duration(interval)*time.Second)
+	go func() {
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+		defer worker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				worker.Start()
+				Flush()
+				worker.Beat()
+			case <-quit:
+				Flush()
+				return
+			}
+		}
+	}()
+	return quit
+}
+
+// ParseTraceParent returns the trace id and parent span id in a W3C traceparent
+// header value, e.g. 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01.
+func ParseTraceParent(value string) (traceId string, parentId string, ok bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" ||
+		len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return "", "", false
+	}
+	if parts[0] == "00" && len(parts) != 4 {
+		return "", "", false
+	}
+	for _, part := range parts[:4] {
+		if _, err := hex.DecodeString(part); err != nil || strings.ToLower(part) != part {
+			return "", "", false
+		}
+	}
+	if isZero(parts[1]) || isZero(parts[2]) {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+func isZero(id string) bool {
+	return strings.Trim(id, "0") == ""
+}
+
+// newId returns a random id of size bytes in hex.
+func newId(size int) string {
+	b := make([]byte, size)
+	for {
+		rand.Read(b)
+		if id := hex.EncodeToString(b); !isZero(id) {
+			return id
+		}
+	}
+}
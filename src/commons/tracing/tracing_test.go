@@ -0,0 +1,235 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type recordingExporter struct {
+	spans []*Span
+}
+
+func (e *recordingExporter) Export(spans []*Span) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func setUpExporter() (*recordingExporter, func()) {
+	exporter := &recordingExporter{}
+	SetExporters(exporter)
+	return exporter, func() {
+		SetExporters()
+	}
+}
+
+func TestParseTraceParent(t *testing.T) {
+	testList := map[string]bool{
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01":       true,
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra": true,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra": false,
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01":       false,
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01":       false,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01":       false,
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01":       false,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902-01":         false,
+		"": false,
+	}
+
+	for value, expected := range testList {
+		traceId, parentId, ok := ParseTraceParent(value)
+		if ok != expected {
+			t.Errorf("%s: expected %t, actual %t", value, expected, ok)
+		}
+		if ok && (traceId != "4bf92f3577b34da6a3ce929d0e0e4736" || parentId != "00f067aa0ba902b7") {
+			t.Errorf("%s: unexpected ids %s, %s", value, traceId, parentId)
+		}
+	}
+}
+
+func TestStartWithoutExporter_ExpectNoSpan(t *testing.T) {
+	span := StartTrace("", "request", KIND_SERVER)
+	if span != nil {
+		t.Error("Expected no span while tracing is disabled")
+	}
+
+	// methods of a nil span do nothing.
+	span.SetAttribute("key", "value")
+	span.Finish()
+	if FromContext(NewContext(context.Background(), span)) != nil {
+		t.Error("Expected no span in context")
+	}
+}
+
+func TestStartTraceWithTraceParent_ExpectJoinedTrace(t *testing.T) {
+	_, tearDown := setUpExporter()
+	defer tearDown()
+
+	span := StartTrace("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "request", KIND_SERVER)
+	if span.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || span.ParentID != "00f067aa0ba902b7" {
+		t.Errorf("Unexpected ids: %s, %s", span.TraceID, span.ParentID)
+	}
+
+	child := Start(span, "db", KIND_CLIENT)
+	if child.TraceID != span.TraceID || child.ParentID != span.SpanID {
+		t.Errorf("Expected child of %s, actual parent %s", span.SpanID, child.ParentID)
+	}
+	expected := "00-" + span.TraceID + "-" + child.SpanID + "-01"
+	if child.TraceParent() != expected {
+		t.Errorf("Expected traceparent: %s, actual: %s", expected, child.TraceParent())
+	}
+
+	if Start(nil, "db", KIND_CLIENT) != nil {
+		t.Error("Expected no span without parent")
+	}
+}
+
+func TestStartLinked_ExpectNewTraceLinkedToOrigin(t *testing.T) {
+	_, tearDown := setUpExporter()
+	defer tearDown()
+
+	origin := StartTrace("", "request", KIND_SERVER)
+	span := StartLinked(origin, "timer", KIND_INTERNAL)
+	if span.TraceID == origin.TraceID || len(span.ParentID) != 0 {
+		t.Errorf("Expected a root span of a new trace, actual trace %s, parent %s", span.TraceID, span.ParentID)
+	}
+	if len(span.Links) != 1 || span.Links[0].TraceID != origin.TraceID || span.Links[0].SpanID != origin.SpanID {
+		t.Errorf("Expected link to %s, actual %v", origin.SpanID, span.Links)
+	}
+
+	if span = StartLinked(nil, "timer", KIND_INTERNAL); span == nil || len(span.Links) != 0 {
+		t.Error("Expected a span without links")
+	}
+}
+
+func TestNewContext_ExpectSpanCarriedByContext(t *testing.T) {
+	_, tearDown := setUpExporter()
+	defer tearDown()
+
+	if FromContext(context.Background()) != nil {
+		t.Error("Expected no span in background context")
+	}
+
+	span := StartTrace("", "request", KIND_SERVER)
+	ctx := NewContext(context.Background(), span)
+	if FromContext(ctx) != span {
+		t.Error("Expected the span of the context")
+	}
+
+	done := make(chan *Span)
+	go func(ctx context.Context) {
+		done <- FromContext(ctx)
+	}(ctx)
+	if <-done != span {
+		t.Error("Expected the span in another goroutine given the context")
+	}
+
+	child := Start(span, "child", KIND_INTERNAL)
+	if FromContext(NewContext(ctx, child)) != child || FromContext(ctx) != span {
+		t.Error("Expected the child span only in the derived context")
+	}
+}
+
+func TestFinishAndFlush_ExpectSpansExportedOnce(t *testing.T) {
+	exporter, tearDown := setUpExporter()
+	defer tearDown()
+
+	span := StartTrace("", "request", KIND_SERVER)
+	span.SetAttribute("http.method", "GET")
+	span.SetError(os.ErrNotExist)
+	span.Finish()
+	span.Finish()
+	Flush()
+
+	if len(exporter.spans) != 1 {
+		t.Fatalf("Expected 1 span, actual %d", len(exporter.spans))
+	}
+	exported := exporter.spans[0]
+	if exported.Status != STATUS_ERROR || exported.Attributes["http.method"] != "GET" || exported.End.IsZero() {
+		t.Errorf("Unexpected span: %v", exported)
+	}
+}
+
+func TestFileExporter_ExpectSpanPerLine(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "tracing")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "spans.json")
+	exporter, err := NewFileExporter(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	SetExporters(exporter)
+	defer SetExporters()
+
+	StartTrace("", "first", KIND_SERVER).Finish()
+	StartTrace("", "second", KIND_SERVER).Finish()
+	Flush()
+
+	data, _ := ioutil.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, actual %d", len(lines))
+	}
+	span := Span{}
+	if err := json.Unmarshal([]byte(lines[1]), &span); err != nil || span.Name != "second" {
+		t.Errorf("Unexpected line: %s", lines[1])
+	}
+}
+
+func TestOTLPExporter_ExpectSpansPosted(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+		json.Unmarshal(data, &body)
+	}))
+	defer server.Close()
+
+	span := &Span{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7",
+		Name: "request", Kind: KIND_SERVER, Status: STATUS_ERROR, Message: "failed",
+		Attributes: map[string]interface{}{"http.status_code": 500}}
+	if err := NewOTLPExporter(server.URL).Export([]*Span{span}); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	data, _ := json.Marshal(body)
+	for _, expected := range []string{`"traceId":"4bf92f3577b34da6a3ce929d0e0e4736"`, `"kind":2`,
+		`"code":2`, `"intValue":"500"`, `"stringValue":"pharos-anchor"`} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("Expected %s in %s", expected, string(data))
+		}
+	}
+}
+
+func TestOTLPExporterWithErrorResponse_ExpectErrorReturn(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	if err := NewOTLPExporter(server.URL).Export([]*Span{{}}); err == nil {
+		t.Error("Expected error for 503 response")
+	}
+}
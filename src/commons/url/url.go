@@ -101,3 +101,51 @@ func Reboot() string { return "/reboot" }
 
 // Returning Restore url as string.
 func Restore() string { return "/restore" }
+
+// Returning Drift url as string.
+func Drift() string { return "/drift" }
+
+// Returning Metrics url as string.
+func Metrics() string { return "/metrics" }
+
+// Returning History url as string.
+func History() string { return "/history" }
+
+// Returning Top url as string.
+func Top() string { return "/top" }
+
+// Returning Alerts url as string.
+func Alerts() string { return "/alerts" }
+
+// Returning Rules url as string.
+func Rules() string { return "/rules" }
+
+// Returning Admin url as string.
+func Admin() string { return "/admin" }
+
+// Returning Logging url as string.
+func Logging() string { return "/logging" }
+
+// Returning Health url as string.
+func Health() string { return "/health" }
+
+// Returning Live url as string.
+func Live() string { return "/live" }
+
+// Returning Ready url as string.
+func Ready() string { return "/ready" }
+
+// Returning Deliveries url as string.
+func Deliveries() string { return "/deliveries" }
+
+// Returning Failed url as string.
+func Failed() string { return "/failed" }
+
+// Returning Replay url as string.
+func Replay() string { return "/replay" }
+
+// Returning Secret url as string.
+func Secret() string { return "/secret" }
+
+// Returning Stream url as string.
+func Stream() string { return "/stream" }
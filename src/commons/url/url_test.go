@@ -110,3 +110,7 @@ func ExampleConfiguration() {
 	fmt.Println(Configuration())
 	// Output: /configuration
 }
+func ExampleDrift() {
+	fmt.Println(Drift())
+	// Output: /drift
+}
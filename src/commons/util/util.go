@@ -64,6 +64,16 @@ func ConvertMapToJson(data map[string]interface{}) (string, error) {
 	return string(result), nil
 }
 
+// ConvertRespToError converts an error response of a node into NodeError,
+// so that the anchor responds with the status code and the message of the node.
+func ConvertRespToError(code int, respStr string) error {
+	message := ""
+	if resp, err := ConvertJsonToMap(respStr); err == nil {
+		message, _ = resp["message"].(string)
+	}
+	return errors.NodeError{code, message}
+}
+
 // isSuccessCode returns true in case of success and false otherwise.
 func IsSuccessCode(code int) bool {
 	if code >= 200 && code <= 299 {
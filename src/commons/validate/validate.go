@@ -0,0 +1,127 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package commons/validate provides checked conversions of values decoded
+// from a JSON request body. Each function returns InvalidField which points out
+// the offending field instead of panicking on an unexpected type.
+package validate
+
+import (
+	"commons/errors"
+	"strconv"
+)
+
+// String converts value into a string.
+func String(field string, value interface{}) (string, error) {
+	str, ok := value.(string)
+	if !ok {
+		return "", errors.InvalidField{field, "must be string"}
+	}
+	return str, nil
+}
+
+// NonEmptyString converts value into a string which is not empty.
+func NonEmptyString(field string, value interface{}) (string, error) {
+	str, err := String(field, value)
+	if err != nil {
+		return "", err
+	}
+	if len(str) == 0 {
+		return "", errors.InvalidField{field, "must not be empty"}
+	}
+	return str, nil
+}
+
+// Bool converts value into a boolean.
+func Bool(field string, value interface{}) (bool, error) {
+	b, ok := value.(bool)
+	if !ok {
+		return false, errors.InvalidField{field, "must be boolean"}
+	}
+	return b, nil
+}
+
+// NonNegativeInt converts value into an integer which is zero or more.
+func NonNegativeInt(field string, value interface{}) (int64, error) {
+	number, ok := value.(float64)
+	if !ok || number != float64(int64(number)) || number < 0 {
+		return 0, errors.InvalidField{field, "must be non-negative integer"}
+	}
+	return int64(number), nil
+}
+
+// Object converts value into a JSON object.
+func Object(field string, value interface{}) (map[string]interface{}, error) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.InvalidField{field, "must be object"}
+	}
+	return object, nil
+}
+
+// List converts value into a JSON array.
+func List(field string, value interface{}) ([]interface{}, error) {
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.InvalidField{field, "must be array"}
+	}
+	return list, nil
+}
+
+// StringList converts value into a list of strings.
+func StringList(field string, value interface{}) ([]string, error) {
+	list, err := List(field, value)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, len(list))
+	for i, item := range list {
+		result[i], err = String(Index(field, i), item)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// ObjectList converts value into a list of JSON objects.
+func ObjectList(field string, value interface{}) ([]map[string]interface{}, error) {
+	list, err := List(field, value)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]interface{}, len(list))
+	for i, item := range list {
+		result[i], err = Object(Index(field, i), item)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Index returns the path of an element of the array field.
+func Index(field string, i int) string {
+	return field + "[" + strconv.Itoa(i) + "]"
+}
+
+// Member returns the path of a member of the object field.
+func Member(field string, name string) string {
+	return field + "." + name
+}
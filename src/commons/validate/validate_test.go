@@ -0,0 +1,113 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package validate
+
+import (
+	"commons/errors"
+	"reflect"
+	"testing"
+)
+
+func TestCalledWithValidValues_ExpectSuccess(t *testing.T) {
+	if str, err := String("name", "value"); err != nil || str != "value" {
+		t.Errorf("Unexpected result: %v, %v", str, err)
+	}
+
+	object := map[string]interface{}{"key": "value"}
+	if res, err := Object("event", object); err != nil || !reflect.DeepEqual(object, res) {
+		t.Errorf("Unexpected result: %v, %v", res, err)
+	}
+
+	if b, err := Bool("active", true); err != nil || !b {
+		t.Errorf("Unexpected result: %v, %v", b, err)
+	}
+
+	if number, err := NonNegativeInt("ttl", float64(60)); err != nil || number != 60 {
+		t.Errorf("Unexpected result: %v, %v", number, err)
+	}
+
+	expected := []string{"a", "b"}
+	if res, err := StringList("nodes", []interface{}{"a", "b"}); err != nil || !reflect.DeepEqual(expected, res) {
+		t.Errorf("Unexpected result: %v, %v", res, err)
+	}
+}
+
+func TestCalledWithInvalidValues_ExpectInvalidFieldWithPath(t *testing.T) {
+	testList := []struct {
+		name  string
+		call  func() error
+		field string
+	}{
+		{name: "string", field: "name", call: func() error {
+			_, err := String("name", 1)
+			return err
+		}},
+		{name: "emptyString", field: "name", call: func() error {
+			_, err := NonEmptyString("name", "")
+			return err
+		}},
+		{name: "bool", field: "active", call: func() error {
+			_, err := Bool("active", "true")
+			return err
+		}},
+		{name: "negativeInt", field: "ttl", call: func() error {
+			_, err := NonNegativeInt("ttl", float64(-1))
+			return err
+		}},
+		{name: "fractionalInt", field: "ttl", call: func() error {
+			_, err := NonNegativeInt("ttl", 1.5)
+			return err
+		}},
+		{name: "object", field: "event", call: func() error {
+			_, err := Object("event", "event")
+			return err
+		}},
+		{name: "list", field: "nodes", call: func() error {
+			_, err := StringList("nodes", "node")
+			return err
+		}},
+		{name: "listItem", field: "nodes[1]", call: func() error {
+			_, err := StringList("nodes", []interface{}{"a", 1})
+			return err
+		}},
+		{name: "objectItem", field: "events[0]", call: func() error {
+			_, err := ObjectList("events", []interface{}{"event"})
+			return err
+		}},
+	}
+
+	for _, test := range testList {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.call()
+			switch err.(type) {
+			default:
+				t.Errorf("Expected err: %s, actual err: %v", "InvalidField", err)
+			case errors.InvalidField:
+				if field := err.(errors.InvalidField).Field; field != test.field {
+					t.Errorf("Expected field: %s, actual field: %s", test.field, field)
+				}
+			}
+		})
+	}
+}
+
+func TestMember_ExpectJoinedPath(t *testing.T) {
+	if path := Member(Index("events", 0), "target"); path != "events[0].target" {
+		t.Errorf("Expected path: %s, actual path: %s", "events[0].target", path)
+	}
+}
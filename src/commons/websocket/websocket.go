@@ -0,0 +1,270 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package commons/websocket implements the server side of the WebSocket protocol
+// (RFC 6455) as far as needed to push text messages to clients. Messages from
+// clients other than control frames are discarded.
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	OPCODE_CONTINUATION = 0x0
+	OPCODE_TEXT         = 0x1
+	OPCODE_BINARY       = 0x2
+	OPCODE_CLOSE        = 0x8
+	OPCODE_PING         = 0x9
+	OPCODE_PONG         = 0xA
+
+	CLOSE_NORMAL = 1000
+
+	VERSION          = "13"
+	ACCEPT_GUID      = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	MAX_CONTROL_SIZE = 125     // payload size limit of control frames.
+	MAX_MESSAGE_SIZE = 1 << 16 // payload size limit of frames from clients.
+	WRITE_TIMEOUT    = 10 * time.Second
+)
+
+// ErrClosed is returned when writing to a closed connection.
+var ErrClosed = errors.New("websocket: connection closed")
+
+// Conn is a WebSocket connection upgraded from an HTTP request.
+type Conn struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	writeLock sync.Mutex
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// IsUpgrade returns whether req asks to switch to the WebSocket protocol.
+func IsUpgrade(req *http.Request) bool {
+	return strings.EqualFold(req.Header.Get("Upgrade"), "websocket") &&
+		headerContains(req.Header, "Connection", "upgrade")
+}
+
+// Upgrade completes the opening handshake of req and takes over its connection.
+// The returned Conn reads frames from the client in the background until it is closed.
+// If the handshake fails, an error response is written and an error is returned.
+func Upgrade(w http.ResponseWriter, req *http.Request) (*Conn, error) {
+	if req.Method != http.MethodGet || !IsUpgrade(req) {
+		http.Error(w, "websocket upgrade is required", http.StatusBadRequest)
+		return nil, errors.New("websocket: not an upgrade request")
+	}
+	if req.Header.Get("Sec-Websocket-Version") != VERSION {
+		w.Header().Set("Sec-WebSocket-Version", VERSION)
+		http.Error(w, "unsupported websocket version", http.StatusUpgradeRequired)
+		return nil, errors.New("websocket: unsupported version")
+	}
+	key := req.Header.Get("Sec-Websocket-Key")
+	if len(key) == 0 {
+		http.Error(w, "Sec-WebSocket-Key is required", http.StatusBadRequest)
+		return nil, errors.New("websocket: missing key")
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket is not supported", http.StatusInternalServerError)
+		return nil, errors.New("websocket: response does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + AcceptKey(key) + "\r\n\r\n"
+	conn.SetWriteDeadline(time.Now().Add(WRITE_TIMEOUT))
+	if _, err = conn.Write([]byte(response)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	c := &Conn{conn: conn, reader: rw.Reader, done: make(chan struct{})}
+	go c.readLoop()
+	return c, nil
+}
+
+// AcceptKey returns the value of Sec-WebSocket-Accept header for key.
+func AcceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + ACCEPT_GUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// WriteText sends data to the client in a text frame.
+func (c *Conn) WriteText(data []byte) error {
+	return c.writeFrame(OPCODE_TEXT, data)
+}
+
+// WritePing sends a ping frame, which the client answers with a pong frame.
+func (c *Conn) WritePing() error {
+	return c.writeFrame(OPCODE_PING, nil)
+}
+
+// Done returns a channel which is closed when the connection is closed
+// by either side or fails.
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Close sends a close frame and closes the connection.
+func (c *Conn) Close() error {
+	payload := make([]byte, 2)
+	binary.BigEndian.PutUint16(payload, CLOSE_NORMAL)
+	c.writeFrame(OPCODE_CLOSE, payload)
+	c.shutdown()
+	return nil
+}
+
+func (c *Conn) shutdown() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.conn.Close()
+	})
+}
+
+// writeFrame writes a single unmasked frame, as frames from a server are not masked.
+func (c *Conn) writeFrame(opcode byte, payload []byte) error {
+	select {
+	case <-c.done:
+		return ErrClosed
+	default:
+	}
+
+	header := []byte{0x80 | opcode}
+	switch length := len(payload); {
+	case length <= 125:
+		header = append(header, byte(length))
+	case length <= 0xFFFF:
+		header = append(header, 126, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(length))
+	default:
+		header = append(header, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(length))
+	}
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	c.conn.SetWriteDeadline(time.Now().Add(WRITE_TIMEOUT))
+	if _, err := c.conn.Write(append(header, payload...)); err != nil {
+		c.shutdown()
+		return err
+	}
+	return nil
+}
+
+// readLoop answers control frames from the client until the connection is closed.
+func (c *Conn) readLoop() {
+	defer c.shutdown()
+	for {
+		opcode, payload, err := c.readFrame()
+		if err != nil {
+			return
+		}
+		switch opcode {
+		case OPCODE_CLOSE:
+			c.writeFrame(OPCODE_CLOSE, payload)
+			return
+		case OPCODE_PING:
+			c.writeFrame(OPCODE_PONG, payload)
+		}
+	}
+}
+
+// readFrame reads a frame from the client, which must be masked.
+func (c *Conn) readFrame() (byte, []byte, error) {
+	head := make([]byte, 2)
+	if _, err := io.ReadFull(c.reader, head); err != nil {
+		return 0, nil, err
+	}
+	opcode := head[0] & 0x0F
+	masked := head[1]&0x80 != 0
+	length := uint64(head[1] & 0x7F)
+
+	if !masked {
+		return 0, nil, errors.New("websocket: unmasked frame from client")
+	}
+	if opcode >= OPCODE_CLOSE && length > MAX_CONTROL_SIZE {
+		return 0, nil, errors.New("websocket: control frame too large")
+	}
+
+	switch length {
+	case 126:
+		ext := make([]byte, 2)
+		if _, err := io.ReadFull(c.reader, ext); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		if _, err := io.ReadFull(c.reader, ext); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext)
+	}
+
+	mask := make([]byte, 4)
+	if _, err := io.ReadFull(c.reader, mask); err != nil {
+		return 0, nil, err
+	}
+
+	if length > MAX_MESSAGE_SIZE {
+		// Discard data messages which are too large to keep in memory.
+		if _, err := io.CopyN(ioutil.Discard, c.reader, int64(length)); err != nil {
+			return 0, nil, err
+		}
+		return opcode, nil, nil
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return opcode, payload, nil
+}
+
+// headerContains returns whether a comma separated header has token.
+func headerContains(header http.Header, name string, token string) bool {
+	for _, value := range header[http.CanonicalHeaderKey(name)] {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package websocket
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const key = "dGhlIHNhbXBsZSBub25jZQ=="
+
+func TestCalledAcceptKey_ExpectKeyOfRFC6455Example(t *testing.T) {
+	expected := "s3pPLMBiTxaQ9kYGzzhZRbK+xOo="
+	if actual := AcceptKey(key); actual != expected {
+		t.Errorf("Expected accept key: %s, actual: %s", expected, actual)
+	}
+}
+
+func TestCalledIsUpgrade_ExpectUpgradeHeadersChecked(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/", nil)
+	if IsUpgrade(req) {
+		t.Error("Expected plain request not to be an upgrade")
+	}
+
+	req.Header.Set("Upgrade", "WebSocket")
+	req.Header.Set("Connection", "keep-alive, Upgrade")
+	if !IsUpgrade(req) {
+		t.Error("Expected request to be an upgrade")
+	}
+}
+
+func TestCalledUpgradeWithoutKey_ExpectBadRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Version", VERSION)
+
+	if _, err := Upgrade(w, req); err == nil || w.Code != http.StatusBadRequest {
+		t.Errorf("Unexpected result: %d, %v", w.Code, err)
+	}
+}
+
+func TestCalledUpgrade_ExpectMessagesExchanged(t *testing.T) {
+	closed := make(chan bool)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		conn, err := Upgrade(w, req)
+		if err != nil {
+			t.Errorf("Unexpected err: %s", err.Error())
+			return
+		}
+		conn.WriteText([]byte("hello"))
+		<-conn.Done()
+		closed <- true
+	}))
+	defer server.Close()
+
+	client, err := net.Dial("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	defer client.Close()
+
+	client.Write([]byte("GET / HTTP/1.1\r\nHost: test\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: " + key + "\r\nSec-WebSocket-Version: 13\r\n\r\n"))
+
+	reader := bufio.NewReader(client)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != AcceptKey(key) {
+		t.Fatalf("Unexpected handshake: %d %v", resp.StatusCode, resp.Header)
+	}
+
+	frame := make([]byte, 7)
+	if _, err = io.ReadFull(reader, frame); err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	if frame[0] != 0x80|OPCODE_TEXT || frame[1] != 5 || string(frame[2:]) != "hello" {
+		t.Errorf("Unexpected frame: %v", frame)
+	}
+
+	// A ping from the client is answered with a pong with the same payload.
+	client.Write(maskedFrame(OPCODE_PING, "ping"))
+	pong := make([]byte, 6)
+	if _, err = io.ReadFull(reader, pong); err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	if pong[0] != 0x80|OPCODE_PONG || !strings.HasSuffix(string(pong), "ping") {
+		t.Errorf("Unexpected frame: %v", pong)
+	}
+
+	client.Write(maskedFrame(OPCODE_CLOSE, ""))
+	<-closed
+}
+
+func maskedFrame(opcode byte, payload string) []byte {
+	mask := []byte{1, 2, 3, 4}
+	frame := []byte{0x80 | opcode, 0x80 | byte(len(payload))}
+	frame = append(frame, mask...)
+	for i := 0; i < len(payload); i++ {
+		frame = append(frame, payload[i]^mask[i%4])
+	}
+	return frame
+}
@@ -0,0 +1,127 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package commons/workers keeps track of background loops of pharos-anchor,
+// so that readiness checks can tell whether they are still running.
+package workers
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MISSED_BEATS is the number of intervals a worker may stay idle after
+// its last iteration before it is reported as stalled.
+const MISSED_BEATS = 2
+
+// Worker is a background loop which starts and beats on every iteration.
+type Worker struct {
+	name     string
+	interval time.Duration
+
+	mutex      sync.Mutex
+	running    bool
+	inProgress bool
+	lastStart  time.Time
+	lastBeat   time.Time
+}
+
+// Status is the state of a worker.
+type Status struct {
+	Name       string    `json:"name"`
+	Running    bool      `json:"running"`
+	Healthy    bool      `json:"healthy"`
+	InProgress bool      `json:"inProgress"`
+	LastStart  time.Time `json:"lastStart"`
+	LastBeat   time.Time `json:"lastBeat"`
+	Interval   string    `json:"interval"`
+}
+
+var (
+	mutex   = &sync.Mutex{}
+	workers = make(map[string]*Worker)
+)
+
+// Register starts tracking a worker which iterates every interval.
+// A worker registered with the same name replaces the previous one.
+func Register(name string, interval time.Duration) *Worker {
+	w := &Worker{name: name, interval: interval, running: true, lastBeat: time.Now()}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	workers[name] = w
+	return w
+}
+
+// Start records that the worker started an iteration.
+func (w *Worker) Start() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.inProgress = true
+	w.lastStart = time.Now()
+}
+
+// Beat records that the worker finished an iteration.
+func (w *Worker) Beat() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.inProgress = false
+	w.lastBeat = time.Now()
+}
+
+// Stop records that the worker exited.
+func (w *Worker) Stop() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.running = false
+}
+
+// status returns the state of the worker at now. A running worker is healthy
+// while an iteration is in progress, however long it takes, and otherwise
+// unless it has been idle for MISSED_BEATS intervals since the last one.
+func (w *Worker) status(now time.Time) Status {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return Status{
+		Name:       w.name,
+		Running:    w.running,
+		Healthy:    w.running && (w.inProgress || now.Sub(w.lastBeat) <= MISSED_BEATS*w.interval),
+		InProgress: w.inProgress,
+		LastStart:  w.lastStart,
+		LastBeat:   w.lastBeat,
+		Interval:   w.interval.String(),
+	}
+}
+
+// Statuses returns the states of all registered workers sorted by name.
+func Statuses() []Status {
+	mutex.Lock()
+	list := make([]*Worker, 0, len(workers))
+	for _, w := range workers {
+		list = append(list, w)
+	}
+	mutex.Unlock()
+
+	now := time.Now()
+	statuses := make([]Status, 0, len(list))
+	for _, w := range list {
+		statuses = append(statuses, w.status(now))
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
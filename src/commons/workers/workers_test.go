@@ -0,0 +1,90 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package workers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatuses_ExpectRegisteredWorkersSortedByName(t *testing.T) {
+	Register("b", time.Minute)
+	Register("a", time.Minute)
+	defer func() {
+		workers = make(map[string]*Worker)
+	}()
+
+	statuses := Statuses()
+	if len(statuses) != 2 || statuses[0].Name != "a" || statuses[1].Name != "b" {
+		t.Fatalf("Unexpected statuses: %v", statuses)
+	}
+	if !statuses[0].Running || !statuses[0].Healthy {
+		t.Errorf("Expected running and healthy worker, actual %v", statuses[0])
+	}
+}
+
+func TestWorkerStatus(t *testing.T) {
+	now := time.Now()
+	testList := []struct {
+		name       string
+		running    bool
+		inProgress bool
+		lastBeat   time.Time
+		healthy    bool
+	}{
+		{"beating", true, false, now.Add(-time.Minute), true},
+		{"stalled", true, false, now.Add(-3 * time.Minute), false},
+		{"long iteration", true, true, now.Add(-10 * time.Minute), true},
+		{"stopped", false, false, now, false},
+	}
+
+	for _, test := range testList {
+		w := &Worker{name: test.name, interval: time.Minute, running: test.running,
+			inProgress: test.inProgress, lastBeat: test.lastBeat}
+		if status := w.status(now); status.Healthy != test.healthy {
+			t.Errorf("%s: expected healthy %t, actual %t", test.name, test.healthy, status.Healthy)
+		}
+	}
+}
+
+func TestBeatAndStop(t *testing.T) {
+	w := Register("worker", time.Millisecond)
+	defer func() {
+		workers = make(map[string]*Worker)
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	if w.status(time.Now()).Healthy {
+		t.Error("Expected stalled worker")
+	}
+
+	w.Start()
+	time.Sleep(5 * time.Millisecond)
+	if status := w.status(time.Now()); !status.Healthy || !status.InProgress {
+		t.Errorf("Expected healthy worker while an iteration is in progress, actual %v", status)
+	}
+
+	w.Beat()
+	if status := w.status(time.Now()); !status.Healthy || status.InProgress {
+		t.Errorf("Expected healthy worker after beat, actual %v", status)
+	}
+
+	w.Stop()
+	if w.status(time.Now()).Running {
+		t.Error("Expected stopped worker")
+	}
+}
@@ -23,26 +23,24 @@ package group
 import (
 	"commons/errors"
 	"commons/logger"
+	"commons/models"
 	"commons/results"
 	"commons/url"
 	"commons/util"
+	"context"
 	appDB "db/mongo/app"
 	groupDB "db/mongo/group"
 	nodeDB "db/mongo/node"
 	noti "controller/notification"
 	"messenger"
-	"strconv"
 )
 
 const (
 	NODES         = "nodes"       // used to indicate a list of nodes.
 	GROUPS        = "groups"      // used to indicate a list of groups.
-	MEMBERS       = "members"     // used to indicate a list of members.
 	APPS          = "apps"        // used to indicate a list of apps.
 	ID            = "id"          // used to indicate an id.
-	RESPONSE_CODE = "code"        // used to indicate a code.
 	ERROR_MESSAGE = "message"     // used to indicate a message.
-	RESPONSES     = "responses"   // used to indicate a list of responses.
 	DESCRIPTION   = "description" // used to indicate a description.
 )
 
@@ -65,93 +63,88 @@ func init() {
 // Command is an interface of group deployment operations.
 type Command interface {
 	// DeployApp request an deployment of edge services to a group specified by groupId parameter.
-	DeployApp(groupId string, body string) (int, map[string]interface{}, error)
+	DeployApp(ctx context.Context, groupId string, description models.AppDescription) (int, models.DeploymentResponse, error)
 
 	// GetApps request a list of applications that is deployed to a group specified by groupId parameter.
-	GetApps(groupId string) (int, map[string]interface{}, error)
+	GetApps(ctx context.Context, groupId string) (int, models.GroupAppList, error)
 
 	// GetApp gets the application's information of the group specified by groupId parameter.
-	GetApp(groupId string, appId string) (int, map[string]interface{}, error)
+	GetApp(ctx context.Context, groupId string, appId string) (int, models.GroupAppInfo, error)
 
 	// UpdateApp request to update an application specified by appId parameter to all members of the group.
-	UpdateAppInfo(groupId string, appId string, body string) (int, map[string]interface{}, error)
+	UpdateAppInfo(ctx context.Context, groupId string, appId string, description models.AppDescription) (int, models.DeploymentResponse, error)
 
 	// DeleteApp request to delete an application specified by appId parameter to all members of the group.
-	DeleteApp(groupId string, appId string) (int, map[string]interface{}, error)
+	DeleteApp(ctx context.Context, groupId string, appId string) (int, models.DeploymentResponse, error)
 
 	// UpdateAppInfo request to update all of images which is included an application specified by
 	// appId parameter to all members of the group.
-	UpdateApp(groupId string, appId string) (int, map[string]interface{}, error)
+	UpdateApp(ctx context.Context, groupId string, appId string) (int, models.DeploymentResponse, error)
 
 	// StartApp request to start an application specified by appId parameter to all members of the group.
-	StartApp(groupId string, appId string) (int, map[string]interface{}, error)
+	StartApp(ctx context.Context, groupId string, appId string) (int, models.DeploymentResponse, error)
 
 	// StopApp request to stop an application specified by appId parameter to all members of the group.
-	StopApp(groupId string, appId string) (int, map[string]interface{}, error)
+	StopApp(ctx context.Context, groupId string, appId string) (int, models.DeploymentResponse, error)
 }
 
 // DeployApp request an deployment of edge services to a group specified by groupId parameter.
 // If response code represents success, add an app id to a list of installed app and returns it.
 // Otherwise, an appropriate error will be returned.
-func (Executor) DeployApp(groupId string, body string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) DeployApp(ctx context.Context, groupId string, description models.AppDescription) (int, models.DeploymentResponse, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get group members from the database.
-	members, err := groupDbExecutor.GetGroupMembers(groupId)
+	members, err := groupDbExecutor.GetGroupMembers(ctx, groupId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.DeploymentResponse{}, err
 	}
 
 	address := getMemberAddress(members)
 	urls := util.MakeRequestUrl(address, url.Management(), url.Apps(), url.Deploy())
 
 	// Request an deployment of edge services to a specific group.
-	codes, respStr := httpExecutor.SendHttpRequest("POST", urls, nil, []byte(body))
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "POST", urls, nil, []byte(description))
 
 	// Convert the received response from string to map.
 	respMap, err := convertRespToMap(respStr)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.DeploymentResponse{}, err
 	}
 
 	// if response code represents success, insert the installed appId into groupDbExecutor.
 	installedAppId := ""
+	deployedNodeIds := make([]string, 0)
 	for i, node := range members {
 		if util.IsSuccessCode(codes[i]) {
-			err = appDbExecutor.AddApp(respMap[i]["id"].(string), []byte(respMap[i]["description"].(string)))
+			err = appDbExecutor.AddApp(ctx, respMap[i]["id"].(string), []byte(respMap[i]["description"].(string)))
 			if err != nil {
-				logger.Logging(logger.ERROR, err.Error())
-				return results.ERROR, nil, err
+				logger.With(ctx).Logging(logger.ERROR, err.Error())
+				return results.ERROR, models.DeploymentResponse{}, err
 			}
 
-			err = nodeDbExecutor.AddAppToNode(node[ID].(string), respMap[i][ID].(string))
+			err = nodeDbExecutor.AddAppToNode(ctx, node[ID].(string), respMap[i][ID].(string))
 			if err != nil {
-				logger.Logging(logger.ERROR, err.Error())
-				return results.ERROR, nil, err
+				logger.With(ctx).Logging(logger.ERROR, err.Error())
+				return results.ERROR, models.DeploymentResponse{}, err
 			}
 			installedAppId = respMap[i][ID].(string)
+			deployedNodeIds = append(deployedNodeIds, node[ID].(string))
+			noti.PublishDeployment(ctx, notiExecutor, node[ID].(string), installedAppId, noti.STATUS_DEPLOYED)
 		}
 	}
+	notiExecutor.ResyncSubscribers(ctx, deployedNodeIds)
 
+	resp := models.DeploymentResponse{ID: installedAppId}
 	result := decideResultCode(codes)
 	if result != results.OK {
 		// Make separate responses to represent partial failure case.
-		resp := make(map[string]interface{})
-		resp[RESPONSES] = makeSeparateResponses(members, codes, respMap)
-		if installedAppId != "" {
-			resp[ID] = installedAppId
-		}
-		return result, resp, err
+		resp.Responses = makeSeparateResponses(members, codes, respMap)
 	}
 
-	resp := make(map[string]interface{})
-	resp[ID] = installedAppId
-
-	notiExecutor.UpdateSubscriber()
-	
 	return result, resp, err
 }
 
@@ -159,101 +152,89 @@ func (Executor) DeployApp(groupId string, body string) (int, map[string]interfac
 // specified by groupId parameter.
 // If response code represents success, returns a list of applications.
 // Otherwise, an appropriate error will be returned.
-func (Executor) GetApps(groupId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetApps(ctx context.Context, groupId string) (int, models.GroupAppList, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get group members from the database.
-	members, err := groupDbExecutor.GetGroupMembers(groupId)
+	members, err := groupDbExecutor.GetGroupMembers(ctx, groupId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.GroupAppList{}, err
 	}
 
-	contains := func(list []map[string]interface{}, appId string) map[string]interface{} {
-		for _, item := range list {
-			if item[ID] == appId {
-				return item
+	indexOf := func(list []models.GroupApp, appId string) int {
+		for i, item := range list {
+			if item.ID == appId {
+				return i
 			}
 		}
-		return nil
+		return -1
 	}
 
-	respValue := make([]map[string]interface{}, 0)
+	res := models.GroupAppList{Apps: make([]models.GroupApp, 0)}
 	for _, node := range members {
 		for _, appId := range node[APPS].([]string) {
-			item := contains(respValue, appId)
-			if item != nil {
-				item[MEMBERS] = append(item[MEMBERS].([]string), node[ID].(string))
+			i := indexOf(res.Apps, appId)
+			if i != -1 {
+				res.Apps[i].Members = append(res.Apps[i].Members, node[ID].(string))
 			} else {
-				item = map[string]interface{}{
+				res.Apps = append(res.Apps, models.GroupApp{
 					ID:      appId,
-					MEMBERS: []string{node[ID].(string)},
-				}
-				respValue = append(respValue, item)
+					Members: []string{node[ID].(string)},
+				})
 			}
 		}
 	}
 
-	res := make(map[string]interface{})
-	res[APPS] = respValue
-
 	return results.OK, res, err
 }
 
 // GetApp gets the application's information of the group specified by groupId parameter.
 // If response code represents success, returns information of application.
 // Otherwise, an appropriate error will be returned.
-func (Executor) GetApp(groupId string, appId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetApp(ctx context.Context, groupId string, appId string) (int, models.GroupAppInfo, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get group members including app specified by appId parameter.
-	members, err := groupDbExecutor.GetGroupMembersByAppID(groupId, appId)
+	members, err := groupDbExecutor.GetGroupMembersByAppID(ctx, groupId, appId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.GroupAppInfo{}, err
 	}
 
 	address := getMemberAddress(members)
 	urls := util.MakeRequestUrl(address, url.Management(), url.Apps(), "/", appId)
 
 	// Request get target application's information.
-	codes, respStr := httpExecutor.SendHttpRequest("GET", urls, nil)
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "GET", urls, nil)
 
 	// Convert the received response from string to map.
 	respMap, err := convertRespToMap(respStr)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.GroupAppInfo{}, err
 	}
 
 	result := decideResultCode(codes)
-	if result != results.OK {
-		// Make separate responses to represent partial failure case.
-		resp := make(map[string]interface{})
-		resp[RESPONSES] = makeSeparateResponses(members, codes, respMap)
-
-		for i, _ := range members {
-			if util.IsSuccessCode(codes[i]) {
-				respValue := resp[RESPONSES].([]map[string]interface{})
-				for key, value := range respMap[i] {
-					respValue[i][key] = value
-				}
+	separateResponses := makeSeparateResponses(members, codes, respMap)
+
+	resp := models.GroupAppInfo{Responses: make([]models.NodeAppInfo, len(members))}
+	for i, node := range members {
+		if util.IsSuccessCode(codes[i]) {
+			err = models.Convert(respMap[i], &resp.Responses[i].AppInfo)
+			if err != nil {
+				logger.With(ctx).Logging(logger.ERROR, err.Error())
+				return results.ERROR, models.GroupAppInfo{}, errors.InternalServerError{"Json Converting Failed"}
 			}
 		}
-		return result, resp, err
-	}
-
-	resp := make(map[string]interface{})
-	respValue := make([]map[string]interface{}, len(members))
-	resp[RESPONSES] = respValue
+		resp.Responses[i].ID = node[ID].(string)
 
-	for i, node := range members {
-		respValue[i] = make(map[string]interface{})
-		respValue[i][ID] = node[ID].(string)
-		for key, value := range respMap[i] {
-			respValue[i][key] = value
+		// Make separate responses to represent partial failure case.
+		if result != results.OK {
+			resp.Responses[i].Code = separateResponses[i].Code
+			resp.Responses[i].Message = separateResponses[i].Message
 		}
 	}
 
@@ -264,214 +245,223 @@ func (Executor) GetApp(groupId string, appId string) (int, map[string]interface{
 // to all members of the group.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) UpdateAppInfo(groupId string, appId string, body string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) UpdateAppInfo(ctx context.Context, groupId string, appId string, description models.AppDescription) (int, models.DeploymentResponse, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get group members including app specified by appId parameter.
-	members, err := groupDbExecutor.GetGroupMembersByAppID(groupId, appId)
+	members, err := groupDbExecutor.GetGroupMembersByAppID(ctx, groupId, appId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.DeploymentResponse{}, err
 	}
 
 	address := getMemberAddress(members)
 	urls := util.MakeRequestUrl(address, url.Management(), url.Apps(), "/", appId)
 
 	// Request update target application's information.
-	codes, respStr := httpExecutor.SendHttpRequest("POST", urls, nil, []byte(body))
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "POST", urls, nil, []byte(description))
 
 	// Convert the received response from string to map.
 	respMap, err := convertRespToMap(respStr)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.DeploymentResponse{}, err
+	}
+
+	for i, node := range members {
+		if util.IsSuccessCode(codes[i]) {
+			noti.PublishDeployment(ctx, notiExecutor, node[ID].(string), appId, noti.STATUS_UPDATED)
+		}
 	}
 
 	result := decideResultCode(codes)
 	if result != results.OK {
 		// Make separate responses to represent partial failure case.
-		resp := make(map[string]interface{})
-		resp[RESPONSES] = makeSeparateResponses(members, codes, respMap)
+		resp := models.DeploymentResponse{Responses: makeSeparateResponses(members, codes, respMap)}
 		return result, resp, err
 	}
 
-	return result, nil, err
+	return result, models.DeploymentResponse{}, err
 }
 
 // DeleteApp request to delete an application specified by appId parameter
 // to all members of the group.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) DeleteApp(groupId string, appId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) DeleteApp(ctx context.Context, groupId string, appId string) (int, models.DeploymentResponse, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get group members including app specified by appId parameter.
-	members, err := groupDbExecutor.GetGroupMembersByAppID(groupId, appId)
+	members, err := groupDbExecutor.GetGroupMembersByAppID(ctx, groupId, appId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.DeploymentResponse{}, err
 	}
 
 	address := getMemberAddress(members)
 	urls := util.MakeRequestUrl(address, url.Management(), url.Apps(), "/", appId)
 
 	// Request delete target application.
-	codes, respStr := httpExecutor.SendHttpRequest("DELETE", urls, nil)
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "DELETE", urls, nil)
 
 	// Convert the received response from string to map.
 	respMap, err := convertRespToMap(respStr)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.DeploymentResponse{}, err
 	}
 
 	// if response code represents success, delete the appId from groupDbExecutor.
+	deletedNodeIds := make([]string, 0)
 	for i, node := range members {
 		if util.IsSuccessCode(codes[i]) {
-			err = nodeDbExecutor.DeleteAppFromNode(node[ID].(string), appId)
+			err = nodeDbExecutor.DeleteAppFromNode(ctx, node[ID].(string), appId)
 			if err != nil {
-				logger.Logging(logger.ERROR, err.Error())
-				return results.ERROR, nil, err
+				logger.With(ctx).Logging(logger.ERROR, err.Error())
+				return results.ERROR, models.DeploymentResponse{}, err
 			}
 
-			err = appDbExecutor.DeleteApp(appId)
+			err = appDbExecutor.DeleteApp(ctx, appId)
 			if err != nil {
-				logger.Logging(logger.ERROR, err.Error())
-				return results.ERROR, nil, err
+				logger.With(ctx).Logging(logger.ERROR, err.Error())
+				return results.ERROR, models.DeploymentResponse{}, err
 			}
+			deletedNodeIds = append(deletedNodeIds, node[ID].(string))
+			noti.PublishDeployment(ctx, notiExecutor, node[ID].(string), appId, noti.STATUS_DELETED)
 		}
 	}
+	notiExecutor.ResyncSubscribers(ctx, deletedNodeIds)
 
 	result := decideResultCode(codes)
 	if result != results.OK {
 		// Make separate responses to represent partial failure case.
-		resp := make(map[string]interface{})
-		resp[RESPONSES] = makeSeparateResponses(members, codes, respMap)
+		resp := models.DeploymentResponse{Responses: makeSeparateResponses(members, codes, respMap)}
 		return result, resp, err
 	}
-	
-	notiExecutor.UpdateSubscriber()
-	
-	return result, nil, err
+
+	return result, models.DeploymentResponse{}, err
 }
 
 // UpdateAppInfo request to update all of images which is included an application
 // specified by appId parameter to all members of the group.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) UpdateApp(groupId string, appId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) UpdateApp(ctx context.Context, groupId string, appId string) (int, models.DeploymentResponse, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get group members including app specified by appId parameter.
-	members, err := groupDbExecutor.GetGroupMembersByAppID(groupId, appId)
+	members, err := groupDbExecutor.GetGroupMembersByAppID(ctx, groupId, appId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.DeploymentResponse{}, err
 	}
 
 	address := getMemberAddress(members)
 	urls := util.MakeRequestUrl(address, url.Management(), url.Apps(), "/", appId, url.Update())
 
 	// Request checking and updating all of images which is included target.
-	codes, respStr := httpExecutor.SendHttpRequest("POST", urls, nil)
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "POST", urls, nil)
 
 	// Convert the received response from string to map.
 	respMap, err := convertRespToMap(respStr)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.DeploymentResponse{}, err
+	}
+
+	for i, node := range members {
+		if util.IsSuccessCode(codes[i]) {
+			noti.PublishDeployment(ctx, notiExecutor, node[ID].(string), appId, noti.STATUS_UPDATED)
+		}
 	}
 
 	result := decideResultCode(codes)
 	if result != results.OK {
 		// Make separate responses to represent partial failure case.
-		resp := make(map[string]interface{})
-		resp[RESPONSES] = makeSeparateResponses(members, codes, respMap)
+		resp := models.DeploymentResponse{Responses: makeSeparateResponses(members, codes, respMap)}
 		return result, resp, err
 	}
 
-	return result, nil, err
+	return result, models.DeploymentResponse{}, err
 }
 
 // StartApp request to start an application specified by appId parameter
 // to all members of the group.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) StartApp(groupId string, appId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) StartApp(ctx context.Context, groupId string, appId string) (int, models.DeploymentResponse, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get group members including app specified by appId parameter.
-	members, err := groupDbExecutor.GetGroupMembersByAppID(groupId, appId)
+	members, err := groupDbExecutor.GetGroupMembersByAppID(ctx, groupId, appId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.DeploymentResponse{}, err
 	}
 
 	address := getMemberAddress(members)
 	urls := util.MakeRequestUrl(address, url.Management(), url.Apps(), "/", appId, url.Start())
 
 	// Request start target application.
-	codes, respStr := httpExecutor.SendHttpRequest("POST", urls, nil)
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "POST", urls, nil)
 
 	// Convert the received response from string to map.
 	respMap, err := convertRespToMap(respStr)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.DeploymentResponse{}, err
 	}
 
 	result := decideResultCode(codes)
 	if result != results.OK {
 		// Make separate responses to represent partial failure case.
-		resp := make(map[string]interface{})
-		resp[RESPONSES] = makeSeparateResponses(members, codes, respMap)
+		resp := models.DeploymentResponse{Responses: makeSeparateResponses(members, codes, respMap)}
 		return result, resp, err
 	}
 
-	return result, nil, err
+	return result, models.DeploymentResponse{}, err
 }
 
 // StopApp request to stop an application specified by appId parameter
 // to all members of the group.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) StopApp(groupId string, appId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) StopApp(ctx context.Context, groupId string, appId string) (int, models.DeploymentResponse, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get group members including app specified by appId parameter.
-	members, err := groupDbExecutor.GetGroupMembersByAppID(groupId, appId)
+	members, err := groupDbExecutor.GetGroupMembersByAppID(ctx, groupId, appId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.DeploymentResponse{}, err
 	}
 
 	address := getMemberAddress(members)
 	urls := util.MakeRequestUrl(address, url.Management(), url.Apps(), "/", appId, url.Stop())
 
 	// Request stop target application.
-	codes, respStr := httpExecutor.SendHttpRequest("POST", urls, nil)
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "POST", urls, nil)
 
 	// Convert the received response from string to map.
 	respMap, err := convertRespToMap(respStr)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.DeploymentResponse{}, err
 	}
 
 	result := decideResultCode(codes)
 	if result != results.OK {
 		// Make separate responses to represent partial failure case.
-		resp := make(map[string]interface{})
-		resp[RESPONSES] = makeSeparateResponses(members, codes, respMap)
+		resp := models.DeploymentResponse{Responses: makeSeparateResponses(members, codes, respMap)}
 		return result, resp, err
 	}
 
-	return result, nil, err
+	return result, models.DeploymentResponse{}, err
 }
 
 // getNodeAddress returns an member's address as an array.
@@ -531,17 +521,16 @@ func decideResultCode(codes []int) int {
 // makeSeparateResponses used to make a separate response
 // when the group operations is a partial success.
 func makeSeparateResponses(members []map[string]interface{}, codes []int,
-	respMap []map[string]interface{}) []map[string]interface{} {
+	respMap []map[string]interface{}) []models.NodeResponse {
 
-	respValue := make([]map[string]interface{}, len(members))
+	respValue := make([]models.NodeResponse, len(members))
 
 	for i, node := range members {
-		respValue[i] = make(map[string]interface{})
-		respValue[i][ID] = node[ID].(string)
-		respValue[i][RESPONSE_CODE] = strconv.Itoa(codes[i])
+		respValue[i].ID = node[ID].(string)
+		respValue[i].Code = models.StatusCode(codes[i])
 
 		if !util.IsSuccessCode(codes[i]) {
-			respValue[i][ERROR_MESSAGE] = respMap[i][ERROR_MESSAGE]
+			respValue[i].Message, _ = respMap[i][ERROR_MESSAGE].(string)
 		}
 	}
 
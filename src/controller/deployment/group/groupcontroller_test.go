@@ -18,7 +18,10 @@ package group
 
 import (
 	"commons/errors"
+	"commons/models"
 	"commons/results"
+	"context"
+	noti "controller/notification"
 	notificationmocks "controller/notification/mocks"
 	appdbmocks "db/mongo/app/mocks"
 	groupdbmocks "db/mongo/group/mocks"
@@ -84,6 +87,11 @@ func init() {
 	executor = Executor{}
 }
 
+// deploymentEvent returns an event of the app on the node with status.
+func deploymentEvent(status string) map[string]interface{} {
+	return map[string]interface{}{"nodeid": nodeId, "appid": appId, "status": status}
+}
+
 func TestCalledDeployApp_ExpectSuccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -93,9 +101,7 @@ func TestCalledDeployApp_ExpectSuccess(t *testing.T) {
 		`{"id":"000000000000000000000000", "description":"description"}`,
 	}
 	expectedUrl := []string{deployUrl, deployUrl}
-	expectedRes := map[string]interface{}{
-		"id": "000000000000000000000000",
-	}
+	expectedRes := models.DeploymentResponse{ID: appId}
 
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 	nodeDbExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
@@ -104,14 +110,15 @@ func TestCalledDeployApp_ExpectSuccess(t *testing.T) {
 	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembers(groupId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil, []byte(body)).Return(respCode, respStr),
-		appDbExecutorMockObj.EXPECT().AddApp(appId, gomock.Any()).Return(nil),
-		nodeDbExecutorMockObj.EXPECT().AddAppToNode(nodeId, appId).Return(nil),
-		appDbExecutorMockObj.EXPECT().AddApp(appId, gomock.Any()).Return(nil),
-		nodeDbExecutorMockObj.EXPECT().AddAppToNode(nodeId, appId).Return(nil),
-		notiMockObj.EXPECT().UpdateSubscriber(),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembers(gomock.Any(), groupId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil, []byte(body)).Return(respCode, respStr),
+		appDbExecutorMockObj.EXPECT().AddApp(gomock.Any(), appId, gomock.Any()).Return(nil),
+		nodeDbExecutorMockObj.EXPECT().AddAppToNode(gomock.Any(), nodeId, appId).Return(nil),
+		appDbExecutorMockObj.EXPECT().AddApp(gomock.Any(), appId, gomock.Any()).Return(nil),
+		nodeDbExecutorMockObj.EXPECT().AddAppToNode(gomock.Any(), nodeId, appId).Return(nil),
+		notiMockObj.EXPECT().ResyncSubscribers(gomock.Any(), []string{nodeId, nodeId}),
 	)
+	notiMockObj.EXPECT().Publish(gomock.Any(), noti.DEPLOYMENT, []string{nodeId}, deploymentEvent(noti.STATUS_DEPLOYED)).Times(2)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	appDbExecutor = appDbExecutorMockObj
@@ -119,7 +126,7 @@ func TestCalledDeployApp_ExpectSuccess(t *testing.T) {
 	httpExecutor = msgMockObj
 	notiExecutor = notiMockObj
 
-	code, res, err := executor.DeployApp(groupId, body)
+	code, res, err := executor.DeployApp(context.Background(), groupId, models.AppDescription(body))
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -130,7 +137,7 @@ func TestCalledDeployApp_ExpectSuccess(t *testing.T) {
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -141,12 +148,12 @@ func TestCalledDeployAppWhenDBHasNotMatchedGroup_ExpectErrorReturn(t *testing.T)
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembers(groupId).Return(nil, notFoundError),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembers(gomock.Any(), groupId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	code, _, err := executor.DeployApp(groupId, body)
+	code, _, err := executor.DeployApp(context.Background(), groupId, models.AppDescription(body))
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -173,14 +180,14 @@ func TestCalledDeployAppWhenMessengerReturnsInvalidResponse_ExpectErrorReturn(t
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembers(groupId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil, []byte(body)).Return(respCode, invalidRespStr),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembers(gomock.Any(), groupId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil, []byte(body)).Return(respCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.DeployApp(groupId, body)
+	code, _, err := executor.DeployApp(context.Background(), groupId, models.AppDescription(body))
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -210,10 +217,10 @@ func TestCalledDeployAppWhenFailedToAddAppIdToDB_ExpectErrorReturn(t *testing.T)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembers(groupId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil, []byte(body)).Return(respCode, respStr),
-		appDbExecutorMockObj.EXPECT().AddApp(appId, []byte("description")).Return(nil).AnyTimes(),
-		nodeDbExecutorMockObj.EXPECT().AddAppToNode(nodeId, appId).Return(notFoundError),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembers(gomock.Any(), groupId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil, []byte(body)).Return(respCode, respStr),
+		appDbExecutorMockObj.EXPECT().AddApp(gomock.Any(), appId, []byte("description")).Return(nil).AnyTimes(),
+		nodeDbExecutorMockObj.EXPECT().AddAppToNode(gomock.Any(), nodeId, appId).Return(notFoundError),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
@@ -221,7 +228,7 @@ func TestCalledDeployAppWhenFailedToAddAppIdToDB_ExpectErrorReturn(t *testing.T)
 	nodeDbExecutor = nodeDbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.DeployApp(groupId, body)
+	code, _, err := executor.DeployApp(context.Background(), groupId, models.AppDescription(body))
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -244,18 +251,11 @@ func TestCalledDeployAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *test
 
 	partialSuccessRespStr := []string{`{"id":"000000000000000000000000", "description":"description"}`, `{"message":"errorMsg"}`}
 	expectedUrl := []string{deployUrl, deployUrl}
-	expectedRes := map[string]interface{}{
-		"id": "000000000000000000000000",
-		"responses": []map[string]interface{}{
-			map[string]interface{}{
-				"id":   nodeId,
-				"code": "200",
-			},
-			map[string]interface{}{
-				"id":      nodeId,
-				"code":    "500",
-				"message": "errorMsg",
-			},
+	expectedRes := models.DeploymentResponse{
+		ID: appId,
+		Responses: []models.NodeResponse{
+			{ID: nodeId, Code: 200},
+			{ID: nodeId, Code: 500, Message: "errorMsg"},
 		},
 	}
 
@@ -263,20 +263,25 @@ func TestCalledDeployAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *test
 	nodeDbExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 	appDbExecutorMockObj := appdbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembers(groupId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil, []byte(body)).Return(partialSuccessRespCode, partialSuccessRespStr),
-		appDbExecutorMockObj.EXPECT().AddApp(appId, []byte("description")).Return(nil).AnyTimes(),
-		nodeDbExecutorMockObj.EXPECT().AddAppToNode(nodeId, appId).Return(nil),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembers(gomock.Any(), groupId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil, []byte(body)).Return(partialSuccessRespCode, partialSuccessRespStr),
+		appDbExecutorMockObj.EXPECT().AddApp(gomock.Any(), appId, []byte("description")).Return(nil).AnyTimes(),
+		nodeDbExecutorMockObj.EXPECT().AddAppToNode(gomock.Any(), nodeId, appId).Return(nil),
 	)
+	notiMockObj.EXPECT().Publish(gomock.Any(), noti.DEPLOYMENT, []string{nodeId}, deploymentEvent(noti.STATUS_DEPLOYED))
+	// Only the member the app is deployed to is synchronised.
+	notiMockObj.EXPECT().ResyncSubscribers(gomock.Any(), []string{nodeId})
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	appDbExecutor = appDbExecutorMockObj
 	nodeDbExecutor = nodeDbExecutorMockObj
 	httpExecutor = msgMockObj
+	notiExecutor = notiMockObj
 
-	code, res, err := executor.DeployApp(groupId, body)
+	code, res, err := executor.DeployApp(context.Background(), groupId, models.AppDescription(body))
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -287,7 +292,7 @@ func TestCalledDeployAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *test
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -295,22 +300,22 @@ func TestCalledGetApps_ExpectSuccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	expectedRes := map[string]interface{}{
-		"apps": []map[string]interface{}{{
-			"id":      appId,
-			"members": []string{nodeId, nodeId},
+	expectedRes := models.GroupAppList{
+		Apps: []models.GroupApp{{
+			ID:      appId,
+			Members: []string{nodeId, nodeId},
 		}},
 	}
 
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembers(groupId).Return(members, nil),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembers(gomock.Any(), groupId).Return(members, nil),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	code, res, err := executor.GetApps(groupId)
+	code, res, err := executor.GetApps(context.Background(), groupId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -321,7 +326,7 @@ func TestCalledGetApps_ExpectSuccess(t *testing.T) {
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -332,12 +337,12 @@ func TestCalledGetAppsWhenDBHasNotMatchedGroup_ExpectErrorReturn(t *testing.T) {
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembers(groupId).Return(nil, notFoundError),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembers(gomock.Any(), groupId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	code, _, err := executor.GetApps(groupId)
+	code, _, err := executor.GetApps(context.Background(), groupId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -360,29 +365,23 @@ func TestCalledGetApp_ExpectSuccess(t *testing.T) {
 
 	respStr := []string{`{"description":"description"}`, `{"description":"description"}`}
 	expectedUrl := []string{baseUrl, baseUrl}
-	expectedRes := map[string]interface{}{
-		"responses": []map[string]interface{}{{
-			"description": "description",
-			"id":          members[0]["id"],
-		},
-			{
-				"description": "description",
-				"id":          members[0]["id"],
-			}},
+	appInfo := models.AppInfo{ID: nodeId, Description: "description"}
+	expectedRes := models.GroupAppInfo{
+		Responses: []models.NodeAppInfo{{AppInfo: appInfo}, {AppInfo: appInfo}},
 	}
 
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("GET", expectedUrl, nil).Return(respCode, respStr),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrl, nil).Return(respCode, respStr),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, res, err := executor.GetApp(groupId, appId)
+	code, res, err := executor.GetApp(context.Background(), groupId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -393,7 +392,7 @@ func TestCalledGetApp_ExpectSuccess(t *testing.T) {
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -404,12 +403,12 @@ func TestCalledGetAppWhenDBHasNotMatchedGroup_ExpectErrorReturn(t *testing.T) {
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(nil, notFoundError),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	code, _, err := executor.GetApp(groupId, appId)
+	code, _, err := executor.GetApp(context.Background(), groupId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -437,14 +436,14 @@ func TestCalledGetAppWhenMessengerReturnsInvalidResponse_ExpectErrorReturn(t *te
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("GET", expectedUrl, nil).Return(respCode, invalidRespStr),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrl, nil).Return(respCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.GetApp(groupId, appId)
+	code, _, err := executor.GetApp(context.Background(), groupId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -467,18 +466,10 @@ func TestCalledGetAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *testing
 
 	partialSuccessRespStr := []string{`{"description": "description"}`, `{"message":"errorMsg"}`}
 	expectedUrl := []string{baseUrl, baseUrl}
-	expectedRes := map[string]interface{}{
-		"responses": []map[string]interface{}{
-			map[string]interface{}{
-				"id":          nodeId,
-				"code":        "200",
-				"description": "description",
-			},
-			map[string]interface{}{
-				"id":      nodeId,
-				"code":    "500",
-				"message": "errorMsg",
-			},
+	expectedRes := models.GroupAppInfo{
+		Responses: []models.NodeAppInfo{
+			{AppInfo: models.AppInfo{ID: nodeId, Description: "description"}, Code: 200},
+			{AppInfo: models.AppInfo{ID: nodeId}, Code: 500, Message: "errorMsg"},
 		},
 	}
 
@@ -486,14 +477,14 @@ func TestCalledGetAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *testing
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("GET", expectedUrl, nil).Return(partialSuccessRespCode, partialSuccessRespStr),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrl, nil).Return(partialSuccessRespCode, partialSuccessRespStr),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, res, err := executor.GetApp(groupId, appId)
+	code, res, err := executor.GetApp(context.Background(), groupId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -504,7 +495,7 @@ func TestCalledGetAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *testing
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -516,16 +507,19 @@ func TestCalledUpdateAppInfo_ExpectSuccess(t *testing.T) {
 
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil, []byte(body)).Return(respCode, nil),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil, []byte(body)).Return(respCode, nil),
 	)
+	notiMockObj.EXPECT().Publish(gomock.Any(), noti.DEPLOYMENT, []string{nodeId}, deploymentEvent(noti.STATUS_UPDATED)).Times(2)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
+	notiExecutor = notiMockObj
 
-	code, _, err := executor.UpdateAppInfo(groupId, appId, body)
+	code, _, err := executor.UpdateAppInfo(context.Background(), groupId, appId, models.AppDescription(body))
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -543,12 +537,12 @@ func TestCalledUpdateAppInfoWhenDBHasNotMatchedGroup_ExpectErrorReturn(t *testin
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(nil, notFoundError),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	code, _, err := executor.UpdateAppInfo(groupId, appId, body)
+	code, _, err := executor.UpdateAppInfo(context.Background(), groupId, appId, models.AppDescription(body))
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -576,14 +570,14 @@ func TestCalledUpdateAppInfoWhenMessengerReturnsInvalidResponse_ExpectErrorRetur
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil, []byte(body)).Return(respCode, invalidRespStr),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil, []byte(body)).Return(respCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.UpdateAppInfo(groupId, appId, body)
+	code, _, err := executor.UpdateAppInfo(context.Background(), groupId, appId, models.AppDescription(body))
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -606,32 +600,28 @@ func TestCalledUpdateAppInfoWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *
 
 	partialSuccessRespStr := []string{`{"message": "successMsg"}`, `{"message":"errorMsg"}`}
 	expectedUrl := []string{baseUrl, baseUrl}
-	expectedRes := map[string]interface{}{
-		"responses": []map[string]interface{}{
-			map[string]interface{}{
-				"id":   nodeId,
-				"code": "200",
-			},
-			map[string]interface{}{
-				"id":      nodeId,
-				"code":    "500",
-				"message": "errorMsg",
-			},
+	expectedRes := models.DeploymentResponse{
+		Responses: []models.NodeResponse{
+			{ID: nodeId, Code: 200},
+			{ID: nodeId, Code: 500, Message: "errorMsg"},
 		},
 	}
 
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil, []byte(body)).Return(partialSuccessRespCode, partialSuccessRespStr),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil, []byte(body)).Return(partialSuccessRespCode, partialSuccessRespStr),
 	)
+	notiMockObj.EXPECT().Publish(gomock.Any(), noti.DEPLOYMENT, []string{nodeId}, deploymentEvent(noti.STATUS_UPDATED))
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
+	notiExecutor = notiMockObj
 
-	code, res, err := executor.UpdateAppInfo(groupId, appId, body)
+	code, res, err := executor.UpdateAppInfo(context.Background(), groupId, appId, models.AppDescription(body))
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -642,7 +632,7 @@ func TestCalledUpdateAppInfoWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -654,16 +644,19 @@ func TestCalledUpdateApp_ExpectSuccess(t *testing.T) {
 
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(respCode, nil),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(respCode, nil),
 	)
+	notiMockObj.EXPECT().Publish(gomock.Any(), noti.DEPLOYMENT, []string{nodeId}, deploymentEvent(noti.STATUS_UPDATED)).Times(2)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
+	notiExecutor = notiMockObj
 
-	code, _, err := executor.UpdateApp(groupId, appId)
+	code, _, err := executor.UpdateApp(context.Background(), groupId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -681,12 +674,12 @@ func TestCalledUpdateAppWhenDBHasNotMatchedGroup_ExpectErrorReturn(t *testing.T)
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(nil, notFoundError),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	code, _, err := executor.UpdateApp(groupId, appId)
+	code, _, err := executor.UpdateApp(context.Background(), groupId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -714,14 +707,14 @@ func TestCalledUpdateAppWhenMessengerReturnsInvalidResponse_ExpectErrorReturn(t
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(respCode, invalidRespStr),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(respCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.UpdateApp(groupId, appId)
+	code, _, err := executor.UpdateApp(context.Background(), groupId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -744,32 +737,28 @@ func TestCalledUpdateAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *test
 
 	partialSuccessRespStr := []string{`{"message": "successMsg"}`, `{"message":"errorMsg"}`}
 	expectedUrl := []string{baseUrl + "/update", baseUrl + "/update"}
-	expectedRes := map[string]interface{}{
-		"responses": []map[string]interface{}{
-			map[string]interface{}{
-				"id":   nodeId,
-				"code": "200",
-			},
-			map[string]interface{}{
-				"id":      nodeId,
-				"code":    "500",
-				"message": "errorMsg",
-			},
+	expectedRes := models.DeploymentResponse{
+		Responses: []models.NodeResponse{
+			{ID: nodeId, Code: 200},
+			{ID: nodeId, Code: 500, Message: "errorMsg"},
 		},
 	}
 
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(partialSuccessRespCode, partialSuccessRespStr),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(partialSuccessRespCode, partialSuccessRespStr),
 	)
+	notiMockObj.EXPECT().Publish(gomock.Any(), noti.DEPLOYMENT, []string{nodeId}, deploymentEvent(noti.STATUS_UPDATED))
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
+	notiExecutor = notiMockObj
 
-	code, res, err := executor.UpdateApp(groupId, appId)
+	code, res, err := executor.UpdateApp(context.Background(), groupId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -780,7 +769,7 @@ func TestCalledUpdateAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *test
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -794,14 +783,14 @@ func TestCalledStartApp_ExpectSuccess(t *testing.T) {
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(respCode, nil),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(respCode, nil),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.StartApp(groupId, appId)
+	code, _, err := executor.StartApp(context.Background(), groupId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -819,12 +808,12 @@ func TestCalledStartAppWhenDBHasNotMatchedGroup_ExpectErrorReturn(t *testing.T)
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(nil, notFoundError),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	code, _, err := executor.StartApp(groupId, appId)
+	code, _, err := executor.StartApp(context.Background(), groupId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -852,14 +841,14 @@ func TestCalledStartAppWhenMessengerReturnsInvalidResponse_ExpectErrorReturn(t *
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(respCode, invalidRespStr),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(respCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.StartApp(groupId, appId)
+	code, _, err := executor.StartApp(context.Background(), groupId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -882,17 +871,10 @@ func TestCalledStartAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *testi
 
 	partialSuccessRespStr := []string{`{"message": "successMsg"}`, `{"message":"errorMsg"}`}
 	expectedUrl := []string{baseUrl + "/start", baseUrl + "/start"}
-	expectedRes := map[string]interface{}{
-		"responses": []map[string]interface{}{
-			map[string]interface{}{
-				"id":   nodeId,
-				"code": "200",
-			},
-			map[string]interface{}{
-				"id":      nodeId,
-				"code":    "500",
-				"message": "errorMsg",
-			},
+	expectedRes := models.DeploymentResponse{
+		Responses: []models.NodeResponse{
+			{ID: nodeId, Code: 200},
+			{ID: nodeId, Code: 500, Message: "errorMsg"},
 		},
 	}
 
@@ -900,14 +882,14 @@ func TestCalledStartAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *testi
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(partialSuccessRespCode, partialSuccessRespStr),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(partialSuccessRespCode, partialSuccessRespStr),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, res, err := executor.StartApp(groupId, appId)
+	code, res, err := executor.StartApp(context.Background(), groupId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -918,7 +900,7 @@ func TestCalledStartAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *testi
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -932,14 +914,14 @@ func TestCalledStopApp_ExpectSuccess(t *testing.T) {
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(respCode, nil),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(respCode, nil),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.StopApp(groupId, appId)
+	code, _, err := executor.StopApp(context.Background(), groupId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -957,12 +939,12 @@ func TestCalledStopAppWhenDBHasNotMatchedGroup_ExpectErrorReturn(t *testing.T) {
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(nil, notFoundError),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	code, _, err := executor.StopApp(groupId, appId)
+	code, _, err := executor.StopApp(context.Background(), groupId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -990,14 +972,14 @@ func TestCalledStopAppWhenMessengerReturnsInvalidResponse_ExpectErrorReturn(t *t
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(respCode, invalidRespStr),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(respCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.StopApp(groupId, appId)
+	code, _, err := executor.StopApp(context.Background(), groupId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -1020,17 +1002,10 @@ func TestCalledStopAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *testin
 
 	partialSuccessRespStr := []string{`{"message": "successMsg"}`, `{"message":"errorMsg"}`}
 	expectedUrl := []string{baseUrl + "/stop", baseUrl + "/stop"}
-	expectedRes := map[string]interface{}{
-		"responses": []map[string]interface{}{
-			map[string]interface{}{
-				"id":   nodeId,
-				"code": "200",
-			},
-			map[string]interface{}{
-				"id":      nodeId,
-				"code":    "500",
-				"message": "errorMsg",
-			},
+	expectedRes := models.DeploymentResponse{
+		Responses: []models.NodeResponse{
+			{ID: nodeId, Code: 200},
+			{ID: nodeId, Code: 500, Message: "errorMsg"},
 		},
 	}
 
@@ -1038,14 +1013,14 @@ func TestCalledStopAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *testin
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(partialSuccessRespCode, partialSuccessRespStr),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(partialSuccessRespCode, partialSuccessRespStr),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, res, err := executor.StopApp(groupId, appId)
+	code, res, err := executor.StopApp(context.Background(), groupId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -1056,7 +1031,7 @@ func TestCalledStopAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *testin
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -1073,14 +1048,15 @@ func TestCalledDeleteApp_ExpectSuccess(t *testing.T) {
 	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("DELETE", expectedUrl, nil).Return(respCode, nil),
-		nodeDbExecutorMockObj.EXPECT().DeleteAppFromNode(nodeId, appId).Return(nil),
-		appDbExecutorMockObj.EXPECT().DeleteApp(appId).Return(nil),
-		nodeDbExecutorMockObj.EXPECT().DeleteAppFromNode(nodeId, appId).Return(nil),
-		appDbExecutorMockObj.EXPECT().DeleteApp(appId).Return(nil).AnyTimes(),
-		notiMockObj.EXPECT().UpdateSubscriber(),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "DELETE", expectedUrl, nil).Return(respCode, nil),
+		nodeDbExecutorMockObj.EXPECT().DeleteAppFromNode(gomock.Any(), nodeId, appId).Return(nil),
+		appDbExecutorMockObj.EXPECT().DeleteApp(gomock.Any(), appId).Return(nil),
+		nodeDbExecutorMockObj.EXPECT().DeleteAppFromNode(gomock.Any(), nodeId, appId).Return(nil),
+		appDbExecutorMockObj.EXPECT().DeleteApp(gomock.Any(), appId).Return(nil).AnyTimes(),
+		notiMockObj.EXPECT().ResyncSubscribers(gomock.Any(), []string{nodeId, nodeId}),
 	)
+	notiMockObj.EXPECT().Publish(gomock.Any(), noti.DEPLOYMENT, []string{nodeId}, deploymentEvent(noti.STATUS_DELETED)).Times(2)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	appDbExecutor = appDbExecutorMockObj
@@ -1088,7 +1064,7 @@ func TestCalledDeleteApp_ExpectSuccess(t *testing.T) {
 	httpExecutor = msgMockObj
 	notiExecutor = notiMockObj
 
-	code, _, err := executor.DeleteApp(groupId, appId)
+	code, _, err := executor.DeleteApp(context.Background(), groupId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -1106,12 +1082,12 @@ func TestCalledDeleteAppWhenDBHasNotMatchedGroup_ExpectErrorReturn(t *testing.T)
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(nil, notFoundError),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	code, _, err := executor.DeleteApp(groupId, appId)
+	code, _, err := executor.DeleteApp(context.Background(), groupId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -1139,14 +1115,14 @@ func TestCalledDeleteAppWhenMessengerReturnsInvalidResponse_ExpectErrorReturn(t
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("DELETE", expectedUrl, nil).Return(respCode, invalidRespStr),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "DELETE", expectedUrl, nil).Return(respCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.DeleteApp(groupId, appId)
+	code, _, err := executor.DeleteApp(context.Background(), groupId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -1169,17 +1145,10 @@ func TestCalledDeleteAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *test
 
 	partialSuccessRespStr := []string{`{"message": "successMsg"}`, `{"message":"errorMsg"}`}
 	expectedUrl := []string{baseUrl, baseUrl}
-	expectedRes := map[string]interface{}{
-		"responses": []map[string]interface{}{
-			map[string]interface{}{
-				"id":   nodeId,
-				"code": "200",
-			},
-			map[string]interface{}{
-				"id":      nodeId,
-				"code":    "500",
-				"message": "errorMsg",
-			},
+	expectedRes := models.DeploymentResponse{
+		Responses: []models.NodeResponse{
+			{ID: nodeId, Code: 200},
+			{ID: nodeId, Code: 500, Message: "errorMsg"},
 		},
 	}
 
@@ -1187,20 +1156,25 @@ func TestCalledDeleteAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *test
 	nodeDbExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 	appDbExecutorMockObj := appdbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(groupId, appId).Return(members, nil),
-		msgMockObj.EXPECT().SendHttpRequest("DELETE", expectedUrl, nil).Return(partialSuccessRespCode, partialSuccessRespStr),
-		nodeDbExecutorMockObj.EXPECT().DeleteAppFromNode(nodeId, appId).Return(nil),
-		appDbExecutorMockObj.EXPECT().DeleteApp(appId).Return(nil).AnyTimes(),
+		groupDbExecutorMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "DELETE", expectedUrl, nil).Return(partialSuccessRespCode, partialSuccessRespStr),
+		nodeDbExecutorMockObj.EXPECT().DeleteAppFromNode(gomock.Any(), nodeId, appId).Return(nil),
+		appDbExecutorMockObj.EXPECT().DeleteApp(gomock.Any(), appId).Return(nil).AnyTimes(),
 	)
+	notiMockObj.EXPECT().Publish(gomock.Any(), noti.DEPLOYMENT, []string{nodeId}, deploymentEvent(noti.STATUS_DELETED))
+	// Only the member the app is deleted from is synchronised.
+	notiMockObj.EXPECT().ResyncSubscribers(gomock.Any(), []string{nodeId})
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	appDbExecutor = appDbExecutorMockObj
 	nodeDbExecutor = nodeDbExecutorMockObj
 	httpExecutor = msgMockObj
+	notiExecutor = notiMockObj
 
-	code, res, err := executor.DeleteApp(groupId, appId)
+	code, res, err := executor.DeleteApp(context.Background(), groupId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -1211,6 +1185,6 @@ func TestCalledDeleteAppWhenMessengerReturnsPartialSuccess_ExpectSuccess(t *test
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
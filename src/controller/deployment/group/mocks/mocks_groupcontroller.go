@@ -22,6 +22,8 @@
 package mocks
 
 import (
+	models "commons/models"
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -50,113 +52,113 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // DeployApp mocks base method
-func (m *MockCommand) DeployApp(groupId, body string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "DeployApp", groupId, body)
+func (m *MockCommand) DeployApp(ctx context.Context, groupId string, description models.AppDescription) (int, models.DeploymentResponse, error) {
+	ret := m.ctrl.Call(m, "DeployApp", ctx, groupId, description)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.DeploymentResponse)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // DeployApp indicates an expected call of DeployApp
-func (mr *MockCommandMockRecorder) DeployApp(groupId, body interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployApp", reflect.TypeOf((*MockCommand)(nil).DeployApp), groupId, body)
+func (mr *MockCommandMockRecorder) DeployApp(ctx, groupId, description interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployApp", reflect.TypeOf((*MockCommand)(nil).DeployApp), ctx, groupId, description)
 }
 
 // GetApps mocks base method
-func (m *MockCommand) GetApps(groupId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetApps", groupId)
+func (m *MockCommand) GetApps(ctx context.Context, groupId string) (int, models.GroupAppList, error) {
+	ret := m.ctrl.Call(m, "GetApps", ctx, groupId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.GroupAppList)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetApps indicates an expected call of GetApps
-func (mr *MockCommandMockRecorder) GetApps(groupId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApps", reflect.TypeOf((*MockCommand)(nil).GetApps), groupId)
+func (mr *MockCommandMockRecorder) GetApps(ctx, groupId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApps", reflect.TypeOf((*MockCommand)(nil).GetApps), ctx, groupId)
 }
 
 // GetApp mocks base method
-func (m *MockCommand) GetApp(groupId, appId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetApp", groupId, appId)
+func (m *MockCommand) GetApp(ctx context.Context, groupId, appId string) (int, models.GroupAppInfo, error) {
+	ret := m.ctrl.Call(m, "GetApp", ctx, groupId, appId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.GroupAppInfo)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetApp indicates an expected call of GetApp
-func (mr *MockCommandMockRecorder) GetApp(groupId, appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApp", reflect.TypeOf((*MockCommand)(nil).GetApp), groupId, appId)
+func (mr *MockCommandMockRecorder) GetApp(ctx, groupId, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApp", reflect.TypeOf((*MockCommand)(nil).GetApp), ctx, groupId, appId)
 }
 
 // UpdateAppInfo mocks base method
-func (m *MockCommand) UpdateAppInfo(groupId, appId, body string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "UpdateAppInfo", groupId, appId, body)
+func (m *MockCommand) UpdateAppInfo(ctx context.Context, groupId, appId string, description models.AppDescription) (int, models.DeploymentResponse, error) {
+	ret := m.ctrl.Call(m, "UpdateAppInfo", ctx, groupId, appId, description)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.DeploymentResponse)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // UpdateAppInfo indicates an expected call of UpdateAppInfo
-func (mr *MockCommandMockRecorder) UpdateAppInfo(groupId, appId, body interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAppInfo", reflect.TypeOf((*MockCommand)(nil).UpdateAppInfo), groupId, appId, body)
+func (mr *MockCommandMockRecorder) UpdateAppInfo(ctx, groupId, appId, description interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAppInfo", reflect.TypeOf((*MockCommand)(nil).UpdateAppInfo), ctx, groupId, appId, description)
 }
 
 // DeleteApp mocks base method
-func (m *MockCommand) DeleteApp(groupId, appId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "DeleteApp", groupId, appId)
+func (m *MockCommand) DeleteApp(ctx context.Context, groupId, appId string) (int, models.DeploymentResponse, error) {
+	ret := m.ctrl.Call(m, "DeleteApp", ctx, groupId, appId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.DeploymentResponse)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // DeleteApp indicates an expected call of DeleteApp
-func (mr *MockCommandMockRecorder) DeleteApp(groupId, appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApp", reflect.TypeOf((*MockCommand)(nil).DeleteApp), groupId, appId)
+func (mr *MockCommandMockRecorder) DeleteApp(ctx, groupId, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApp", reflect.TypeOf((*MockCommand)(nil).DeleteApp), ctx, groupId, appId)
 }
 
 // UpdateApp mocks base method
-func (m *MockCommand) UpdateApp(groupId, appId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "UpdateApp", groupId, appId)
+func (m *MockCommand) UpdateApp(ctx context.Context, groupId, appId string) (int, models.DeploymentResponse, error) {
+	ret := m.ctrl.Call(m, "UpdateApp", ctx, groupId, appId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.DeploymentResponse)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // UpdateApp indicates an expected call of UpdateApp
-func (mr *MockCommandMockRecorder) UpdateApp(groupId, appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApp", reflect.TypeOf((*MockCommand)(nil).UpdateApp), groupId, appId)
+func (mr *MockCommandMockRecorder) UpdateApp(ctx, groupId, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApp", reflect.TypeOf((*MockCommand)(nil).UpdateApp), ctx, groupId, appId)
 }
 
 // StartApp mocks base method
-func (m *MockCommand) StartApp(groupId, appId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "StartApp", groupId, appId)
+func (m *MockCommand) StartApp(ctx context.Context, groupId, appId string) (int, models.DeploymentResponse, error) {
+	ret := m.ctrl.Call(m, "StartApp", ctx, groupId, appId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.DeploymentResponse)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // StartApp indicates an expected call of StartApp
-func (mr *MockCommandMockRecorder) StartApp(groupId, appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartApp", reflect.TypeOf((*MockCommand)(nil).StartApp), groupId, appId)
+func (mr *MockCommandMockRecorder) StartApp(ctx, groupId, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartApp", reflect.TypeOf((*MockCommand)(nil).StartApp), ctx, groupId, appId)
 }
 
 // StopApp mocks base method
-func (m *MockCommand) StopApp(groupId, appId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "StopApp", groupId, appId)
+func (m *MockCommand) StopApp(ctx context.Context, groupId, appId string) (int, models.DeploymentResponse, error) {
+	ret := m.ctrl.Call(m, "StopApp", ctx, groupId, appId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.DeploymentResponse)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // StopApp indicates an expected call of StopApp
-func (mr *MockCommandMockRecorder) StopApp(groupId, appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopApp", reflect.TypeOf((*MockCommand)(nil).StopApp), groupId, appId)
+func (mr *MockCommandMockRecorder) StopApp(ctx, groupId, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopApp", reflect.TypeOf((*MockCommand)(nil).StopApp), ctx, groupId, appId)
 }
@@ -21,6 +21,8 @@
 package mock_node
 
 import (
+	models "commons/models"
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -49,113 +51,108 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // DeployApp mocks base method
-func (m *MockCommand) DeployApp(nodeId, body string, query map[string]interface{}) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "DeployApp", nodeId, body, query)
+func (m *MockCommand) DeployApp(ctx context.Context, nodeId string, description models.AppDescription, query map[string]interface{}) (int, models.AppInfo, error) {
+	ret := m.ctrl.Call(m, "DeployApp", ctx, nodeId, description, query)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.AppInfo)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // DeployApp indicates an expected call of DeployApp
-func (mr *MockCommandMockRecorder) DeployApp(nodeId, body, query interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployApp", reflect.TypeOf((*MockCommand)(nil).DeployApp), nodeId, body, query)
+func (mr *MockCommandMockRecorder) DeployApp(ctx, nodeId, description, query interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployApp", reflect.TypeOf((*MockCommand)(nil).DeployApp), ctx, nodeId, description, query)
 }
 
 // GetApps mocks base method
-func (m *MockCommand) GetApps(nodeId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetApps", nodeId)
+func (m *MockCommand) GetApps(ctx context.Context, nodeId string) (int, models.NodeAppList, error) {
+	ret := m.ctrl.Call(m, "GetApps", ctx, nodeId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.NodeAppList)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetApps indicates an expected call of GetApps
-func (mr *MockCommandMockRecorder) GetApps(nodeId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApps", reflect.TypeOf((*MockCommand)(nil).GetApps), nodeId)
+func (mr *MockCommandMockRecorder) GetApps(ctx, nodeId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApps", reflect.TypeOf((*MockCommand)(nil).GetApps), ctx, nodeId)
 }
 
 // GetApp mocks base method
-func (m *MockCommand) GetApp(nodeId, appId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetApp", nodeId, appId)
+func (m *MockCommand) GetApp(ctx context.Context, nodeId, appId string) (int, models.AppInfo, error) {
+	ret := m.ctrl.Call(m, "GetApp", ctx, nodeId, appId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.AppInfo)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetApp indicates an expected call of GetApp
-func (mr *MockCommandMockRecorder) GetApp(nodeId, appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApp", reflect.TypeOf((*MockCommand)(nil).GetApp), nodeId, appId)
+func (mr *MockCommandMockRecorder) GetApp(ctx, nodeId, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApp", reflect.TypeOf((*MockCommand)(nil).GetApp), ctx, nodeId, appId)
 }
 
 // UpdateAppInfo mocks base method
-func (m *MockCommand) UpdateAppInfo(nodeId, appId, body string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "UpdateAppInfo", nodeId, appId, body)
+func (m *MockCommand) UpdateAppInfo(ctx context.Context, nodeId, appId string, description models.AppDescription) (int, error) {
+	ret := m.ctrl.Call(m, "UpdateAppInfo", ctx, nodeId, appId, description)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateAppInfo indicates an expected call of UpdateAppInfo
-func (mr *MockCommandMockRecorder) UpdateAppInfo(nodeId, appId, body interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAppInfo", reflect.TypeOf((*MockCommand)(nil).UpdateAppInfo), nodeId, appId, body)
+func (mr *MockCommandMockRecorder) UpdateAppInfo(ctx, nodeId, appId, description interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAppInfo", reflect.TypeOf((*MockCommand)(nil).UpdateAppInfo), ctx, nodeId, appId, description)
 }
 
 // DeleteApp mocks base method
-func (m *MockCommand) DeleteApp(nodeId, appId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "DeleteApp", nodeId, appId)
+func (m *MockCommand) DeleteApp(ctx context.Context, nodeId, appId string) (int, error) {
+	ret := m.ctrl.Call(m, "DeleteApp", ctx, nodeId, appId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteApp indicates an expected call of DeleteApp
-func (mr *MockCommandMockRecorder) DeleteApp(nodeId, appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApp", reflect.TypeOf((*MockCommand)(nil).DeleteApp), nodeId, appId)
+func (mr *MockCommandMockRecorder) DeleteApp(ctx, nodeId, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApp", reflect.TypeOf((*MockCommand)(nil).DeleteApp), ctx, nodeId, appId)
 }
 
 // UpdateApp mocks base method
-func (m *MockCommand) UpdateApp(nodeId, appId string, query map[string]interface{}) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "UpdateApp", nodeId, appId, query)
+func (m *MockCommand) UpdateApp(ctx context.Context, nodeId, appId string, query map[string]interface{}) (int, error) {
+	ret := m.ctrl.Call(m, "UpdateApp", ctx, nodeId, appId, query)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateApp indicates an expected call of UpdateApp
-func (mr *MockCommandMockRecorder) UpdateApp(nodeId, appId, query interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApp", reflect.TypeOf((*MockCommand)(nil).UpdateApp), nodeId, appId, query)
+func (mr *MockCommandMockRecorder) UpdateApp(ctx, nodeId, appId, query interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApp", reflect.TypeOf((*MockCommand)(nil).UpdateApp), ctx, nodeId, appId, query)
 }
 
 // StartApp mocks base method
-func (m *MockCommand) StartApp(nodeId, appId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "StartApp", nodeId, appId)
+func (m *MockCommand) StartApp(ctx context.Context, nodeId, appId string) (int, error) {
+	ret := m.ctrl.Call(m, "StartApp", ctx, nodeId, appId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // StartApp indicates an expected call of StartApp
-func (mr *MockCommandMockRecorder) StartApp(nodeId, appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartApp", reflect.TypeOf((*MockCommand)(nil).StartApp), nodeId, appId)
+func (mr *MockCommandMockRecorder) StartApp(ctx, nodeId, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartApp", reflect.TypeOf((*MockCommand)(nil).StartApp), ctx, nodeId, appId)
 }
 
 // StopApp mocks base method
-func (m *MockCommand) StopApp(nodeId, appId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "StopApp", nodeId, appId)
+func (m *MockCommand) StopApp(ctx context.Context, nodeId, appId string) (int, error) {
+	ret := m.ctrl.Call(m, "StopApp", ctx, nodeId, appId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // StopApp indicates an expected call of StopApp
-func (mr *MockCommandMockRecorder) StopApp(nodeId, appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopApp", reflect.TypeOf((*MockCommand)(nil).StopApp), nodeId, appId)
+func (mr *MockCommandMockRecorder) StopApp(ctx, nodeId, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopApp", reflect.TypeOf((*MockCommand)(nil).StopApp), ctx, nodeId, appId)
 }
@@ -22,9 +22,11 @@ package node
 import (
 	"commons/errors"
 	"commons/logger"
+	"commons/models"
 	"commons/results"
 	"commons/url"
 	"commons/util"
+	"context"
 	noti "controller/notification"
 	appDB "db/mongo/app"
 	appEventDB "db/mongo/event/app"
@@ -69,44 +71,44 @@ func init() {
 type Command interface {
 	// DeployApp request an deployment of edge services to an node specified by
 	// nodeId parameter.
-	DeployApp(nodeId string, body string, query map[string]interface{}) (int, map[string]interface{}, error)
+	DeployApp(ctx context.Context, nodeId string, description models.AppDescription, query map[string]interface{}) (int, models.AppInfo, error)
 
 	// GetApps request a list of applications that is deployed to an node specified
 	// by nodeId parameter.
-	GetApps(nodeId string) (int, map[string]interface{}, error)
+	GetApps(ctx context.Context, nodeId string) (int, models.NodeAppList, error)
 
 	// GetApp gets the application's information of the node specified by nodeId parameter.
-	GetApp(nodeId string, appId string) (int, map[string]interface{}, error)
+	GetApp(ctx context.Context, nodeId string, appId string) (int, models.AppInfo, error)
 
 	// UpdateApp request to update an application specified by appId parameter.
-	UpdateAppInfo(nodeId string, appId string, body string) (int, map[string]interface{}, error)
+	UpdateAppInfo(ctx context.Context, nodeId string, appId string, description models.AppDescription) (int, error)
 
 	// DeleteApp request to delete an application specified by appId parameter.
-	DeleteApp(nodeId string, appId string) (int, map[string]interface{}, error)
+	DeleteApp(ctx context.Context, nodeId string, appId string) (int, error)
 
 	// UpdateAppInfo request to update all of images which is included an application
 	// specified by appId parameter.
-	UpdateApp(nodeId string, appId string, query map[string]interface{}) (int, map[string]interface{}, error)
+	UpdateApp(ctx context.Context, nodeId string, appId string, query map[string]interface{}) (int, error)
 
 	// StartApp request to start an application specified by appId parameter.
-	StartApp(nodeId string, appId string) (int, map[string]interface{}, error)
+	StartApp(ctx context.Context, nodeId string, appId string) (int, error)
 
 	// StopApp request to stop an application specified by appId parameter.
-	StopApp(nodeId string, appId string) (int, map[string]interface{}, error)
+	StopApp(ctx context.Context, nodeId string, appId string) (int, error)
 }
 
 // DeployApp request an deployment of edge services to an node specified by nodeId parameter.
 // If response code represents success, add an app id to a list of installed app and returns it.
 // Otherwise, an appropriate error will be returned.
-func (Executor) DeployApp(nodeId string, body string, query map[string]interface{}) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) DeployApp(ctx context.Context, nodeId string, description models.AppDescription, query map[string]interface{}) (int, models.AppInfo, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get node specified by nodeId parameter.
-	node, err := nodeDbExecutor.GetNode(nodeId)
+	node, err := nodeDbExecutor.GetNode(ctx, nodeId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.AppInfo{}, err
 	}
 
 	address := getNodeAddress(node)
@@ -118,302 +120,300 @@ func (Executor) DeployApp(nodeId string, body string, query map[string]interface
 		eventId := generateRandStringBytes(39)
 		subsId := generateRandStringBytes(39)
 
-		err = subsDbExecutor.AddSubscriber(subsId, APP, eventUrl.([]string)[0],
-			[]string{PULLED, CREATED, STARTED}, []string{eventId}, make(map[string][]string))
+		err = subsDbExecutor.AddSubscriber(ctx, subsId, APP, eventUrl.([]string)[0], "", "",
+			[]string{PULLED, CREATED, STARTED}, []string{eventId}, make(map[string][]string), 0)
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
-			return results.ERROR, nil, err
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return results.ERROR, models.AppInfo{}, err
 		}
-		err = appEventDbExecutor.AddEvent(eventId, subsId, []string{nodeId})
+		err = appEventDbExecutor.AddEvent(ctx, eventId, subsId, []string{nodeId})
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
-			subsDbExecutor.DeleteSubscriber(subsId)
-			return results.ERROR, nil, err
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			subsDbExecutor.DeleteSubscriber(ctx, subsId)
+			return results.ERROR, models.AppInfo{}, err
 		}
 
 		eventIDQuery := make(map[string]interface{})
 		eventIDQuery[EVENTID] = []string{eventId}
 
 		// Request an deployment of edge services to a specific node.
-		codes, respStr = httpExecutor.SendHttpRequest("POST", urls, eventIDQuery, []byte(body))
+		codes, respStr = httpExecutor.SendHttpRequest(ctx, "POST", urls, eventIDQuery, []byte(description))
 
-		err = subsDbExecutor.DeleteSubscriber(subsId)
+		err = subsDbExecutor.DeleteSubscriber(ctx, subsId)
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
-			return results.ERROR, nil, err
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return results.ERROR, models.AppInfo{}, err
 		}
-		err = appEventDbExecutor.DeleteEvent(eventId)
+		err = appEventDbExecutor.DeleteEvent(ctx, eventId)
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
-			return results.ERROR, nil, err
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return results.ERROR, models.AppInfo{}, err
 		}
 	} else {
 		// Request an deployment of edge services to a specific node.
-		codes, respStr = httpExecutor.SendHttpRequest("POST", urls, nil, []byte(body))
-	}
-
-	// Convert the received response from string to map.
-	respMap, err := convertRespToMap(respStr)
-	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		codes, respStr = httpExecutor.SendHttpRequest(ctx, "POST", urls, nil, []byte(description))
 	}
 
 	// if response code represents success, insert the installed appId into nodeDbExecutor.
+	resp := models.AppInfo{}
 	result := codes[0]
 	if util.IsSuccessCode(result) {
-		err = appDbExecutor.AddApp(respMap["id"].(string), []byte(respMap["description"].(string)))
+		err = convertResp(result, respStr, &resp)
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
-			return results.ERROR, nil, err
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return results.ERROR, models.AppInfo{}, err
 		}
-		err = nodeDbExecutor.AddAppToNode(nodeId, respMap["id"].(string))
+		err = appDbExecutor.AddApp(ctx, resp.ID, []byte(resp.Description))
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
-			return results.ERROR, nil, err
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return results.ERROR, models.AppInfo{}, err
+		}
+		err = nodeDbExecutor.AddAppToNode(ctx, nodeId, resp.ID)
+		if err != nil {
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return results.ERROR, models.AppInfo{}, err
 		}
 	}
 
-	notiExecutor.UpdateSubscriber()
+	notiExecutor.ResyncSubscribers(ctx, []string{nodeId})
+	if !util.IsSuccessCode(result) {
+		err = util.ConvertRespToError(result, respStr[0])
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.AppInfo{}, err
+	}
+	noti.PublishDeployment(ctx, notiExecutor, nodeId, resp.ID, noti.STATUS_DEPLOYED)
 
-	return result, respMap, err
+	return result, resp, err
 }
 
 // GetApps request a list of applications that is deployed to an node
 // specified by nodeId parameter.
 // If response code represents success, returns a list of applications.
 // Otherwise, an appropriate error will be returned.
-func (Executor) GetApps(nodeId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetApps(ctx context.Context, nodeId string) (int, models.NodeAppList, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get node specified by nodeId parameter.
-	node, err := nodeDbExecutor.GetNode(nodeId)
+	node, err := nodeDbExecutor.GetNode(ctx, nodeId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.NodeAppList{}, err
 	}
 
 	address := getNodeAddress(node)
 	urls := util.MakeRequestUrl(address, url.Management(), url.Apps())
 
 	// Request list of applications that is deployed to node.
-	codes, respStr := httpExecutor.SendHttpRequest("GET", urls, nil)
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "GET", urls, nil)
 
-	// Convert the received response from string to map.
-	result := codes[0]
-	respMap, err := convertRespToMap(respStr)
+	// Convert the received response into a list of applications.
+	resp := models.NodeAppList{}
+	err = convertResp(codes[0], respStr, &resp)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.NodeAppList{}, err
 	}
 
-	return result, respMap, err
+	return codes[0], resp, err
 }
 
 // GetApp gets the application's information of the node specified by nodeId parameter.
 // If response code represents success, returns information of application.
 // Otherwise, an appropriate error will be returned.
-func (Executor) GetApp(nodeId string, appId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetApp(ctx context.Context, nodeId string, appId string) (int, models.AppInfo, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get node including app specified by appId parameter.
-	node, err := nodeDbExecutor.GetNodeByAppID(nodeId, appId)
+	node, err := nodeDbExecutor.GetNodeByAppID(ctx, nodeId, appId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.AppInfo{}, err
 	}
 
 	address := getNodeAddress(node)
 	urls := util.MakeRequestUrl(address, url.Management(), url.Apps(), "/", appId)
 
 	// Request get target application's information
-	codes, respStr := httpExecutor.SendHttpRequest("GET", urls, nil)
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "GET", urls, nil)
 
-	// Convert the received response from string to map.
-	result := codes[0]
-	respMap, err := convertRespToMap(respStr)
+	// Convert the received response into an application's information.
+	resp := models.AppInfo{}
+	err = convertResp(codes[0], respStr, &resp)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.AppInfo{}, err
 	}
 
-	return result, respMap, err
+	return codes[0], resp, err
 }
 
 // UpdateApp request to update an application specified by appId parameter.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) UpdateAppInfo(nodeId string, appId string, body string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) UpdateAppInfo(ctx context.Context, nodeId string, appId string, description models.AppDescription) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get node including app specified by appId parameter.
-	node, err := nodeDbExecutor.GetNodeByAppID(nodeId, appId)
+	node, err := nodeDbExecutor.GetNodeByAppID(ctx, nodeId, appId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
 	}
 
 	address := getNodeAddress(node)
 	urls := util.MakeRequestUrl(address, url.Management(), url.Apps(), "/", appId)
 
 	// Request update target application's information.
-	codes, respStr := httpExecutor.SendHttpRequest("POST", urls, nil, []byte(body))
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "POST", urls, nil, []byte(description))
 
-	// Convert the received response from string to map.
-	result := codes[0]
-	respMap, err := convertRespToMap(respStr)
+	err = convertResp(codes[0], respStr, nil)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
 	}
 
-	return result, respMap, err
+	noti.PublishDeployment(ctx, notiExecutor, nodeId, appId, noti.STATUS_UPDATED)
+
+	return codes[0], err
 }
 
 // DeleteApp request to delete an application specified by appId parameter.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) DeleteApp(nodeId string, appId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) DeleteApp(ctx context.Context, nodeId string, appId string) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get node including app specified by appId parameter.
-	node, err := nodeDbExecutor.GetNodeByAppID(nodeId, appId)
+	node, err := nodeDbExecutor.GetNodeByAppID(ctx, nodeId, appId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
 	}
 
 	address := getNodeAddress(node)
 	urls := util.MakeRequestUrl(address, url.Management(), url.Apps(), "/", appId)
 
 	// Request delete target application
-	codes, respStr := httpExecutor.SendHttpRequest("DELETE", urls, nil)
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "DELETE", urls, nil)
 
-	// Convert the received response from string to map.
 	result := codes[0]
 	if !util.IsSuccessCode(result) {
-		respMap, err := convertRespToMap(respStr)
-		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
-			return results.ERROR, nil, err
-		}
-		return result, respMap, err
+		err = util.ConvertRespToError(result, respStr[0])
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
 	}
 
 	// if response code represents success, delete the appId from nodeDbExecutor.
-	err = nodeDbExecutor.DeleteAppFromNode(nodeId, appId)
+	err = nodeDbExecutor.DeleteAppFromNode(ctx, nodeId, appId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
 	}
 
-	err = appDbExecutor.DeleteApp(appId)
+	err = appDbExecutor.DeleteApp(ctx, appId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
 	}
 
-	notiExecutor.UpdateSubscriber()
-	
-	return result, nil, err
+	notiExecutor.ResyncSubscribers(ctx, []string{nodeId})
+	noti.PublishDeployment(ctx, notiExecutor, nodeId, appId, noti.STATUS_DELETED)
+
+	return result, err
 }
 
 // UpdateAppInfo request to update all of images which is included an application
 // specified by appId parameter.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) UpdateApp(nodeId string, appId string, query map[string]interface{}) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) UpdateApp(ctx context.Context, nodeId string, appId string, query map[string]interface{}) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get node including app specified by appId parameter.
-	node, err := nodeDbExecutor.GetNodeByAppID(nodeId, appId)
+	node, err := nodeDbExecutor.GetNodeByAppID(ctx, nodeId, appId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
 	}
 
 	address := getNodeAddress(node)
 	urls := util.MakeRequestUrl(address, url.Management(), url.Apps(), "/", appId, url.Update())
 
 	// Request checking and updating all of images which is included target.
-	codes, respStr := httpExecutor.SendHttpRequest("POST", urls, query)
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "POST", urls, query)
 
-	// Convert the received response from string to map.
-	result := codes[0]
-	respMap, err := convertRespToMap(respStr)
+	err = convertResp(codes[0], respStr, nil)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
 	}
 
-	return result, respMap, err
+	noti.PublishDeployment(ctx, notiExecutor, nodeId, appId, noti.STATUS_UPDATED)
+
+	return codes[0], err
 }
 
 // StartApp request to start an application specified by appId parameter.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) StartApp(nodeId string, appId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) StartApp(ctx context.Context, nodeId string, appId string) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get node including app specified by appId parameter.
-	node, err := nodeDbExecutor.GetNodeByAppID(nodeId, appId)
+	node, err := nodeDbExecutor.GetNodeByAppID(ctx, nodeId, appId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
 	}
 
 	address := getNodeAddress(node)
 	urls := util.MakeRequestUrl(address, url.Management(), url.Apps(), "/", appId, url.Start())
 
 	// Request start target application.
-	codes, respStr := httpExecutor.SendHttpRequest("POST", urls, nil)
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "POST", urls, nil)
 
-	// Convert the received response from string to map.
-	result := codes[0]
-	respMap, err := convertRespToMap(respStr)
+	err = convertResp(codes[0], respStr, nil)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
 	}
 
-	return result, respMap, err
+	return codes[0], err
 }
 
 // StopApp request to stop an application specified by appId parameter.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) StopApp(nodeId string, appId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) StopApp(ctx context.Context, nodeId string, appId string) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get node including app specified by appId parameter.
-	node, err := nodeDbExecutor.GetNodeByAppID(nodeId, appId)
+	node, err := nodeDbExecutor.GetNodeByAppID(ctx, nodeId, appId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
 	}
 
 	address := getNodeAddress(node)
 	urls := util.MakeRequestUrl(address, url.Management(), url.Apps(), "/", appId, url.Stop())
 
 	// Request stop target application.
-	codes, respStr := httpExecutor.SendHttpRequest("POST", urls, nil)
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "POST", urls, nil)
 
-	// Convert the received response from string to map.
-	result := codes[0]
-	respMap, err := convertRespToMap(respStr)
+	err = convertResp(codes[0], respStr, nil)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
 	}
 
-	return result, respMap, err
+	return codes[0], err
 }
 
 // getNodeAddress returns an address as an array.
@@ -426,16 +426,23 @@ func getNodeAddress(node map[string]interface{}) []map[string]interface{} {
 	return result
 }
 
-// convertRespToMap converts a response in the form of JSON data into a map.
-// If successful, this function returns an error as nil.
-// otherwise, an appropriate error will be returned.
-func convertRespToMap(respStr []string) (map[string]interface{}, error) {
-	resp, err := util.ConvertJsonToMap(respStr[0])
+// convertResp converts a response of a node in the form of JSON data into v.
+// v can be nil when the response has nothing to be returned.
+// If the node fails the request, an error of the node will be returned.
+func convertResp(code int, respStr []string, v interface{}) error {
+	if !util.IsSuccessCode(code) {
+		return util.ConvertRespToError(code, respStr[0])
+	}
+
+	if v == nil {
+		v = &map[string]interface{}{}
+	}
+	err := models.Decode(respStr[0], v)
 	if err != nil {
-		logger.Logging(logger.ERROR, "Failed to convert response from string to map")
-		return nil, errors.InternalServerError{"Json Converting Failed"}
+		logger.Logging(logger.ERROR, "Failed to convert response from string to model")
+		return errors.InternalServerError{"Json Converting Failed"}
 	}
-	return resp, err
+	return nil
 }
 
 func generateRandStringBytes(n int) string {
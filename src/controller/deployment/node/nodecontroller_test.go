@@ -18,7 +18,10 @@ package node
 
 import (
 	"commons/errors"
+	"commons/models"
 	"commons/results"
+	"context"
+	noti "controller/notification"
 	notificationmocks "controller/notification/mocks"
 	appdbmocks "db/mongo/app/mocks"
 	appeventdbmocks "db/mongo/event/app/mocks"
@@ -86,9 +89,9 @@ func TestCalledDeployAppWithEventQuery_ExpectSuccess(t *testing.T) {
 	}
 	respStr := []string{`{"id":"000000000000000000000000", "description":"description"}`}
 	expectedUrl := []string{"http://" + ip + ":" + port + "/api/v1/management/apps/deploy"}
-	expectedRes := map[string]interface{}{
-		"id":          "000000000000000000000000",
-		"description": "description",
+	expectedRes := models.AppInfo{
+		ID:          appId,
+		Description: "description",
 	}
 
 	subsDbMockObj := subsdbmocks.NewMockCommand(ctrl)
@@ -99,16 +102,17 @@ func TestCalledDeployAppWithEventQuery_ExpectSuccess(t *testing.T) {
 	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		subsDbMockObj.EXPECT().AddSubscriber(gomock.Any(), APP, testEventUrl[0],
-			[]string{PULLED, CREATED, STARTED}, gomock.Any(), make(map[string][]string)).Return(nil),
-		appEventDbMockObj.EXPECT().AddEvent(gomock.Any(), gomock.Any(), []string{nodeId}).Return(nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, gomock.Any(), []byte(body)).Return(respCode, respStr),
-		subsDbMockObj.EXPECT().DeleteSubscriber(gomock.Any()),
-		appEventDbMockObj.EXPECT().DeleteEvent(gomock.Any()),
-		appDbMockObj.EXPECT().AddApp(appId, []byte("description")).Return(nil),
-		dbExecutorMockObj.EXPECT().AddAppToNode(nodeId, appId).Return(nil),
-		notiMockObj.EXPECT().UpdateSubscriber(),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		subsDbMockObj.EXPECT().AddSubscriber(gomock.Any(), gomock.Any(), APP, testEventUrl[0], "", "",
+			[]string{PULLED, CREATED, STARTED}, gomock.Any(), make(map[string][]string), int64(0)).Return(nil),
+		appEventDbMockObj.EXPECT().AddEvent(gomock.Any(), gomock.Any(), gomock.Any(), []string{nodeId}).Return(nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, gomock.Any(), []byte(body)).Return(respCode, respStr),
+		subsDbMockObj.EXPECT().DeleteSubscriber(gomock.Any(), gomock.Any()),
+		appEventDbMockObj.EXPECT().DeleteEvent(gomock.Any(), gomock.Any()),
+		appDbMockObj.EXPECT().AddApp(gomock.Any(), appId, []byte("description")).Return(nil),
+		dbExecutorMockObj.EXPECT().AddAppToNode(gomock.Any(), nodeId, appId).Return(nil),
+		notiMockObj.EXPECT().ResyncSubscribers(gomock.Any(), []string{nodeId}),
+		notiMockObj.EXPECT().Publish(gomock.Any(), noti.DEPLOYMENT, []string{nodeId}, map[string]interface{}{"nodeid": nodeId, "appid": appId, "status": noti.STATUS_DEPLOYED}),
 	)
 	// pass mockObj to a real object.
 	subsDbExecutor = subsDbMockObj
@@ -118,7 +122,7 @@ func TestCalledDeployAppWithEventQuery_ExpectSuccess(t *testing.T) {
 	httpExecutor = msgMockObj
 	notiExecutor = notiMockObj
 
-	code, res, err := executor.DeployApp(nodeId, body, testQuery)
+	code, res, err := executor.DeployApp(context.Background(), nodeId, models.AppDescription(body), testQuery)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -129,7 +133,7 @@ func TestCalledDeployAppWithEventQuery_ExpectSuccess(t *testing.T) {
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -146,15 +150,15 @@ func TestCalledDeployAppWithEventQueryWhenAddSubscriberFailed_ExpectReturnError(
 	dbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		subsDbMockObj.EXPECT().AddSubscriber(gomock.Any(), APP, testEventUrl[0],
-			[]string{PULLED, CREATED, STARTED}, gomock.Any(), make(map[string][]string)).Return(errors.Unknown{}),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		subsDbMockObj.EXPECT().AddSubscriber(gomock.Any(), gomock.Any(), APP, testEventUrl[0], "", "",
+			[]string{PULLED, CREATED, STARTED}, gomock.Any(), make(map[string][]string), int64(0)).Return(errors.Unknown{}),
 	)
 	// pass mockObj to a real object.
 	subsDbExecutor = subsDbMockObj
 	nodeDbExecutor = dbExecutorMockObj
 
-	_, _, err := executor.DeployApp(nodeId, body, testQuery)
+	_, _, err := executor.DeployApp(context.Background(), nodeId, models.AppDescription(body), testQuery)
 
 	switch err.(type) {
 	default:
@@ -177,18 +181,18 @@ func TestCalledDeployAppWithEventQueryWhenAddEventFailed_ExpectReturnError(t *te
 	appEventDbMockObj := appeventdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		subsDbMockObj.EXPECT().AddSubscriber(gomock.Any(), APP, testEventUrl[0],
-			[]string{PULLED, CREATED, STARTED}, gomock.Any(), make(map[string][]string)).Return(nil),
-		appEventDbMockObj.EXPECT().AddEvent(gomock.Any(), gomock.Any(), []string{nodeId}).Return(errors.Unknown{}),
-		subsDbMockObj.EXPECT().DeleteSubscriber(gomock.Any()).Return(nil),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		subsDbMockObj.EXPECT().AddSubscriber(gomock.Any(), gomock.Any(), APP, testEventUrl[0], "", "",
+			[]string{PULLED, CREATED, STARTED}, gomock.Any(), make(map[string][]string), int64(0)).Return(nil),
+		appEventDbMockObj.EXPECT().AddEvent(gomock.Any(), gomock.Any(), gomock.Any(), []string{nodeId}).Return(errors.Unknown{}),
+		subsDbMockObj.EXPECT().DeleteSubscriber(gomock.Any(), gomock.Any()).Return(nil),
 	)
 	// pass mockObj to a real object.
 	subsDbExecutor = subsDbMockObj
 	nodeDbExecutor = dbExecutorMockObj
 	appEventDbExecutor = appEventDbMockObj
 
-	_, _, err := executor.DeployApp(nodeId, body, testQuery)
+	_, _, err := executor.DeployApp(context.Background(), nodeId, models.AppDescription(body), testQuery)
 
 	switch err.(type) {
 	default:
@@ -203,9 +207,9 @@ func TestCalledDeployApp_ExpectSuccess(t *testing.T) {
 
 	respStr := []string{`{"id":"000000000000000000000000", "description":"description"}`}
 	expectedUrl := []string{"http://" + ip + ":" + port + "/api/v1/management/apps/deploy"}
-	expectedRes := map[string]interface{}{
-		"id":          "000000000000000000000000",
-		"description": "description",
+	expectedRes := models.AppInfo{
+		ID:          appId,
+		Description: "description",
 	}
 
 	dbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
@@ -214,11 +218,12 @@ func TestCalledDeployApp_ExpectSuccess(t *testing.T) {
 	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil, []byte(body)).Return(respCode, respStr),
-		appDbMockObj.EXPECT().AddApp(appId, []byte("description")).Return(nil),
-		dbExecutorMockObj.EXPECT().AddAppToNode(nodeId, appId).Return(nil),
-		notiMockObj.EXPECT().UpdateSubscriber(),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil, []byte(body)).Return(respCode, respStr),
+		appDbMockObj.EXPECT().AddApp(gomock.Any(), appId, []byte("description")).Return(nil),
+		dbExecutorMockObj.EXPECT().AddAppToNode(gomock.Any(), nodeId, appId).Return(nil),
+		notiMockObj.EXPECT().ResyncSubscribers(gomock.Any(), []string{nodeId}),
+		notiMockObj.EXPECT().Publish(gomock.Any(), noti.DEPLOYMENT, []string{nodeId}, map[string]interface{}{"nodeid": nodeId, "appid": appId, "status": noti.STATUS_DEPLOYED}),
 	)
 	// pass mockObj to a real object.
 	appDbExecutor = appDbMockObj
@@ -226,7 +231,7 @@ func TestCalledDeployApp_ExpectSuccess(t *testing.T) {
 	httpExecutor = msgMockObj
 	notiExecutor = notiMockObj
 
-	code, res, err := executor.DeployApp(nodeId, body, nil)
+	code, res, err := executor.DeployApp(context.Background(), nodeId, models.AppDescription(body), nil)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -237,7 +242,7 @@ func TestCalledDeployApp_ExpectSuccess(t *testing.T) {
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -248,12 +253,12 @@ func TestCalledDeployAppWhenDBHasNotMatchedNode_ExpectErrorReturn(t *testing.T)
 	dbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(nil, notFoundError),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 
-	code, _, err := executor.DeployApp(nodeId, body, nil)
+	code, _, err := executor.DeployApp(context.Background(), nodeId, models.AppDescription(body), nil)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -280,14 +285,14 @@ func TestCalledDeployAppWhenMessengerReturnsInvalidResponse_ExpectErrorReturn(t
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil, []byte(body)).Return(respCode, invalidRespStr),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil, []byte(body)).Return(respCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.DeployApp(nodeId, body, nil)
+	code, _, err := executor.DeployApp(context.Background(), nodeId, models.AppDescription(body), nil)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -316,17 +321,17 @@ func TestCalledDeployAppWhenFailedToAddAppIdToDB_ExpectErrorReturn(t *testing.T)
 	appDbMockObj := appdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil, []byte(body)).Return(respCode, respStr),
-		appDbMockObj.EXPECT().AddApp(appId, []byte("description")).Return(nil),
-		dbExecutorMockObj.EXPECT().AddAppToNode(nodeId, appId).Return(notFoundError),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil, []byte(body)).Return(respCode, respStr),
+		appDbMockObj.EXPECT().AddApp(gomock.Any(), appId, []byte("description")).Return(nil),
+		dbExecutorMockObj.EXPECT().AddAppToNode(gomock.Any(), nodeId, appId).Return(notFoundError),
 	)
 	// pass mockObj to a real object.
 	appDbExecutor = appDbMockObj
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.DeployApp(nodeId, body, nil)
+	code, _, err := executor.DeployApp(context.Background(), nodeId, models.AppDescription(body), nil)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -347,24 +352,24 @@ func TestCalledGetApps_ExpectSuccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	respStr := []string{`{"description":"description"}`}
+	respStr := []string{`{"apps":[{"id":"000000000000000000000000","state":"running"}]}`}
 	expectedUrl := []string{"http://" + ip + ":" + port + "/api/v1/management/apps"}
-	expectedRes := map[string]interface{}{
-		"description": "description",
+	expectedRes := models.NodeAppList{
+		Apps: []models.AppState{{ID: appId, State: "running"}},
 	}
 
 	dbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("GET", expectedUrl, nil).Return(respCode, respStr),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrl, nil).Return(respCode, respStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, res, err := executor.GetApps(nodeId)
+	code, res, err := executor.GetApps(context.Background(), nodeId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -375,7 +380,7 @@ func TestCalledGetApps_ExpectSuccess(t *testing.T) {
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -389,14 +394,14 @@ func TestCalledGetAppsWhenMessengerReturnsInvalidResponse_ExpectErrorReturn(t *t
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("GET", expectedUrl, nil).Return(respCode, invalidRespStr),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrl, nil).Return(respCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.GetApps(nodeId)
+	code, _, err := executor.GetApps(context.Background(), nodeId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -420,12 +425,12 @@ func TestCalledGetAppsWhenDBHasNotMatchedNode_ExpectErrorReturn(t *testing.T) {
 	dbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(nil, notFoundError),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 
-	code, _, err := executor.GetApps(nodeId)
+	code, _, err := executor.GetApps(context.Background(), nodeId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -446,24 +451,25 @@ func TestCalledGetApp_ExpectSuccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	respStr := []string{`{"description":"description"}`}
+	respStr := []string{`{"id":"000000000000000000000000","state":"running"}`}
 	expectedUrl := []string{"http://" + ip + ":" + port + "/api/v1/management/apps/" + appId}
-	expectedRes := map[string]interface{}{
-		"description": "description",
+	expectedRes := models.AppInfo{
+		ID:    appId,
+		State: "running",
 	}
 
 	dbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("GET", expectedUrl, nil).Return(respCode, respStr),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrl, nil).Return(respCode, respStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, res, err := executor.GetApp(nodeId, appId)
+	code, res, err := executor.GetApp(context.Background(), nodeId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -474,7 +480,7 @@ func TestCalledGetApp_ExpectSuccess(t *testing.T) {
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -488,14 +494,14 @@ func TestCalledGetAppWhenMessengerReturnsInvalidResponse_ExpectErrorReturn(t *te
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("GET", expectedUrl, nil).Return(respCode, invalidRespStr),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrl, nil).Return(respCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.GetApp(nodeId, appId)
+	code, _, err := executor.GetApp(context.Background(), nodeId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -519,12 +525,12 @@ func TestCalledGetAppWhenDBHasNotMatchedNode_ExpectErrorReturn(t *testing.T) {
 	dbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(nil, notFoundError),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 
-	code, _, err := executor.GetApp(nodeId, appId)
+	code, _, err := executor.GetApp(context.Background(), nodeId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -549,16 +555,19 @@ func TestCalledUpdateAppInfo_ExpectSuccess(t *testing.T) {
 
 	dbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil, []byte(body)).Return(respCode, respStr),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil, []byte(body)).Return(respCode, respStr),
+		notiMockObj.EXPECT().Publish(gomock.Any(), noti.DEPLOYMENT, []string{nodeId}, map[string]interface{}{"nodeid": nodeId, "appid": appId, "status": noti.STATUS_UPDATED}),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
+	notiExecutor = notiMockObj
 
-	code, _, err := executor.UpdateAppInfo(nodeId, appId, body)
+	code, err := executor.UpdateAppInfo(context.Background(), nodeId, appId, models.AppDescription(body))
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -577,13 +586,13 @@ func TestCalledUpdateAppInfoWhenDBHasNotMatchedNode_ExpectErrorReturn(t *testing
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(nil, notFoundError),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.UpdateAppInfo(nodeId, appId, body)
+	code, err := executor.UpdateAppInfo(context.Background(), nodeId, appId, models.AppDescription(body))
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -610,14 +619,14 @@ func TestCalledUpdateAppInfoWhenMessengerReturnsInvalidResponse_ExpectErrorRetur
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil, []byte(body)).Return(respCode, invalidRespStr),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil, []byte(body)).Return(respCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.UpdateAppInfo(nodeId, appId, body)
+	code, err := executor.UpdateAppInfo(context.Background(), nodeId, appId, models.AppDescription(body))
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -642,16 +651,19 @@ func TestCalledUpdateApp_ExpectSuccess(t *testing.T) {
 
 	dbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(respCode, respStr),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(respCode, respStr),
+		notiMockObj.EXPECT().Publish(gomock.Any(), noti.DEPLOYMENT, []string{nodeId}, map[string]interface{}{"nodeid": nodeId, "appid": appId, "status": noti.STATUS_UPDATED}),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
+	notiExecutor = notiMockObj
 
-	code, _, err := executor.UpdateApp(nodeId, appId, nil)
+	code, err := executor.UpdateApp(context.Background(), nodeId, appId, nil)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -670,13 +682,13 @@ func TestCalledUpdateAppWhenDBHasNotMatchedNode_ExpectErrorReturn(t *testing.T)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(nil, notFoundError),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.UpdateApp(nodeId, appId, nil)
+	code, err := executor.UpdateApp(context.Background(), nodeId, appId, nil)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -703,14 +715,14 @@ func TestCalledUpdateAppWhenMessengerReturnsInvalidResponse_ExpectErrorReturn(t
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(respCode, invalidRespStr),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(respCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.UpdateApp(nodeId, appId, nil)
+	code, err := executor.UpdateApp(context.Background(), nodeId, appId, nil)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -737,14 +749,14 @@ func TestCalledStartApp_ExpectSuccess(t *testing.T) {
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(respCode, respStr),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(respCode, respStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.StartApp(nodeId, appId)
+	code, err := executor.StartApp(context.Background(), nodeId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -762,12 +774,12 @@ func TestCalledStartAppWhenDBHasNotMatchedNode_ExpectErrorReturn(t *testing.T) {
 	dbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(nil, notFoundError),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 
-	code, _, err := executor.StartApp(nodeId, appId)
+	code, err := executor.StartApp(context.Background(), nodeId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -794,14 +806,14 @@ func TestCalledStartAppWhenMessengerReturnsInvalidResponse_ExpectErrorReturn(t *
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(respCode, invalidRespStr),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(respCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.StartApp(nodeId, appId)
+	code, err := executor.StartApp(context.Background(), nodeId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -828,14 +840,14 @@ func TestCalledStopApp_ExpectSuccess(t *testing.T) {
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(respCode, respStr),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(respCode, respStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.StopApp(nodeId, appId)
+	code, err := executor.StopApp(context.Background(), nodeId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -854,13 +866,13 @@ func TestCalledStopAppWhenDBHasNotMatchedNode_ExpectErrorReturn(t *testing.T) {
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(nil, notFoundError),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.StopApp(nodeId, appId)
+	code, err := executor.StopApp(context.Background(), nodeId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -887,14 +899,14 @@ func TestCalledStopAppWhenMessengerReturnsInvalidResponse_ExpectErrorReturn(t *t
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(respCode, invalidRespStr),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(respCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.StopApp(nodeId, appId)
+	code, err := executor.StopApp(context.Background(), nodeId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -923,11 +935,12 @@ func TestCalledDeleteApp_ExpectSuccess(t *testing.T) {
 	notiMockObj := notificationmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("DELETE", expectedUrl, nil).Return(respCode, respStr),
-		dbExecutorMockObj.EXPECT().DeleteAppFromNode(nodeId, appId).Return(nil),
-		appDbMockObj.EXPECT().DeleteApp(appId).Return(nil),
-		notiMockObj.EXPECT().UpdateSubscriber(),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "DELETE", expectedUrl, nil).Return(respCode, respStr),
+		dbExecutorMockObj.EXPECT().DeleteAppFromNode(gomock.Any(), nodeId, appId).Return(nil),
+		appDbMockObj.EXPECT().DeleteApp(gomock.Any(), appId).Return(nil),
+		notiMockObj.EXPECT().ResyncSubscribers(gomock.Any(), []string{nodeId}),
+		notiMockObj.EXPECT().Publish(gomock.Any(), noti.DEPLOYMENT, []string{nodeId}, map[string]interface{}{"nodeid": nodeId, "appid": appId, "status": noti.STATUS_DELETED}),
 	)
 	// pass mockObj to a real object.
 	appDbExecutor = appDbMockObj
@@ -935,7 +948,7 @@ func TestCalledDeleteApp_ExpectSuccess(t *testing.T) {
 	httpExecutor = msgMockObj
 	notiExecutor = notiMockObj
 
-	code, _, err := executor.DeleteApp(nodeId, appId)
+	code, err := executor.DeleteApp(context.Background(), nodeId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -954,13 +967,13 @@ func TestCalledDeleteAppWhenDBHasNotMatchedNode_ExpectErrorReturn(t *testing.T)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(nil, notFoundError),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.DeleteApp(nodeId, appId)
+	code, err := executor.DeleteApp(context.Background(), nodeId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -977,35 +990,37 @@ func TestCalledDeleteAppWhenDBHasNotMatchedNode_ExpectErrorReturn(t *testing.T)
 	}
 }
 
-func TestCalledDeleteAppWhenMessengerReturnsErrorCode_ExpectSuccess(t *testing.T) {
+func TestCalledDeleteAppWhenMessengerReturnsErrorCode_ExpectNodeErrorReturn(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	expectedUrl := []string{"http://" + ip + ":" + port + "/api/v1/management/apps/" + appId}
+	errorRespStr := []string{`{"message":"failed to delete"}`}
 
 	dbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("DELETE", expectedUrl, nil).Return(errorRespCode, respStr),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "DELETE", expectedUrl, nil).Return(errorRespCode, errorRespStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.DeleteApp(nodeId, appId)
-
-	if err != nil {
-		t.Errorf("Unexpected err: %s", err.Error())
-	}
+	code, err := executor.DeleteApp(context.Background(), nodeId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
 	}
+
+	expectedErr := errors.NodeError{results.ERROR, "failed to delete"}
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf("Expected err: %v, actual err: %v", expectedErr, err)
+	}
 }
 
-func TestCalledDeleteAppWhenMessengerReturnsErrorCodeWithInvalidResponse_ExpectSuccess(t *testing.T) {
+func TestCalledDeleteAppWhenMessengerReturnsErrorCodeWithInvalidResponse_ExpectNodeErrorReturn(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -1015,27 +1030,23 @@ func TestCalledDeleteAppWhenMessengerReturnsErrorCodeWithInvalidResponse_ExpectS
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("DELETE", expectedUrl, nil).Return(errorRespCode, invalidRespStr),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "DELETE", expectedUrl, nil).Return(errorRespCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.DeleteApp(nodeId, appId)
+	code, err := executor.DeleteApp(context.Background(), nodeId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
 	}
 
-	if err == nil {
-		t.Errorf("Expected err: %s, actual err: %s", "InternalServerError", "nil")
-	}
-
 	switch err.(type) {
 	default:
-		t.Errorf("Expected err: %s, actual err: %s", "InternalServerError", err.Error())
-	case errors.InternalServerError:
+		t.Errorf("Expected err: %s, actual err: %v", "NodeError", err)
+	case errors.NodeError:
 	}
 }
 
@@ -1049,15 +1060,15 @@ func TestCalledDeleteAppWhenFailedToDeleteAppIdFromDB_ExpectErrorReturn(t *testi
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("DELETE", expectedUrl, nil).Return(respCode, nil),
-		dbExecutorMockObj.EXPECT().DeleteAppFromNode(nodeId, appId).Return(notFoundError),
+		dbExecutorMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "DELETE", expectedUrl, nil).Return(respCode, nil),
+		dbExecutorMockObj.EXPECT().DeleteAppFromNode(gomock.Any(), nodeId, appId).Return(notFoundError),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, _, err := executor.DeleteApp(nodeId, appId)
+	code, err := executor.DeleteApp(context.Background(), nodeId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -1,5 +1,5 @@
 /*******************************************************************************
- * Copyright 2018 Samsung Electronics All Rights Reserved.
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -21,17 +21,125 @@ package health
 import (
 	"commons/logger"
 	"commons/results"
+	"commons/workers"
+	"context"
+	nodemanager "controller/management/node"
+	healthDB "db/mongo/health"
+	"runtime"
+	"strconv"
 )
 
 type Command interface {
-	Ping() (int, error)
+	// Ping checks whether pharos-anchor is alive.
+	Ping(ctx context.Context) (int, error)
+
+	// Ready checks whether pharos-anchor is ready to serve requests.
+	Ready(ctx context.Context) (int, map[string]interface{}, error)
 }
 
+const (
+	STATUS          = "status"
+	STATUS_UP       = "up"
+	STATUS_DOWN     = "down"
+	STATUS_DEGRADED = "degraded" // some targets of a component fail, which does not make it unready.
+	VERSION         = "version"
+	GO_VERSION      = "goVersion"
+	COMPONENTS      = "components"
+	MESSAGE         = "message"
+	MONGODB         = "mongodb"
+	HEALTHCHECK     = "healthcheck"
+	RUNNING         = "running"
+	EXPIRED         = "expired"
+	IN_PROGRESS     = "inProgress"
+	LAST_START      = "lastStart"
+	LAST_BEAT       = "lastBeat"
+	INTERVAL        = "interval"
+)
+
+// Version of pharos-anchor, which is set at build time by
+// -ldflags "-X controller/health.Version=<version>".
+var Version = "dev"
+
 type Executor struct{}
 
-func (Executor) Ping() (int, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+var dbExecutor healthDB.Command
+var workerStatuses func() []workers.Status
+var countHealthcheckTimers func() (int, int)
+
+func init() {
+	dbExecutor = healthDB.Executor{}
+	workerStatuses = workers.Statuses
+	countHealthcheckTimers = nodemanager.CountHealthcheckTimers
+}
+
+func (Executor) Ping(ctx context.Context) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	return results.OK, nil
 }
+
+// Ready checks connectivity to MongoDB and whether background workers
+// keep running, and reports the status of each component with versions.
+// A worker is up while an iteration is in progress, so long iterations of
+// e.g. the drift detector do not make pharos-anchor unready.
+// If any component is down, results.UNAVAILABLE is returned with the report.
+func (Executor) Ready(ctx context.Context) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	components := make(map[string]interface{})
+	ready := true
+
+	db := map[string]interface{}{STATUS: STATUS_UP}
+	version, err := dbExecutor.GetVersion(ctx)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		db[STATUS], db[MESSAGE] = STATUS_DOWN, err.Error()
+		ready = false
+	} else {
+		db[VERSION] = version
+	}
+	components[MONGODB] = db
+
+	// Nodes which timed out are reported, but they do not make pharos-anchor unready.
+	running, expired := countHealthcheckTimers()
+	healthcheck := map[string]interface{}{
+		STATUS:  STATUS_UP,
+		RUNNING: running,
+		EXPIRED: expired,
+	}
+	if expired != 0 {
+		healthcheck[STATUS] = STATUS_DEGRADED
+		healthcheck[MESSAGE] = strconv.Itoa(expired) + " node(s) missed healthcheck messages"
+	}
+	components[HEALTHCHECK] = healthcheck
+
+	for _, worker := range workerStatuses() {
+		status := STATUS_UP
+		if !worker.Healthy {
+			status = STATUS_DOWN
+			ready = false
+		}
+		components[worker.Name] = map[string]interface{}{
+			STATUS:      status,
+			RUNNING:     worker.Running,
+			IN_PROGRESS: worker.InProgress,
+			LAST_START:  worker.LastStart,
+			LAST_BEAT:   worker.LastBeat,
+			INTERVAL:    worker.Interval,
+		}
+	}
+
+	res := map[string]interface{}{
+		STATUS:     STATUS_UP,
+		VERSION:    Version,
+		GO_VERSION: runtime.Version(),
+		COMPONENTS: components,
+	}
+	if !ready {
+		res[STATUS] = STATUS_DOWN
+		return results.UNAVAILABLE, res, nil
+	}
+	return results.OK, res, nil
+}
@@ -0,0 +1,123 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package health
+
+import (
+	"commons/errors"
+	"commons/results"
+	"commons/workers"
+	"context"
+	healthdbmocks "db/mongo/health/mocks"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+)
+
+func setUpWorkers(statuses ...workers.Status) {
+	workerStatuses = func() []workers.Status { return statuses }
+	countHealthcheckTimers = func() (int, int) { return 2, 1 }
+}
+
+func TestCalledReady_ExpectComponentsReported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbExecutorMockObj := healthdbmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		dbExecutorMockObj.EXPECT().GetVersion(gomock.Any()).Return("3.4.4", nil),
+	)
+
+	// pass mockObj to a real object.
+	dbExecutor = dbExecutorMockObj
+	setUpWorkers(workers.Status{Name: "collector", Running: true, Healthy: true, LastBeat: time.Now()})
+
+	code, res, err := Executor{}.Ready(context.Background())
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+	if code != results.OK {
+		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
+	}
+	if res[STATUS] != STATUS_UP {
+		t.Errorf("Expected status: %s, actual status: %v", STATUS_UP, res[STATUS])
+	}
+
+	components := res[COMPONENTS].(map[string]interface{})
+	db := components[MONGODB].(map[string]interface{})
+	if db[STATUS] != STATUS_UP || db[VERSION] != "3.4.4" {
+		t.Errorf("Unexpected mongodb component: %v", db)
+	}
+	healthcheck := components[HEALTHCHECK].(map[string]interface{})
+	if healthcheck[STATUS] != STATUS_DEGRADED || healthcheck[RUNNING] != 2 || healthcheck[EXPIRED] != 1 {
+		t.Errorf("Unexpected healthcheck component: %v", healthcheck)
+	}
+	if _, exists := components["collector"]; !exists {
+		t.Error("Expected collector component")
+	}
+}
+
+func TestCalledReadyWhenDBConnectionFailed_ExpectUnavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbExecutorMockObj := healthdbmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		dbExecutorMockObj.EXPECT().GetVersion(gomock.Any()).Return("", errors.DBConnectionError{}),
+	)
+
+	// pass mockObj to a real object.
+	dbExecutor = dbExecutorMockObj
+	setUpWorkers()
+
+	code, res, _ := Executor{}.Ready(context.Background())
+
+	if code != results.UNAVAILABLE {
+		t.Errorf("Expected code: %d, actual code: %d", results.UNAVAILABLE, code)
+	}
+	db := res[COMPONENTS].(map[string]interface{})[MONGODB].(map[string]interface{})
+	if db[STATUS] != STATUS_DOWN {
+		t.Errorf("Expected mongodb down, actual %v", db)
+	}
+}
+
+func TestCalledReadyWhenWorkerStalled_ExpectUnavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbExecutorMockObj := healthdbmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		dbExecutorMockObj.EXPECT().GetVersion(gomock.Any()).Return("3.4.4", nil),
+	)
+
+	// pass mockObj to a real object.
+	dbExecutor = dbExecutorMockObj
+	setUpWorkers(workers.Status{Name: "collector", Running: true, Healthy: false})
+
+	code, res, _ := Executor{}.Ready(context.Background())
+
+	if code != results.UNAVAILABLE {
+		t.Errorf("Expected code: %d, actual code: %d", results.UNAVAILABLE, code)
+	}
+	worker := res[COMPONENTS].(map[string]interface{})["collector"].(map[string]interface{})
+	if worker[STATUS] != STATUS_DOWN {
+		t.Errorf("Expected collector down, actual %v", worker)
+	}
+}
@@ -16,12 +16,13 @@
  *******************************************************************************/
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: nodecontroller.go
+// Source: checks.go
 
-// Package mock_node is a generated GoMock package.
+// Package mocks is a generated GoMock package.
 package mocks
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -50,14 +51,28 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // Ping mocks base method
-func (m *MockCommand) Ping() (int, error) {
-	ret := m.ctrl.Call(m, "Ping")
+func (m *MockCommand) Ping(ctx context.Context) (int, error) {
+	ret := m.ctrl.Call(m, "Ping", ctx)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Ping indicates an expected call of Ping
-func (mr *MockCommandMockRecorder) Ping() *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockCommand)(nil).Ping))
+func (mr *MockCommandMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockCommand)(nil).Ping), ctx)
+}
+
+// Ready mocks base method
+func (m *MockCommand) Ready(ctx context.Context) (int, map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "Ready", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(map[string]interface{})
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Ready indicates an expected call of Ready
+func (mr *MockCommandMockRecorder) Ready(ctx interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*MockCommand)(nil).Ready), ctx)
 }
@@ -22,14 +22,15 @@ package app
 import (
 	"commons/logger"
 	"commons/results"
+	"context"
 	appDB "db/mongo/app"
 )
 
 // Command is an interface of app operations.
 type Command interface {
-	GetAppsWithImageName(imageName string) (int, map[string]interface{}, error)
-	GetApp(appId string) (int, map[string]interface{}, error)
-	GetApps() (int, map[string]interface{}, error)
+	GetAppsWithImageName(ctx context.Context, imageName string) (int, map[string]interface{}, error)
+	GetApp(ctx context.Context, appId string) (int, map[string]interface{}, error)
+	GetApps(ctx context.Context) (int, map[string]interface{}, error)
 }
 
 const (
@@ -46,17 +47,17 @@ func init() {
 	appDbExecutor = appDB.Executor{}
 }
 
-func (Executor) GetAppsWithImageName(imageName string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetAppsWithImageName(ctx context.Context, imageName string) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	query := make(map[string]interface{})
 	query[IMAGES] = imageName
 
 	// Get matched apps with query stored in the database.
-	apps, err := appDbExecutor.GetApps(query)
+	apps, err := appDbExecutor.GetApps(ctx, query)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, nil, err
 	}
 
@@ -66,28 +67,28 @@ func (Executor) GetAppsWithImageName(imageName string) (int, map[string]interfac
 	return results.OK, res, err
 }
 
-func (Executor) GetApp(appId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetApp(ctx context.Context, appId string) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get matched app with appId stored in the database.
-	app, err := appDbExecutor.GetApp(appId)
+	app, err := appDbExecutor.GetApp(ctx, appId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, nil, err
 	}
 
 	return results.OK, app, err
 }
 
-func (Executor) GetApps() (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetApps(ctx context.Context) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get all of apps stored in the database.
-	apps, err := appDbExecutor.GetApps()
+	apps, err := appDbExecutor.GetApps(ctx)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, nil, err
 	}
 
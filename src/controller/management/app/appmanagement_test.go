@@ -19,6 +19,7 @@ package app
 import (
 	"commons/errors"
 	"commons/results"
+	"context"
 	dbmocks "db/mongo/app/mocks"
 	"github.com/golang/mock/gomock"
 	"testing"
@@ -59,12 +60,12 @@ func TestCalledGetAppsWithImageName_ExpectSuccess(t *testing.T) {
 	dbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetApps(query).Return(apps, nil),
+		dbExecutorMockObj.EXPECT().GetApps(gomock.Any(), query).Return(apps, nil),
 	)
 	// pass mockObj to a real object.
 	appDbExecutor = dbExecutorMockObj
 
-	code, _, err := manager.GetAppsWithImageName(IMAGENAME)
+	code, _, err := manager.GetAppsWithImageName(context.Background(), IMAGENAME)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -85,13 +86,13 @@ func TestCalledGetAppsWithInvalidImageName_ExpectErrorReturn(t *testing.T) {
 	query[IMAGES] = INVALID_IMAGENAME
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetApps(query).Return(nil, notFoundError),
+		dbExecutorMockObj.EXPECT().GetApps(gomock.Any(), query).Return(nil, notFoundError),
 	)
 
 	// pass mockObj to a real object.
 	appDbExecutor = dbExecutorMockObj
 
-	code, _, err := manager.GetAppsWithImageName(INVALID_IMAGENAME)
+	code, _, err := manager.GetAppsWithImageName(context.Background(), INVALID_IMAGENAME)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -22,6 +22,7 @@
 package mocks
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -50,8 +51,8 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // GetAppsWithImageName mocks base method
-func (m *MockCommand) GetAppsWithImageName(imageName string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetAppsWithImageName", imageName)
+func (m *MockCommand) GetAppsWithImageName(ctx context.Context, imageName string) (int, map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetAppsWithImageName", ctx, imageName)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(map[string]interface{})
 	ret2, _ := ret[2].(error)
@@ -59,13 +60,13 @@ func (m *MockCommand) GetAppsWithImageName(imageName string) (int, map[string]in
 }
 
 // GetAppsWithImageName indicates an expected call of GetAppsWithImageName
-func (mr *MockCommandMockRecorder) GetAppsWithImageName(imageName interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppsWithImageName", reflect.TypeOf((*MockCommand)(nil).GetAppsWithImageName), imageName)
+func (mr *MockCommandMockRecorder) GetAppsWithImageName(ctx, imageName interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppsWithImageName", reflect.TypeOf((*MockCommand)(nil).GetAppsWithImageName), ctx, imageName)
 }
 
 // GetApp mocks base method
-func (m *MockCommand) GetApp(appId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetApp", appId)
+func (m *MockCommand) GetApp(ctx context.Context, appId string) (int, map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetApp", ctx, appId)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(map[string]interface{})
 	ret2, _ := ret[2].(error)
@@ -73,13 +74,13 @@ func (m *MockCommand) GetApp(appId string) (int, map[string]interface{}, error)
 }
 
 // GetApp indicates an expected call of GetApp
-func (mr *MockCommandMockRecorder) GetApp(appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApp", reflect.TypeOf((*MockCommand)(nil).GetApp), appId)
+func (mr *MockCommandMockRecorder) GetApp(ctx, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApp", reflect.TypeOf((*MockCommand)(nil).GetApp), ctx, appId)
 }
 
 // GetApps mocks base method
-func (m *MockCommand) GetApps() (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetApps")
+func (m *MockCommand) GetApps(ctx context.Context) (int, map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetApps", ctx)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(map[string]interface{})
 	ret2, _ := ret[2].(error)
@@ -87,6 +88,6 @@ func (m *MockCommand) GetApps() (int, map[string]interface{}, error) {
 }
 
 // GetApps indicates an expected call of GetApps
-func (mr *MockCommandMockRecorder) GetApps() *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApps", reflect.TypeOf((*MockCommand)(nil).GetApps))
+func (mr *MockCommandMockRecorder) GetApps(ctx interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApps", reflect.TypeOf((*MockCommand)(nil).GetApps), ctx)
 }
@@ -21,8 +21,9 @@ package group
 import (
 	"commons/errors"
 	"commons/logger"
+	"commons/models"
 	"commons/results"
-	"commons/util"
+	"context"
 	noti "controller/notification"
 	groupDB "db/mongo/group"
 	nodeDB "db/mongo/node"
@@ -30,28 +31,29 @@ import (
 
 type Command interface {
 	// CreateGroup inserts a new group to databases.
-	CreateGroup(body string) (int, map[string]interface{}, error)
+	CreateGroup(ctx context.Context, req models.CreateGroupRequest) (int, models.Group, error)
 
 	// GetGroup returns the information of the group specified by groupId parameter.
-	GetGroup(groupId string) (int, map[string]interface{}, error)
+	GetGroup(ctx context.Context, groupId string) (int, models.Group, error)
 
 	// GetGroups returns a list of groups that is created on databases.
-	GetGroups() (int, map[string]interface{}, error)
+	GetGroups(ctx context.Context) (int, models.GroupList, error)
 
 	// JoinGroup adds the node to a list of members.
-	JoinGroup(groupId string, body string) (int, map[string]interface{}, error)
+	JoinGroup(ctx context.Context, groupId string, req models.MembersRequest) (int, error)
 
 	// LeaveGroup removes the node from a list of members.
-	LeaveGroup(groupId string, body string) (int, map[string]interface{}, error)
+	LeaveGroup(ctx context.Context, groupId string, req models.MembersRequest) (int, error)
 
 	// DeleteGroup deletes the group with a primary key matching the groupId argument.
-	DeleteGroup(groupId string) (int, map[string]interface{}, error)
+	DeleteGroup(ctx context.Context, groupId string) (int, error)
 }
 
 const (
-	AGENTS     = "nodes"  // used to indicate a list of nodes.
-	GROUPS     = "groups" // used to indicate a list of groups.
-	GROUP_NAME = "name"   // used to indicate a group name.
+	AGENTS     = "nodes"   // used to indicate a list of nodes.
+	GROUPS     = "groups"  // used to indicate a list of groups.
+	GROUP_NAME = "name"    // used to indicate a group name.
+	MEMBERS    = "members" // used to indicate a list of members.
 )
 
 type Executor struct{}
@@ -68,63 +70,59 @@ func init() {
 
 // CreateGroup inserts a new group to databases.
 // This function returns a unique id in case of success and an error otherwise.
-func (Executor) CreateGroup(body string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
-
-	bodyMap, err := util.ConvertJsonToMap(body)
-	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
-	}
+func (Executor) CreateGroup(ctx context.Context, req models.CreateGroupRequest) (int, models.Group, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Check whether 'name' is included.
-	_, exists := bodyMap[GROUP_NAME]
-	if !exists {
-		return results.ERROR, nil, errors.InvalidJSON{"name field is required"}
+	if len(req.Name) == 0 {
+		return results.ERROR, models.Group{}, errors.InvalidJSON{"name field is required"}
 	}
 
-	name := bodyMap[GROUP_NAME].(string)
-	group, err := groupDbExecutor.CreateGroup(name)
+	group, err := groupDbExecutor.CreateGroup(ctx, req.Name)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.Group{}, err
 	}
 
-	return results.OK, group, err
+	sendGroupEvent(ctx, group[noti.ID].(string), noti.STATUS_CREATED, nil)
+
+	return results.OK, convertToGroup(group), err
 }
 
 // GetGroup returns the information of the group specified by groupId parameter.
 // If response code represents success, returns information about the group.
 // Otherwise, an appropriate error will be returned.
-func (Executor) GetGroup(groupId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetGroup(ctx context.Context, groupId string) (int, models.Group, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	group, err := groupDbExecutor.GetGroup(groupId)
+	group, err := groupDbExecutor.GetGroup(ctx, groupId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.Group{}, err
 	}
 
-	return results.OK, group, err
+	return results.OK, convertToGroup(group), err
 }
 
 // GetGroups returns a list of groups that is created on databases.
 // If response code represents success, returns a list of groups.
 // Otherwise, an appropriate error will be returned.
-func (Executor) GetGroups() (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetGroups(ctx context.Context) (int, models.GroupList, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	groups, err := groupDbExecutor.GetGroups()
+	groups, err := groupDbExecutor.GetGroups(ctx)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.GroupList{}, err
 	}
 
-	res := make(map[string]interface{})
-	res[GROUPS] = groups
+	res := models.GroupList{Groups: make([]models.Group, len(groups))}
+	for i, group := range groups {
+		res.Groups[i] = convertToGroup(group)
+	}
 
 	return results.OK, res, err
 }
@@ -132,88 +130,121 @@ func (Executor) GetGroups() (int, map[string]interface{}, error) {
 // JoinGroup adds the node to a list of members.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) JoinGroup(groupId string, body string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) JoinGroup(ctx context.Context, groupId string, req models.MembersRequest) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	bodyMap, err := util.ConvertJsonToMap(body)
+	err := validateMembersRequest(req)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
-	}
-
-	// Check whether 'nodes' is included.
-	_, exists := bodyMap[AGENTS]
-	if !exists {
-		return results.ERROR, nil, errors.InvalidJSON{"nodes field is required"}
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
 	}
 
 	// Validate nodeIds in request body.
-	for _, nodeId := range bodyMap[AGENTS].([]interface{}) {
-		_, err := nodeDbExecutor.GetNode(nodeId.(string))
+	for _, nodeId := range req.Nodes {
+		_, err := nodeDbExecutor.GetNode(ctx, nodeId)
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
-			return results.ERROR, nil, err
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return results.ERROR, err
 		}
 	}
 
-	for _, nodeId := range bodyMap[AGENTS].([]interface{}) {
-		err = groupDbExecutor.JoinGroup(groupId, nodeId.(string))
+	for _, nodeId := range req.Nodes {
+		err = groupDbExecutor.JoinGroup(ctx, groupId, nodeId)
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
-			return results.ERROR, nil, err
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return results.ERROR, err
 		}
 	}
 
-	notiExecutor.UpdateSubscriber()
+	// The new members are requested to watch apps for subscribers of the group.
+	notiExecutor.ResyncSubscribers(ctx, req.Nodes)
+	sendGroupEvent(ctx, groupId, noti.STATUS_JOINED, req.Nodes)
 
-	return results.OK, nil, err
+	return results.OK, err
 }
 
 // LeaveGroup removes the node from a list of members.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) LeaveGroup(groupId string, body string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) LeaveGroup(ctx context.Context, groupId string, req models.MembersRequest) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	bodyMap, err := util.ConvertJsonToMap(body)
+	err := validateMembersRequest(req)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
-	}
-
-	// Check whether 'nodes' is included.
-	_, exists := bodyMap[AGENTS]
-	if !exists {
-		return results.ERROR, nil, errors.InvalidJSON{"nodes field is required"}
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
 	}
 
-	for _, nodeId := range bodyMap[AGENTS].([]interface{}) {
-		err = groupDbExecutor.LeaveGroup(groupId, nodeId.(string))
+	for _, nodeId := range req.Nodes {
+		err = groupDbExecutor.LeaveGroup(ctx, groupId, nodeId)
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
-			return results.ERROR, nil, err
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return results.ERROR, err
 		}
 	}
 
-	notiExecutor.UpdateSubscriber()
+	sendGroupEvent(ctx, groupId, noti.STATUS_LEFT, req.Nodes)
+	notiExecutor.ResyncSubscribers(ctx, req.Nodes)
 
-	return results.OK, nil, err
+	return results.OK, err
 }
 
 // DeleteGroup deletes the group with a primary key matching the groupId argument.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) DeleteGroup(groupId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) DeleteGroup(ctx context.Context, groupId string) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	// Get members of the group to send the event to.
+	group, err := groupDbExecutor.GetGroup(ctx, groupId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
+	}
 
-	err := groupDbExecutor.DeleteGroup(groupId)
+	err = groupDbExecutor.DeleteGroup(ctx, groupId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
+	}
+
+	members, _ := group[MEMBERS].([]string)
+	sendGroupEvent(ctx, groupId, noti.STATUS_DELETED, members)
+	// Members stop matching app events of the group.
+	notiExecutor.ResyncSubscribers(ctx, members)
+
+	return results.OK, err
+}
+
+// sendGroupEvent sends an event of a group with status, which carries ids of
+// the members it concerns if there are any, to subscribers of the nodes and the group.
+func sendGroupEvent(ctx context.Context, groupId string, status string, nodeIds []string) {
+	event := map[string]interface{}{
+		noti.ID:     groupId,
+		noti.STATUS: status,
 	}
+	if len(nodeIds) != 0 {
+		event[noti.NODES] = nodeIds
+	}
+	notiExecutor.Publish(ctx, noti.GROUP, nodeIds, event)
+}
+
+// validateMembersRequest checks a request of JoinGroup and LeaveGroup.
+// If 'nodes' field is not included, InvalidJSON will be returned.
+func validateMembersRequest(req models.MembersRequest) error {
+	if req.Nodes == nil {
+		return errors.InvalidJSON{"nodes field is required"}
+	}
+	return nil
+}
 
-	return results.OK, nil, err
+// convertToGroup converts a group read from databases into models.Group.
+func convertToGroup(group map[string]interface{}) models.Group {
+	id, _ := group[noti.ID].(string)
+	name, _ := group[GROUP_NAME].(string)
+	members, _ := group[MEMBERS].([]string)
+	return models.Group{ID: id, Name: name, Members: members}
 }
@@ -18,7 +18,11 @@ package group
 
 import (
 	"commons/errors"
+	"commons/models"
 	"commons/results"
+	"context"
+	noti "controller/notification"
+	notimocks "controller/notification/mocks"
 	groupdbmocks "db/mongo/group/mocks"
 	nodedbmocks "db/mongo/node/mocks"
 	"github.com/golang/mock/gomock"
@@ -53,6 +57,12 @@ var (
 		"name":    groupName,
 		"members": []string{},
 	}
+	groupModel = models.Group{
+		ID:      groupId,
+		Name:    groupName,
+		Members: []string{},
+	}
+	membersRequest = models.MembersRequest{Nodes: []string{nodeId}}
 
 	body                   = `{"description":"description"}`
 	respCode               = []int{results.OK, results.OK}
@@ -74,15 +84,18 @@ func TestCalledCreateGroup_ExpectSuccess(t *testing.T) {
 	defer ctrl.Finish()
 
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
+	notiExecutorMockObj := notimocks.NewMockCommand(ctrl)
 
+	created := map[string]interface{}{noti.ID: groupId, noti.STATUS: noti.STATUS_CREATED}
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().CreateGroup(groupName).Return(group, nil),
+		groupDbExecutorMockObj.EXPECT().CreateGroup(gomock.Any(), groupName).Return(group, nil),
+		notiExecutorMockObj.EXPECT().Publish(gomock.Any(), noti.GROUP, nil, created),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
+	notiExecutor = notiExecutorMockObj
 
-	body := `{"name":"testGroup"}`
-	code, res, err := manager.CreateGroup(body)
+	code, res, err := manager.CreateGroup(context.Background(), models.CreateGroupRequest{Name: groupName})
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -92,8 +105,8 @@ func TestCalledCreateGroup_ExpectSuccess(t *testing.T) {
 		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
 	}
 
-	if !reflect.DeepEqual(group, res) {
-		t.Errorf("Expected res: %s, actual res: %s", group, res)
+	if !reflect.DeepEqual(groupModel, res) {
+		t.Errorf("Expected res: %v, actual res: %v", groupModel, res)
 	}
 }
 
@@ -104,13 +117,12 @@ func TestCalledCreateGroupWhenFailedToInsertGroupToDB_ExpectErrorReturn(t *testi
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().CreateGroup(groupName).Return(nil, notFoundError),
+		groupDbExecutorMockObj.EXPECT().CreateGroup(gomock.Any(), groupName).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	body := `{"name":"testGroup"}`
-	code, _, err := manager.CreateGroup(body)
+	code, _, err := manager.CreateGroup(context.Background(), models.CreateGroupRequest{Name: groupName})
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -134,12 +146,12 @@ func TestCalledGetGroup_ExpectSuccess(t *testing.T) {
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroup(groupId).Return(group, nil),
+		groupDbExecutorMockObj.EXPECT().GetGroup(gomock.Any(), groupId).Return(group, nil),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	code, res, err := manager.GetGroup(groupId)
+	code, res, err := manager.GetGroup(context.Background(), groupId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -149,8 +161,8 @@ func TestCalledGetGroup_ExpectSuccess(t *testing.T) {
 		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
 	}
 
-	if !reflect.DeepEqual(group, res) {
-		t.Errorf("Expected res: %s, actual res: %s", group, res)
+	if !reflect.DeepEqual(groupModel, res) {
+		t.Errorf("Expected res: %v, actual res: %v", groupModel, res)
 	}
 }
 
@@ -161,12 +173,12 @@ func TestCalledGetGroupWhenDBHasNotMatchedGroup_ExpectErrorReturn(t *testing.T)
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroup(groupId).Return(nil, notFoundError),
+		groupDbExecutorMockObj.EXPECT().GetGroup(gomock.Any(), groupId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	code, _, err := manager.GetGroup(groupId)
+	code, _, err := manager.GetGroup(context.Background(), groupId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -192,12 +204,12 @@ func TestCalledGetGroups_ExpectSuccess(t *testing.T) {
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroups().Return(groups, nil),
+		groupDbExecutorMockObj.EXPECT().GetGroups(gomock.Any()).Return(groups, nil),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	code, res, err := manager.GetGroups()
+	code, res, err := manager.GetGroups(context.Background())
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -207,8 +219,9 @@ func TestCalledGetGroups_ExpectSuccess(t *testing.T) {
 		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
 	}
 
-	if !reflect.DeepEqual(groups, res["groups"].([]map[string]interface{})) {
-		t.Errorf("Expected res: %s, actual res: %s", groups, res["groups"].([]map[string]interface{}))
+	expected := []models.Group{groupModel}
+	if !reflect.DeepEqual(expected, res.Groups) {
+		t.Errorf("Expected res: %v, actual res: %v", expected, res.Groups)
 	}
 }
 
@@ -219,12 +232,12 @@ func TestCalledGetGroupsWhenFailedToGetGroupsFromDB_ExpectErrorReturn(t *testing
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroups().Return(nil, notFoundError),
+		groupDbExecutorMockObj.EXPECT().GetGroups(gomock.Any()).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	code, _, err := manager.GetGroups()
+	code, _, err := manager.GetGroups(context.Background())
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -247,17 +260,21 @@ func TestCalledJoinGroup_ExpectSuccess(t *testing.T) {
 
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 	nodeDbExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
+	notiExecutorMockObj := notimocks.NewMockCommand(ctrl)
 
+	joined := map[string]interface{}{noti.ID: groupId, noti.NODES: []string{nodeId}, noti.STATUS: noti.STATUS_JOINED}
 	gomock.InOrder(
-		nodeDbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		groupDbExecutorMockObj.EXPECT().JoinGroup(groupId, nodeId).Return(nil),
+		nodeDbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		groupDbExecutorMockObj.EXPECT().JoinGroup(gomock.Any(), groupId, nodeId).Return(nil),
+		notiExecutorMockObj.EXPECT().ResyncSubscribers(gomock.Any(), []string{nodeId}),
+		notiExecutorMockObj.EXPECT().Publish(gomock.Any(), noti.GROUP, []string{nodeId}, joined),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	nodeDbExecutor = nodeDbExecutorMockObj
+	notiExecutor = notiExecutorMockObj
 
-	nodes := `{"nodes":["000000000000000000000001"]}`
-	code, _, err := manager.JoinGroup(groupId, nodes)
+	code, err := manager.JoinGroup(context.Background(), groupId, membersRequest)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -268,12 +285,11 @@ func TestCalledJoinGroup_ExpectSuccess(t *testing.T) {
 	}
 }
 
-func TestCalledJoinGroupWithInvalidRequestBody_ExpectErrorReturn(t *testing.T) {
+func TestCalledJoinGroupWithoutNodes_ExpectErrorReturn(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	invalidJsonStr := `{"invalidJson"}`
-	code, _, err := manager.JoinGroup(groupId, invalidJsonStr)
+	code, err := manager.JoinGroup(context.Background(), groupId, models.MembersRequest{})
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -298,15 +314,14 @@ func TestCalledJoinGroupWhenDBHasNotMatchedGroup_ExpectErrorReturn(t *testing.T)
 	nodeDbExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodeDbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		groupDbExecutorMockObj.EXPECT().JoinGroup(groupId, nodeId).Return(notFoundError),
+		nodeDbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		groupDbExecutorMockObj.EXPECT().JoinGroup(gomock.Any(), groupId, nodeId).Return(notFoundError),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 	nodeDbExecutor = nodeDbExecutorMockObj
 
-	nodes := `{"nodes":["000000000000000000000001"]}`
-	code, _, err := manager.JoinGroup(groupId, nodes)
+	code, err := manager.JoinGroup(context.Background(), groupId, membersRequest)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -328,15 +343,19 @@ func TestCalledLeaveGroup_ExpectSuccess(t *testing.T) {
 	defer ctrl.Finish()
 
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
+	notiExecutorMockObj := notimocks.NewMockCommand(ctrl)
 
+	left := map[string]interface{}{noti.ID: groupId, noti.NODES: []string{nodeId}, noti.STATUS: noti.STATUS_LEFT}
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().LeaveGroup(groupId, nodeId).Return(nil),
+		groupDbExecutorMockObj.EXPECT().LeaveGroup(gomock.Any(), groupId, nodeId).Return(nil),
+		notiExecutorMockObj.EXPECT().Publish(gomock.Any(), noti.GROUP, []string{nodeId}, left),
+		notiExecutorMockObj.EXPECT().ResyncSubscribers(gomock.Any(), []string{nodeId}),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
+	notiExecutor = notiExecutorMockObj
 
-	nodes := `{"nodes":["000000000000000000000001"]}`
-	code, _, err := manager.LeaveGroup(groupId, nodes)
+	code, err := manager.LeaveGroup(context.Background(), groupId, membersRequest)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -347,12 +366,11 @@ func TestCalledLeaveGroup_ExpectSuccess(t *testing.T) {
 	}
 }
 
-func TestCalledLeaveGroupWithInvalidRequestBody_ExpectErrorReturn(t *testing.T) {
+func TestCalledLeaveGroupWithoutNodes_ExpectErrorReturn(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	invalidJsonStr := `{"invalidJson"}`
-	code, _, err := manager.LeaveGroup(groupId, invalidJsonStr)
+	code, err := manager.LeaveGroup(context.Background(), groupId, models.MembersRequest{})
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -376,13 +394,12 @@ func TestCalledLeaveGroupWhenDBHasNotMatchedGroup_ExpectErrorReturn(t *testing.T
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().LeaveGroup(groupId, nodeId).Return(notFoundError),
+		groupDbExecutorMockObj.EXPECT().LeaveGroup(gomock.Any(), groupId, nodeId).Return(notFoundError),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	nodes := `{"nodes":["000000000000000000000001"]}`
-	code, _, err := manager.LeaveGroup(groupId, nodes)
+	code, err := manager.LeaveGroup(context.Background(), groupId, membersRequest)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -399,19 +416,28 @@ func TestCalledLeaveGroupWhenDBHasNotMatchedGroup_ExpectErrorReturn(t *testing.T
 	}
 }
 
-func TestCalledDeleteGroup_ExpectSuccess(t *testing.T) {
+func TestCalledDeleteGroup_ExpectEventSentAndMembersResynced(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
+	notiExecutorMockObj := notimocks.NewMockCommand(ctrl)
 
+	members := []string{nodeId, "othernodeid"}
+	memberGroup := map[string]interface{}{"id": groupId, "name": groupName, "members": members}
+	// One event is sent for all members.
+	deleted := map[string]interface{}{noti.ID: groupId, noti.NODES: members, noti.STATUS: noti.STATUS_DELETED}
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().DeleteGroup(groupId).Return(nil),
+		groupDbExecutorMockObj.EXPECT().GetGroup(gomock.Any(), groupId).Return(memberGroup, nil),
+		groupDbExecutorMockObj.EXPECT().DeleteGroup(gomock.Any(), groupId).Return(nil),
+		notiExecutorMockObj.EXPECT().Publish(gomock.Any(), noti.GROUP, members, deleted),
+		notiExecutorMockObj.EXPECT().ResyncSubscribers(gomock.Any(), members),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
+	notiExecutor = notiExecutorMockObj
 
-	code, _, err := manager.DeleteGroup(groupId)
+	code, err := manager.DeleteGroup(context.Background(), groupId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -429,12 +455,12 @@ func TestCalledDeleteGroupWhenDBHasNotMatchedGroup_ExpectErrorReturn(t *testing.
 	groupDbExecutorMockObj := groupdbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().DeleteGroup(groupId).Return(notFoundError),
+		groupDbExecutorMockObj.EXPECT().GetGroup(gomock.Any(), groupId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	code, _, err := manager.DeleteGroup(groupId)
+	code, err := manager.DeleteGroup(context.Background(), groupId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
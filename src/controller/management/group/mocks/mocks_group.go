@@ -22,6 +22,8 @@
 package mocks
 
 import (
+	models "commons/models"
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -50,85 +52,82 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // CreateGroup mocks base method
-func (m *MockCommand) CreateGroup(body string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "CreateGroup", body)
+func (m *MockCommand) CreateGroup(ctx context.Context, req models.CreateGroupRequest) (int, models.Group, error) {
+	ret := m.ctrl.Call(m, "CreateGroup", ctx, req)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.Group)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // CreateGroup indicates an expected call of CreateGroup
-func (mr *MockCommandMockRecorder) CreateGroup(body interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockCommand)(nil).CreateGroup), body)
+func (mr *MockCommandMockRecorder) CreateGroup(ctx, req interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockCommand)(nil).CreateGroup), ctx, req)
 }
 
 // GetGroup mocks base method
-func (m *MockCommand) GetGroup(groupId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetGroup", groupId)
+func (m *MockCommand) GetGroup(ctx context.Context, groupId string) (int, models.Group, error) {
+	ret := m.ctrl.Call(m, "GetGroup", ctx, groupId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.Group)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetGroup indicates an expected call of GetGroup
-func (mr *MockCommandMockRecorder) GetGroup(groupId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockCommand)(nil).GetGroup), groupId)
+func (mr *MockCommandMockRecorder) GetGroup(ctx, groupId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockCommand)(nil).GetGroup), ctx, groupId)
 }
 
 // GetGroups mocks base method
-func (m *MockCommand) GetGroups() (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetGroups")
+func (m *MockCommand) GetGroups(ctx context.Context) (int, models.GroupList, error) {
+	ret := m.ctrl.Call(m, "GetGroups", ctx)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.GroupList)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetGroups indicates an expected call of GetGroups
-func (mr *MockCommandMockRecorder) GetGroups() *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroups", reflect.TypeOf((*MockCommand)(nil).GetGroups))
+func (mr *MockCommandMockRecorder) GetGroups(ctx interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroups", reflect.TypeOf((*MockCommand)(nil).GetGroups), ctx)
 }
 
 // JoinGroup mocks base method
-func (m *MockCommand) JoinGroup(groupId, body string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "JoinGroup", groupId, body)
+func (m *MockCommand) JoinGroup(ctx context.Context, groupId string, req models.MembersRequest) (int, error) {
+	ret := m.ctrl.Call(m, "JoinGroup", ctx, groupId, req)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // JoinGroup indicates an expected call of JoinGroup
-func (mr *MockCommandMockRecorder) JoinGroup(groupId, body interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinGroup", reflect.TypeOf((*MockCommand)(nil).JoinGroup), groupId, body)
+func (mr *MockCommandMockRecorder) JoinGroup(ctx, groupId, req interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinGroup", reflect.TypeOf((*MockCommand)(nil).JoinGroup), ctx, groupId, req)
 }
 
 // LeaveGroup mocks base method
-func (m *MockCommand) LeaveGroup(groupId, body string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "LeaveGroup", groupId, body)
+func (m *MockCommand) LeaveGroup(ctx context.Context, groupId string, req models.MembersRequest) (int, error) {
+	ret := m.ctrl.Call(m, "LeaveGroup", ctx, groupId, req)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // LeaveGroup indicates an expected call of LeaveGroup
-func (mr *MockCommandMockRecorder) LeaveGroup(groupId, body interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveGroup", reflect.TypeOf((*MockCommand)(nil).LeaveGroup), groupId, body)
+func (mr *MockCommandMockRecorder) LeaveGroup(ctx, groupId, req interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveGroup", reflect.TypeOf((*MockCommand)(nil).LeaveGroup), ctx, groupId, req)
 }
 
 // DeleteGroup mocks base method
-func (m *MockCommand) DeleteGroup(groupId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "DeleteGroup", groupId)
+func (m *MockCommand) DeleteGroup(ctx context.Context, groupId string) (int, error) {
+	ret := m.ctrl.Call(m, "DeleteGroup", ctx, groupId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteGroup indicates an expected call of DeleteGroup
-func (mr *MockCommandMockRecorder) DeleteGroup(groupId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockCommand)(nil).DeleteGroup), groupId)
+func (mr *MockCommandMockRecorder) DeleteGroup(ctx, groupId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockCommand)(nil).DeleteGroup), ctx, groupId)
 }
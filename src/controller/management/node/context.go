@@ -21,9 +21,9 @@ import (
 	"sync"
 )
 
-var common context
+var common nodeContext
 
-type context struct {
+type nodeContext struct {
 	sync.Mutex
 	timers map[string]chan bool
 }
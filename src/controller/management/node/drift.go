@@ -0,0 +1,320 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package node
+
+import (
+	conf "commons/config"
+	"commons/errors"
+	"commons/logger"
+	"commons/models"
+	"commons/results"
+	"commons/tracing"
+	"commons/url"
+	"commons/util"
+	"commons/workers"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+const (
+	DIFFERENCES          = "differences"    // used to indicate a list of configuration differences.
+	PROPERTY             = "property"       // used to indicate a property of a difference.
+	STORED               = "stored"         // used to indicate a value stored in the anchor.
+	ACTUAL               = "actual"         // used to indicate a value reported by a node.
+	CHECKED_AT           = "checkedat"      // used to indicate the time at which drift was checked.
+	STATUS_CONFIG_DRIFT  = "configdrift"    // used to notify that configuration of node has drifted.
+	DRIFT_CHECK_INTERVAL = 10 * time.Minute // a period between two drift checks of all nodes.
+	DRIFT_DETECTOR       = "driftdetector"  // name of the worker checking drifts.
+)
+
+// CheckConfigurationDrift fetches the configuration of the node with nodeId
+// and compares it with the configuration stored in the anchor.
+// The differences are recorded, and if notify is true and the differences
+// have changed since the last check, a 'configdrift' event is sent to subscribers.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) CheckConfigurationDrift(ctx context.Context, nodeId string, notify bool) (int, models.Drift, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	// Get node specified by nodeId parameter.
+	node, err := nodeDbExecutor.GetNode(ctx, nodeId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.Drift{}, err
+	}
+
+	return checkConfigurationDrift(ctx, node, notify)
+}
+
+// CheckConfigurationDrifts checks configuration drift of all connected nodes.
+// Configurations are requested to all the nodes at once, and the result of
+// each node is returned with its own HTTP status code.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) CheckConfigurationDrifts(ctx context.Context, notify bool) (int, models.DriftList, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	nodes, err := nodeDbExecutor.GetNodes(ctx)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.DriftList{}, err
+	}
+
+	drifts := make([]models.Drift, 0)
+	targets := make([]map[string]interface{}, 0)
+	stored := make([]conf.Configuration, 0)
+	address := make([]map[string]interface{}, 0)
+	for _, node := range nodes {
+		if node[STATUS] != STATUS_CONNECTED {
+			continue
+		}
+
+		config, nodeAddress, err := getDriftCheckTarget(node)
+		if err != nil {
+			nodeId, _ := node[ID].(string)
+			drifts = append(drifts, makeDriftError(nodeId, http.StatusInternalServerError, err))
+			continue
+		}
+		targets = append(targets, node)
+		stored = append(stored, config)
+		address = append(address, nodeAddress...)
+	}
+
+	if len(targets) != 0 {
+		urls := util.MakeRequestUrl(address, url.Management(), url.Device(), url.Configuration())
+		codes, respStr := httpExecutor.SendHttpRequest(ctx, "GET", urls, nil)
+
+		for i, node := range targets {
+			nodeId, _ := node[ID].(string)
+			_, drift, err := compareConfiguration(ctx, nodeId, stored[i], codes[i], respStr[i], notify)
+			switch {
+			case !util.IsSuccessCode(codes[i]):
+				drift = makeDriftError(nodeId, codes[i], err)
+			case err != nil:
+				drift = makeDriftError(nodeId, http.StatusInternalServerError, err)
+			default:
+				drift.Code = http.StatusOK
+			}
+			drifts = append(drifts, drift)
+		}
+	}
+
+	return results.OK, models.DriftList{Drifts: drifts}, nil
+}
+
+// makeDriftError makes the result of a node whose drift check failed.
+func makeDriftError(nodeId string, code int, err error) models.Drift {
+	return models.Drift{
+		ID:      nodeId,
+		Code:    models.StatusCode(code),
+		Message: err.Error(),
+	}
+}
+
+// GetConfigurationDrift returns the result of the latest drift check of the node with nodeId.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetConfigurationDrift(ctx context.Context, nodeId string) (int, models.Drift, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	drift, err := driftDbExecutor.GetDrift(ctx, nodeId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.Drift{}, err
+	}
+	return results.OK, convertToDrift(drift), err
+}
+
+// GetConfigurationDrifts returns the results of the latest drift check of all nodes.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetConfigurationDrifts(ctx context.Context) (int, models.DriftList, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	drifts, err := driftDbExecutor.GetDrifts(ctx)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.DriftList{}, err
+	}
+
+	res := models.DriftList{Drifts: make([]models.Drift, len(drifts))}
+	for i, drift := range drifts {
+		res.Drifts[i] = convertToDrift(drift)
+	}
+	return results.OK, res, err
+}
+
+// StartDriftDetector checks configuration drift of all connected nodes
+// every interval until a signal is sent to the returned channel.
+func StartDriftDetector(interval time.Duration, notify bool) chan bool {
+	quit := make(chan bool)
+	worker := workers.Register(DRIFT_DETECTOR, interval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		defer worker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				worker.Start()
+				Executor{}.CheckConfigurationDrifts(context.Background(), notify)
+				worker.Beat()
+			case <-quit:
+				return
+			}
+		}
+	}()
+	return quit
+}
+
+// checkConfigurationDrift requests the configuration of a node
+// and compares it with the configuration stored in the anchor.
+func checkConfigurationDrift(ctx context.Context, node map[string]interface{}, notify bool) (int, models.Drift, error) {
+	nodeId, _ := node[ID].(string)
+
+	stored, address, err := getDriftCheckTarget(node)
+	if err != nil {
+		return results.ERROR, models.Drift{}, err
+	}
+
+	urls := util.MakeRequestUrl(address, url.Management(), url.Device(), url.Configuration())
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "GET", urls, nil)
+	return compareConfiguration(ctx, nodeId, stored, codes[0], respStr[0], notify)
+}
+
+// getDriftCheckTarget returns the configuration of a node stored in the anchor
+// and the address to request its actual configuration.
+func getDriftCheckTarget(node map[string]interface{}) (conf.Configuration, []map[string]interface{}, error) {
+	storedConfig, exists := node["config"].(map[string]interface{})
+	if !exists {
+		return conf.Configuration{}, nil, errors.InternalServerError{"stored configuration is invalid"}
+	}
+
+	stored, err := conf.FromMap(storedConfig)
+	if err != nil {
+		logger.Logging(logger.ERROR, err.Error())
+		return conf.Configuration{}, nil, errors.InternalServerError{err.Error()}
+	}
+
+	address, err := getNodeAddress(node)
+	if err != nil {
+		logger.Logging(logger.ERROR, err.Error())
+		return conf.Configuration{}, nil, err
+	}
+	return stored, address, nil
+}
+
+// compareConfiguration compares the configuration which a node responded
+// with code and respStr with the stored one, and records the differences.
+func compareConfiguration(ctx context.Context, nodeId string, stored conf.Configuration, code int, respStr string,
+	notify bool) (int, models.Drift, error) {
+
+	if !util.IsSuccessCode(code) {
+		return code, models.Drift{}, errors.InternalServerError{"failed to get configuration from node: " + respStr}
+	}
+
+	actual, err := conf.Parse(respStr)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.Drift{}, err
+	}
+
+	differences := make([]map[string]interface{}, 0)
+	for _, difference := range conf.Diff(stored, actual) {
+		differences = append(differences, difference.ToMap())
+	}
+
+	// Keep the previous differences to notify only when the drift has changed.
+	var previous interface{}
+	if drift, err := driftDbExecutor.GetDrift(ctx, nodeId); err == nil {
+		previous = drift[DIFFERENCES]
+	}
+
+	checkedAt := time.Now().String()
+	err = driftDbExecutor.SetDrift(ctx, nodeId, differences, checkedAt)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.Drift{}, err
+	}
+
+	if notify && len(differences) != 0 && !isSameJson(previous, differences) {
+		// The notification is sent after the request is answered,
+		// so it does not carry the context and the request id of the request.
+		go sendDriftNotification(tracing.FromContext(ctx), nodeId, differences)
+	}
+
+	res := models.Drift{
+		ID:          nodeId,
+		Differences: convertToDifferences(differences),
+		CheckedAt:   checkedAt,
+	}
+	return results.OK, res, nil
+}
+
+// sendDriftNotification sends an event of drifted configuration of a node.
+// It is traced on its own, linked to origin, the span of the drift check.
+func sendDriftNotification(origin *tracing.Span, nodeId string, differences []map[string]interface{}) {
+	span := tracing.StartLinked(origin, "drift notification", tracing.KIND_INTERNAL)
+	span.SetAttribute("node.id", nodeId)
+	defer span.Finish()
+	ctx := tracing.NewContext(context.Background(), span)
+
+	event := make(map[string]interface{})
+	event[ID] = nodeId
+	event[STATUS] = STATUS_CONFIG_DRIFT
+	event[DIFFERENCES] = differences
+	event[TIMESTAMP] = time.Now().String()
+
+	sendEvent(ctx, nodeId, event)
+}
+
+// convertToDrift converts the result of a drift check read from databases into models.Drift.
+func convertToDrift(drift map[string]interface{}) models.Drift {
+	id, _ := drift[ID].(string)
+	differences, _ := drift[DIFFERENCES].([]map[string]interface{})
+	checkedAt, _ := drift[CHECKED_AT].(string)
+	return models.Drift{ID: id, Differences: convertToDifferences(differences), CheckedAt: checkedAt}
+}
+
+// convertToDifferences converts differences made by conf.Difference.ToMap into models.Difference.
+func convertToDifferences(differences []map[string]interface{}) []models.Difference {
+	res := make([]models.Difference, len(differences))
+	for i, difference := range differences {
+		property, _ := difference[PROPERTY].(string)
+		res[i] = models.Difference{Property: property, Stored: difference[STORED], Actual: difference[ACTUAL]}
+	}
+	return res
+}
+
+// isSameJson returns true if both values are encoded to the same JSON.
+// It is used to compare values decoded from the database with new ones.
+func isSameJson(a interface{}, b interface{}) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return string(encodedA) == string(encodedB)
+}
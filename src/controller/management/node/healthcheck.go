@@ -20,8 +20,10 @@ package node
 import (
 	"commons/errors"
 	"commons/logger"
+	"commons/models"
 	"commons/results"
-	"commons/util"
+	"commons/tracing"
+	"context"
 	"encoding/json"
 	"strconv"
 	"time"
@@ -32,50 +34,43 @@ import (
 // change the status of device from connected to disconnected.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (executor Executor) PingNode(nodeId string, body string) (int, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (executor Executor) PingNode(ctx context.Context, nodeId string, req models.PingRequest) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get node specified by nodeId parameter.
-	_, _, err := executor.GetNode(nodeId)
+	_, _, err := executor.GetNode(ctx, nodeId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, err
-	}
-
-	bodyMap, err := util.ConvertJsonToMap(body)
-	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, err
 	}
 
 	// Check whether 'interval' is included.
-	_, exists := bodyMap[INTERVAL]
-	if !exists {
+	if len(req.Interval) == 0 {
 		return results.ERROR, errors.InvalidJSON{"interval field is required"}
 	}
 
-	interval, err := strconv.Atoi(bodyMap[INTERVAL].(string))
+	interval, err := strconv.Atoi(req.Interval)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, errors.InvalidJSON{"invalid value type(interval must be integer)"}
 	}
 
 	common.Lock()
-	_, exists = common.timers[nodeId]
+	_, exists := common.timers[nodeId]
 	if !exists {
-		logger.Logging(logger.DEBUG, "first ping request is received from node")
+		logger.With(ctx).Logging(logger.DEBUG, "first ping request is received from node")
 	} else {
 		// Status is updated with 'connected'.
-		executor.UpdateNodeStatus(nodeId, STATUS_CONNECTED)
+		executor.UpdateNodeStatus(ctx, nodeId, STATUS_CONNECTED)
 
 		if common.timers[nodeId] != nil {
 			// If ping request is received in interval time, send signal to stop timer.
 			common.timers[nodeId] <- true
-			logger.Logging(logger.DEBUG, "ping request is received in interval time")
+			logger.With(ctx).Logging(logger.DEBUG, "ping request is received in interval time")
 		} else {
-			logger.Logging(logger.DEBUG, "ping request is received after interval time-out")
-			sendNotification(nodeId, STATUS_CONNECTED)
+			logger.With(ctx).Logging(logger.DEBUG, "ping request is received after interval time-out")
+			sendNotification(ctx, nodeId, STATUS_CONNECTED)
 		}
 	}
 	common.Unlock()
@@ -83,6 +78,7 @@ func (executor Executor) PingNode(nodeId string, body string) (int, error) {
 	// Start timer with received interval time.
 	timeDurationMin := time.Duration(interval+MAXIMUM_NETWORK_LATENCY_SEC) * TIME_UNIT
 	timer := time.NewTimer(timeDurationMin)
+	origin := tracing.FromContext(ctx)
 	go func() {
 		quit := make(chan bool)
 		common.Lock()
@@ -92,14 +88,22 @@ func (executor Executor) PingNode(nodeId string, body string) (int, error) {
 		select {
 		// Block until timer finishes.
 		case <-timer.C:
-			logger.Logging(logger.ERROR, "ping request is not received in interval time")
+			// The ping request has been answered long before, so its context
+			// and its request id are not used for the work of the timer.
+			// The work is traced on its own, linked to the ping request.
+			span := tracing.StartLinked(origin, "healthcheck timeout", tracing.KIND_INTERNAL)
+			span.SetAttribute("node.id", nodeId)
+			ctx := tracing.NewContext(context.Background(), span)
+			logger.Log(logger.ERROR, "ping request is not received in interval time", "node", nodeId)
 
 			// Status is updated with 'disconnected'.
-			err = executor.UpdateNodeStatus(nodeId, STATUS_DISCONNECTED)
+			err := executor.UpdateNodeStatus(ctx, nodeId, STATUS_DISCONNECTED)
 			if err != nil {
-				logger.Logging(logger.ERROR, err.Error())
+				logger.Log(logger.ERROR, "updating status of node failed", "node", nodeId, "error", err)
+				span.SetError(err)
 			}
-			sendNotification(nodeId, STATUS_DISCONNECTED)
+			sendNotification(ctx, nodeId, STATUS_DISCONNECTED)
+			span.Finish()
 
 		case <-quit:
 			timer.Stop()
@@ -115,14 +119,37 @@ func (executor Executor) PingNode(nodeId string, body string) (int, error) {
 	return results.OK, err
 }
 
-func sendNotification(nodeId string, status string) {
-	eventIds := make([]string, 0)
-	eventIds = append(eventIds, nodeId)
+// CountHealthcheckTimers returns the number of nodes waiting for the next
+// healthcheck message and the number of nodes which timed out. A timer of a
+// node which has already timed out remains in the map as nil until the next ping.
+func CountHealthcheckTimers() (running int, expired int) {
+	common.Lock()
+	defer common.Unlock()
+
+	for _, timer := range common.timers {
+		if timer != nil {
+			running++
+		} else {
+			expired++
+		}
+	}
+	return running, expired
+}
+
+func sendNotification(ctx context.Context, nodeId string, status string) {
 	event := make(map[string]interface{})
 	event[ID] = nodeId
 	event[STATUS] = status
 	event[TIMESTAMP] = time.Now().String()
 
+	sendEvent(ctx, nodeId, event)
+}
+
+// sendEvent forwards a node event to the subscribers of the node with nodeId.
+func sendEvent(ctx context.Context, nodeId string, event map[string]interface{}) {
+	eventIds := make([]string, 0)
+	eventIds = append(eventIds, nodeId)
+
 	notification := make(map[string]interface{})
 	notification[EVENT_ID] = eventIds
 	notification[EVENT] = event
@@ -131,7 +158,7 @@ func sendNotification(nodeId string, status string) {
 	if err != nil {
 		return
 	}
-	notiExecutor.NotificationHandler(NODE, notiStr)
+	notiExecutor.NotificationHandler(ctx, NODE, notiStr)
 }
 
 // convertMapToJson converts map data into a JSON.
@@ -0,0 +1,62 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package node
+
+import (
+	"commons/logger"
+	"commons/metrics"
+	"context"
+)
+
+var (
+	nodeCount = metrics.NewGaugeFunc("anchor_nodes",
+		"Number of nodes per status.", []string{STATUS}, collectNodeCount)
+	healthcheckTimerCount = metrics.NewGaugeFunc("anchor_healthcheck_timers",
+		"Number of nodes waiting for the next healthcheck message.", nil, collectHealthcheckTimerCount)
+)
+
+// collectNodeCount counts nodes in the database per status.
+func collectNodeCount() []metrics.Sample {
+	nodes, err := nodeDbExecutor.GetNodes(context.Background())
+	if err != nil {
+		logger.Logging(logger.ERROR, err.Error())
+		return nil
+	}
+
+	counts := map[string]float64{
+		STATUS_REGISTERED:   0,
+		STATUS_CONNECTED:    0,
+		STATUS_DISCONNECTED: 0,
+	}
+	for _, node := range nodes {
+		if status, ok := node[STATUS].(string); ok {
+			counts[status]++
+		}
+	}
+
+	samples := make([]metrics.Sample, 0, len(counts))
+	for status, count := range counts {
+		samples = append(samples, metrics.Sample{LabelValues: []string{status}, Value: count})
+	}
+	return samples
+}
+
+// collectHealthcheckTimerCount counts running timers.
+func collectHealthcheckTimerCount() []metrics.Sample {
+	running, _ := CountHealthcheckTimers()
+	return []metrics.Sample{{Value: float64(running)}}
+}
@@ -22,6 +22,8 @@
 package mock_node
 
 import (
+	models "commons/models"
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -50,148 +52,204 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // RegisterNode mocks base method
-func (m *MockCommand) RegisterNode(body string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "RegisterNode", body)
+func (m *MockCommand) RegisterNode(ctx context.Context, req models.RegisterNodeRequest) (int, models.IDResponse, error) {
+	ret := m.ctrl.Call(m, "RegisterNode", ctx, req)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.IDResponse)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // RegisterNode indicates an expected call of RegisterNode
-func (mr *MockCommandMockRecorder) RegisterNode(body interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterNode", reflect.TypeOf((*MockCommand)(nil).RegisterNode), body)
+func (mr *MockCommandMockRecorder) RegisterNode(ctx, req interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterNode", reflect.TypeOf((*MockCommand)(nil).RegisterNode), ctx, req)
 }
 
 // UnRegisterNode mocks base method
-func (m *MockCommand) UnRegisterNode(nodeId string) (int, error) {
-	ret := m.ctrl.Call(m, "UnRegisterNode", nodeId)
+func (m *MockCommand) UnRegisterNode(ctx context.Context, nodeId string) (int, error) {
+	ret := m.ctrl.Call(m, "UnRegisterNode", ctx, nodeId)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnRegisterNode indicates an expected call of UnRegisterNode
-func (mr *MockCommandMockRecorder) UnRegisterNode(nodeId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnRegisterNode", reflect.TypeOf((*MockCommand)(nil).UnRegisterNode), nodeId)
+func (mr *MockCommandMockRecorder) UnRegisterNode(ctx, nodeId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnRegisterNode", reflect.TypeOf((*MockCommand)(nil).UnRegisterNode), ctx, nodeId)
 }
 
 // GetNode mocks base method
-func (m *MockCommand) GetNode(nodeId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetNode", nodeId)
+func (m *MockCommand) GetNode(ctx context.Context, nodeId string) (int, models.Node, error) {
+	ret := m.ctrl.Call(m, "GetNode", ctx, nodeId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.Node)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetNode indicates an expected call of GetNode
-func (mr *MockCommandMockRecorder) GetNode(nodeId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNode", reflect.TypeOf((*MockCommand)(nil).GetNode), nodeId)
+func (mr *MockCommandMockRecorder) GetNode(ctx, nodeId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNode", reflect.TypeOf((*MockCommand)(nil).GetNode), ctx, nodeId)
 }
 
 // GetNodes mocks base method
-func (m *MockCommand) GetNodes() (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetNodes")
+func (m *MockCommand) GetNodes(ctx context.Context) (int, models.NodeList, error) {
+	ret := m.ctrl.Call(m, "GetNodes", ctx)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.NodeList)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetNodes indicates an expected call of GetNodes
-func (mr *MockCommandMockRecorder) GetNodes() *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodes", reflect.TypeOf((*MockCommand)(nil).GetNodes))
+func (mr *MockCommandMockRecorder) GetNodes(ctx interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodes", reflect.TypeOf((*MockCommand)(nil).GetNodes), ctx)
 }
 
 // GetNodesWithAppID mocks base method
-func (m *MockCommand) GetNodesWithAppID(appId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetNodesWithAppID", appId)
+func (m *MockCommand) GetNodesWithAppID(ctx context.Context, appId string) (int, models.NodeList, error) {
+	ret := m.ctrl.Call(m, "GetNodesWithAppID", ctx, appId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.NodeList)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetNodesWithAppID indicates an expected call of GetNodesWithAppID
-func (mr *MockCommandMockRecorder) GetNodesWithAppID(appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodesWithAppID", reflect.TypeOf((*MockCommand)(nil).GetNodesWithAppID), appId)
+func (mr *MockCommandMockRecorder) GetNodesWithAppID(ctx, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodesWithAppID", reflect.TypeOf((*MockCommand)(nil).GetNodesWithAppID), ctx, appId)
 }
 
 // UpdateNodeStatus mocks base method
-func (m *MockCommand) UpdateNodeStatus(nodeId, status string) error {
-	ret := m.ctrl.Call(m, "UpdateNodeStatus", nodeId, status)
+func (m *MockCommand) UpdateNodeStatus(ctx context.Context, nodeId, status string) error {
+	ret := m.ctrl.Call(m, "UpdateNodeStatus", ctx, nodeId, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateNodeStatus indicates an expected call of UpdateNodeStatus
-func (mr *MockCommandMockRecorder) UpdateNodeStatus(nodeId, status interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodeStatus", reflect.TypeOf((*MockCommand)(nil).UpdateNodeStatus), nodeId, status)
+func (mr *MockCommandMockRecorder) UpdateNodeStatus(ctx, nodeId, status interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodeStatus", reflect.TypeOf((*MockCommand)(nil).UpdateNodeStatus), ctx, nodeId, status)
 }
 
 // PingNode mocks base method
-func (m *MockCommand) PingNode(nodeId, body string) (int, error) {
-	ret := m.ctrl.Call(m, "PingNode", nodeId, body)
+func (m *MockCommand) PingNode(ctx context.Context, nodeId string, req models.PingRequest) (int, error) {
+	ret := m.ctrl.Call(m, "PingNode", ctx, nodeId, req)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // PingNode indicates an expected call of PingNode
-func (mr *MockCommandMockRecorder) PingNode(nodeId, body interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingNode", reflect.TypeOf((*MockCommand)(nil).PingNode), nodeId, body)
+func (mr *MockCommandMockRecorder) PingNode(ctx, nodeId, req interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingNode", reflect.TypeOf((*MockCommand)(nil).PingNode), ctx, nodeId, req)
 }
 
 // GetNodeConfiguration mocks base method
-func (m *MockCommand) GetNodeConfiguration(nodeId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetNodeConfiguration", nodeId)
+func (m *MockCommand) GetNodeConfiguration(ctx context.Context, nodeId string) (int, models.Configuration, error) {
+	ret := m.ctrl.Call(m, "GetNodeConfiguration", ctx, nodeId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.Configuration)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetNodeConfiguration indicates an expected call of GetNodeConfiguration
-func (mr *MockCommandMockRecorder) GetNodeConfiguration(nodeId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeConfiguration", reflect.TypeOf((*MockCommand)(nil).GetNodeConfiguration), nodeId)
+func (mr *MockCommandMockRecorder) GetNodeConfiguration(ctx, nodeId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeConfiguration", reflect.TypeOf((*MockCommand)(nil).GetNodeConfiguration), ctx, nodeId)
 }
 
 // SetNodeConfiguration mocks base method
-func (m *MockCommand) SetNodeConfiguration(nodeId, body string) (int, error) {
-	ret := m.ctrl.Call(m, "SetNodeConfiguration", nodeId, body)
+func (m *MockCommand) SetNodeConfiguration(ctx context.Context, nodeId string, req models.Configuration) (int, error) {
+	ret := m.ctrl.Call(m, "SetNodeConfiguration", ctx, nodeId, req)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SetNodeConfiguration indicates an expected call of SetNodeConfiguration
-func (mr *MockCommandMockRecorder) SetNodeConfiguration(nodeId, body interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNodeConfiguration", reflect.TypeOf((*MockCommand)(nil).SetNodeConfiguration), nodeId, body)
+func (mr *MockCommandMockRecorder) SetNodeConfiguration(ctx, nodeId, req interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNodeConfiguration", reflect.TypeOf((*MockCommand)(nil).SetNodeConfiguration), ctx, nodeId, req)
 }
 
 // Reboot mocks base method
-func (m *MockCommand) Reboot(nodeId string) (int, error) {
-	ret := m.ctrl.Call(m, "Reboot", nodeId)
+func (m *MockCommand) Reboot(ctx context.Context, nodeId string) (int, error) {
+	ret := m.ctrl.Call(m, "Reboot", ctx, nodeId)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Reboot indicates an expected call of Reboot
-func (mr *MockCommandMockRecorder) Reboot(nodeId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reboot", reflect.TypeOf((*MockCommand)(nil).Reboot), nodeId)
+func (mr *MockCommandMockRecorder) Reboot(ctx, nodeId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reboot", reflect.TypeOf((*MockCommand)(nil).Reboot), ctx, nodeId)
 }
 
 // Restore mocks base method
-func (m *MockCommand) Restore(nodeId string) (int, error) {
-	ret := m.ctrl.Call(m, "Restore", nodeId)
+func (m *MockCommand) Restore(ctx context.Context, nodeId string) (int, error) {
+	ret := m.ctrl.Call(m, "Restore", ctx, nodeId)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Restore indicates an expected call of Restore
-func (mr *MockCommandMockRecorder) Restore(nodeId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockCommand)(nil).Restore), nodeId)
+func (mr *MockCommandMockRecorder) Restore(ctx, nodeId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockCommand)(nil).Restore), ctx, nodeId)
+}
+
+// CheckConfigurationDrift mocks base method
+func (m *MockCommand) CheckConfigurationDrift(ctx context.Context, nodeId string, notify bool) (int, models.Drift, error) {
+	ret := m.ctrl.Call(m, "CheckConfigurationDrift", ctx, nodeId, notify)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(models.Drift)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CheckConfigurationDrift indicates an expected call of CheckConfigurationDrift
+func (mr *MockCommandMockRecorder) CheckConfigurationDrift(ctx, nodeId, notify interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckConfigurationDrift", reflect.TypeOf((*MockCommand)(nil).CheckConfigurationDrift), ctx, nodeId, notify)
+}
+
+// CheckConfigurationDrifts mocks base method
+func (m *MockCommand) CheckConfigurationDrifts(ctx context.Context, notify bool) (int, models.DriftList, error) {
+	ret := m.ctrl.Call(m, "CheckConfigurationDrifts", ctx, notify)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(models.DriftList)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CheckConfigurationDrifts indicates an expected call of CheckConfigurationDrifts
+func (mr *MockCommandMockRecorder) CheckConfigurationDrifts(ctx, notify interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckConfigurationDrifts", reflect.TypeOf((*MockCommand)(nil).CheckConfigurationDrifts), ctx, notify)
+}
+
+// GetConfigurationDrift mocks base method
+func (m *MockCommand) GetConfigurationDrift(ctx context.Context, nodeId string) (int, models.Drift, error) {
+	ret := m.ctrl.Call(m, "GetConfigurationDrift", ctx, nodeId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(models.Drift)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetConfigurationDrift indicates an expected call of GetConfigurationDrift
+func (mr *MockCommandMockRecorder) GetConfigurationDrift(ctx, nodeId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigurationDrift", reflect.TypeOf((*MockCommand)(nil).GetConfigurationDrift), ctx, nodeId)
+}
+
+// GetConfigurationDrifts mocks base method
+func (m *MockCommand) GetConfigurationDrifts(ctx context.Context) (int, models.DriftList, error) {
+	ret := m.ctrl.Call(m, "GetConfigurationDrifts", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(models.DriftList)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetConfigurationDrifts indicates an expected call of GetConfigurationDrifts
+func (mr *MockCommandMockRecorder) GetConfigurationDrifts(ctx interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigurationDrifts", reflect.TypeOf((*MockCommand)(nil).GetConfigurationDrifts), ctx)
 }
@@ -20,34 +20,41 @@
 package node
 
 import (
+	conf "commons/config"
 	"commons/errors"
 	"commons/logger"
+	"commons/models"
 	"commons/results"
 	"commons/url"
 	"commons/util"
+	"context"
 	noti "controller/notification"
 	groupSearch "controller/search/group"
+	driftDB "db/mongo/drift"
 	groupDB "db/mongo/group"
 	nodeDB "db/mongo/node"
 	"github.com/satori/go.uuid"
 	"messenger"
-	"strings"
 	"time"
 )
 
 // Command is an interface of node operations.
 type Command interface {
-	RegisterNode(body string) (int, map[string]interface{}, error)
-	UnRegisterNode(nodeId string) (int, error)
-	GetNode(nodeId string) (int, map[string]interface{}, error)
-	GetNodes() (int, map[string]interface{}, error)
-	GetNodesWithAppID(appId string) (int, map[string]interface{}, error)
-	UpdateNodeStatus(nodeId string, status string) error
-	PingNode(nodeId string, body string) (int, error)
-	GetNodeConfiguration(nodeId string) (int, map[string]interface{}, error)
-	SetNodeConfiguration(nodeId string, body string) (int, error)
-	Reboot(nodeId string) (int, error)
-	Restore(nodeId string) (int, error)
+	RegisterNode(ctx context.Context, req models.RegisterNodeRequest) (int, models.IDResponse, error)
+	UnRegisterNode(ctx context.Context, nodeId string) (int, error)
+	GetNode(ctx context.Context, nodeId string) (int, models.Node, error)
+	GetNodes(ctx context.Context) (int, models.NodeList, error)
+	GetNodesWithAppID(ctx context.Context, appId string) (int, models.NodeList, error)
+	UpdateNodeStatus(ctx context.Context, nodeId string, status string) error
+	PingNode(ctx context.Context, nodeId string, req models.PingRequest) (int, error)
+	GetNodeConfiguration(ctx context.Context, nodeId string) (int, models.Configuration, error)
+	SetNodeConfiguration(ctx context.Context, nodeId string, req models.Configuration) (int, error)
+	Reboot(ctx context.Context, nodeId string) (int, error)
+	Restore(ctx context.Context, nodeId string) (int, error)
+	CheckConfigurationDrift(ctx context.Context, nodeId string, notify bool) (int, models.Drift, error)
+	CheckConfigurationDrifts(ctx context.Context, notify bool) (int, models.DriftList, error)
+	GetConfigurationDrift(ctx context.Context, nodeId string) (int, models.Drift, error)
+	GetConfigurationDrifts(ctx context.Context) (int, models.DriftList, error)
 }
 
 const (
@@ -75,6 +82,7 @@ type Executor struct{}
 
 var nodeDbExecutor nodeDB.Command
 var groupDbExecutor groupDB.Command
+var driftDbExecutor driftDB.Command
 var httpExecutor messenger.Command
 var notiExecutor noti.Command
 var groupSearchExecutor groupSearch.Command
@@ -82,6 +90,7 @@ var groupSearchExecutor groupSearch.Command
 func init() {
 	nodeDbExecutor = nodeDB.Executor{}
 	groupDbExecutor = groupDB.Executor{}
+	driftDbExecutor = driftDB.Executor{}
 	httpExecutor = messenger.NewExecutor()
 	notiExecutor = noti.Executor{}
 	groupSearchExecutor = groupSearch.Executor{}
@@ -90,58 +99,57 @@ func init() {
 // RegisterNode inserts a new node with ip which is passed in call to function.
 // If successful, a unique id that is created automatically will be returned.
 // otherwise, an appropriate error will be returned.
-func (Executor) RegisterNode(body string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
-
-	// If body is not empty, try to get node id from body.
-	// This code will be used to update the information of node without changing id.
-	bodyMap, err := util.ConvertJsonToMap(body)
-	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
-	}
+func (Executor) RegisterNode(ctx context.Context, req models.RegisterNodeRequest) (int, models.IDResponse, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Check whether 'ip' is included.
-	ip, exists := bodyMap["ip"].(string)
-	if !exists {
-		return results.ERROR, nil, errors.InvalidJSON{"ip field is required"}
+	if len(req.IP) == 0 {
+		return results.ERROR, models.IDResponse{}, errors.InvalidJSON{"ip field is required"}
 	}
 
 	// Check whether 'config' is included.
-	config, exists := bodyMap["config"].(map[string]interface{})
-	if !exists {
-		return results.ERROR, nil, errors.InvalidJSON{"config field is required"}
+	if req.Config == nil {
+		return results.ERROR, models.IDResponse{}, errors.InvalidJSON{"config field is required"}
 	}
 
-	// Check whether 'apps' is included.
-	appIds := make([]string, 0)
-	if apps, exists := bodyMap["apps"]; exists {
-		for _, app := range apps.([]interface{}) {
-			appIds = append(appIds, app.(string))
-		}
+	// 'apps' is optional.
+	appIds := req.Apps
+	if appIds == nil {
+		appIds = make([]string, 0)
+	}
+
+	// Check whether 'config' conforms to the schema of known properties.
+	nodeConfig, err := conf.FromMap(req.Config)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.IDResponse{}, err
+	}
+
+	err = nodeConfig.Validate()
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.IDResponse{}, err
 	}
 
 	// check whether deviceId already exists.
 	var deviceId string
-	for _, prop := range config["properties"].([]interface{}) {
-		if value, exists := prop.(map[string]interface{})["deviceid"]; exists {
-			deviceId = value.(string)
-		}
+	if value, exists := nodeConfig.Get(conf.DEVICE_ID); exists {
+		deviceId = value.(string)
 	}
 
 	// Generate a unique deviceId.
 	for len(deviceId) == 0 {
 		uuid, err := generateUUIDv4()
 		if err != nil {
-			return results.ERROR, nil, err
+			return results.ERROR, models.IDResponse{}, err
 		}
 
-		_, err = nodeDbExecutor.GetNode(uuid)
+		_, err = nodeDbExecutor.GetNode(ctx, uuid)
 		if err != nil {
 			switch err.(type) {
 			default:
-				return results.ERROR, nil, err
+				return results.ERROR, models.IDResponse{}, err
 			case errors.NotFound:
 				deviceId = uuid
 			}
@@ -149,45 +157,43 @@ func (Executor) RegisterNode(body string) (int, map[string]interface{}, error) {
 	}
 
 	// Add new node to database with given ip, port, status.
-	node, err := nodeDbExecutor.AddNode(deviceId, ip, STATUS_CONNECTED, config, appIds)
+	node, err := nodeDbExecutor.AddNode(ctx, deviceId, req.IP, STATUS_CONNECTED, req.Config, appIds)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.IDResponse{}, err
 	}
 
 	// Send notification to subscribers.
 	go func() {
-		notiExecutor.UpdateSubscriber()
-		sendNotification(node[ID].(string), STATUS_REGISTERED)
+		notiExecutor.ResyncSubscribers(ctx, []string{node[ID].(string)})
+		sendNotification(ctx, node[ID].(string), STATUS_REGISTERED)
 	}()
 
-	res := make(map[string]interface{})
-	res[ID] = node[ID]
-	return results.OK, res, err
+	return results.OK, models.IDResponse{ID: node[ID].(string)}, err
 }
 
 // UnRegisterNode deletes the node with a primary key matching the nodeId argument.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) UnRegisterNode(nodeId string) (int, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) UnRegisterNode(ctx context.Context, nodeId string) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get node specified by nodeId parameter.
-	node, err := nodeDbExecutor.GetNode(nodeId)
+	node, err := nodeDbExecutor.GetNode(ctx, nodeId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, err
 	}
 
 	address, err := getNodeAddress(node)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, err
 	}
 
 	urls := util.MakeRequestUrl(address, url.Management(), url.Unregister())
-	httpExecutor.SendHttpRequest("POST", urls, nil)
+	httpExecutor.SendHttpRequest(ctx, "POST", urls, nil)
 
 	// Stop timer and close the channel for ping.
 	common.Lock()
@@ -199,19 +205,25 @@ func (Executor) UnRegisterNode(nodeId string) (int, error) {
 	common.Unlock()
 
 	// Delete node specified by nodeId parameter.
-	err = nodeDbExecutor.DeleteNode(nodeId)
+	err = nodeDbExecutor.DeleteNode(ctx, nodeId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, err
 	}
 
+	// Delete the result of configuration drift check of the node.
+	err = driftDbExecutor.DeleteDrift(ctx, nodeId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.DEBUG, err.Error())
+	}
+
 	// Remove the node from a list of group members.
 	query := make(map[string]interface{})
 	query["nodeId"] = []string{nodeId}
 
-	_, groups, _ := groupSearchExecutor.SearchGroups(query)
+	_, groups, _ := groupSearchExecutor.SearchGroups(ctx, query)
 	for _, group := range groups["groups"].([]map[string]interface{}) {
-		groupDbExecutor.LeaveGroup(group["id"].(string), nodeId)
+		groupDbExecutor.LeaveGroup(ctx, group["id"].(string), nodeId)
 	}
 
 	return results.OK, err
@@ -220,91 +232,67 @@ func (Executor) UnRegisterNode(nodeId string) (int, error) {
 // GetNode returns the node with a primary key matching the nodeId argument.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) GetNode(nodeId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetNode(ctx context.Context, nodeId string) (int, models.Node, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get node specified by nodeId parameter.
-	node, err := nodeDbExecutor.GetNode(nodeId)
+	node, err := nodeDbExecutor.GetNode(ctx, nodeId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
-	}
-
-	// Copy from the original map to the target map, except "config" field.
-	res := make(map[string]interface{})
-	for key, value := range node {
-		if strings.Compare(key, "config") != 0 {
-			res[key] = value
-		}
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.Node{}, err
 	}
 
-	return results.OK, res, err
+	return results.OK, convertToNode(node, false), err
 }
 
 // GetNodes returns all nodes in databases as an array.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) GetNodes() (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetNodes(ctx context.Context) (int, models.NodeList, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get all nodes stored in the database.
-	nodes, err := nodeDbExecutor.GetNodes()
+	nodes, err := nodeDbExecutor.GetNodes(ctx)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.NodeList{}, err
 	}
 
-	copiedNodes := make([]map[string]interface{}, 0)
-	for _, node := range nodes {
-		// Copy from the original map to the target map, except "config" field.
-		res := make(map[string]interface{})
-		for key, value := range node {
-			if strings.Compare(key, "config") != 0 {
-				res[key] = value
-			}
-		}
-		copiedNodes = append(copiedNodes, res)
-	}
-
-	res := make(map[string]interface{})
-	res[NODES] = copiedNodes
-
-	return results.OK, res, err
+	return results.OK, convertToNodeList(nodes, false), err
 }
 
-func (Executor) GetNodesWithAppID(appID string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+// GetNodesWithAppID returns nodes on which the app with appID is installed.
+// Unlike GetNodes, the configuration of each node is included.
+func (Executor) GetNodesWithAppID(ctx context.Context, appID string) (int, models.NodeList, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	query := make(map[string]interface{})
 	query[APPS] = appID
 
 	// Get matched nodes with query stored in the database.
-	nodes, err := nodeDbExecutor.GetNodes(query)
+	nodes, err := nodeDbExecutor.GetNodes(ctx, query)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.NodeList{}, err
 	}
 
-	res := make(map[string]interface{})
-	res[NODES] = nodes
-
-	return results.OK, res, err
+	return results.OK, convertToNodeList(nodes, true), err
 }
 
 // UpdateNodeStatus returns the node's status.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) UpdateNodeStatus(nodeId string, status string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) UpdateNodeStatus(ctx context.Context, nodeId string, status string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get node specified by nodeId parameter.
-	err := nodeDbExecutor.UpdateNodeStatus(nodeId, status)
+	err := nodeDbExecutor.UpdateNodeStatus(ctx, nodeId, status)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return err
 	}
 
@@ -314,25 +302,27 @@ func (Executor) UpdateNodeStatus(nodeId string, status string) error {
 // Reboot reboots the device with nodeId.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) Reboot(nodeId string) (int, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) Reboot(ctx context.Context, nodeId string) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get node specified by nodeId parameter.
-	node, err := nodeDbExecutor.GetNode(nodeId)
+	node, err := nodeDbExecutor.GetNode(ctx, nodeId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, err
 	}
 
 	address, err := getNodeAddress(node)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, err
 	}
 
 	urls := util.MakeRequestUrl(address, url.Management(), url.Device(), url.Reboot())
-	httpExecutor.SendHttpRequest("POST", urls, nil)
+	httpExecutor.SendHttpRequest(ctx, "POST", urls, nil)
+
+	sendNodeEvent(ctx, noti.DEVICE, nodeId, noti.STATUS_REBOOTED, nil)
 
 	return results.OK, err
 }
@@ -340,64 +330,106 @@ func (Executor) Reboot(nodeId string) (int, error) {
 // Restore restore the device with nodeId to initial state.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) Restore(nodeId string) (int, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) Restore(ctx context.Context, nodeId string) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get matched nodes with query stored in the database.
-	node, err := nodeDbExecutor.GetNode(nodeId)
+	node, err := nodeDbExecutor.GetNode(ctx, nodeId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, err
 	}
 
 	address, err := getNodeAddress(node)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, err
 	}
 
 	urls := util.MakeRequestUrl(address, url.Management(), url.Device(), url.Restore())
-	httpExecutor.SendHttpRequest("POST", urls, nil)
+	httpExecutor.SendHttpRequest(ctx, "POST", urls, nil)
+
+	sendNodeEvent(ctx, noti.DEVICE, nodeId, noti.STATUS_RESTORED, nil)
 
 	return results.OK, err
 }
 
-func (Executor) GetNodeConfiguration(nodeId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetNodeConfiguration(ctx context.Context, nodeId string) (int, models.Configuration, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get matched nodes with query stored in the database.
-	node, err := nodeDbExecutor.GetNode(nodeId)
+	node, err := nodeDbExecutor.GetNode(ctx, nodeId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.Configuration{}, err
 	}
 
-	res := node["config"].(map[string]interface{})
+	res := models.Configuration{}
+	err = models.Convert(node["config"], &res)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.Configuration{}, errors.InternalServerError{"stored configuration is invalid"}
+	}
 	return results.OK, res, err
 }
 
-func (Executor) SetNodeConfiguration(nodeId string, body string) (int, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+// SetNodeConfiguration updates configuration of the node with nodeId.
+// Only writable properties registered in commons/config can be changed,
+// and the request is validated before it is sent to the node.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) SetNodeConfiguration(ctx context.Context, nodeId string, req models.Configuration) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	// Check whether the request conforms to the schema of writable properties.
+	updatedConfig, err := conf.FromProperties(req.Properties)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
+	}
+
+	err = updatedConfig.ValidateUpdate()
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
+	}
 
 	// Get node specified by nodeId parameter.
-	node, err := nodeDbExecutor.GetNode(nodeId)
+	node, err := nodeDbExecutor.GetNode(ctx, nodeId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, err
 	}
 
+	storedConfig, exists := node["config"].(map[string]interface{})
+	if !exists {
+		return results.ERROR, errors.InternalServerError{"stored configuration is invalid"}
+	}
+
+	originConfig, err := conf.FromMap(storedConfig)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, errors.InternalServerError{err.Error()}
+	}
+
 	address, err := getNodeAddress(node)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
+	}
+
+	body, err := models.Encode(req)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, err
 	}
 
 	urls := util.MakeRequestUrl(address, url.Management(), url.Device(), url.Configuration())
 
-	codes, _ := httpExecutor.SendHttpRequest("POST", urls, nil, []byte(body))
+	codes, _ := httpExecutor.SendHttpRequest(ctx, "POST", urls, nil, []byte(body))
 
 	result := codes[0]
 	if !util.IsSuccessCode(result) {
@@ -405,34 +437,34 @@ func (Executor) SetNodeConfiguration(nodeId string, body string) (int, error) {
 	}
 
 	// Update configuration information.
-	updatedProps, err := util.ConvertJsonToMap(body)
-	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, err
-	}
+	originConfig.Update(updatedConfig)
 
-	originProps := node["config"].(map[string]interface{})["properties"]
-	for _, originProp := range originProps.([]interface{}) {
-		for originKey, _ := range originProp.(map[string]interface{}) {
-			for _, updatedProp := range updatedProps["properties"].([]interface{}) {
-				for updatedKey, updatedValue := range updatedProp.(map[string]interface{}) {
-					if strings.Compare(originKey, updatedKey) == 0 {
-						originProp.(map[string]interface{})[originKey] = updatedValue
-					}
-				}
-			}
-		}
-	}
-
-	err = nodeDbExecutor.UpdateNodeConfiguration(nodeId, node["config"].(map[string]interface{}))
+	err = nodeDbExecutor.UpdateNodeConfiguration(ctx, nodeId, originConfig.ToMap())
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, err
 	}
 
+	sendNodeEvent(ctx, noti.CONFIGURATION, nodeId, noti.STATUS_UPDATED, map[string]interface{}{
+		PROPERTIES: updatedConfig.ToMap()[PROPERTIES],
+	})
+
 	return results.OK, nil
 }
 
+// sendNodeEvent sends an event of eventType the anchor raises about a node with status
+// to subscribers of the node, along with fields describing the event.
+func sendNodeEvent(ctx context.Context, eventType string, nodeId string, status string, fields map[string]interface{}) {
+	event := map[string]interface{}{
+		noti.NODE_ID: nodeId,
+		STATUS:       status,
+	}
+	for key, value := range fields {
+		event[key] = value
+	}
+	notiExecutor.Publish(ctx, eventType, []string{nodeId}, event)
+}
+
 // getNodeAddress returns an address as an array.
 func getNodeAddress(node map[string]interface{}) ([]map[string]interface{}, error) {
 	result := make([]map[string]interface{}, 1)
@@ -449,6 +481,30 @@ func getNodeAddress(node map[string]interface{}) ([]map[string]interface{}, erro
 	return result, nil
 }
 
+// convertToNode converts a node read from databases into models.Node.
+// The configuration of the node is included only if withConfig is true.
+func convertToNode(node map[string]interface{}, withConfig bool) models.Node {
+	id, _ := node[ID].(string)
+	ip, _ := node["ip"].(string)
+	apps, _ := node[APPS].([]string)
+	status, _ := node[STATUS].(string)
+
+	res := models.Node{ID: id, IP: ip, Apps: apps, Status: status}
+	if withConfig {
+		res.Config, _ = node["config"].(map[string]interface{})
+	}
+	return res
+}
+
+// convertToNodeList converts nodes read from databases into models.NodeList.
+func convertToNodeList(nodes []map[string]interface{}, withConfig bool) models.NodeList {
+	res := models.NodeList{Nodes: make([]models.Node, len(nodes))}
+	for i, node := range nodes {
+		res.Nodes[i] = convertToNode(node, withConfig)
+	}
+	return res
+}
+
 // generateUUIDv4 generates a random UUID.
 func generateUUIDv4() (string, error) {
 	ret, err := uuid.NewV4()
@@ -18,13 +18,20 @@ package node
 
 import (
 	"commons/errors"
+	"commons/metrics"
+	"commons/models"
 	"commons/results"
 	"commons/util"
+	"context"
+	noti "controller/notification"
+	notimocks "controller/notification/mocks"
 	searchmocks "controller/search/group/mocks"
+	driftdbmocks "db/mongo/drift/mocks"
 	nodedbmocks "db/mongo/node/mocks"
 	"encoding/json"
 	"github.com/golang/mock/gomock"
 	msgmocks "messenger/mocks"
+	"net/http"
 	"reflect"
 	"testing"
 )
@@ -39,10 +46,10 @@ const (
 )
 
 var (
-	registrationBody = map[string]interface{}{
-		"ip":     ip,
-		"config": config,
-		"apps":   []string{},
+	registrationRequest = models.RegisterNodeRequest{
+		IP:     ip,
+		Config: config,
+		Apps:   []string{},
 	}
 	property = map[string]interface{}{
 		"key": "value",
@@ -56,16 +63,27 @@ var (
 	config     = map[string]interface{}{
 		"properties": properties,
 	}
+	writableConfig = models.Configuration{
+		Properties: []map[string]interface{}{
+			{"devicename": "edge-device"},
+		},
+	}
 	node = map[string]interface{}{
 		"id":     nodeId,
 		"ip":     ip,
 		"apps":   []string{},
 		"config": config,
 	}
-	nodeWithoutConfig = map[string]interface{}{
-		"id":   nodeId,
-		"ip":   ip,
-		"apps": []string{},
+	nodeModel = models.Node{
+		ID:     nodeId,
+		IP:     ip,
+		Apps:   []string{},
+		Config: config,
+	}
+	nodeWithoutConfig = models.Node{
+		ID:   nodeId,
+		IP:   ip,
+		Apps: []string{},
 	}
 	groups = map[string]interface{}{
 		"groups": []map[string]interface{}{},
@@ -87,16 +105,23 @@ func TestCalledRegisterNodeWithValidBody_ExpectSuccess(t *testing.T) {
 	defer ctrl.Finish()
 
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
+	notiMockObj := notimocks.NewMockCommand(ctrl)
+
+	done := make(chan bool)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any()).Return(nil, notFoundError),
-		nodedDBExecutorMockObj.EXPECT().AddNode(gomock.Any(), ip, status, gomock.Any(), []string{}).Return(node, nil),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), gomock.Any()).Return(nil, notFoundError),
+		nodedDBExecutorMockObj.EXPECT().AddNode(gomock.Any(), gomock.Any(), ip, status, gomock.Any(), []string{}).Return(node, nil),
+		notiMockObj.EXPECT().ResyncSubscribers(gomock.Any(), []string{nodeId}),
+		notiMockObj.EXPECT().NotificationHandler(gomock.Any(), NODE, gomock.Any()).Do(func(context.Context, string, string) {
+			done <- true
+		}).Return(results.OK, nil),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
+	notiExecutor = notiMockObj
 
-	jsonString, _ := json.Marshal(registrationBody)
-	code, _, err := manager.RegisterNode(string(jsonString))
+	code, res, err := manager.RegisterNode(context.Background(), registrationRequest)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -105,38 +130,20 @@ func TestCalledRegisterNodeWithValidBody_ExpectSuccess(t *testing.T) {
 	if code != results.OK {
 		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
 	}
-}
 
-func TestCalledRegisterNodeWithInValidJsonFormatBody_ExpectErrorReturn(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	invalidBody := `{"ip"}`
-
-	code, _, err := manager.RegisterNode(invalidBody)
-
-	if code != results.ERROR {
-		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
-	}
-
-	if err == nil {
-		t.Errorf("Expected err: %s, actual err: %s", "InvalidJSON", "nil")
-	}
-
-	switch err.(type) {
-	default:
-		t.Errorf("Expected err: %s, actual err: %s", "InvalidJSON", err.Error())
-	case errors.InvalidJSON:
+	if res.ID != nodeId {
+		t.Errorf("Expected id: %s, actual id: %s", nodeId, res.ID)
 	}
+	<-done
 }
 
 func TestCalledRegisterNodeWithInvalidBodyNotIncludingIPField_ExpectErrorReturn(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	invalidBody := `{"key":"value"}`
+	invalidRequest := models.RegisterNodeRequest{Config: config}
 
-	code, _, err := manager.RegisterNode(invalidBody)
+	code, _, err := manager.RegisterNode(context.Background(), invalidRequest)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -160,15 +167,14 @@ func TestCalledRegisterNodeWhenFailedToInsertNewNodeToDB_ExpectErrorReturn(t *te
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any()).Return(nil, notFoundError),
-		nodedDBExecutorMockObj.EXPECT().AddNode(gomock.Any(), ip, status, gomock.Any(), []string{}).Return(nil, notFoundError),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), gomock.Any()).Return(nil, notFoundError),
+		nodedDBExecutorMockObj.EXPECT().AddNode(gomock.Any(), gomock.Any(), ip, status, gomock.Any(), []string{}).Return(nil, notFoundError),
 	)
 
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	jsonString, _ := json.Marshal(registrationBody)
-	code, _, err := manager.RegisterNode(string(jsonString))
+	code, _, err := manager.RegisterNode(context.Background(), registrationRequest)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -196,20 +202,23 @@ func TestCalledUnRegisterNodeWithValidBody_ExpectSuccess(t *testing.T) {
 
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
+	driftDBExecutorMockObj := driftdbmocks.NewMockCommand(ctrl)
 	searchExecutorMockObj := searchmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(respCode, respStr),
-		nodedDBExecutorMockObj.EXPECT().DeleteNode(nodeId).Return(nil),
-		searchExecutorMockObj.EXPECT().SearchGroups(query).Return(results.OK, groups, nil),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(respCode, respStr),
+		nodedDBExecutorMockObj.EXPECT().DeleteNode(gomock.Any(), nodeId).Return(nil),
+		driftDBExecutorMockObj.EXPECT().DeleteDrift(gomock.Any(), nodeId).Return(nil),
+		searchExecutorMockObj.EXPECT().SearchGroups(gomock.Any(), query).Return(results.OK, groups, nil),
 	)
 	// pass mockObj to a real object.
 	httpExecutor = msgMockObj
 	nodeDbExecutor = nodedDBExecutorMockObj
+	driftDbExecutor = driftDBExecutorMockObj
 	groupSearchExecutor = searchExecutorMockObj
 
-	code, err := manager.UnRegisterNode(nodeId)
+	code, err := manager.UnRegisterNode(context.Background(), nodeId)
 
 	if code != results.OK {
 		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
@@ -227,12 +236,12 @@ func TestCalledUnRegisterNodeWhenDBHasNotMatchedNode_ExpectErrorReturn(t *testin
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(nil, notFoundError),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, err := manager.UnRegisterNode(nodeId)
+	code, err := manager.UnRegisterNode(context.Background(), nodeId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -256,13 +265,13 @@ func TestCalledGetNode_ExpectSuccess(t *testing.T) {
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
 	)
 
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, res, err := manager.GetNode(nodeId)
+	code, res, err := manager.GetNode(context.Background(), nodeId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -284,13 +293,13 @@ func TestCalledGetNodeWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(nil, notFoundError),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(nil, notFoundError),
 	)
 
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, _, err := manager.GetNode(nodeId)
+	code, _, err := manager.GetNode(context.Background(), nodeId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -312,18 +321,17 @@ func TestCalledGetNodes_ExpectSuccess(t *testing.T) {
 	defer ctrl.Finish()
 
 	nodes := []map[string]interface{}{node}
-	nodesWithoutConfig := []map[string]interface{}{nodeWithoutConfig}
 
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNodes().Return(nodes, nil),
+		nodedDBExecutorMockObj.EXPECT().GetNodes(gomock.Any()).Return(nodes, nil),
 	)
 
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, res, err := manager.GetNodes()
+	code, res, err := manager.GetNodes(context.Background())
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -333,7 +341,7 @@ func TestCalledGetNodes_ExpectSuccess(t *testing.T) {
 		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
 	}
 
-	if !reflect.DeepEqual(res["nodes"].([]map[string]interface{}), nodesWithoutConfig) {
+	if !reflect.DeepEqual(res, models.NodeList{Nodes: []models.Node{nodeWithoutConfig}}) {
 		t.Error()
 	}
 }
@@ -345,13 +353,13 @@ func TestCalledGetNodesWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNodes().Return(nil, notFoundError),
+		nodedDBExecutorMockObj.EXPECT().GetNodes(gomock.Any()).Return(nil, notFoundError),
 	)
 
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, _, err := manager.GetNodes()
+	code, _, err := manager.GetNodes(context.Background())
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -381,13 +389,13 @@ func TestCalledGetNodesWithAppId_ExpectSuccess(t *testing.T) {
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNodes(query).Return(nodes, nil),
+		nodedDBExecutorMockObj.EXPECT().GetNodes(gomock.Any(), query).Return(nodes, nil),
 	)
 
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, res, err := manager.GetNodesWithAppID(appId)
+	code, res, err := manager.GetNodesWithAppID(context.Background(), appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -397,7 +405,7 @@ func TestCalledGetNodesWithAppId_ExpectSuccess(t *testing.T) {
 		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
 	}
 
-	if !reflect.DeepEqual(res["nodes"].([]map[string]interface{}), nodes) {
+	if !reflect.DeepEqual(res, models.NodeList{Nodes: []models.Node{nodeModel}}) {
 		t.Error()
 	}
 }
@@ -413,13 +421,13 @@ func TestCalledGetNodesWithInvalidAppId_ExpectErrorReturn(t *testing.T) {
 	query[APPS] = invalidAppId
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNodes(query).Return(nil, notFoundError),
+		nodedDBExecutorMockObj.EXPECT().GetNodes(gomock.Any(), query).Return(nil, notFoundError),
 	)
 
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, _, err := manager.GetNodesWithAppID(invalidAppId)
+	code, _, err := manager.GetNodesWithAppID(context.Background(), invalidAppId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -443,13 +451,13 @@ func TestCalledUpdateNodeStatus_ExpectSuccess(t *testing.T) {
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().UpdateNodeStatus(nodeId, status).Return(nil),
+		nodedDBExecutorMockObj.EXPECT().UpdateNodeStatus(gomock.Any(), nodeId, status).Return(nil),
 	)
 
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	err := manager.UpdateNodeStatus(nodeId, status)
+	err := manager.UpdateNodeStatus(context.Background(), nodeId, status)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -463,13 +471,13 @@ func TestCalledUpdateNodeStatusWhenDBReturnsError_ExpectErrorReturn(t *testing.T
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().UpdateNodeStatus(nodeId, status).Return(notFoundError),
+		nodedDBExecutorMockObj.EXPECT().UpdateNodeStatus(gomock.Any(), nodeId, status).Return(notFoundError),
 	)
 
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	err := manager.UpdateNodeStatus(nodeId, status)
+	err := manager.UpdateNodeStatus(context.Background(), nodeId, status)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -489,12 +497,12 @@ func TestCalledPingNodeWhenDBHasNotMatchedNode_ExpectErrorReturn(t *testing.T) {
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(nil, notFoundError),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, err := manager.PingNode(nodeId, "")
+	code, err := manager.PingNode(context.Background(), nodeId, models.PingRequest{})
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -518,13 +526,12 @@ func TestCalledPingNodeWithInvalidBody_ExpectErrorReturn(t *testing.T) {
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	invalidKeyBody := `{"key":"value"}`
-	code, err := manager.PingNode(nodeId, invalidKeyBody)
+	code, err := manager.PingNode(context.Background(), nodeId, models.PingRequest{})
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -548,13 +555,13 @@ func TestCalledPingNodeWithInvalidValueBody_ExpectErrorReturn(t *testing.T) {
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	invalidValueBody := `{"interval":"value"}`
-	code, err := manager.PingNode(nodeId, invalidValueBody)
+	invalidValueRequest := models.PingRequest{Interval: "value"}
+	code, err := manager.PingNode(context.Background(), nodeId, invalidValueRequest)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -578,13 +585,13 @@ func TestCalledGetNodeConfiguration_ExpectSuccess(t *testing.T) {
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
 	)
 
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, res, err := manager.GetNodeConfiguration(nodeId)
+	code, res, err := manager.GetNodeConfiguration(context.Background(), nodeId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -594,7 +601,8 @@ func TestCalledGetNodeConfiguration_ExpectSuccess(t *testing.T) {
 		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
 	}
 
-	if !reflect.DeepEqual(res, config) {
+	expected := models.Configuration{Properties: []map[string]interface{}{property, reverseproxy}}
+	if !reflect.DeepEqual(res, expected) {
 		t.Error()
 	}
 }
@@ -606,13 +614,13 @@ func TestCalledGetNodeConfigurationWhenDBReturnsError_ExpectErrorReturn(t *testi
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(nil, notFoundError),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(nil, notFoundError),
 	)
 
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, _, err := manager.GetNodeConfiguration(nodeId)
+	code, _, err := manager.GetNodeConfiguration(context.Background(), nodeId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -635,18 +643,21 @@ func TestRestore_ExpectSuccess(t *testing.T) {
 
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	notiMockObj := notimocks.NewMockCommand(ctrl)
 
 	expectedUrl := []string{"http://" + ip + ":" + port + "/api/v1/management/device/restore"}
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(respCode, respStr),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(respCode, respStr),
+		notiMockObj.EXPECT().Publish(gomock.Any(), noti.DEVICE, []string{nodeId}, map[string]interface{}{"nodeid": nodeId, STATUS: noti.STATUS_RESTORED}),
 	)
 
+	notiExecutor = notiMockObj
 	httpExecutor = msgMockObj
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, err := manager.Restore(nodeId)
+	code, err := manager.Restore(context.Background(), nodeId)
 
 	if code != results.OK {
 		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
@@ -664,12 +675,12 @@ func TestRestoreWhenGetNodeFailed_ExpectReturnError(t *testing.T) {
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(nil, notFoundError),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(nil, notFoundError),
 	)
 
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, err := manager.Restore(nodeId)
+	code, err := manager.Restore(context.Background(), nodeId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -692,18 +703,21 @@ func TestReboot_ExpectSuccess(t *testing.T) {
 
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	notiMockObj := notimocks.NewMockCommand(ctrl)
 
 	expectedUrl := []string{"http://" + ip + ":" + port + "/api/v1/management/device/reboot"}
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil).Return(respCode, respStr),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil).Return(respCode, respStr),
+		notiMockObj.EXPECT().Publish(gomock.Any(), noti.DEVICE, []string{nodeId}, map[string]interface{}{"nodeid": nodeId, STATUS: noti.STATUS_REBOOTED}),
 	)
 
+	notiExecutor = notiMockObj
 	httpExecutor = msgMockObj
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, err := manager.Reboot(nodeId)
+	code, err := manager.Reboot(context.Background(), nodeId)
 
 	if code != results.OK {
 		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
@@ -721,12 +735,12 @@ func TestRebootWhenGetNodeFailed_ExpectReturnError(t *testing.T) {
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(nil, notFoundError),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(nil, notFoundError),
 	)
 
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, err := manager.Reboot(nodeId)
+	code, err := manager.Reboot(context.Background(), nodeId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -748,23 +762,31 @@ func TestCalledSetNodeConfiguration_ExpectSuccess(t *testing.T) {
 	defer ctrl.Finish()
 
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	notiMockObj := notimocks.NewMockCommand(ctrl)
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
-	jsonBody, _ := json.Marshal(config)
+	jsonBody, _ := json.Marshal(writableConfig)
 	jsonNodeData, _ := json.Marshal(node)
 	nodeDataMap, _ := util.ConvertJsonToMap(string(jsonNodeData))
 	expectedUrl := []string{"http://" + ip + ":" + port + "/api/v1/management/device/configuration"}
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(nodeDataMap, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil, jsonBody).Return(respCode, respStr),
-		nodedDBExecutorMockObj.EXPECT().UpdateNodeConfiguration(nodeId, gomock.Any()).Return(nil),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(nodeDataMap, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil, jsonBody).Return(respCode, respStr),
+		nodedDBExecutorMockObj.EXPECT().UpdateNodeConfiguration(gomock.Any(), nodeId, gomock.Any()).Return(nil),
+		notiMockObj.EXPECT().Publish(gomock.Any(), noti.CONFIGURATION, []string{nodeId}, gomock.Any()).Do(
+			func(ctx context.Context, eventType string, nodeIds []string, event map[string]interface{}) {
+				if event[STATUS] != noti.STATUS_UPDATED || event[PROPERTIES] == nil {
+					t.Errorf("Unexpected event: %v", event)
+				}
+			}),
 	)
 	// pass mockObj to a real object.
+	notiExecutor = notiMockObj
 	httpExecutor = msgMockObj
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, err := manager.SetNodeConfiguration(nodeId, string(jsonBody))
+	code, err := manager.SetNodeConfiguration(context.Background(), nodeId, writableConfig)
 
 	if code != results.OK {
 		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
@@ -783,14 +805,13 @@ func TestCalledSetNodeConfigurationWhenDBHasNotMatchedNode_ExpectErrorReturn(t *
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(nil, notFoundError),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(nil, notFoundError),
 	)
 	// pass mockObj to a real object.
 	httpExecutor = msgMockObj
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	body, _ := json.Marshal(config)
-	code, err := manager.SetNodeConfiguration(nodeId, string(body))
+	code, err := manager.SetNodeConfiguration(context.Background(), nodeId, writableConfig)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -814,21 +835,21 @@ func TestCalledSetNodeConfigurationWhenFailedToUpdateConfiguration_ExpectErrorRe
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
-	jsonBody, _ := json.Marshal(config)
+	jsonBody, _ := json.Marshal(writableConfig)
 	jsonNodeData, _ := json.Marshal(node)
 	nodeDataMap, _ := util.ConvertJsonToMap(string(jsonNodeData))
 	expectedUrl := []string{"http://" + ip + ":" + port + "/api/v1/management/device/configuration"}
 
 	gomock.InOrder(
-		nodedDBExecutorMockObj.EXPECT().GetNode(nodeId).Return(nodeDataMap, nil),
-		msgMockObj.EXPECT().SendHttpRequest("POST", expectedUrl, nil, jsonBody).Return(respCode, respStr),
-		nodedDBExecutorMockObj.EXPECT().UpdateNodeConfiguration(nodeId, gomock.Any()).Return(notFoundError),
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(nodeDataMap, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "POST", expectedUrl, nil, jsonBody).Return(respCode, respStr),
+		nodedDBExecutorMockObj.EXPECT().UpdateNodeConfiguration(gomock.Any(), nodeId, gomock.Any()).Return(notFoundError),
 	)
 	// pass mockObj to a real object.
 	httpExecutor = msgMockObj
 	nodeDbExecutor = nodedDBExecutorMockObj
 
-	code, err := manager.SetNodeConfiguration(nodeId, string(jsonBody))
+	code, err := manager.SetNodeConfiguration(context.Background(), nodeId, writableConfig)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -844,3 +865,366 @@ func TestCalledSetNodeConfigurationWhenFailedToUpdateConfiguration_ExpectErrorRe
 	case errors.NotFound:
 	}
 }
+
+func TestCalledSetNodeConfigurationWithInvalidBody_ExpectErrorReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	invalidRequests := map[string]models.Configuration{
+		"withoutProperties":  {},
+		"multipleProperties": {Properties: []map[string]interface{}{{"devicename": "a", "pinginterval": "10"}}},
+		"invalidValueType":   {Properties: []map[string]interface{}{{"devicename": 10}}},
+		"unknownProperty":    {Properties: []map[string]interface{}{{"key": "value"}}},
+		"readOnlyProperty":   {Properties: []map[string]interface{}{{"deviceid": "id"}}},
+		"readOnlyObject":     {Properties: []map[string]interface{}{{"reverseproxy": map[string]interface{}{"enabled": true}}}},
+	}
+
+	for name, req := range invalidRequests {
+		t.Run(name, func(t *testing.T) {
+			code, err := manager.SetNodeConfiguration(context.Background(), nodeId, req)
+
+			if code != results.ERROR {
+				t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
+			}
+
+			switch err.(type) {
+			default:
+				t.Errorf("Expected err: %s, actual err: %v", "InvalidJSON", err)
+			case errors.InvalidJSON:
+			}
+		})
+	}
+}
+
+func TestCalledRegisterNodeWithInvalidConfiguration_ExpectErrorReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	invalidRequest := models.RegisterNodeRequest{
+		IP:     ip,
+		Config: map[string]interface{}{"properties": []interface{}{map[string]interface{}{"deviceid": 10}}},
+	}
+
+	code, _, err := manager.RegisterNode(context.Background(), invalidRequest)
+
+	if code != results.ERROR {
+		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
+	}
+
+	switch err.(type) {
+	default:
+		t.Errorf("Expected err: %s, actual err: %v", "InvalidJSON", err)
+	case errors.InvalidJSON:
+	}
+}
+
+func TestCalledCheckConfigurationDrift_ExpectDifferencesRecorded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
+	driftDBExecutorMockObj := driftdbmocks.NewMockCommand(ctrl)
+
+	actualConfig := `{"properties":[{"key":"changed"},{"reverseproxy":{"enabled":false}}]}`
+	expectedUrl := []string{"http://" + ip + ":" + port + "/api/v1/management/device/configuration"}
+	expectedDifferences := []map[string]interface{}{
+		{"property": "key", "stored": "value", "actual": "changed"},
+	}
+	expectedDrift := []models.Difference{
+		{Property: "key", Stored: "value", Actual: "changed"},
+	}
+
+	gomock.InOrder(
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrl, nil).Return(respCode, []string{actualConfig}),
+		driftDBExecutorMockObj.EXPECT().GetDrift(gomock.Any(), nodeId).Return(nil, notFoundError),
+		driftDBExecutorMockObj.EXPECT().SetDrift(gomock.Any(), nodeId, expectedDifferences, gomock.Any()).Return(nil),
+	)
+	// pass mockObj to a real object.
+	httpExecutor = msgMockObj
+	nodeDbExecutor = nodedDBExecutorMockObj
+	driftDbExecutor = driftDBExecutorMockObj
+
+	code, res, err := manager.CheckConfigurationDrift(context.Background(), nodeId, false)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if code != results.OK {
+		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
+	}
+
+	if !reflect.DeepEqual(expectedDrift, res.Differences) {
+		t.Errorf("Expected differences: %v, actual differences: %v", expectedDrift, res.Differences)
+	}
+}
+
+func TestCalledCheckConfigurationDriftWithChangedDrift_ExpectNotificationSent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
+	driftDBExecutorMockObj := driftdbmocks.NewMockCommand(ctrl)
+	notiMockObj := notimocks.NewMockCommand(ctrl)
+
+	actualConfig := `{"properties":[{"key":"changed"},{"reverseproxy":{"enabled":false}}]}`
+	done := make(chan bool)
+
+	gomock.InOrder(
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", gomock.Any(), nil).Return(respCode, []string{actualConfig}),
+		driftDBExecutorMockObj.EXPECT().GetDrift(gomock.Any(), nodeId).Return(nil, notFoundError),
+		driftDBExecutorMockObj.EXPECT().SetDrift(gomock.Any(), nodeId, gomock.Any(), gomock.Any()).Return(nil),
+		notiMockObj.EXPECT().NotificationHandler(gomock.Any(), NODE, gomock.Any()).Do(func(context.Context, string, string) {
+			done <- true
+		}).Return(results.OK, nil),
+	)
+	// pass mockObj to a real object.
+	httpExecutor = msgMockObj
+	nodeDbExecutor = nodedDBExecutorMockObj
+	driftDbExecutor = driftDBExecutorMockObj
+	notiExecutor = notiMockObj
+
+	code, _, err := manager.CheckConfigurationDrift(context.Background(), nodeId, true)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if code != results.OK {
+		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
+	}
+	<-done
+}
+
+func TestCalledCheckConfigurationDriftWhenNodeReturnsError_ExpectErrorReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		nodedDBExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", gomock.Any(), nil).Return([]int{results.ERROR}, respStr),
+	)
+	// pass mockObj to a real object.
+	httpExecutor = msgMockObj
+	nodeDbExecutor = nodedDBExecutorMockObj
+
+	code, _, err := manager.CheckConfigurationDrift(context.Background(), nodeId, false)
+
+	if code != results.ERROR {
+		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
+	}
+
+	switch err.(type) {
+	default:
+		t.Errorf("Expected err: %s, actual err: %v", "InternalServerError", err)
+	case errors.InternalServerError:
+	}
+}
+
+func TestCalledCheckConfigurationDrifts_ExpectOnlyConnectedNodesChecked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
+	driftDBExecutorMockObj := driftdbmocks.NewMockCommand(ctrl)
+
+	connectedNode := map[string]interface{}{
+		"id": nodeId, "ip": ip, "config": config, "status": STATUS_CONNECTED,
+	}
+	disconnectedNode := map[string]interface{}{
+		"id": "disconnected", "ip": ip, "config": config, "status": STATUS_DISCONNECTED,
+	}
+	actualConfig, _ := json.Marshal(config)
+
+	gomock.InOrder(
+		nodedDBExecutorMockObj.EXPECT().GetNodes(gomock.Any()).Return([]map[string]interface{}{connectedNode, disconnectedNode}, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", gomock.Any(), nil).Return(respCode, []string{string(actualConfig)}),
+		driftDBExecutorMockObj.EXPECT().GetDrift(gomock.Any(), nodeId).Return(nil, notFoundError),
+		driftDBExecutorMockObj.EXPECT().SetDrift(gomock.Any(), nodeId, []map[string]interface{}{}, gomock.Any()).Return(nil),
+	)
+	// pass mockObj to a real object.
+	httpExecutor = msgMockObj
+	nodeDbExecutor = nodedDBExecutorMockObj
+	driftDbExecutor = driftDBExecutorMockObj
+
+	code, res, err := manager.CheckConfigurationDrifts(context.Background(), false)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if code != results.OK {
+		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
+	}
+
+	if len(res.Drifts) != 1 {
+		t.Errorf("Expected drifts: %d, actual drifts: %v", 1, res.Drifts)
+	}
+}
+
+func TestCalledCheckConfigurationDriftsWhenNodeFailed_ExpectNodesRequestedAtOnceWithHttpCodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
+	driftDBExecutorMockObj := driftdbmocks.NewMockCommand(ctrl)
+
+	failingNode := map[string]interface{}{
+		"id": "failing", "ip": "127.0.0.2", "config": config, "status": STATUS_CONNECTED,
+	}
+	connectedNode := map[string]interface{}{
+		"id": nodeId, "ip": ip, "config": config, "status": STATUS_CONNECTED,
+	}
+	unconfiguredNode := map[string]interface{}{
+		"id": "noconfig", "ip": ip, "status": STATUS_CONNECTED,
+	}
+	expectedUrls := []string{
+		"http://127.0.0.2:" + port + "/api/v1/management/device/configuration",
+		"http://" + ip + ":" + port + "/api/v1/management/device/configuration",
+	}
+	actualConfig, _ := json.Marshal(config)
+
+	gomock.InOrder(
+		nodedDBExecutorMockObj.EXPECT().GetNodes(gomock.Any()).Return(
+			[]map[string]interface{}{failingNode, connectedNode, unconfiguredNode}, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrls, nil).Return(
+			[]int{http.StatusServiceUnavailable, results.OK}, []string{`{"message":"busy"}`, string(actualConfig)}),
+		driftDBExecutorMockObj.EXPECT().GetDrift(gomock.Any(), nodeId).Return(nil, notFoundError),
+		driftDBExecutorMockObj.EXPECT().SetDrift(gomock.Any(), nodeId, []map[string]interface{}{}, gomock.Any()).Return(nil),
+	)
+	// pass mockObj to a real object.
+	httpExecutor = msgMockObj
+	nodeDbExecutor = nodedDBExecutorMockObj
+	driftDbExecutor = driftDBExecutorMockObj
+
+	code, res, err := manager.CheckConfigurationDrifts(context.Background(), false)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if code != results.OK {
+		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
+	}
+
+	codes := make(map[string]models.StatusCode)
+	for _, drift := range res.Drifts {
+		codes[drift.ID] = drift.Code
+	}
+	expected := map[string]models.StatusCode{"noconfig": 500, "failing": 503, nodeId: 200}
+	if !reflect.DeepEqual(expected, codes) {
+		t.Errorf("Expected codes: %v, actual codes: %v", expected, codes)
+	}
+}
+
+func TestCalledGetConfigurationDrift_ExpectSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	driftDBExecutorMockObj := driftdbmocks.NewMockCommand(ctrl)
+	drift := map[string]interface{}{ID: nodeId, DIFFERENCES: []map[string]interface{}{}}
+
+	gomock.InOrder(
+		driftDBExecutorMockObj.EXPECT().GetDrift(gomock.Any(), nodeId).Return(drift, nil),
+	)
+	// pass mockObj to a real object.
+	driftDbExecutor = driftDBExecutorMockObj
+
+	code, res, err := manager.GetConfigurationDrift(context.Background(), nodeId)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if code != results.OK {
+		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
+	}
+
+	expected := models.Drift{ID: nodeId, Differences: []models.Difference{}}
+	if !reflect.DeepEqual(expected, res) {
+		t.Errorf("Expected res: %v, actual res: %v", expected, res)
+	}
+}
+
+func TestCalledGetConfigurationDriftsWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	driftDBExecutorMockObj := driftdbmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		driftDBExecutorMockObj.EXPECT().GetDrifts(gomock.Any()).Return(nil, notFoundError),
+	)
+	// pass mockObj to a real object.
+	driftDbExecutor = driftDBExecutorMockObj
+
+	code, _, err := manager.GetConfigurationDrifts(context.Background())
+
+	if code != results.ERROR {
+		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
+	}
+
+	switch err.(type) {
+	default:
+		t.Errorf("Expected err: %s, actual err: %v", "NotFound", err)
+	case errors.NotFound:
+	}
+}
+
+func TestCalledCollectNodeCount_ExpectCountPerStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nodes := []map[string]interface{}{
+		{"id": "a", "status": STATUS_CONNECTED},
+		{"id": "b", "status": STATUS_CONNECTED},
+		{"id": "c", "status": STATUS_DISCONNECTED},
+	}
+
+	nodedDBExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		nodedDBExecutorMockObj.EXPECT().GetNodes(gomock.Any()).Return(nodes, nil),
+	)
+
+	// pass mockObj to a real object.
+	nodeDbExecutor = nodedDBExecutorMockObj
+
+	counts := make(map[string]float64)
+	for _, sample := range collectNodeCount() {
+		counts[sample.LabelValues[0]] = sample.Value
+	}
+
+	expected := map[string]float64{STATUS_REGISTERED: 0, STATUS_CONNECTED: 2, STATUS_DISCONNECTED: 1}
+	if !reflect.DeepEqual(expected, counts) {
+		t.Errorf("Expected counts: %v, actual counts: %v", expected, counts)
+	}
+}
+
+func TestCalledCollectHealthcheckTimerCount_ExpectOnlyRunningTimersCounted(t *testing.T) {
+	common.Lock()
+	common.timers["running"] = make(chan bool)
+	common.timers["timedout"] = nil
+	common.Unlock()
+
+	defer func() {
+		common.Lock()
+		delete(common.timers, "running")
+		delete(common.timers, "timedout")
+		common.Unlock()
+	}()
+
+	expected := []metrics.Sample{{Value: 1}}
+	if samples := collectHealthcheckTimerCount(); !reflect.DeepEqual(expected, samples) {
+		t.Errorf("Expected samples: %v, actual samples: %v", expected, samples)
+	}
+}
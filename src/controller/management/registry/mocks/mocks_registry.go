@@ -22,6 +22,8 @@
 package mocks
 
 import (
+	models "commons/models"
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -50,55 +52,55 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // AddDockerRegistry mocks base method
-func (m *MockCommand) AddDockerRegistry(body string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "AddDockerRegistry", body)
+func (m *MockCommand) AddDockerRegistry(ctx context.Context, req models.RegistryRequest) (int, models.IDResponse, error) {
+	ret := m.ctrl.Call(m, "AddDockerRegistry", ctx, req)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.IDResponse)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // AddDockerRegistry indicates an expected call of AddDockerRegistry
-func (mr *MockCommandMockRecorder) AddDockerRegistry(body interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDockerRegistry", reflect.TypeOf((*MockCommand)(nil).AddDockerRegistry), body)
+func (mr *MockCommandMockRecorder) AddDockerRegistry(ctx, req interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDockerRegistry", reflect.TypeOf((*MockCommand)(nil).AddDockerRegistry), ctx, req)
 }
 
 // DeleteDockerRegistry mocks base method
-func (m *MockCommand) DeleteDockerRegistry(registryId string) (int, error) {
-	ret := m.ctrl.Call(m, "DeleteDockerRegistry", registryId)
+func (m *MockCommand) DeleteDockerRegistry(ctx context.Context, registryId string) (int, error) {
+	ret := m.ctrl.Call(m, "DeleteDockerRegistry", ctx, registryId)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteDockerRegistry indicates an expected call of DeleteDockerRegistry
-func (mr *MockCommandMockRecorder) DeleteDockerRegistry(registryId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDockerRegistry", reflect.TypeOf((*MockCommand)(nil).DeleteDockerRegistry), registryId)
+func (mr *MockCommandMockRecorder) DeleteDockerRegistry(ctx, registryId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDockerRegistry", reflect.TypeOf((*MockCommand)(nil).DeleteDockerRegistry), ctx, registryId)
 }
 
 // GetDockerRegistries mocks base method
-func (m *MockCommand) GetDockerRegistries() (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetDockerRegistries")
+func (m *MockCommand) GetDockerRegistries(ctx context.Context) (int, models.RegistryList, error) {
+	ret := m.ctrl.Call(m, "GetDockerRegistries", ctx)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.RegistryList)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetDockerRegistries indicates an expected call of GetDockerRegistries
-func (mr *MockCommandMockRecorder) GetDockerRegistries() *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDockerRegistries", reflect.TypeOf((*MockCommand)(nil).GetDockerRegistries))
+func (mr *MockCommandMockRecorder) GetDockerRegistries(ctx interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDockerRegistries", reflect.TypeOf((*MockCommand)(nil).GetDockerRegistries), ctx)
 }
 
 // DockerRegistryEventHandler mocks base method
-func (m *MockCommand) DockerRegistryEventHandler(body string) (int, error) {
-	ret := m.ctrl.Call(m, "DockerRegistryEventHandler", body)
+func (m *MockCommand) DockerRegistryEventHandler(ctx context.Context, body string) (int, error) {
+	ret := m.ctrl.Call(m, "DockerRegistryEventHandler", ctx, body)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DockerRegistryEventHandler indicates an expected call of DockerRegistryEventHandler
-func (mr *MockCommandMockRecorder) DockerRegistryEventHandler(body interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DockerRegistryEventHandler", reflect.TypeOf((*MockCommand)(nil).DockerRegistryEventHandler), body)
+func (mr *MockCommandMockRecorder) DockerRegistryEventHandler(ctx, body interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DockerRegistryEventHandler", reflect.TypeOf((*MockCommand)(nil).DockerRegistryEventHandler), ctx, body)
 }
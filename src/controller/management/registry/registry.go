@@ -20,21 +20,27 @@ package registry
 import (
 	"commons/errors"
 	"commons/logger"
+	"commons/models"
 	"commons/results"
 	"commons/url"
 	"commons/util"
+	"commons/validate"
+	"context"
 	appmanager "controller/management/app"
 	nodemanager "controller/management/node"
+	noti "controller/notification"
 	"db/mongo/registry"
 	"messenger"
 )
 
 type Command interface {
 	// AddDockerRegistry add docker registry to database.
-	AddDockerRegistry(body string) (int, map[string]interface{}, error)
-	DeleteDockerRegistry(registryId string) (int, error)
-	GetDockerRegistries() (int, map[string]interface{}, error)
-	DockerRegistryEventHandler(body string) (int, error)
+	AddDockerRegistry(ctx context.Context, req models.RegistryRequest) (int, models.IDResponse, error)
+	DeleteDockerRegistry(ctx context.Context, registryId string) (int, error)
+	GetDockerRegistries(ctx context.Context) (int, models.RegistryList, error)
+	// DockerRegistryEventHandler takes a notification of a docker registry as
+	// it is, since the body is forwarded to nodes which run the pushed images.
+	DockerRegistryEventHandler(ctx context.Context, body string) (int, error)
 }
 
 const (
@@ -49,6 +55,7 @@ const (
 	REQUESTINFO = "request"
 	REGISTRIES  = "registries"
 	REGISTRY    = "registry"
+	URL         = "url"
 	EVENTS      = "events"
 )
 
@@ -58,139 +65,179 @@ var appmanagementExecutor appmanager.Command
 var nodemanagementExecutor nodemanager.Command
 var registryDbExecutor registry.Command
 var httpExecutor messenger.Command
+var notiExecutor noti.Command
 
 func init() {
 	appmanagementExecutor = appmanager.Executor{}
 	nodemanagementExecutor = nodemanager.Executor{}
 	registryDbExecutor = registry.Executor{}
 	httpExecutor = messenger.NewExecutor()
+	notiExecutor = noti.Executor{}
 }
 
-func (Executor) AddDockerRegistry(body string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
-
-	reqBody, err := util.ConvertJsonToMap(body)
-	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
-	}
+func (Executor) AddDockerRegistry(ctx context.Context, req models.RegistryRequest) (int, models.IDResponse, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Check whether 'ip' is included.
-	ip, exists := reqBody[IP].(string)
-	if !exists {
-		return results.ERROR, nil, errors.InvalidJSON{"ip field is required"}
+	if len(req.IP) == 0 {
+		return results.ERROR, models.IDResponse{}, errors.InvalidJSON{"ip field is required"}
 	}
 
-	registry, err := registryDbExecutor.AddDockerRegistry(ip)
+	registry, err := registryDbExecutor.AddDockerRegistry(ctx, req.IP)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.IDResponse{}, err
 	}
 
-	res := make(map[string]interface{})
-	res[ID] = registry[ID]
+	registryId := registry[ID].(string)
+	sendRegistryEvent(ctx, registryId, noti.STATUS_ADDED)
 
-	return results.OK, res, err
+	return results.OK, models.IDResponse{ID: registryId}, err
 }
 
-func (Executor) DeleteDockerRegistry(registryId string) (int, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) DeleteDockerRegistry(ctx context.Context, registryId string) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Delete registry specified by registryId parameter.
-	err := registryDbExecutor.DeleteDockerRegistry(registryId)
+	err := registryDbExecutor.DeleteDockerRegistry(ctx, registryId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, err
 	}
 
+	sendRegistryEvent(ctx, registryId, noti.STATUS_REMOVED)
+
 	return results.OK, err
 }
 
-func (Executor) GetDockerRegistries() (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetDockerRegistries(ctx context.Context) (int, models.RegistryList, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get all of registries list.
-	registries, err := registryDbExecutor.GetDockerRegistries()
+	registries, err := registryDbExecutor.GetDockerRegistries(ctx)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.RegistryList{}, err
 	}
 
-	res := make(map[string]interface{})
-	res[REGISTRIES] = registries
+	res := models.RegistryList{Registries: make([]models.Registry, len(registries))}
+	for i, registry := range registries {
+		id, _ := registry[ID].(string)
+		registryUrl, _ := registry[URL].(string)
+		res.Registries[i] = models.Registry{ID: id, URL: registryUrl}
+	}
 
 	return results.OK, res, err
 }
 
-func (Executor) DockerRegistryEventHandler(body string) (int, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) DockerRegistryEventHandler(ctx context.Context, body string) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	convertedBody, err := util.ConvertJsonToMap(body)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
+	}
+	events, err := validate.ObjectList(EVENTS, convertedBody[EVENTS])
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, err
 	}
-	events := convertedBody[EVENTS]
 
-	for _, eventInfo := range events.([]interface{}) {
+	for i, eventInfo := range events {
 		parsedEvent := make(map[string]interface{})
-		parsedEvent, err = parseEventInfo(eventInfo.(map[string]interface{}))
+		parsedEvent, err = parseEventInfo(validate.Index(EVENTS, i), eventInfo)
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
 			return results.ERROR, err
 		}
 
 		imageName := parsedEvent[HOST].(string) + "/" + parsedEvent[REPOSITORY].(string)
-		_, apps, err := appmanagementExecutor.GetAppsWithImageName(imageName)
+		_, apps, err := appmanagementExecutor.GetAppsWithImageName(ctx, imageName)
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
 			return results.ERROR, err
 		}
 
 		for _, app := range apps[APPS].([]map[string]interface{}) {
 			appId := app[ID]
-			_, nodes, err := nodemanagementExecutor.GetNodesWithAppID(appId.(string))
+			_, nodes, err := nodemanagementExecutor.GetNodesWithAppID(ctx, appId.(string))
 			if err != nil {
-				logger.Logging(logger.ERROR, err.Error())
+				logger.With(ctx).Logging(logger.ERROR, err.Error())
 				return results.ERROR, err
 			}
-			address := getMemberAddress(nodes[NODES].([]map[string]interface{}))
+			address := getMemberAddress(nodes.Nodes)
 			urls := util.MakeRequestUrl(address, url.Management(), url.Apps(), "/", appId.(string), url.Events())
-			_, _ = httpExecutor.SendHttpRequest(POST, urls, nil, []byte(body))
+			_, _ = httpExecutor.SendHttpRequest(ctx, POST, urls, nil, []byte(body))
 		}
 	}
 	return results.OK, nil
 }
 
+// sendRegistryEvent sends an event of a registry with status to subscribers of all nodes,
+// since every node pulls its images through the registered registries.
+func sendRegistryEvent(ctx context.Context, registryId string, status string) {
+	nodeIds := make([]string, 0)
+	_, nodes, err := nodemanagementExecutor.GetNodes(ctx)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+	} else {
+		for _, node := range nodes.Nodes {
+			nodeIds = append(nodeIds, node.ID)
+		}
+	}
+
+	notiExecutor.Publish(ctx, noti.REGISTRY, nodeIds, map[string]interface{}{
+		noti.ID:     registryId,
+		noti.STATUS: status,
+	})
+}
+
 // getNodeAddress returns an member's address as an array.
-func getMemberAddress(members []map[string]interface{}) []map[string]interface{} {
+func getMemberAddress(members []models.Node) []map[string]interface{} {
 	result := make([]map[string]interface{}, len(members))
 	for i, node := range members {
 		result[i] = map[string]interface{}{
-			"ip":    node["ip"],
-			"config": node["config"],
+			"ip":    node.IP,
+			"config": node.Config,
 		}
 	}
 	return result
 }
 
 // parseEventInfo parse data which is matched image-info on DB from event-notification.
-func parseEventInfo(eventInfo map[string]interface{}) (map[string]interface{}, error) {
+// field is the path of eventInfo in the request body, used to point out an invalid value.
+func parseEventInfo(field string, eventInfo map[string]interface{}) (map[string]interface{}, error) {
 	logger.Logging(logger.DEBUG, "IN")
 	defer logger.Logging(logger.DEBUG, "OUT")
 
-	targetInfoEvent := make(map[string]interface{})
-	requestInfoEvent := make(map[string]interface{})
 	parsedEvent := make(map[string]interface{})
 
-	targetInfoEvent = eventInfo[TARGETINFO].(map[string]interface{})
-	requestInfoEvent = eventInfo[REQUESTINFO].(map[string]interface{})
-	parsedEvent[HOST] = requestInfoEvent[HOST]
-	parsedEvent[REPOSITORY] = targetInfoEvent[REPOSITORY]
+	targetField := validate.Member(field, TARGETINFO)
+	targetInfoEvent, err := validate.Object(targetField, eventInfo[TARGETINFO])
+	if err != nil {
+		return nil, err
+	}
+
+	requestField := validate.Member(field, REQUESTINFO)
+	requestInfoEvent, err := validate.Object(requestField, eventInfo[REQUESTINFO])
+	if err != nil {
+		return nil, err
+	}
+
+	parsedEvent[HOST], err = validate.String(validate.Member(requestField, HOST), requestInfoEvent[HOST])
+	if err != nil {
+		return nil, err
+	}
+
+	parsedEvent[REPOSITORY], err = validate.String(validate.Member(targetField, REPOSITORY), targetInfoEvent[REPOSITORY])
+	if err != nil {
+		return nil, err
+	}
 
 	return parsedEvent, nil
 }
@@ -18,10 +18,14 @@ package registry
 
 import (
 	"commons/errors"
+	"commons/models"
 	"commons/results"
 	"commons/url"
+	"context"
 	appmocks "controller/management/app/mocks"
 	nodemocks "controller/management/node/mocks"
+	noti "controller/notification"
+	notimocks "controller/notification/mocks"
 	dbmocks "db/mongo/registry/mocks"
 	"github.com/golang/mock/gomock"
 	msgmocks "messenger/mocks"
@@ -56,11 +60,14 @@ var (
 	config     = map[string]interface{}{
 		"properties": properties,
 	}
-	node = map[string]interface{}{
-		"id":     nodeId,
-		"ip":     ip,
-		"apps":   []string{},
-		"config": config,
+	node = models.Node{
+		ID:     nodeId,
+		IP:     ip,
+		Apps:   []string{},
+		Config: config,
+	}
+	nodes = models.NodeList{
+		Nodes: []models.Node{{ID: nodeId, IP: ip}},
 	}
 	registryModel = map[string]interface{}{
 		"id": registryId,
@@ -87,21 +94,24 @@ func TestCalledAddDockerRegistry_ExpectSuccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	body := `{"ip":"127.0.0.1"}`
-	expectedRes := map[string]interface{}{
-		"id": "000000000000000000000001",
-	}
+	expectedRes := models.IDResponse{ID: registryId}
 
 	registryDbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
+	nodemanagementExecutorMockObj := nodemocks.NewMockCommand(ctrl)
+	notiMockObj := notimocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		registryDbExecutorMockObj.EXPECT().AddDockerRegistry(ip).Return(registryModel, nil),
+		registryDbExecutorMockObj.EXPECT().AddDockerRegistry(gomock.Any(), ip).Return(registryModel, nil),
+		nodemanagementExecutorMockObj.EXPECT().GetNodes(gomock.Any()).Return(results.OK, nodes, nil),
+		notiMockObj.EXPECT().Publish(gomock.Any(), noti.REGISTRY, []string{nodeId}, map[string]interface{}{"id": registryId, "status": noti.STATUS_ADDED}),
 	)
 
 	// pass mockObj to a real object.
 	registryDbExecutor = registryDbExecutorMockObj
+	nodemanagementExecutor = nodemanagementExecutorMockObj
+	notiExecutor = notiMockObj
 
-	code, res, err := manager.AddDockerRegistry(body)
+	code, res, err := manager.AddDockerRegistry(context.Background(), models.RegistryRequest{IP: ip})
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -116,36 +126,11 @@ func TestCalledAddDockerRegistry_ExpectSuccess(t *testing.T) {
 	}
 }
 
-func TestCalledAddDockerRegistryWithInValidJsonFormatBody_ExpectErrorReturn(t *testing.T) {
+func TestCalledAddDockerRegistryWithoutIP_ExpectErrorReturn(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	invalidBody := `{"ip"}`
-
-	code, _, err := manager.AddDockerRegistry(invalidBody)
-
-	if code != results.ERROR {
-		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
-	}
-
-	if err == nil {
-		t.Errorf("Expected err: %s, actual err: %s", "InvalidJSON", "nil")
-	}
-
-	switch err.(type) {
-	default:
-		t.Errorf("Expected err: %s, actual err: %s", "InvalidJSON", err.Error())
-	case errors.InvalidJSON:
-	}
-}
-
-func TestCalledAddDockerRegistryWithInvalidBodyNotIncludingIPField_ExpectErrorReturn(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	invalidBody := `{"key":"value"}`
-
-	code, _, err := manager.AddDockerRegistry(invalidBody)
+	code, _, err := manager.AddDockerRegistry(context.Background(), models.RegistryRequest{})
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -166,18 +151,16 @@ func TestCalledAddDockerRegistryWhenFailedToInsertNewRegistryToDB_ExpectErrorRet
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	body := `{"ip":"127.0.0.1"}`
-
 	registryDbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		registryDbExecutorMockObj.EXPECT().AddDockerRegistry(ip).Return(nil, notFoundError),
+		registryDbExecutorMockObj.EXPECT().AddDockerRegistry(gomock.Any(), ip).Return(nil, notFoundError),
 	)
 
 	// pass mockObj to a real object.
 	registryDbExecutor = registryDbExecutorMockObj
 
-	code, _, err := manager.AddDockerRegistry(body)
+	code, _, err := manager.AddDockerRegistry(context.Background(), models.RegistryRequest{IP: ip})
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -199,15 +182,21 @@ func TestCalledDeleteDockerRegistry_ExpectSuccess(t *testing.T) {
 	defer ctrl.Finish()
 
 	registryDbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
+	nodemanagementExecutorMockObj := nodemocks.NewMockCommand(ctrl)
+	notiMockObj := notimocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		registryDbExecutorMockObj.EXPECT().DeleteDockerRegistry(registryId).Return(nil),
+		registryDbExecutorMockObj.EXPECT().DeleteDockerRegistry(gomock.Any(), registryId).Return(nil),
+		nodemanagementExecutorMockObj.EXPECT().GetNodes(gomock.Any()).Return(results.OK, nodes, nil),
+		notiMockObj.EXPECT().Publish(gomock.Any(), noti.REGISTRY, []string{nodeId}, map[string]interface{}{"id": registryId, "status": noti.STATUS_REMOVED}),
 	)
 
 	// pass mockObj to a real object.
 	registryDbExecutor = registryDbExecutorMockObj
+	nodemanagementExecutor = nodemanagementExecutorMockObj
+	notiExecutor = notiMockObj
 
-	code, err := manager.DeleteDockerRegistry(registryId)
+	code, err := manager.DeleteDockerRegistry(context.Background(), registryId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -225,13 +214,13 @@ func TestCalledDeleteDockerRegistryWithInvalidId_ExpectErrorReturn(t *testing.T)
 	registryDbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		registryDbExecutorMockObj.EXPECT().DeleteDockerRegistry(invalidregistryId).Return(notFoundError),
+		registryDbExecutorMockObj.EXPECT().DeleteDockerRegistry(gomock.Any(), invalidregistryId).Return(notFoundError),
 	)
 
 	// pass mockObj to a real object.
 	registryDbExecutor = registryDbExecutorMockObj
 
-	code, err := manager.DeleteDockerRegistry(invalidregistryId)
+	code, err := manager.DeleteDockerRegistry(context.Background(), invalidregistryId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -252,18 +241,18 @@ func TestCalledGetDockerRegistries_ExpectSuccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	registries := []map[string]interface{}{registryModel}
+	registries := []map[string]interface{}{{"id": registryId, "url": ip}}
 
 	registryDbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		registryDbExecutorMockObj.EXPECT().GetDockerRegistries().Return(registries, nil),
+		registryDbExecutorMockObj.EXPECT().GetDockerRegistries(gomock.Any()).Return(registries, nil),
 	)
 
 	// pass mockObj to a real object.
 	registryDbExecutor = registryDbExecutorMockObj
 
-	code, res, err := manager.GetDockerRegistries()
+	code, res, err := manager.GetDockerRegistries(context.Background())
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -273,8 +262,9 @@ func TestCalledGetDockerRegistries_ExpectSuccess(t *testing.T) {
 		t.Errorf("Expected code: %d, actual code: %d", results.OK, code)
 	}
 
-	if !reflect.DeepEqual(res["registries"].([]map[string]interface{}), registries) {
-		t.Error()
+	expectedRes := models.RegistryList{Registries: []models.Registry{{ID: registryId, URL: ip}}}
+	if !reflect.DeepEqual(res, expectedRes) {
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -285,13 +275,13 @@ func TestCalledGetDockerRegistriesWhenDBReturnsError_ExpectErrorReturn(t *testin
 	registryDbExecutorMockObj := dbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		registryDbExecutorMockObj.EXPECT().GetDockerRegistries().Return(nil, notFoundError),
+		registryDbExecutorMockObj.EXPECT().GetDockerRegistries(gomock.Any()).Return(nil, notFoundError),
 	)
 
 	// pass mockObj to a real object.
 	registryDbExecutor = registryDbExecutorMockObj
 
-	code, _, err := manager.GetDockerRegistries()
+	code, _, err := manager.GetDockerRegistries(context.Background())
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -319,18 +309,16 @@ func TestCalledDockerRegistryEventHandlerWithValidRegistryEvent_ExpectSendReques
 	apps := []map[string]interface{}{app}
 	matchedApplist := make(map[string]interface{})
 	matchedApplist["apps"] = apps
-	nodes := []map[string]interface{}{node}
-	matchedNodelist := make(map[string]interface{})
-	matchedNodelist["nodes"] = nodes
+	matchedNodelist := models.NodeList{Nodes: []models.Node{node}}
 	dummy_imagename := dummy_host + seperator + dummy_repositry
 	dummy_urls := make([]string, 0)
 	dummy_url := "http://" + ip + ":48098/api/v1" + url.Management() + url.Apps() + seperator + appId + url.Events()
 	dummy_urls = append(dummy_urls, dummy_url)
 
 	gomock.InOrder(
-		appmanagementExecutorMockObj.EXPECT().GetAppsWithImageName(dummy_imagename).Return(results.OK, matchedApplist, nil),
-		nodemanagementExecutorMockObj.EXPECT().GetNodesWithAppID(appId).Return(results.OK, matchedNodelist, nil),
-		msgMockObj.EXPECT().SendHttpRequest(POST, dummy_urls, nil, []byte(dummyRegistryEvent)),
+		appmanagementExecutorMockObj.EXPECT().GetAppsWithImageName(gomock.Any(), dummy_imagename).Return(results.OK, matchedApplist, nil),
+		nodemanagementExecutorMockObj.EXPECT().GetNodesWithAppID(gomock.Any(), appId).Return(results.OK, matchedNodelist, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), POST, dummy_urls, nil, []byte(dummyRegistryEvent)),
 	)
 
 	// pass mockObj to a real object.
@@ -338,7 +326,7 @@ func TestCalledDockerRegistryEventHandlerWithValidRegistryEvent_ExpectSendReques
 	nodemanagementExecutor = nodemanagementExecutorMockObj
 	httpExecutor = msgMockObj
 
-	code, err := manager.DockerRegistryEventHandler(dummyRegistryEvent)
+	code, err := manager.DockerRegistryEventHandler(context.Background(), dummyRegistryEvent)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -355,7 +343,7 @@ func TestCalledDockerRegistryEventHandlerWithInValidJsonFormatBody_ExpectErrorRe
 
 	invalidBody := `{"invalidBody"}`
 
-	code, err := manager.DockerRegistryEventHandler(invalidBody)
+	code, err := manager.DockerRegistryEventHandler(context.Background(), invalidBody)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -371,3 +359,30 @@ func TestCalledDockerRegistryEventHandlerWithInValidJsonFormatBody_ExpectErrorRe
 	case errors.InvalidJSON:
 	}
 }
+
+func TestCalledDockerRegistryEventHandlerWithMalformedEvent_ExpectInvalidFieldReturn(t *testing.T) {
+	testList := map[string]string{
+		"events":     `{"events":"event"}`,
+		"event":      `{"events":["event"]}`,
+		"target":     `{"events":[{"target":"target","request":{"host":"host"}}]}`,
+		"request":    `{"events":[{"target":{"repository":"repo"}}]}`,
+		"host":       `{"events":[{"target":{"repository":"repo"},"request":{"host":1}}]}`,
+		"repository": `{"events":[{"target":{},"request":{"host":"host"}}]}`,
+	}
+
+	for name, body := range testList {
+		t.Run(name, func(t *testing.T) {
+			code, err := manager.DockerRegistryEventHandler(context.Background(), body)
+
+			if code != results.ERROR {
+				t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
+			}
+
+			switch err.(type) {
+			default:
+				t.Errorf("Expected err: %s, actual err: %v", "InvalidField", err)
+			case errors.InvalidField:
+			}
+		})
+	}
+}
@@ -0,0 +1,592 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package controller/monitoring/resource/alert evaluates user-defined rules on
+// resource usage of nodes and apps, and notifies subscribers of resource events
+// when an alert is fired or resolved.
+package alert
+
+import (
+	"commons/errors"
+	"commons/logger"
+	"commons/models"
+	"commons/results"
+	"commons/url"
+	"commons/util"
+	"context"
+	noti "controller/notification"
+	alertDB "db/mongo/alert"
+	groupDB "db/mongo/group"
+	nodeDB "db/mongo/node"
+	"messenger"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Command is an interface of alert operations.
+type Command interface {
+	// AddRule inserts a new alert rule.
+	AddRule(ctx context.Context, body string) (int, map[string]interface{}, error)
+
+	// GetRules returns all alert rules.
+	GetRules(ctx context.Context) (int, map[string]interface{}, error)
+
+	// GetRule returns an alert rule.
+	GetRule(ctx context.Context, ruleId string) (int, map[string]interface{}, error)
+
+	// DeleteRule deletes an alert rule and its alerts.
+	DeleteRule(ctx context.Context, ruleId string) (int, error)
+
+	// GetAlerts returns alerts matched with query.
+	GetAlerts(ctx context.Context, query map[string][]string) (int, map[string]interface{}, error)
+
+	// Evaluate checks all rules against resource usage of nodes collected at now.
+	Evaluate(ctx context.Context, samples map[string]models.ResourceSample, now time.Time)
+}
+
+const (
+	ID               = "id"
+	MEMBERS          = "members"
+	IP               = "ip"     // used to indicate an ip address.
+	CONFIG           = "config" // used to indicate a configuration of a node.
+	APPS             = "apps"   // used to indicate apps deployed to a node.
+	GET              = "GET"
+	STATUS           = "status" // query key of the status of alerts.
+	NODE_ID          = "nodeId" // query key of the node of alerts.
+	RULE_ID          = "ruleId" // query key of the rule of alerts.
+	TARGET_NODE      = "node"
+	TARGET_APP       = "app"
+	METRIC_CPU       = "cpu"
+	METRIC_MEM       = "mem"
+	METRIC_DISK      = "disk"
+	METRIC_MEM_USAGE = "memusage" // memory usage of an app in MB.
+	STATUS_FIRING    = noti.STATUS_FIRING
+	STATUS_RESOLVED  = noti.STATUS_RESOLVED
+	EVENT_ID         = "eventid"
+	EVENT            = "event"
+)
+
+// operators compare a value with the threshold of a rule.
+var operators = map[string]func(value float64, threshold float64) bool{
+	">":  func(value float64, threshold float64) bool { return value > threshold },
+	">=": func(value float64, threshold float64) bool { return value >= threshold },
+	"<":  func(value float64, threshold float64) bool { return value < threshold },
+	"<=": func(value float64, threshold float64) bool { return value <= threshold },
+}
+
+// metrics are the metrics which can be used in rules of each target.
+var metrics = map[string][]string{
+	TARGET_NODE: {METRIC_CPU, METRIC_MEM, METRIC_DISK},
+	TARGET_APP:  {METRIC_CPU, METRIC_MEM, METRIC_MEM_USAGE},
+}
+
+// condition is the state of a rule on a node kept between evaluations.
+type condition struct {
+	ruleId  string
+	since   time.Time // time when the rule started to hold.
+	firing  bool
+	firedAt string
+}
+
+// Executor implements the Command interface.
+type Executor struct{}
+
+var alertDbExecutor alertDB.Command
+var groupDbExecutor groupDB.Command
+var nodeDbExecutor nodeDB.Command
+var httpExecutor messenger.Command
+var notiExecutor noti.Command
+
+var (
+	mutex      = &sync.Mutex{}
+	conditions = make(map[string]*condition)
+	loaded     = false
+)
+
+func init() {
+	alertDbExecutor = alertDB.Executor{}
+	groupDbExecutor = groupDB.Executor{}
+	nodeDbExecutor = nodeDB.Executor{}
+	httpExecutor = messenger.NewExecutor()
+	notiExecutor = noti.Executor{}
+}
+
+// AddRule validates a rule in body and inserts it to databases.
+// If successful, this function returns the rule with a new id.
+// otherwise, an appropriate error will be returned.
+func (Executor) AddRule(ctx context.Context, body string) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	req := models.AlertRule{}
+	err := models.Decode(body, &req)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	err = validateRule(req)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	rule, err := alertDbExecutor.AddRule(ctx, alertDB.Rule{
+		Name:      req.Name,
+		Target:    req.Target,
+		Metric:    req.Metric,
+		Operator:  req.Operator,
+		Threshold: req.Threshold,
+		Duration:  req.Duration,
+		NodeID:    req.NodeID,
+		GroupID:   req.GroupID,
+		AppID:     req.AppID,
+	})
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	resp := models.AlertRule{}
+	models.Convert(rule, &resp)
+	return convertToMap(resp)
+}
+
+// GetRules returns all rules.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetRules(ctx context.Context) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	rules, err := alertDbExecutor.GetRules(ctx)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	resp := models.AlertRuleList{Rules: make([]models.AlertRule, 0)}
+	models.Convert(rules, &resp.Rules)
+	return convertToMap(resp)
+}
+
+// GetRule returns the rule specified by ruleId parameter.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetRule(ctx context.Context, ruleId string) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	rule, err := alertDbExecutor.GetRule(ctx, ruleId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	resp := models.AlertRule{}
+	models.Convert(rule, &resp)
+	return convertToMap(resp)
+}
+
+// DeleteRule deletes the rule specified by ruleId parameter with its alerts.
+// Alerts of the rule which are firing are dropped without being resolved.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) DeleteRule(ctx context.Context, ruleId string) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	err := alertDbExecutor.DeleteRule(ctx, ruleId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	for id, c := range conditions {
+		if c.ruleId == ruleId {
+			delete(conditions, id)
+		}
+	}
+	return results.OK, nil
+}
+
+// GetAlerts returns alerts filtered by 'status', 'nodeId' and 'ruleId' in query.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetAlerts(ctx context.Context, query map[string][]string) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	alerts, err := alertDbExecutor.GetAlerts(ctx)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	resp := models.AlertList{Alerts: make([]models.Alert, 0)}
+	for _, alert := range alerts {
+		if !matches(alert, query, STATUS) || !matches(alert, query, NODE_ID) || !matches(alert, query, RULE_ID) {
+			continue
+		}
+		x := models.Alert{}
+		models.Convert(alert, &x)
+		resp.Alerts = append(resp.Alerts, x)
+	}
+	return convertToMap(resp)
+}
+
+// Evaluate checks every rule against usage of the nodes targeted by the rule.
+// An alert is fired when a rule has held for the duration of the rule,
+// and resolved at the first evaluation on which it does not hold.
+// Nodes absent from samples, e.g. disconnected ones, keep their state.
+// Values are measured before states are locked, and alerts are stored and
+// notified after states are unlocked.
+func (Executor) Evaluate(ctx context.Context, samples map[string]models.ResourceSample, now time.Time) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	rules, err := alertDbExecutor.GetRules(ctx)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return
+	}
+
+	ruleIds := make(map[string]bool)
+	measured := make([]measurement, 0)
+	for _, r := range rules {
+		rule := models.AlertRule{}
+		models.Convert(r, &rule)
+		ruleIds[rule.ID] = true
+
+		nodeIds := getTargetNodes(ctx, rule, samples)
+		if len(nodeIds) == 0 {
+			continue
+		}
+		if rule.Target == TARGET_NODE {
+			measured = append(measured, measureNodes(rule, nodeIds, samples)...)
+		} else {
+			measured = append(measured, measureApp(ctx, rule, nodeIds)...)
+		}
+	}
+
+	for _, c := range apply(ctx, measured, ruleIds, now) {
+		setAlert(ctx, c, now)
+	}
+}
+
+// measurement is a value of the metric of a rule taken on a node.
+type measurement struct {
+	rule   models.AlertRule
+	nodeId string
+	value  float64
+}
+
+// change is an alert which is fired or resolved by an evaluation.
+type change struct {
+	rule    models.AlertRule
+	nodeId  string
+	id      string
+	status  string
+	value   float64
+	firedAt string
+}
+
+// apply updates states of rules with measured values under the lock,
+// and returns alerts which are fired or resolved.
+func apply(ctx context.Context, measured []measurement, ruleIds map[string]bool, now time.Time) []change {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if !loaded {
+		loaded = loadConditions(ctx)
+	}
+
+	changes := make([]change, 0)
+	for _, m := range measured {
+		if c, changed := update(m.rule, m.nodeId, m.value, now); changed {
+			changes = append(changes, c)
+		}
+	}
+
+	// Drop states of rules deleted by others.
+	for id, c := range conditions {
+		if !ruleIds[c.ruleId] {
+			delete(conditions, id)
+		}
+	}
+	return changes
+}
+
+// loadConditions restores alerts which were firing before the anchor restarted.
+func loadConditions(ctx context.Context) bool {
+	alerts, err := alertDbExecutor.GetAlerts(ctx)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return false
+	}
+
+	for _, value := range alerts {
+		alert := models.Alert{}
+		models.Convert(value, &alert)
+		if alert.Status == STATUS_FIRING {
+			conditions[alert.ID] = &condition{ruleId: alert.RuleID, firing: true, firedAt: alert.FiredAt}
+		}
+	}
+	return true
+}
+
+// getTargetNodes returns nodes in samples which are narrowed by a rule.
+func getTargetNodes(ctx context.Context, rule models.AlertRule, samples map[string]models.ResourceSample) []string {
+	candidates := make([]string, 0)
+	switch {
+	case len(rule.NodeID) != 0:
+		candidates = append(candidates, rule.NodeID)
+	case len(rule.GroupID) != 0:
+		group, err := groupDbExecutor.GetGroup(ctx, rule.GroupID)
+		if err != nil {
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return candidates
+		}
+		members, _ := group[MEMBERS].([]string)
+		candidates = append(candidates, members...)
+	default:
+		for nodeId := range samples {
+			candidates = append(candidates, nodeId)
+		}
+	}
+
+	nodes := make([]string, 0)
+	for _, nodeId := range candidates {
+		if _, exists := samples[nodeId]; exists {
+			nodes = append(nodes, nodeId)
+		}
+	}
+	return nodes
+}
+
+// measureNodes returns the values of the metric of a node rule in samples.
+func measureNodes(rule models.AlertRule, nodeIds []string, samples map[string]models.ResourceSample) []measurement {
+	measured := make([]measurement, 0)
+	for _, nodeId := range nodeIds {
+		x := samples[nodeId]
+		var value float64
+		switch rule.Metric {
+		case METRIC_CPU:
+			value = x.CPU
+		case METRIC_MEM:
+			value = x.Mem
+		case METRIC_DISK:
+			value = x.Disk
+		default:
+			continue
+		}
+		measured = append(measured, measurement{rule: rule, nodeId: nodeId, value: value})
+	}
+	return measured
+}
+
+// measureApp requests resource usage of the app of a rule to all nodes which
+// the app is deployed to at once, and returns the values of the metric.
+// Nodes which the app is not deployed to, or which fail, are left out.
+func measureApp(ctx context.Context, rule models.AlertRule, nodeIds []string) []measurement {
+	measured := make([]measurement, 0)
+
+	nodes, err := nodeDbExecutor.GetNodes(ctx, map[string]interface{}{APPS: rule.AppID})
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return measured
+	}
+
+	targets := make([]map[string]interface{}, 0)
+	address := make([]map[string]interface{}, 0)
+	for _, node := range nodes {
+		id, _ := node[ID].(string)
+		if !util.IsContainedStringInList(nodeIds, id) {
+			continue
+		}
+		targets = append(targets, node)
+		address = append(address, map[string]interface{}{
+			IP:     node[IP],
+			CONFIG: node[CONFIG],
+		})
+	}
+	if len(targets) == 0 {
+		return measured
+	}
+
+	urls := util.MakeRequestUrl(address, url.Monitoring(), url.Apps(), "/", rule.AppID, url.Resource())
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, GET, urls, nil)
+
+	for i, node := range targets {
+		nodeId := node[ID].(string)
+		if !util.IsSuccessCode(codes[i]) {
+			continue
+		}
+
+		resp, err := util.ConvertJsonToMap(respStr[i])
+		if err != nil {
+			logger.With(ctx).Logging(logger.ERROR, nodeId, err.Error())
+			continue
+		}
+
+		app := models.AppResource{}
+		err = models.Convert(resp, &app)
+		if err != nil {
+			logger.With(ctx).Logging(logger.ERROR, nodeId, err.Error())
+			continue
+		}
+
+		var value float64
+		cpu, mem, memUsage := app.Usage()
+		switch rule.Metric {
+		case METRIC_CPU:
+			value = cpu
+		case METRIC_MEM:
+			value = mem
+		case METRIC_MEM_USAGE:
+			value = memUsage
+		default:
+			continue
+		}
+		measured = append(measured, measurement{rule: rule, nodeId: nodeId, value: value})
+	}
+	return measured
+}
+
+// update changes the state of a rule on a node with a new value,
+// and returns the alert with true if it is fired or resolved.
+func update(rule models.AlertRule, nodeId string, value float64, now time.Time) (change, bool) {
+	id := rule.ID + "/" + nodeId
+	c, exists := conditions[id]
+
+	if !operators[rule.Operator](value, rule.Threshold) {
+		delete(conditions, id)
+		if exists && c.firing {
+			return change{rule, nodeId, id, STATUS_RESOLVED, value, c.firedAt}, true
+		}
+		return change{}, false
+	}
+
+	if !exists {
+		c = &condition{ruleId: rule.ID, since: now}
+		conditions[id] = c
+	}
+	if c.firing || now.Sub(c.since) < time.Duration(rule.Duration)*time.Second {
+		return change{}, false
+	}
+
+	c.firing = true
+	c.firedAt = now.UTC().Format(time.RFC3339)
+	return change{rule, nodeId, id, STATUS_FIRING, value, c.firedAt}, true
+}
+
+// setAlert stores the state of an alert and sends it to subscribers of
+// resource events of the node.
+func setAlert(ctx context.Context, c change, now time.Time) {
+	alert := alertDB.Alert{
+		ID:      c.id,
+		RuleID:  c.rule.ID,
+		NodeID:  c.nodeId,
+		AppID:   c.rule.AppID,
+		Status:  c.status,
+		Value:   c.value,
+		FiredAt: c.firedAt,
+	}
+	if c.status == STATUS_RESOLVED {
+		alert.ResolvedAt = now.UTC().Format(time.RFC3339)
+	}
+
+	err := alertDbExecutor.SetAlert(ctx, alert)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+	}
+
+	event := map[string]interface{}{
+		"id":        c.id,
+		"ruleId":    c.rule.ID,
+		"name":      c.rule.Name,
+		"nodeId":    c.nodeId,
+		"metric":    c.rule.Metric,
+		"operator":  c.rule.Operator,
+		"threshold": c.rule.Threshold,
+		"value":     c.value,
+		"status":    c.status,
+		"timestamp": now.UTC().Format(time.RFC3339),
+	}
+	if len(c.rule.AppID) != 0 {
+		event["appId"] = c.rule.AppID
+	}
+
+	notification := map[string]interface{}{
+		EVENT_ID: []string{c.nodeId},
+		EVENT:    event,
+	}
+	body, err := models.Encode(notification)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return
+	}
+	notiExecutor.NotificationHandler(ctx, noti.RESOURCE, body)
+}
+
+// validateRule checks whether fields of a rule are consistent.
+func validateRule(rule models.AlertRule) error {
+	available, exists := metrics[rule.Target]
+	if !exists {
+		return errors.InvalidField{"target", "must be " + TARGET_NODE + " or " + TARGET_APP}
+	}
+
+	if !util.IsContainedStringInList(available, rule.Metric) {
+		return errors.InvalidField{"metric", "must be one of " + strings.Join(available, ", ")}
+	}
+
+	if _, exists := operators[rule.Operator]; !exists {
+		return errors.InvalidField{"operator", "must be one of >, >=, <, <="}
+	}
+
+	if rule.Duration < 0 {
+		return errors.InvalidField{"duration", "must not be negative"}
+	}
+
+	if rule.Target == TARGET_APP && len(rule.AppID) == 0 {
+		return errors.InvalidField{"appId", "is required for an app rule"}
+	}
+	return nil
+}
+
+// matches checks whether the field of an alert named key is the value of key in query, if given.
+func matches(alert map[string]interface{}, query map[string][]string, key string) bool {
+	values, exists := query[key]
+	if !exists || len(values) == 0 || len(values[0]) == 0 {
+		return true
+	}
+	return alert[key] == values[0]
+}
+
+// convertToMap converts a response into a map as other controllers return.
+func convertToMap(resp interface{}) (int, map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	err := models.Convert(resp, &result)
+	if err != nil {
+		logger.Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, errors.InternalServerError{err.Error()}
+	}
+	return results.OK, result, nil
+}
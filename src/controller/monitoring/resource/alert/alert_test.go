@@ -0,0 +1,296 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package alert
+
+import (
+	"commons/errors"
+	"commons/models"
+	"commons/results"
+	"context"
+	noti "controller/notification"
+	notimocks "controller/notification/mocks"
+	alertDB "db/mongo/alert"
+	alertdbmocks "db/mongo/alert/mocks"
+	groupdbmocks "db/mongo/group/mocks"
+	nodedbmocks "db/mongo/node/mocks"
+	"github.com/golang/mock/gomock"
+	msgmocks "messenger/mocks"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const (
+	ruleId  = "000000000000000000000001"
+	nodeId  = "000000000000000000000002"
+	nodeId2 = "000000000000000000000003"
+	groupId = "000000000000000000000004"
+	appId   = "000000000000000000005"
+	testIp  = "127.0.0.1"
+	testIp2 = "127.0.0.2"
+	port    = "48098"
+)
+
+var (
+	start   = time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	cpuRule = map[string]interface{}{
+		"id":        ruleId,
+		"name":      "busy",
+		"target":    TARGET_NODE,
+		"metric":    METRIC_CPU,
+		"operator":  ">",
+		"threshold": 90.0,
+		"duration":  120,
+		"nodeId":    "",
+		"groupId":   "",
+		"appId":     "",
+	}
+	appRule = map[string]interface{}{
+		"id":        ruleId,
+		"name":      "leak",
+		"target":    TARGET_APP,
+		"metric":    METRIC_MEM_USAGE,
+		"operator":  ">",
+		"threshold": 512.0,
+		"duration":  0,
+		"nodeId":    "",
+		"groupId":   groupId,
+		"appId":     appId,
+	}
+	appResource = `{"services":[{"cname":"web","memusage":"412MiB"},{"cname":"db","memusage":"0.25GiB"}]}`
+	config      = map[string]interface{}{
+		"properties": []interface{}{
+			map[string]interface{}{"reverseproxy": map[string]interface{}{"enabled": false}},
+		},
+	}
+)
+
+func resetConditions() {
+	conditions = make(map[string]*condition)
+	loaded = true
+}
+
+func usage(cpu float64) map[string]models.ResourceSample {
+	return map[string]models.ResourceSample{nodeId: {CPU: cpu}}
+}
+
+func TestCalledAddRule_ExpectRuleInserted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alertDbMockObj := alertdbmocks.NewMockCommand(ctrl)
+
+	rule := alertDB.Rule{Name: "busy", Target: TARGET_NODE, Metric: METRIC_CPU, Operator: ">", Threshold: 90, Duration: 120}
+
+	gomock.InOrder(
+		alertDbMockObj.EXPECT().AddRule(gomock.Any(), rule).Return(cpuRule, nil),
+	)
+
+	// pass mockObj to a real object.
+	alertDbExecutor = alertDbMockObj
+
+	body := `{"name":"busy","target":"node","metric":"cpu","operator":">","threshold":90,"duration":120}`
+	code, res, err := Executor{}.AddRule(context.Background(), body)
+	if code != results.OK || err != nil {
+		t.Fatalf("Unexpected result: %d, %v", code, err)
+	}
+
+	if res["id"] != ruleId {
+		t.Errorf("Expected id: %s, actual res: %v", ruleId, res)
+	}
+	if _, exists := res["appId"]; exists {
+		t.Errorf("Expected empty appId omitted, actual res: %v", res)
+	}
+}
+
+func TestCalledAddRuleWithInvalidRule_ExpectErrorReturn(t *testing.T) {
+	testList := map[string]string{
+		"target":   `{"target":"cluster","metric":"cpu","operator":">"}`,
+		"metric":   `{"target":"node","metric":"memusage","operator":">"}`,
+		"operator": `{"target":"node","metric":"cpu","operator":"=="}`,
+		"duration": `{"target":"node","metric":"cpu","operator":">","duration":-1}`,
+		"appId":    `{"target":"app","metric":"memusage","operator":">"}`,
+	}
+
+	for field, body := range testList {
+		t.Run(field, func(t *testing.T) {
+			code, _, err := Executor{}.AddRule(context.Background(), body)
+			if code != results.ERROR {
+				t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
+			}
+			if e, ok := err.(errors.InvalidField); !ok || e.Field != field {
+				t.Errorf("Expected err: InvalidField of %s, actual err: %v", field, err)
+			}
+		})
+	}
+}
+
+func TestCalledEvaluate_ExpectAlertFiredAfterDurationAndResolved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alertDbMockObj := alertdbmocks.NewMockCommand(ctrl)
+	notiMockObj := notimocks.NewMockCommand(ctrl)
+
+	id := ruleId + "/" + nodeId
+	firing := alertDB.Alert{ID: id, RuleID: ruleId, NodeID: nodeId, Status: STATUS_FIRING,
+		Value: 95, FiredAt: "2018-01-01T00:02:00Z"}
+	resolved := firing
+	resolved.Status = STATUS_RESOLVED
+	resolved.Value = 50
+	resolved.ResolvedAt = "2018-01-01T00:03:00Z"
+
+	gomock.InOrder(
+		alertDbMockObj.EXPECT().GetRules(gomock.Any()).Return([]map[string]interface{}{cpuRule}, nil).Times(3),
+		alertDbMockObj.EXPECT().SetAlert(gomock.Any(), firing).Return(nil),
+		notiMockObj.EXPECT().NotificationHandler(gomock.Any(), noti.RESOURCE, gomock.Any()).Return(results.OK, nil),
+		alertDbMockObj.EXPECT().GetRules(gomock.Any()).Return([]map[string]interface{}{cpuRule}, nil).Times(2),
+		alertDbMockObj.EXPECT().SetAlert(gomock.Any(), resolved).Return(nil),
+		notiMockObj.EXPECT().NotificationHandler(gomock.Any(), noti.RESOURCE, gomock.Any()).Return(results.OK, nil),
+	)
+
+	// pass mockObj to a real object.
+	alertDbExecutor = alertDbMockObj
+	notiExecutor = notiMockObj
+	resetConditions()
+
+	executor := Executor{}
+	executor.Evaluate(context.Background(), usage(95), start)
+	executor.Evaluate(context.Background(), usage(95), start.Add(time.Minute))
+	executor.Evaluate(context.Background(), usage(95), start.Add(2*time.Minute))
+	executor.Evaluate(context.Background(), usage(95), start.Add(150*time.Second))
+	executor.Evaluate(context.Background(), usage(50), start.Add(3*time.Minute))
+
+	if len(conditions) != 0 {
+		t.Errorf("Expected no conditions, actual conditions: %v", conditions)
+	}
+}
+
+func TestCalledEvaluateAfterRestart_ExpectFiringAlertResolved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alertDbMockObj := alertdbmocks.NewMockCommand(ctrl)
+	notiMockObj := notimocks.NewMockCommand(ctrl)
+
+	id := ruleId + "/" + nodeId
+	stored := map[string]interface{}{"id": id, "ruleId": ruleId, "nodeId": nodeId,
+		"status": STATUS_FIRING, "value": 95.0, "firedAt": "2018-01-01T00:00:00Z"}
+	resolved := alertDB.Alert{ID: id, RuleID: ruleId, NodeID: nodeId, Status: STATUS_RESOLVED,
+		Value: 10, FiredAt: "2018-01-01T00:00:00Z", ResolvedAt: "2018-01-01T01:00:00Z"}
+
+	gomock.InOrder(
+		alertDbMockObj.EXPECT().GetRules(gomock.Any()).Return([]map[string]interface{}{cpuRule}, nil),
+		alertDbMockObj.EXPECT().GetAlerts(gomock.Any()).Return([]map[string]interface{}{stored}, nil),
+		alertDbMockObj.EXPECT().SetAlert(gomock.Any(), resolved).Return(nil),
+		notiMockObj.EXPECT().NotificationHandler(gomock.Any(), noti.RESOURCE, gomock.Any()).Return(results.OK, nil),
+	)
+
+	// pass mockObj to a real object.
+	alertDbExecutor = alertDbMockObj
+	notiExecutor = notiMockObj
+	resetConditions()
+	loaded = false
+
+	Executor{}.Evaluate(context.Background(), usage(10), start.Add(time.Hour))
+}
+
+func TestCalledEvaluateWithAppRule_ExpectMembersOfGroupRequestedAtOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alertDbMockObj := alertdbmocks.NewMockCommand(ctrl)
+	groupDbMockObj := groupdbmocks.NewMockCommand(ctrl)
+	nodeDbMockObj := nodedbmocks.NewMockCommand(ctrl)
+	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	notiMockObj := notimocks.NewMockCommand(ctrl)
+
+	group := map[string]interface{}{"id": groupId, "members": []string{nodeId, nodeId2}}
+	samples := map[string]models.ResourceSample{nodeId: {}, nodeId2: {}, "000000000000000000000009": {}}
+	nodes := []map[string]interface{}{
+		{"id": nodeId, "ip": testIp, "apps": []string{appId}, "config": config},
+		{"id": nodeId2, "ip": testIp2, "apps": []string{appId}, "config": config},
+		{"id": "000000000000000000000009", "ip": testIp2, "apps": []string{appId}, "config": config},
+	}
+	expectedUrls := []string{
+		"http://" + testIp + ":" + port + "/api/v1/monitoring/apps/" + appId + "/resource",
+		"http://" + testIp2 + ":" + port + "/api/v1/monitoring/apps/" + appId + "/resource",
+	}
+	firing := alertDB.Alert{ID: ruleId + "/" + nodeId, RuleID: ruleId, NodeID: nodeId, AppID: appId,
+		Status: STATUS_FIRING, Value: 668, FiredAt: "2018-01-01T00:00:00Z"}
+
+	gomock.InOrder(
+		alertDbMockObj.EXPECT().GetRules(gomock.Any()).Return([]map[string]interface{}{appRule}, nil),
+		groupDbMockObj.EXPECT().GetGroup(gomock.Any(), groupId).Return(group, nil),
+		nodeDbMockObj.EXPECT().GetNodes(gomock.Any(), map[string]interface{}{APPS: appId}).Return(nodes, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrls, nil).Return(
+			[]int{results.OK, results.ERROR}, []string{appResource, `{"message":"not running"}`}),
+		alertDbMockObj.EXPECT().SetAlert(gomock.Any(), firing).Return(nil),
+		notiMockObj.EXPECT().NotificationHandler(gomock.Any(), noti.RESOURCE, gomock.Any()).Do(func(context.Context, string, string) {
+			// States must be unlocked while subscribers are notified.
+			mutex.Lock()
+			mutex.Unlock()
+		}).Return(results.OK, nil),
+	)
+
+	// pass mockObj to a real object.
+	alertDbExecutor = alertDbMockObj
+	groupDbExecutor = groupDbMockObj
+	nodeDbExecutor = nodeDbMockObj
+	httpExecutor = msgMockObj
+	notiExecutor = notiMockObj
+	resetConditions()
+
+	Executor{}.Evaluate(context.Background(), samples, start)
+}
+
+func TestCalledGetAlertsWithQuery_ExpectFilteredAlerts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alertDbMockObj := alertdbmocks.NewMockCommand(ctrl)
+
+	alerts := []map[string]interface{}{
+		{"id": "a", "ruleId": ruleId, "nodeId": nodeId, "appId": "", "status": STATUS_FIRING,
+			"value": 95.0, "firedAt": "2018-01-01T00:00:00Z", "resolvedAt": ""},
+		{"id": "b", "ruleId": ruleId, "nodeId": nodeId2, "appId": "", "status": STATUS_RESOLVED,
+			"value": 50.0, "firedAt": "2018-01-01T00:00:00Z", "resolvedAt": "2018-01-01T00:10:00Z"},
+	}
+
+	gomock.InOrder(
+		alertDbMockObj.EXPECT().GetAlerts(gomock.Any()).Return(alerts, nil),
+	)
+
+	// pass mockObj to a real object.
+	alertDbExecutor = alertDbMockObj
+
+	code, res, err := Executor{}.GetAlerts(context.Background(), map[string][]string{STATUS: {STATUS_FIRING}})
+	if code != results.OK || err != nil {
+		t.Fatalf("Unexpected result: %d, %v", code, err)
+	}
+
+	expected := map[string]interface{}{
+		"alerts": []interface{}{
+			map[string]interface{}{"id": "a", "ruleId": ruleId, "nodeId": nodeId, "status": STATUS_FIRING,
+				"value": 95.0, "firedAt": "2018-01-01T00:00:00Z"},
+		},
+	}
+	if !reflect.DeepEqual(expected, res) {
+		t.Errorf("Expected res: %v, actual res: %v", expected, res)
+	}
+}
@@ -0,0 +1,260 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package controller/monitoring/resource/group requests resource usage of
+// all members of a group at once, and aggregates the results.
+package group
+
+import (
+	"commons/errors"
+	"commons/logger"
+	"commons/models"
+	"commons/results"
+	"commons/url"
+	"commons/util"
+	"context"
+	groupDB "db/mongo/group"
+	"messenger"
+	"strconv"
+)
+
+const (
+	ID            = "id"        // used to indicate an id.
+	IP            = "ip"        // used to indicate an ip address.
+	CONFIG        = "config"    // used to indicate a configuration of a node.
+	RESPONSE_CODE = "code"      // used to indicate a code.
+	ERROR_MESSAGE = "message"   // used to indicate a message.
+	RESPONSES     = "responses" // used to indicate a list of responses.
+	AGGREGATE     = "aggregate" // used to indicate statistics over members.
+	GET           = "GET"
+)
+
+// Command is an interface of group resource monitoring operations.
+type Command interface {
+	// GetGroupResourceInfo request resource usage of all members of a group.
+	GetGroupResourceInfo(ctx context.Context, groupId string) (int, map[string]interface{}, error)
+
+	// GetGroupAppResourceInfo request resource usage of an application
+	// from all members of a group which the application is deployed to.
+	GetGroupAppResourceInfo(ctx context.Context, groupId string, appId string) (int, map[string]interface{}, error)
+}
+
+type Executor struct{}
+
+var groupDbExecutor groupDB.Command
+var httpExecutor messenger.Command
+
+func init() {
+	groupDbExecutor = groupDB.Executor{}
+	httpExecutor = messenger.NewExecutor()
+}
+
+// GetGroupResourceInfo request resource usage to all members of the group specified
+// by groupId parameter. The response has the usage of each member and an aggregate
+// over members which succeeded, with the average of cores as cpu and the fullest
+// partition as disk of each member.
+// If some of members fail, MULTI_STATUS is returned with the code of each member.
+// Otherwise, an appropriate error will be returned.
+func (Executor) GetGroupResourceInfo(ctx context.Context, groupId string) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	members, err := groupDbExecutor.GetGroupMembers(ctx, groupId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	result, resp, respMap, err := request(ctx, members, url.Monitoring(), url.Resource())
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	var cpu, mem, disk []float64
+	for _, value := range respMap {
+		resource := models.NodeResource{}
+		if models.Convert(value, &resource) != nil {
+			continue
+		}
+		c, m, d := resource.Usage()
+		cpu, mem, disk = append(cpu, c), append(mem, m), append(disk, d)
+	}
+	resp[AGGREGATE] = models.NodeResourceStatistics{
+		Nodes: len(cpu),
+		CPU:   statistic(cpu),
+		Mem:   statistic(mem),
+		Disk:  statistic(disk),
+	}
+	return result, resp, err
+}
+
+// GetGroupAppResourceInfo request resource usage of the application specified by
+// appId parameter to members of the group which the application is deployed to.
+// The response has the usage of each member and an aggregate over members which
+// succeeded, with the sum of services of the application on each member.
+// If some of members fail, MULTI_STATUS is returned with the code of each member.
+// Otherwise, an appropriate error will be returned.
+func (Executor) GetGroupAppResourceInfo(ctx context.Context, groupId string, appId string) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	members, err := groupDbExecutor.GetGroupMembersByAppID(ctx, groupId, appId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	result, resp, respMap, err := request(ctx, members, url.Monitoring(), url.Apps(), "/", appId, url.Resource())
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	var cpu, mem, memUsage []float64
+	for _, value := range respMap {
+		resource := models.AppResource{}
+		if models.Convert(value, &resource) != nil {
+			continue
+		}
+		c, m, u := resource.Usage()
+		cpu, mem, memUsage = append(cpu, c), append(mem, m), append(memUsage, u)
+	}
+	resp[AGGREGATE] = models.AppResourceStatistics{
+		Nodes:    len(cpu),
+		CPU:      statistic(cpu),
+		Mem:      statistic(mem),
+		MemUsage: statistic(memUsage),
+	}
+	return result, resp, err
+}
+
+// request sends a request to all members at once, and makes a response
+// which has the result of each member. The responses of members which succeeded
+// are also returned to be aggregated.
+func request(ctx context.Context, members []map[string]interface{}, apiParts ...string) (int,
+	map[string]interface{}, []map[string]interface{}, error) {
+
+	address := getMemberAddress(members)
+	urls := util.MakeRequestUrl(address, apiParts...)
+
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, GET, urls, nil)
+
+	// Convert the received response from string to map.
+	respMap, err := convertRespToMap(respStr)
+	if err != nil {
+		return results.ERROR, nil, nil, err
+	}
+
+	result := decideResultCode(codes)
+
+	respValue := make([]map[string]interface{}, len(members))
+	succeeded := make([]map[string]interface{}, 0)
+	for i, node := range members {
+		respValue[i] = make(map[string]interface{})
+		respValue[i][ID] = node[ID].(string)
+
+		if result != results.OK {
+			// Make separate responses to represent partial failure case.
+			respValue[i][RESPONSE_CODE] = strconv.Itoa(codes[i])
+			if !util.IsSuccessCode(codes[i]) {
+				respValue[i][ERROR_MESSAGE] = respMap[i][ERROR_MESSAGE]
+				continue
+			}
+		}
+
+		for key, value := range respMap[i] {
+			respValue[i][key] = value
+		}
+		succeeded = append(succeeded, respMap[i])
+	}
+
+	resp := make(map[string]interface{})
+	resp[RESPONSES] = respValue
+	return result, resp, succeeded, nil
+}
+
+// statistic computes the average and maximum of values.
+func statistic(values []float64) models.Statistic {
+	result := models.Statistic{}
+	if len(values) == 0 {
+		return result
+	}
+
+	for _, value := range values {
+		result.Avg += value
+		if value > result.Max {
+			result.Max = value
+		}
+	}
+	result.Avg /= float64(len(values))
+	return result
+}
+
+// getMemberAddress returns an member's address as an array.
+func getMemberAddress(members []map[string]interface{}) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(members))
+	for i, node := range members {
+		result[i] = map[string]interface{}{
+			IP:     node[IP],
+			CONFIG: node[CONFIG],
+		}
+	}
+	return result
+}
+
+// convertRespToMap converts a response in the form of JSON data into a map.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func convertRespToMap(respStr []string) ([]map[string]interface{}, error) {
+	respMap := make([]map[string]interface{}, len(respStr))
+	for i, v := range respStr {
+		resp, err := util.ConvertJsonToMap(v)
+		if err != nil {
+			logger.Logging(logger.ERROR, "Failed to convert response from string to map")
+			return nil, errors.InternalServerError{"Json Converting Failed"}
+		}
+		respMap[i] = resp
+	}
+
+	return respMap, nil
+}
+
+// decideResultCode returns a result of group operations.
+// OK: Returned when all members of the group send a success response.
+// MULTI_STATUS: Partial success for multiple requests. Some requests succeeded
+// but at least one failed.
+// ERROR: Returned when all members of the group send an error response.
+func decideResultCode(codes []int) int {
+	successCounts := 0
+	for _, code := range codes {
+		if util.IsSuccessCode(code) {
+			successCounts++
+		}
+	}
+
+	result := results.OK
+	switch successCounts {
+	case len(codes):
+		result = results.OK
+	case 0:
+		result = results.ERROR
+	default:
+		result = results.MULTI_STATUS
+	}
+	return result
+}
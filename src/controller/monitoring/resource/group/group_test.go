@@ -0,0 +1,192 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package group
+
+import (
+	"commons/errors"
+	"commons/models"
+	"commons/results"
+	"context"
+	groupdbmocks "db/mongo/group/mocks"
+	"github.com/golang/mock/gomock"
+	msgmocks "messenger/mocks"
+	"reflect"
+	"testing"
+)
+
+const (
+	groupId = "000000000000000000000001"
+	nodeId  = "000000000000000000000002"
+	nodeId2 = "000000000000000000000003"
+	appId   = "000000000000000000004"
+)
+
+var (
+	config = map[string]interface{}{
+		"properties": []interface{}{
+			map[string]interface{}{"reverseproxy": map[string]interface{}{"enabled": false}},
+		},
+	}
+	members = []map[string]interface{}{
+		{"id": nodeId, "ip": "127.0.0.1", "config": config},
+		{"id": nodeId2, "ip": "127.0.0.2", "config": config},
+	}
+	nodeUrls = []string{
+		"http://127.0.0.1:48098/api/v1/monitoring/resource",
+		"http://127.0.0.2:48098/api/v1/monitoring/resource",
+	}
+	appUrls = []string{
+		"http://127.0.0.1:48098/api/v1/monitoring/apps/" + appId + "/resource",
+		"http://127.0.0.2:48098/api/v1/monitoring/apps/" + appId + "/resource",
+	}
+	nodeResource  = `{"cpu":["10%","30%"],"mem":{"usedpercent":"40%"},"disk":[{"path":"/","usedpercent":"60%"}]}`
+	nodeResource2 = `{"cpu":["60%"],"mem":{"usedpercent":"20%"},"disk":[{"path":"/","usedpercent":"80%"}]}`
+	appResource   = `{"services":[{"cname":"web","cpu":"5%","mem":"10%","memusage":"256MiB"}]}`
+	errorResponse = `{"message":"not found app"}`
+)
+
+func TestCalledGetGroupResourceInfo_ExpectResponsesAndAggregate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	groupDbMockObj := groupdbmocks.NewMockCommand(ctrl)
+	msgMockObj := msgmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		groupDbMockObj.EXPECT().GetGroupMembers(gomock.Any(), groupId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), GET, nodeUrls, nil).
+			Return([]int{results.OK, results.OK}, []string{nodeResource, nodeResource2}),
+	)
+
+	// pass mockObj to a real object.
+	groupDbExecutor = groupDbMockObj
+	httpExecutor = msgMockObj
+
+	code, res, err := Executor{}.GetGroupResourceInfo(context.Background(), groupId)
+	if code != results.OK || err != nil {
+		t.Fatalf("Unexpected result: %d, %v", code, err)
+	}
+
+	responses := res[RESPONSES].([]map[string]interface{})
+	if len(responses) != 2 || responses[1][ID] != nodeId2 || responses[1]["cpu"] == nil {
+		t.Errorf("Unexpected responses: %v", responses)
+	}
+	if _, exists := responses[0][RESPONSE_CODE]; exists {
+		t.Errorf("Expected no code when all members succeed, actual response: %v", responses[0])
+	}
+
+	expected := models.NodeResourceStatistics{
+		Nodes: 2,
+		CPU:   models.Statistic{Avg: 40, Max: 60},
+		Mem:   models.Statistic{Avg: 30, Max: 40},
+		Disk:  models.Statistic{Avg: 70, Max: 80},
+	}
+	if !reflect.DeepEqual(expected, res[AGGREGATE]) {
+		t.Errorf("Expected aggregate: %v, actual aggregate: %v", expected, res[AGGREGATE])
+	}
+}
+
+func TestCalledGetGroupAppResourceInfoWhenMemberFailed_ExpectMultiStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	groupDbMockObj := groupdbmocks.NewMockCommand(ctrl)
+	msgMockObj := msgmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		groupDbMockObj.EXPECT().GetGroupMembersByAppID(gomock.Any(), groupId, appId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), GET, appUrls, nil).
+			Return([]int{results.OK, results.ERROR}, []string{appResource, errorResponse}),
+	)
+
+	// pass mockObj to a real object.
+	groupDbExecutor = groupDbMockObj
+	httpExecutor = msgMockObj
+
+	code, res, err := Executor{}.GetGroupAppResourceInfo(context.Background(), groupId, appId)
+	if code != results.MULTI_STATUS || err != nil {
+		t.Fatalf("Unexpected result: %d, %v", code, err)
+	}
+
+	responses := res[RESPONSES].([]map[string]interface{})
+	if responses[0][RESPONSE_CODE] != "200" || responses[0]["services"] == nil {
+		t.Errorf("Unexpected response of succeeded member: %v", responses[0])
+	}
+	expectedFailure := map[string]interface{}{ID: nodeId2, RESPONSE_CODE: "500", ERROR_MESSAGE: "not found app"}
+	if !reflect.DeepEqual(expectedFailure, responses[1]) {
+		t.Errorf("Expected response: %v, actual response: %v", expectedFailure, responses[1])
+	}
+
+	expected := models.AppResourceStatistics{
+		Nodes:    1,
+		CPU:      models.Statistic{Avg: 5, Max: 5},
+		Mem:      models.Statistic{Avg: 10, Max: 10},
+		MemUsage: models.Statistic{Avg: 256, Max: 256},
+	}
+	if !reflect.DeepEqual(expected, res[AGGREGATE]) {
+		t.Errorf("Expected aggregate: %v, actual aggregate: %v", expected, res[AGGREGATE])
+	}
+}
+
+func TestCalledGetGroupResourceInfoWhenAllMembersFailed_ExpectErrorCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	groupDbMockObj := groupdbmocks.NewMockCommand(ctrl)
+	msgMockObj := msgmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		groupDbMockObj.EXPECT().GetGroupMembers(gomock.Any(), groupId).Return(members, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), GET, nodeUrls, nil).
+			Return([]int{results.ERROR, results.ERROR}, []string{errorResponse, errorResponse}),
+	)
+
+	// pass mockObj to a real object.
+	groupDbExecutor = groupDbMockObj
+	httpExecutor = msgMockObj
+
+	code, res, err := Executor{}.GetGroupResourceInfo(context.Background(), groupId)
+	if code != results.ERROR || err != nil {
+		t.Fatalf("Unexpected result: %d, %v", code, err)
+	}
+	if aggregate := res[AGGREGATE].(models.NodeResourceStatistics); aggregate.Nodes != 0 {
+		t.Errorf("Expected no nodes in aggregate, actual aggregate: %v", aggregate)
+	}
+}
+
+func TestCalledGetGroupResourceInfoWithNotExistGroup_ExpectErrorReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	groupDbMockObj := groupdbmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		groupDbMockObj.EXPECT().GetGroupMembers(gomock.Any(), groupId).Return(nil, errors.NotFound{groupId}),
+	)
+
+	// pass mockObj to a real object.
+	groupDbExecutor = groupDbMockObj
+
+	code, _, err := Executor{}.GetGroupResourceInfo(context.Background(), groupId)
+	if code != results.ERROR {
+		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
+	}
+	if _, ok := err.(errors.NotFound); !ok {
+		t.Errorf("Expected err: %s, actual err: %v", "NotFound", err)
+	}
+}
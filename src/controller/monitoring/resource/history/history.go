@@ -0,0 +1,407 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+// Package controller/monitoring/resource/history collects resource usage of
+// all connected nodes periodically and keeps it as a bounded history.
+// The history is kept only in memory, so it is lost when the anchor restarts.
+package history
+
+import (
+	"commons/errors"
+	"commons/logger"
+	"commons/models"
+	"commons/results"
+	"commons/url"
+	"commons/util"
+	"commons/workers"
+	"context"
+	"controller/monitoring/resource/alert"
+	groupDB "db/mongo/group"
+	nodeDB "db/mongo/node"
+	"messenger"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Command is an interface of resource history operations.
+type Command interface {
+	// GetNodeResourceHistory returns resource usage of a node over time.
+	GetNodeResourceHistory(ctx context.Context, nodeId string, query map[string][]string) (int, map[string]interface{}, error)
+
+	// GetGroupResourceHistory returns average and maximum resource usage of members of a group over time.
+	GetGroupResourceHistory(ctx context.Context, groupId string, query map[string][]string) (int, map[string]interface{}, error)
+
+	// GetTopNodes returns nodes using the most resources at the latest collection.
+	GetTopNodes(ctx context.Context, query map[string][]string) (int, map[string]interface{}, error)
+}
+
+const (
+	ID                = "id"
+	IP                = "ip"
+	CONFIG            = "config"
+	STATUS            = "status"
+	MEMBERS           = "members"
+	STATUS_CONNECTED  = "connected"
+	RESOLUTION        = "resolution" // query key of the resolution of samples.
+	FROM              = "from"       // query key of the start of a period in RFC3339.
+	TO                = "to"         // query key of the end of a period in RFC3339.
+	METRIC            = "metric"     // query key of the resource to rank nodes by.
+	LIMIT             = "limit"      // query key of the number of nodes to return.
+	GROUP_ID          = "groupId"    // query key of a group to rank members of.
+	RESOLUTION_RAW    = "raw"
+	RESOLUTION_5M     = "5m"
+	RESOLUTION_1H     = "1h"
+	METRIC_CPU        = "cpu"
+	METRIC_MEM        = "mem"
+	METRIC_DISK       = "disk"
+	DEFAULT_LIMIT     = 5
+	COLLECT_INTERVAL  = time.Minute         // a period between two collections.
+	COLLECTOR         = "resourcecollector" // name of the worker collecting resource usage.
+	RAW_CAPACITY      = 120                 // raw samples of 2 hours at the default interval.
+	FIVE_MIN_CAPACITY = 288                 // 5 minute averages of a day.
+	ONE_HOUR_CAPACITY = 168                 // 1 hour averages of a week.
+)
+
+// Executor implements the Command interface.
+type Executor struct{}
+
+var nodeDbExecutor nodeDB.Command
+var groupDbExecutor groupDB.Command
+var httpExecutor messenger.Command
+var alertExecutor alert.Command
+
+var history = newStore([]tier{
+	{name: RESOLUTION_RAW, capacity: RAW_CAPACITY},
+	{name: RESOLUTION_5M, resolution: 5 * time.Minute, capacity: FIVE_MIN_CAPACITY},
+	{name: RESOLUTION_1H, resolution: time.Hour, capacity: ONE_HOUR_CAPACITY},
+})
+
+func init() {
+	nodeDbExecutor = nodeDB.Executor{}
+	groupDbExecutor = groupDB.Executor{}
+	httpExecutor = messenger.NewExecutor()
+	alertExecutor = alert.Executor{}
+}
+
+// StartCollector collects resource usage of all connected nodes
+// every interval until a signal is sent to the returned channel.
+func StartCollector(interval time.Duration) chan bool {
+	quit := make(chan bool)
+	worker := workers.Register(COLLECTOR, interval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		defer worker.Stop()
+
+		for {
+			select {
+			case now := <-ticker.C:
+				worker.Start()
+				collect(context.Background(), now)
+				worker.Beat()
+			case <-quit:
+				return
+			}
+		}
+	}()
+	return quit
+}
+
+// collect requests resource usage of all connected nodes at once and adds
+// the responses to the history with the same time, so that samples of
+// different nodes can be aggregated. History of removed nodes is dropped.
+// The samples are then evaluated against alert rules.
+func collect(ctx context.Context, now time.Time) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	nodes, err := nodeDbExecutor.GetNodes(ctx)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return
+	}
+
+	ids := make(map[string]bool)
+	targets := make([]string, 0)
+	address := make([]map[string]interface{}, 0)
+	for _, node := range nodes {
+		nodeId, _ := node[ID].(string)
+		ids[nodeId] = true
+
+		if status, _ := node[STATUS].(string); status != STATUS_CONNECTED {
+			continue
+		}
+		targets = append(targets, nodeId)
+		address = append(address, map[string]interface{}{IP: node[IP], CONFIG: node[CONFIG]})
+	}
+	history.retain(ids)
+
+	if len(targets) == 0 {
+		return
+	}
+
+	urls := util.MakeRequestUrl(address, url.Monitoring(), url.Resource())
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, "GET", urls, nil)
+	samples := make(map[string]models.ResourceSample)
+	for i, nodeId := range targets {
+		if i >= len(codes) || !util.IsSuccessCode(codes[i]) {
+			logger.With(ctx).Logging(logger.ERROR, "failed to collect resource of node", nodeId)
+			continue
+		}
+
+		resource := models.NodeResource{}
+		err = models.Decode(respStr[i], &resource)
+		if err != nil {
+			logger.With(ctx).Logging(logger.ERROR, nodeId, err.Error())
+			continue
+		}
+		x := summarize(resource, now)
+		history.add(nodeId, x)
+		samples[nodeId] = x.toModel()
+	}
+	alertExecutor.Evaluate(ctx, samples, now)
+}
+
+// summarize converts resource usage reported by a node into a sample.
+func summarize(resource models.NodeResource, now time.Time) sample {
+	x := sample{time: now}
+	x.cpu, x.mem, x.disk = resource.Usage()
+	return x
+}
+
+// GetNodeResourceHistory returns samples of a node of the resolution in query
+// between 'from' and 'to'. Samples of the raw resolution are returned by default.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetNodeResourceHistory(ctx context.Context, nodeId string, query map[string][]string) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	tierIndex, from, to, err := parsePeriod(query)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	_, err = nodeDbExecutor.GetNode(ctx, nodeId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	resp := models.ResourceHistory{
+		ID:         nodeId,
+		Resolution: history.tiers[tierIndex].name,
+		Samples:    make([]models.ResourceSample, 0),
+	}
+	for _, x := range history.get(nodeId, tierIndex, from, to) {
+		resp.Samples = append(resp.Samples, x.toModel())
+	}
+	return convertToMap(resp)
+}
+
+// GetGroupResourceHistory returns the average and maximum of samples of members
+// of a group at each time, with the same query as GetNodeResourceHistory.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetGroupResourceHistory(ctx context.Context, groupId string, query map[string][]string) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	tierIndex, from, to, err := parsePeriod(query)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	members, err := getMembers(ctx, groupId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	samples := make(map[time.Time][]sample)
+	for _, nodeId := range members {
+		for _, x := range history.get(nodeId, tierIndex, from, to) {
+			samples[x.time] = append(samples[x.time], x)
+		}
+	}
+
+	times := make([]time.Time, 0, len(samples))
+	for t := range samples {
+		times = append(times, t)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	resp := models.GroupResourceHistory{
+		ID:         groupId,
+		Resolution: history.tiers[tierIndex].name,
+		Samples:    make([]models.ResourceAggregate, 0, len(times)),
+	}
+	for _, t := range times {
+		resp.Samples = append(resp.Samples, aggregate(t, samples[t]))
+	}
+	return convertToMap(resp)
+}
+
+// GetTopNodes returns at most 'limit' nodes in descending order of the latest
+// usage of 'metric', which is cpu by default. If 'groupId' is given,
+// only members of the group are ranked.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetTopNodes(ctx context.Context, query map[string][]string) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	metric := getQuery(query, METRIC, METRIC_CPU)
+	value, err := metricOf(metric)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	limit, err := strconv.Atoi(getQuery(query, LIMIT, strconv.Itoa(DEFAULT_LIMIT)))
+	if err != nil || limit <= 0 {
+		return results.ERROR, nil, errors.InvalidParam{LIMIT + " must be a positive integer"}
+	}
+
+	candidates := history.nodes()
+	if groupId := getQuery(query, GROUP_ID, ""); len(groupId) != 0 {
+		candidates, err = getMembers(ctx, groupId)
+		if err != nil {
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return results.ERROR, nil, err
+		}
+	}
+
+	nodes := make([]models.NodeResourceSample, 0)
+	latest := make(map[string]sample)
+	for _, nodeId := range candidates {
+		if x, exists := history.latest(nodeId); exists {
+			latest[nodeId] = x
+			nodes = append(nodes, models.NodeResourceSample{ID: nodeId, ResourceSample: x.toModel()})
+		}
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := value(latest[nodes[i].ID]), value(latest[nodes[j].ID])
+		if a != b {
+			return a > b
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+	if len(nodes) > limit {
+		nodes = nodes[:limit]
+	}
+	return convertToMap(models.TopNodes{Metric: metric, Nodes: nodes})
+}
+
+// parsePeriod parses the resolution and the period of samples in query.
+func parsePeriod(query map[string][]string) (int, time.Time, time.Time, error) {
+	resolution := getQuery(query, RESOLUTION, RESOLUTION_RAW)
+	tierIndex := history.tierIndex(resolution)
+	if tierIndex < 0 {
+		return 0, time.Time{}, time.Time{}, errors.InvalidParam{RESOLUTION + " must be one of " +
+			strings.Join([]string{RESOLUTION_RAW, RESOLUTION_5M, RESOLUTION_1H}, ", ")}
+	}
+
+	var bounds [2]time.Time
+	for i, key := range []string{FROM, TO} {
+		value := getQuery(query, key, "")
+		if len(value) == 0 {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return 0, time.Time{}, time.Time{}, errors.InvalidParam{key + " must be RFC3339 time"}
+		}
+		bounds[i] = t
+	}
+	return tierIndex, bounds[0], bounds[1], nil
+}
+
+func getQuery(query map[string][]string, key string, defaultValue string) string {
+	if values, exists := query[key]; exists && len(values) != 0 && len(values[0]) != 0 {
+		return values[0]
+	}
+	return defaultValue
+}
+
+// metricOf returns a function which takes the value of metric from a sample.
+func metricOf(metric string) (func(sample) float64, error) {
+	switch metric {
+	case METRIC_CPU:
+		return func(x sample) float64 { return x.cpu }, nil
+	case METRIC_MEM:
+		return func(x sample) float64 { return x.mem }, nil
+	case METRIC_DISK:
+		return func(x sample) float64 { return x.disk }, nil
+	}
+	return nil, errors.InvalidParam{METRIC + " must be one of " +
+		strings.Join([]string{METRIC_CPU, METRIC_MEM, METRIC_DISK}, ", ")}
+}
+
+func getMembers(ctx context.Context, groupId string) ([]string, error) {
+	group, err := groupDbExecutor.GetGroup(ctx, groupId)
+	if err != nil {
+		return nil, err
+	}
+	members, _ := group[MEMBERS].([]string)
+	return members, nil
+}
+
+// aggregate computes the average and maximum of samples collected at t.
+func aggregate(t time.Time, samples []sample) models.ResourceAggregate {
+	result := models.ResourceAggregate{Time: t.UTC().Format(time.RFC3339), Nodes: len(samples)}
+	for _, x := range samples {
+		result.CPU.Avg += x.cpu
+		result.Mem.Avg += x.mem
+		result.Disk.Avg += x.disk
+		result.CPU.Max = maxOf(result.CPU.Max, x.cpu)
+		result.Mem.Max = maxOf(result.Mem.Max, x.mem)
+		result.Disk.Max = maxOf(result.Disk.Max, x.disk)
+	}
+
+	n := float64(len(samples))
+	result.CPU.Avg /= n
+	result.Mem.Avg /= n
+	result.Disk.Avg /= n
+	return result
+}
+
+func maxOf(a float64, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func (x sample) toModel() models.ResourceSample {
+	return models.ResourceSample{Time: x.time.UTC().Format(time.RFC3339), CPU: x.cpu, Mem: x.mem, Disk: x.disk}
+}
+
+// convertToMap converts a response into a map as other controllers return.
+func convertToMap(resp interface{}) (int, map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	err := models.Convert(resp, &result)
+	if err != nil {
+		logger.Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, errors.InternalServerError{err.Error()}
+	}
+	return results.OK, result, nil
+}
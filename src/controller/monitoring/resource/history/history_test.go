@@ -0,0 +1,241 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package history
+
+import (
+	"commons/errors"
+	"commons/models"
+	"commons/results"
+	"context"
+	alertmocks "controller/monitoring/resource/alert/mocks"
+	groupdbmocks "db/mongo/group/mocks"
+	nodedbmocks "db/mongo/node/mocks"
+	"github.com/golang/mock/gomock"
+	msgmocks "messenger/mocks"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const (
+	nodeId  = "000000000000000000000001"
+	nodeId2 = "000000000000000000000002"
+	groupId = "000000000000000000000003"
+)
+
+var (
+	start  = time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	config = map[string]interface{}{
+		"properties": []interface{}{
+			map[string]interface{}{"reverseproxy": map[string]interface{}{"enabled": false}},
+		},
+	}
+	connectedNode = map[string]interface{}{
+		"id":     nodeId,
+		"ip":     "127.0.0.1",
+		"status": STATUS_CONNECTED,
+		"config": config,
+	}
+	disconnectedNode = map[string]interface{}{
+		"id":     nodeId2,
+		"ip":     "127.0.0.2",
+		"status": "disconnected",
+		"config": config,
+	}
+	resourceBody = `{"cpu":["10.00%","30.00%"],"mem":{"free":"0KB","total":"0KB","used":"0KB","usedpercent":"50.00%"},` +
+		`"disk":[{"path":"/","usedpercent":"20%"},{"path":"/data","usedpercent":"70%"}]}`
+	group = map[string]interface{}{
+		"id":      groupId,
+		"name":    "group",
+		"members": []string{nodeId, nodeId2},
+	}
+)
+
+func newTestStore() *store {
+	return newStore([]tier{
+		{name: RESOLUTION_RAW, capacity: 3},
+		{name: RESOLUTION_5M, resolution: 5 * time.Minute, capacity: 2},
+	})
+}
+
+func TestAddSamples_ExpectBoundedAndDownsampled(t *testing.T) {
+	s := newTestStore()
+	for i := 0; i < 11; i++ {
+		s.add(nodeId, sample{time: start.Add(time.Duration(i) * time.Minute), cpu: float64(i)})
+	}
+
+	raw := s.get(nodeId, 0, time.Time{}, time.Time{})
+	if len(raw) != 3 || raw[0].cpu != 8 || raw[2].cpu != 10 {
+		t.Errorf("Unexpected raw samples: %v", raw)
+	}
+
+	// Averages of [0,4] and [5,9], while [10] is still pending.
+	expected := []sample{{time: start, cpu: 2}, {time: start.Add(5 * time.Minute), cpu: 7}}
+	if downsampled := s.get(nodeId, 1, time.Time{}, time.Time{}); !reflect.DeepEqual(expected, downsampled) {
+		t.Errorf("Expected samples: %v, actual samples: %v", expected, downsampled)
+	}
+
+	if period := s.get(nodeId, 0, start.Add(9*time.Minute), start.Add(9*time.Minute)); len(period) != 1 {
+		t.Errorf("Unexpected samples in period: %v", period)
+	}
+
+	s.retain(map[string]bool{})
+	if _, exists := s.latest(nodeId); exists {
+		t.Errorf("Expected history removed, actual exists")
+	}
+}
+
+func TestCalledCollect_ExpectConnectedNodesSampled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nodeDbMockObj := nodedbmocks.NewMockCommand(ctrl)
+	msgMockObj := msgmocks.NewMockCommand(ctrl)
+	alertMockObj := alertmocks.NewMockCommand(ctrl)
+
+	expectedSample := models.ResourceSample{Time: "2018-01-01T00:00:00Z", CPU: 20, Mem: 50, Disk: 70}
+
+	gomock.InOrder(
+		nodeDbMockObj.EXPECT().GetNodes(gomock.Any()).Return([]map[string]interface{}{connectedNode, disconnectedNode}, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", []string{"http://127.0.0.1:48098/api/v1/monitoring/resource"}, nil).
+			Return([]int{results.OK}, []string{resourceBody}),
+		alertMockObj.EXPECT().Evaluate(gomock.Any(), map[string]models.ResourceSample{nodeId: expectedSample}, start),
+	)
+
+	// pass mockObj to a real object.
+	nodeDbExecutor = nodeDbMockObj
+	httpExecutor = msgMockObj
+	alertExecutor = alertMockObj
+	history = newTestStore()
+
+	collect(context.Background(), start)
+
+	expected := sample{time: start, cpu: 20, mem: 50, disk: 70}
+	if x, exists := history.latest(nodeId); !exists || !reflect.DeepEqual(expected, x) {
+		t.Errorf("Expected sample: %v, actual sample: %v", expected, x)
+	}
+	if _, exists := history.latest(nodeId2); exists {
+		t.Errorf("Expected no sample of disconnected node")
+	}
+}
+
+func TestCalledGetNodeResourceHistory_ExpectSamplesReturned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nodeDbMockObj := nodedbmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		nodeDbMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(connectedNode, nil),
+	)
+
+	// pass mockObj to a real object.
+	nodeDbExecutor = nodeDbMockObj
+	history = newTestStore()
+	history.add(nodeId, sample{time: start, cpu: 1, mem: 2, disk: 3})
+
+	code, res, err := Executor{}.GetNodeResourceHistory(context.Background(), nodeId, map[string][]string{})
+	if code != results.OK || err != nil {
+		t.Fatalf("Unexpected result: %d, %v", code, err)
+	}
+
+	expected := map[string]interface{}{
+		"id":         nodeId,
+		"resolution": RESOLUTION_RAW,
+		"samples": []interface{}{
+			map[string]interface{}{"time": "2018-01-01T00:00:00Z", "cpu": 1.0, "mem": 2.0, "disk": 3.0},
+		},
+	}
+	if !reflect.DeepEqual(expected, res) {
+		t.Errorf("Expected res: %v, actual res: %v", expected, res)
+	}
+}
+
+func TestCalledGetNodeResourceHistoryWithInvalidQuery_ExpectErrorReturn(t *testing.T) {
+	testList := map[string]map[string][]string{
+		"resolution": {RESOLUTION: {"1s"}},
+		"from":       {FROM: {"yesterday"}},
+	}
+
+	for name, query := range testList {
+		t.Run(name, func(t *testing.T) {
+			code, _, err := Executor{}.GetNodeResourceHistory(context.Background(), nodeId, query)
+			if code != results.ERROR {
+				t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
+			}
+			if _, ok := err.(errors.InvalidParam); !ok {
+				t.Errorf("Expected err: %s, actual err: %v", "InvalidParam", err)
+			}
+		})
+	}
+}
+
+func TestCalledGetGroupResourceHistory_ExpectAggregatedSamples(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	groupDbMockObj := groupdbmocks.NewMockCommand(ctrl)
+
+	gomock.InOrder(
+		groupDbMockObj.EXPECT().GetGroup(gomock.Any(), groupId).Return(group, nil),
+	)
+
+	// pass mockObj to a real object.
+	groupDbExecutor = groupDbMockObj
+	history = newTestStore()
+	history.add(nodeId, sample{time: start, cpu: 10, mem: 20, disk: 30})
+	history.add(nodeId2, sample{time: start, cpu: 30, mem: 40, disk: 50})
+	history.add(nodeId2, sample{time: start.Add(time.Minute), cpu: 50, mem: 50, disk: 50})
+
+	code, res, err := Executor{}.GetGroupResourceHistory(context.Background(), groupId, map[string][]string{})
+	if code != results.OK || err != nil {
+		t.Fatalf("Unexpected result: %d, %v", code, err)
+	}
+
+	samples := res["samples"].([]interface{})
+	expected := map[string]interface{}{
+		"time":  "2018-01-01T00:00:00Z",
+		"nodes": 2.0,
+		"cpu":   map[string]interface{}{"avg": 20.0, "max": 30.0},
+		"mem":   map[string]interface{}{"avg": 30.0, "max": 40.0},
+		"disk":  map[string]interface{}{"avg": 40.0, "max": 50.0},
+	}
+	if len(samples) != 2 || !reflect.DeepEqual(expected, samples[0]) {
+		t.Errorf("Expected first sample: %v, actual samples: %v", expected, samples)
+	}
+}
+
+func TestCalledGetTopNodes_ExpectNodesSortedByMetric(t *testing.T) {
+	history = newTestStore()
+	history.add(nodeId, sample{time: start, cpu: 10, mem: 90})
+	history.add(nodeId2, sample{time: start, cpu: 30, mem: 40})
+
+	code, res, err := Executor{}.GetTopNodes(context.Background(), map[string][]string{METRIC: {METRIC_MEM}, LIMIT: {"1"}})
+	if code != results.OK || err != nil {
+		t.Fatalf("Unexpected result: %d, %v", code, err)
+	}
+
+	nodes := res["nodes"].([]interface{})
+	if len(nodes) != 1 || nodes[0].(map[string]interface{})["id"] != nodeId {
+		t.Errorf("Unexpected nodes: %v", nodes)
+	}
+
+	code, _, err = Executor{}.GetTopNodes(context.Background(), map[string][]string{METRIC: {"gpu"}})
+	if _, ok := err.(errors.InvalidParam); code != results.ERROR || !ok {
+		t.Errorf("Expected err: %s, actual err: %v", "InvalidParam", err)
+	}
+}
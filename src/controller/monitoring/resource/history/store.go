@@ -0,0 +1,180 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package history
+
+import (
+	"sync"
+	"time"
+)
+
+// sample is the resource usage of a node in percent.
+type sample struct {
+	time time.Time
+	cpu  float64
+	mem  float64
+	disk float64
+}
+
+// tier is a series of samples of a resolution. Samples of a tier other than
+// raw are averages of raw samples over the resolution. Only the latest
+// capacity samples are kept so that memory used per node is bounded.
+type tier struct {
+	name       string
+	resolution time.Duration
+	capacity   int
+}
+
+// bucket accumulates raw samples until a period of a tier ends.
+type bucket struct {
+	start time.Time
+	sum   sample
+	count int
+}
+
+// series keeps samples of a node for every tier.
+type series struct {
+	samples [][]sample
+	pending []bucket
+}
+
+// store keeps series of nodes in memory.
+type store struct {
+	mutex  sync.Mutex
+	tiers  []tier
+	series map[string]*series
+}
+
+// newStore creates a store. The first of tiers must be the raw tier.
+func newStore(tiers []tier) *store {
+	return &store{tiers: tiers, series: make(map[string]*series)}
+}
+
+// tierIndex returns the index of the tier with name, or -1.
+func (s *store) tierIndex(name string) int {
+	for i, t := range s.tiers {
+		if t.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// add appends a raw sample of a node and downsamples it into other tiers.
+func (s *store) add(nodeId string, x sample) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ser, exists := s.series[nodeId]
+	if !exists {
+		ser = &series{
+			samples: make([][]sample, len(s.tiers)),
+			pending: make([]bucket, len(s.tiers)),
+		}
+		s.series[nodeId] = ser
+	}
+
+	for i, t := range s.tiers {
+		if t.resolution == 0 {
+			ser.append(i, t.capacity, x)
+			continue
+		}
+
+		start := x.time.Truncate(t.resolution)
+		b := &ser.pending[i]
+		if b.count != 0 && !b.start.Equal(start) {
+			ser.append(i, t.capacity, b.average())
+			*b = bucket{}
+		}
+		if b.count == 0 {
+			b.start = start
+		}
+		b.sum.cpu += x.cpu
+		b.sum.mem += x.mem
+		b.sum.disk += x.disk
+		b.count++
+	}
+}
+
+func (ser *series) append(i int, capacity int, x sample) {
+	if len(ser.samples[i]) >= capacity {
+		ser.samples[i] = ser.samples[i][1:]
+	}
+	ser.samples[i] = append(ser.samples[i], x)
+}
+
+func (b bucket) average() sample {
+	n := float64(b.count)
+	return sample{time: b.start, cpu: b.sum.cpu / n, mem: b.sum.mem / n, disk: b.sum.disk / n}
+}
+
+// get returns samples of a tier of a node between from and to inclusive.
+// A zero time means no bound.
+func (s *store) get(nodeId string, tierIndex int, from time.Time, to time.Time) []sample {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	result := make([]sample, 0)
+	ser, exists := s.series[nodeId]
+	if !exists {
+		return result
+	}
+
+	for _, x := range ser.samples[tierIndex] {
+		if (!from.IsZero() && x.time.Before(from)) || (!to.IsZero() && x.time.After(to)) {
+			continue
+		}
+		result = append(result, x)
+	}
+	return result
+}
+
+// latest returns the latest raw sample of a node.
+func (s *store) latest(nodeId string) (sample, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ser, exists := s.series[nodeId]
+	if !exists || len(ser.samples[0]) == 0 {
+		return sample{}, false
+	}
+	raw := ser.samples[0]
+	return raw[len(raw)-1], true
+}
+
+// nodes returns ids of nodes which have samples.
+func (s *store) nodes() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ids := make([]string, 0, len(s.series))
+	for id := range s.series {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// retain removes series of nodes which are not in ids, e.g. unregistered nodes.
+func (s *store) retain(ids map[string]bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for id := range s.series {
+		if !ids[id] {
+			delete(s.series, id)
+		}
+	}
+}
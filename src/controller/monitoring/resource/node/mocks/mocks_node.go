@@ -5,6 +5,8 @@
 package mock_node
 
 import (
+	models "commons/models"
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -33,29 +35,29 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // GetNodeResourceInfo mocks base method
-func (m *MockCommand) GetNodeResourceInfo(nodeId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetNodeResourceInfo", nodeId)
+func (m *MockCommand) GetNodeResourceInfo(ctx context.Context, nodeId string) (int, models.NodeResource, error) {
+	ret := m.ctrl.Call(m, "GetNodeResourceInfo", ctx, nodeId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.NodeResource)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetNodeResourceInfo indicates an expected call of GetNodeResourceInfo
-func (mr *MockCommandMockRecorder) GetNodeResourceInfo(nodeId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeResourceInfo", reflect.TypeOf((*MockCommand)(nil).GetNodeResourceInfo), nodeId)
+func (mr *MockCommandMockRecorder) GetNodeResourceInfo(ctx, nodeId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeResourceInfo", reflect.TypeOf((*MockCommand)(nil).GetNodeResourceInfo), ctx, nodeId)
 }
 
 // GetAppResourceInfo mocks base method
-func (m *MockCommand) GetAppResourceInfo(nodeId, appId string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetAppResourceInfo", nodeId, appId)
+func (m *MockCommand) GetAppResourceInfo(ctx context.Context, nodeId, appId string) (int, models.AppResource, error) {
+	ret := m.ctrl.Call(m, "GetAppResourceInfo", ctx, nodeId, appId)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.AppResource)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetAppResourceInfo indicates an expected call of GetAppResourceInfo
-func (mr *MockCommandMockRecorder) GetAppResourceInfo(nodeId, appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppResourceInfo", reflect.TypeOf((*MockCommand)(nil).GetAppResourceInfo), nodeId, appId)
+func (mr *MockCommandMockRecorder) GetAppResourceInfo(ctx, nodeId, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppResourceInfo", reflect.TypeOf((*MockCommand)(nil).GetAppResourceInfo), ctx, nodeId, appId)
 }
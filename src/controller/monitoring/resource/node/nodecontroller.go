@@ -19,9 +19,11 @@ package node
 import (
 	"commons/errors"
 	"commons/logger"
+	"commons/models"
 	"commons/results"
 	"commons/url"
 	"commons/util"
+	"context"
 	nodeDB "db/mongo/node"
 	"messenger"
 )
@@ -33,8 +35,8 @@ const (
 )
 
 type Command interface {
-	GetNodeResourceInfo(nodeId string) (int, map[string]interface{}, error)
-	GetAppResourceInfo(nodeId string, appId string) (int, map[string]interface{}, error)
+	GetNodeResourceInfo(ctx context.Context, nodeId string) (int, models.NodeResource, error)
+	GetAppResourceInfo(ctx context.Context, nodeId string, appId string) (int, models.AppResource, error)
 }
 
 type Executor struct{}
@@ -51,63 +53,63 @@ func init() {
 // GetNodeResourceInfo request an node resource (cpu, mem, disk, network usage) information.
 // If response code represents success, returns resource information.
 // Otherwise, an appropriate error will be returned.
-func (Executor) GetNodeResourceInfo(nodeId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetNodeResourceInfo(ctx context.Context, nodeId string) (int, models.NodeResource, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get node specified by nodeId parameter.
-	node, err := nodeDbExecutor.GetNode(nodeId)
+	node, err := nodeDbExecutor.GetNode(ctx, nodeId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.NodeResource{}, err
 	}
 
 	address := getNodeAddress(node)
 	urls := util.MakeRequestUrl(address, url.Monitoring(), url.Resource())
 
 	// Request to return node's resource information.
-	codes, respStr := httpExecutor.SendHttpRequest(GET, urls, nil)
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, GET, urls, nil)
 
-	// Convert the received response from string to map.
-	result := codes[0]
-	respMap, err := convertRespToMap(respStr)
+	// Convert the received response into resource information.
+	resp := models.NodeResource{}
+	err = convertResp(codes[0], respStr, &resp)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.NodeResource{}, err
 	}
 
-	return result, respMap, err
+	return codes[0], resp, err
 }
 
 // GetAppResourceInfo request an node resource (cpu, mem, net i/o, block i/o) information.
 // If response code represents success, returns resource information.
 // Otherwise, an appropriate error will be returned.
-func (Executor) GetAppResourceInfo(nodeId string, appId string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetAppResourceInfo(ctx context.Context, nodeId string, appId string) (int, models.AppResource, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Get node specified by nodeId parameter.
-	node, err := nodeDbExecutor.GetNode(nodeId)
+	node, err := nodeDbExecutor.GetNode(ctx, nodeId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.AppResource{}, err
 	}
 
 	address := getNodeAddress(node)
 	urls := util.MakeRequestUrl(address, url.Monitoring(), url.Apps(), "/", appId, url.Resource())
 
 	// Request to return node's resource information.
-	codes, respStr := httpExecutor.SendHttpRequest(GET, urls, nil)
+	codes, respStr := httpExecutor.SendHttpRequest(ctx, GET, urls, nil)
 
-	// Convert the received response from string to map.
-	result := codes[0]
-	respMap, err := convertRespToMap(respStr)
+	// Convert the received response into resource information.
+	resp := models.AppResource{}
+	err = convertResp(codes[0], respStr, &resp)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.AppResource{}, err
 	}
 
-	return result, respMap, err
+	return codes[0], resp, err
 }
 
 // getNodeAddress returns an address as an array.
@@ -120,14 +122,17 @@ func getNodeAddress(node map[string]interface{}) []map[string]interface{} {
 	return result
 }
 
-// convertRespToMap converts a response in the form of JSON data into a map.
-// If successful, this function returns an error as nil.
-// otherwise, an appropriate error will be returned.
-func convertRespToMap(respStr []string) (map[string]interface{}, error) {
-	resp, err := util.ConvertJsonToMap(respStr[0])
+// convertResp converts a response of a node in the form of JSON data into v.
+// If the node fails the request, an error of the node will be returned.
+func convertResp(code int, respStr []string, v interface{}) error {
+	if !util.IsSuccessCode(code) {
+		return util.ConvertRespToError(code, respStr[0])
+	}
+
+	err := models.Decode(respStr[0], v)
 	if err != nil {
-		logger.Logging(logger.ERROR, "Failed to convert response from string to map")
-		return nil, errors.InternalServerError{"Json Converting Failed"}
+		logger.Logging(logger.ERROR, "Failed to convert response from string to model")
+		return errors.InternalServerError{"Json Converting Failed"}
 	}
-	return resp, err
+	return nil
 }
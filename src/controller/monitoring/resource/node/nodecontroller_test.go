@@ -18,7 +18,9 @@ package node
 
 import (
 	"commons/errors"
+	"commons/models"
 	"commons/results"
+	"context"
 	nodedbmocks "db/mongo/node/mocks"
 	"github.com/golang/mock/gomock"
 	msgmocks "messenger/mocks"
@@ -72,25 +74,24 @@ func TestGetNodeResourceInfo_ExpectSuccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	respStr := []string{`{"cpu":"00%","mem":"00%","disk":"00%","network":"00%"}`}
+	respStr := []string{`{"cpu":["00%"],"mem":{"usedpercent":"00%"},"disk":[{"path":"/","usedpercent":"00%"}]}`}
 	expectedUrl := []string{"http://" + testIp + ":" + port + "/api/v1/monitoring/resource"}
-	expectedRes := map[string]interface{}{
-		"cpu":     "00%",
-		"mem":     "00%",
-		"disk":    "00%",
-		"network": "00%",
+	expectedRes := models.NodeResource{
+		CPU:  []string{"00%"},
+		Mem:  models.Memory{UsedPercent: "00%"},
+		Disk: []models.Disk{{Path: "/", UsedPercent: "00%"}},
 	}
 	dbExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("GET", expectedUrl, nil).Return(respCode, respStr),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrl, nil).Return(respCode, respStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
-	code, res, err := resourceMonitor.GetNodeResourceInfo(nodeId)
+	code, res, err := resourceMonitor.GetNodeResourceInfo(context.Background(), nodeId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -101,7 +102,7 @@ func TestGetNodeResourceInfo_ExpectSuccess(t *testing.T) {
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -112,11 +113,11 @@ func TestGetNodeResourceInfoWithGetNodeError_ExpectErrorReturn(t *testing.T) {
 	dbExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(nil, errors.NotFound{}),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(nil, errors.NotFound{}),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
-	code, _, err := resourceMonitor.GetNodeResourceInfo(nodeId)
+	code, _, err := resourceMonitor.GetNodeResourceInfo(context.Background(), nodeId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -133,7 +134,7 @@ func TestGetNodeResourceInfoWithGetNodeError_ExpectErrorReturn(t *testing.T) {
 	}
 }
 
-func TestGetNodeResourceInfoWhenSendHttpRequestReturnErrorCode_ExpectSuccess(t *testing.T) {
+func TestGetNodeResourceInfoWhenSendHttpRequestReturnErrorCode_ExpectNodeErrorReturn(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -143,24 +144,25 @@ func TestGetNodeResourceInfoWhenSendHttpRequestReturnErrorCode_ExpectSuccess(t *
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("GET", expectedUrl, nil).Return(errorRespCode, respStr),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrl, nil).Return(errorRespCode, respStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
-	code, _, err := resourceMonitor.GetNodeResourceInfo(nodeId)
-
-	if err != nil {
-		t.Errorf("Unexpected err: %s", err.Error())
-	}
+	code, _, err := resourceMonitor.GetNodeResourceInfo(context.Background(), nodeId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
 	}
+
+	expectedErr := errors.NodeError{results.ERROR, ""}
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf("Expected err: %v, actual err: %v", expectedErr, err)
+	}
 }
 
-func TestGetNodeResourceInfoWhenSendhttpRequestReturnErrorCodeAndInvalidResponse_ExpectErrorReturn(t *testing.T) {
+func TestGetNodeResourceInfoWhenSendhttpRequestReturnErrorCodeAndInvalidResponse_ExpectNodeErrorReturn(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -170,26 +172,22 @@ func TestGetNodeResourceInfoWhenSendhttpRequestReturnErrorCodeAndInvalidResponse
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("GET", expectedUrl, nil).Return(errorRespCode, invalidRespStr),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrl, nil).Return(errorRespCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
-	code, _, err := resourceMonitor.GetNodeResourceInfo(nodeId)
+	code, _, err := resourceMonitor.GetNodeResourceInfo(context.Background(), nodeId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
 	}
 
-	if err == nil {
-		t.Errorf("Expected err: %s, actual err: %s", "InternalServerError", "nil")
-	}
-
 	switch err.(type) {
 	default:
-		t.Errorf("Expected err: %s, actual err: %s", "InternalServerError", err.Error())
-	case errors.InternalServerError:
+		t.Errorf("Expected err: %s, actual err: %v", "NodeError", err)
+	case errors.NodeError:
 	}
 }
 
@@ -197,22 +195,22 @@ func TestGetAppResourceInfo_ExpectSuccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	respStr := []string{`{"services":"test"}`}
+	respStr := []string{`{"services":[{"cid":"cid","cname":"service"}]}`}
 	expectedUrl := []string{"http://" + testIp + ":" + port + "/api/v1/monitoring/apps/" + appId + "/resource"}
-	expectedRes := map[string]interface{}{
-		"services": "test",
+	expectedRes := models.AppResource{
+		Services: []models.ServiceResource{{CID: "cid", CName: "service"}},
 	}
 	dbExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("GET", expectedUrl, nil).Return(respCode, respStr),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrl, nil).Return(respCode, respStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
-	code, res, err := resourceMonitor.GetAppResourceInfo(nodeId, appId)
+	code, res, err := resourceMonitor.GetAppResourceInfo(context.Background(), nodeId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -223,7 +221,7 @@ func TestGetAppResourceInfo_ExpectSuccess(t *testing.T) {
 	}
 
 	if !reflect.DeepEqual(expectedRes, res) {
-		t.Errorf("Expected res: %s, actual res: %s", expectedRes, res)
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
 	}
 }
 
@@ -234,11 +232,11 @@ func TestGetAppResourceInfoWithGetNodeError_ExpectErrorReturn(t *testing.T) {
 	dbExecutorMockObj := nodedbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(nil, errors.NotFound{}),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(nil, errors.NotFound{}),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
-	code, _, err := resourceMonitor.GetAppResourceInfo(nodeId, appId)
+	code, _, err := resourceMonitor.GetAppResourceInfo(context.Background(), nodeId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
@@ -255,7 +253,7 @@ func TestGetAppResourceInfoWithGetNodeError_ExpectErrorReturn(t *testing.T) {
 	}
 }
 
-func TestGetAppResourceInfoWhenSendHttpRequestReturnErrorCode_ExpectSuccess(t *testing.T) {
+func TestGetAppResourceInfoWhenSendHttpRequestReturnErrorCode_ExpectNodeErrorReturn(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -265,24 +263,25 @@ func TestGetAppResourceInfoWhenSendHttpRequestReturnErrorCode_ExpectSuccess(t *t
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("GET", expectedUrl, nil).Return(errorRespCode, respStr),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrl, nil).Return(errorRespCode, respStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
-	code, _, err := resourceMonitor.GetAppResourceInfo(nodeId, appId)
-
-	if err != nil {
-		t.Errorf("Unexpected err: %s", err.Error())
-	}
+	code, _, err := resourceMonitor.GetAppResourceInfo(context.Background(), nodeId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
 	}
+
+	expectedErr := errors.NodeError{results.ERROR, ""}
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf("Expected err: %v, actual err: %v", expectedErr, err)
+	}
 }
 
-func TestGetAppResourceInfoWhenSendhttpRequestReturnErrorCodeAndInvalidResponse_ExpectErrorReturn(t *testing.T) {
+func TestGetAppResourceInfoWhenSendhttpRequestReturnErrorCodeAndInvalidResponse_ExpectNodeErrorReturn(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -292,25 +291,21 @@ func TestGetAppResourceInfoWhenSendhttpRequestReturnErrorCodeAndInvalidResponse_
 	msgMockObj := msgmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		dbExecutorMockObj.EXPECT().GetNode(nodeId).Return(node, nil),
-		msgMockObj.EXPECT().SendHttpRequest("GET", expectedUrl, nil).Return(errorRespCode, invalidRespStr),
+		dbExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(node, nil),
+		msgMockObj.EXPECT().SendHttpRequest(gomock.Any(), "GET", expectedUrl, nil).Return(errorRespCode, invalidRespStr),
 	)
 	// pass mockObj to a real object.
 	nodeDbExecutor = dbExecutorMockObj
 	httpExecutor = msgMockObj
-	code, _, err := resourceMonitor.GetAppResourceInfo(nodeId, appId)
+	code, _, err := resourceMonitor.GetAppResourceInfo(context.Background(), nodeId, appId)
 
 	if code != results.ERROR {
 		t.Errorf("Expected code: %d, actual code: %d", results.ERROR, code)
 	}
 
-	if err == nil {
-		t.Errorf("Expected err: %s, actual err: %s", "InternalServerError", "nil")
-	}
-
 	switch err.(type) {
 	default:
-		t.Errorf("Expected err: %s, actual err: %s", "InternalServerError", err.Error())
-	case errors.InternalServerError:
+		t.Errorf("Expected err: %s, actual err: %v", "NodeError", err)
+	case errors.NodeError:
 	}
 }
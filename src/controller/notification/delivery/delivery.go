@@ -0,0 +1,654 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package controller/notification/delivery sends events to subscribers
+// through a persistent queue. Deliveries to a subscriber are sent in the
+// order they were queued and retried with exponential backoff, and a delivery
+// which fails MAX_ATTEMPTS times is moved to dead letters, which can be
+// inspected and replayed. A subscriber is deactivated after MAX_FAILURES
+// deliveries to it in a row are moved to dead letters.
+package delivery
+
+import (
+	"commons/errors"
+	"commons/logger"
+	"commons/metrics"
+	"commons/results"
+	"commons/workers"
+	"context"
+	deliveryDB "db/mongo/event/delivery"
+	subsDB "db/mongo/event/subscriber"
+	"encoding/json"
+	"messenger"
+	"sync"
+	"time"
+)
+
+// Command is an interface of delivery operations.
+type Command interface {
+	// Enqueue queues an event of eventType to be sent to the subscriber.
+	Enqueue(ctx context.Context, subscriberId string, url string, eventType string, eventId string, body string) error
+
+	// GetDeliveries returns deliveries waiting to be sent.
+	GetDeliveries(ctx context.Context) (int, map[string]interface{}, error)
+
+	// GetQueuedEventIds returns ids of events waiting to be sent to a subscriber.
+	GetQueuedEventIds(ctx context.Context, subscriberId string) ([]string, error)
+
+	// GetFailedDeliveries returns deliveries which failed MAX_ATTEMPTS times.
+	GetFailedDeliveries(ctx context.Context) (int, map[string]interface{}, error)
+
+	// ReplayFailedDelivery queues a failed delivery again.
+	ReplayFailedDelivery(ctx context.Context, deliveryId string) (int, error)
+
+	// DeleteFailedDelivery discards a failed delivery.
+	DeleteFailedDelivery(ctx context.Context, deliveryId string) (int, error)
+
+	// DeleteSubscriberDeliveries discards deliveries queued to a subscriber.
+	DeleteSubscriberDeliveries(ctx context.Context, subscriberId string) error
+}
+
+const (
+	ID                  = "id"
+	SUBSCRIBER_ID       = "subscriberId"
+	URL                 = "url"
+	EVENT_TYPE          = "eventType"
+	EVENT_ID            = "eventId"
+	BODY                = "body"
+	EVENT               = "event"
+	ATTEMPTS            = "attempts"
+	NEXT_ATTEMPT        = "nextAttempt"
+	LAST_ERROR          = "lastError"
+	SECRET              = "secret"
+	FAILURES            = "failures"
+	DEACTIVATED         = "deactivated"
+	CURSOR              = "cursor"
+	CREATED_AT          = "createdAt"
+	DELIVERIES          = "deliveries"
+	TYPE                = "type"
+	RESULT_SUCCESS      = "success"
+	RESULT_FAILURE      = "failure"
+	DISPATCHER          = "notificationdispatcher" // name of the worker sending deliveries.
+	DISPATCH_INTERVAL   = 5 * time.Second          // a period between two checks of deliveries to retry.
+	MAX_ATTEMPTS        = 8                        // attempts before a delivery is moved to dead letters.
+	INITIAL_BACKOFF     = 5 * time.Second          // a delay before the first retry, doubled on every failure.
+	MAX_BACKOFF         = 10 * time.Minute
+	SEND_TIMEOUT        = 10 * time.Second // time a subscriber has to answer a delivery over HTTP.
+	MAX_BATCH_PER_ROUND = 100              // deliveries sent to a subscriber in a round.
+	MAX_FAILURES        = 3                // deliveries moved to dead letters in a row before a subscriber is deactivated.
+)
+
+var (
+	deliveryCount = metrics.NewCounter("anchor_notification_deliveries_total",
+		"Number of attempts to send events to subscribers.", TYPE, "result")
+	deadLetterCount = metrics.NewCounter("anchor_notification_dead_letters_total",
+		"Number of events moved to dead letters after failing to be sent.", TYPE)
+	deactivationCount = metrics.NewCounter("anchor_notification_deactivated_subscribers_total",
+		"Number of subscribers deactivated after repeated failed deliveries.")
+)
+
+// Executor implements the Command interface.
+type Executor struct{}
+
+var deliveryDbExecutor deliveryDB.Command
+var subsDbExecutor subsDB.Command
+var httpExecutor messenger.Command
+
+// wakeup triggers the dispatcher to send queued deliveries without waiting for the next interval.
+var wakeup = make(chan bool, 1)
+
+// sending holds subscribers whose deliveries are being sent, which are skipped
+// by later rounds until the deliveries are sent.
+var (
+	sendingMutex sync.Mutex
+	sending      = make(map[string]bool)
+)
+
+func init() {
+	deliveryDbExecutor = deliveryDB.Executor{}
+	subsDbExecutor = subsDB.Executor{}
+	httpExecutor = messenger.NewExecutorWithTimeout(SEND_TIMEOUT)
+}
+
+// Enqueue stores a delivery of body to the subscriber and wakes the dispatcher up.
+// eventId is the id of the event in the event history, which becomes the cursor
+// of the subscriber once the delivery is sent, or empty if the event is not stored.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) Enqueue(ctx context.Context, subscriberId string, url string, eventType string, eventId string, body string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	_, err := deliveryDbExecutor.AddDelivery(ctx, deliveryDB.Delivery{
+		SubscriberID: subscriberId,
+		URL:          url,
+		EventType:    eventType,
+		EventID:      eventId,
+		Body:         body,
+		CreatedAt:    time.Now().Unix(),
+	})
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return err
+	}
+
+	wake()
+	return nil
+}
+
+// GetDeliveries returns all queued deliveries in the order they will be sent.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetDeliveries(ctx context.Context) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	deliveries, err := deliveryDbExecutor.GetDeliveries(ctx)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	res := make(map[string]interface{})
+	res[DELIVERIES] = toResponses(deliveries)
+	return results.OK, res, nil
+}
+
+// GetQueuedEventIds returns ids of events in the event history which are
+// queued to the subscriber, in the order they will be sent.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetQueuedEventIds(ctx context.Context, subscriberId string) ([]string, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	deliveries, err := deliveryDbExecutor.GetSubscriberDeliveries(ctx, subscriberId, 0)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return nil, err
+	}
+
+	eventIds := make([]string, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		if eventId, _ := delivery[EVENT_ID].(string); len(eventId) != 0 {
+			eventIds = append(eventIds, eventId)
+		}
+	}
+	return eventIds, nil
+}
+
+// GetFailedDeliveries returns all dead letters.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetFailedDeliveries(ctx context.Context) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	deliveries, err := deliveryDbExecutor.GetDeadLetters(ctx)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	res := make(map[string]interface{})
+	res[DELIVERIES] = toResponses(deliveries)
+	return results.OK, res, nil
+}
+
+// ReplayFailedDelivery moves a dead letter back to the end of the queue of
+// its subscriber with the number of attempts reset.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) ReplayFailedDelivery(ctx context.Context, deliveryId string) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	deadLetter, err := deliveryDbExecutor.GetDeadLetter(ctx, deliveryId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
+	}
+	e, err := toEntry(deadLetter)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
+	}
+
+	_, err = deliveryDbExecutor.AddDelivery(ctx, deliveryDB.Delivery{
+		SubscriberID: e.subscriberId,
+		URL:          e.url,
+		EventType:    e.eventType,
+		EventID:      e.eventId,
+		Body:         e.body,
+		CreatedAt:    e.createdAt,
+	})
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
+	}
+
+	err = deliveryDbExecutor.DeleteDeadLetter(ctx, deliveryId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
+	}
+
+	wake()
+	return results.OK, nil
+}
+
+// DeleteFailedDelivery deletes a dead letter.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) DeleteFailedDelivery(ctx context.Context, deliveryId string) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	err := deliveryDbExecutor.DeleteDeadLetter(ctx, deliveryId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
+	}
+	return results.OK, nil
+}
+
+// DeleteSubscriberDeliveries deletes all deliveries queued to a subscriber,
+// which is called when the subscriber is removed. Dead letters are kept.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) DeleteSubscriberDeliveries(ctx context.Context, subscriberId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	err := deliveryDbExecutor.DeleteSubscriberDeliveries(ctx, subscriberId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return err
+	}
+	return nil
+}
+
+// StartDispatcher sends queued deliveries whenever a delivery is queued and
+// every interval to retry failed ones, until a signal is sent to the returned channel.
+func StartDispatcher(interval time.Duration) chan bool {
+	quit := make(chan bool)
+	worker := workers.Register(DISPATCHER, interval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		defer worker.Stop()
+
+		ctx := context.Background()
+		dispatch(ctx, time.Now())
+		for {
+			select {
+			case now := <-ticker.C:
+				worker.Start()
+				dispatch(ctx, now)
+				worker.Beat()
+			case <-wakeup:
+				worker.Start()
+				dispatch(ctx, time.Now())
+				worker.Beat()
+			case <-quit:
+				return
+			}
+		}
+	}()
+	return quit
+}
+
+// wake triggers the dispatcher unless it is already triggered.
+func wake() {
+	select {
+	case wakeup <- true:
+	default:
+	}
+}
+
+// dispatch sends queued deliveries of subscribers which have deliveries due by now
+// concurrently, reading at most MAX_BATCH_PER_ROUND of them for each subscriber.
+// It waits for them at most DISPATCH_INTERVAL, so that a slow subscriber does not
+// hold the others back, and deliveries to a subscriber still being sent to are
+// left to a later round.
+func dispatch(ctx context.Context, now time.Time) {
+	subscriberIds, err := deliveryDbExecutor.GetDueSubscriberIds(ctx, now.Unix())
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, subscriberId := range subscriberIds {
+		if !startSending(subscriberId) {
+			continue
+		}
+		wg.Add(1)
+		go func(subscriberId string) {
+			defer wg.Done()
+			defer stopSending(subscriberId)
+			if queue := readQueue(ctx, subscriberId); len(queue) != 0 {
+				dispatchQueue(ctx, queue, now)
+			}
+		}(subscriberId)
+	}
+
+	done := make(chan bool)
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(DISPATCH_INTERVAL):
+		logger.With(ctx).Logging(logger.INFO, "deliveries to slow subscribers are left to a later round")
+	}
+}
+
+// startSending marks a subscriber as being sent to.
+// It returns false if deliveries to the subscriber are already being sent.
+func startSending(subscriberId string) bool {
+	sendingMutex.Lock()
+	defer sendingMutex.Unlock()
+	if sending[subscriberId] {
+		return false
+	}
+	sending[subscriberId] = true
+	return true
+}
+
+// stopSending marks a subscriber as no longer being sent to.
+func stopSending(subscriberId string) {
+	sendingMutex.Lock()
+	defer sendingMutex.Unlock()
+	delete(sending, subscriberId)
+}
+
+// readQueue returns the first MAX_BATCH_PER_ROUND deliveries queued to a subscriber.
+// The head of the queue is read even if it is not due yet, so that it holds
+// later deliveries back. A malformed delivery, which can never be sent, is deleted.
+func readQueue(ctx context.Context, subscriberId string) []entry {
+	deliveries, err := deliveryDbExecutor.GetSubscriberDeliveries(ctx, subscriberId, MAX_BATCH_PER_ROUND)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return nil
+	}
+
+	queue := make([]entry, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		e, err := toEntry(delivery)
+		if err != nil {
+			logger.With(ctx).Log(logger.ERROR, "deleting malformed delivery", "subscriber", subscriberId, "error", err.Error())
+			if deliveryId, ok := delivery[ID].(string); ok {
+				if err = deliveryDbExecutor.DeleteDelivery(ctx, deliveryId); err != nil {
+					logger.With(ctx).Logging(logger.ERROR, err.Error())
+				}
+			}
+			continue
+		}
+		queue = append(queue, e)
+	}
+	return queue
+}
+
+// dispatchQueue sends deliveries to a subscriber in order. It stops at a delivery
+// which is waiting for its next attempt or fails, so that later deliveries are
+// not sent before it. A delivery moved to dead letters no longer blocks the queue.
+func dispatchQueue(ctx context.Context, queue []entry, now time.Time) {
+	if queue[0].nextAttempt > now.Unix() {
+		return
+	}
+
+	subscriberId := queue[0].subscriberId
+	subscriber, err := getSubscriber(ctx, subscriberId)
+	if err != nil {
+		switch err.(type) {
+		default:
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+		case errors.NotFound:
+			// The subscriber has been removed after events to it were queued.
+			dropDeliveries(ctx, subscriberId)
+		}
+		return
+	}
+	if subscriber.deactivated {
+		return
+	}
+	defer saveCursor(ctx, subscriber)
+
+	for _, delivery := range queue {
+		if delivery.nextAttempt > now.Unix() {
+			return
+		}
+		if !send(ctx, delivery, subscriber, now) {
+			return
+		}
+	}
+}
+
+// entry is a delivery read from the queue or dead letters.
+type entry struct {
+	id           string
+	subscriberId string
+	url          string
+	eventType    string
+	eventId      string
+	body         string
+	attempts     int
+	nextAttempt  int64
+	createdAt    int64
+}
+
+// toEntry reads the fields of a delivery.
+// If successful, this function returns an error as nil.
+// otherwise, an InvalidField error for the first malformed field will be returned.
+func toEntry(delivery map[string]interface{}) (entry, error) {
+	e := entry{}
+	texts := []struct {
+		key   string
+		field *string
+	}{
+		{ID, &e.id}, {SUBSCRIBER_ID, &e.subscriberId}, {URL, &e.url},
+		{EVENT_TYPE, &e.eventType}, {EVENT_ID, &e.eventId}, {BODY, &e.body},
+	}
+	for _, text := range texts {
+		value, ok := delivery[text.key].(string)
+		if !ok {
+			return entry{}, errors.InvalidField{text.key, "of delivery is not a string"}
+		}
+		*text.field = value
+	}
+
+	var ok bool
+	if e.attempts, ok = delivery[ATTEMPTS].(int); !ok {
+		return entry{}, errors.InvalidField{ATTEMPTS, "of delivery " + e.id + " is not an integer"}
+	}
+	if e.nextAttempt, ok = delivery[NEXT_ATTEMPT].(int64); !ok {
+		return entry{}, errors.InvalidField{NEXT_ATTEMPT, "of delivery " + e.id + " is not a unix time"}
+	}
+	if e.createdAt, ok = delivery[CREATED_AT].(int64); !ok {
+		return entry{}, errors.InvalidField{CREATED_AT, "of delivery " + e.id + " is not a unix time"}
+	}
+	return e, nil
+}
+
+// target is the state of a subscriber which deliveries are sent with.
+type target struct {
+	id          string
+	url         string // url of the subscriber, which may have been updated after deliveries were queued.
+	secret      string
+	failures    int
+	deactivated bool
+	cursor      string
+	delivered   string // id of the latest event sent in this round.
+}
+
+// getSubscriber returns the state of a subscriber.
+func getSubscriber(ctx context.Context, subscriberId string) (*target, error) {
+	subscriber, err := subsDbExecutor.GetSubscriber(ctx, subscriberId)
+	if err != nil {
+		return nil, err
+	}
+	t := &target{id: subscriberId}
+	t.url, _ = subscriber[URL].(string)
+	t.secret, _ = subscriber[SECRET].(string)
+	t.failures, _ = subscriber[FAILURES].(int)
+	t.deactivated, _ = subscriber[DEACTIVATED].(bool)
+	t.cursor, _ = subscriber[CURSOR].(string)
+	return t, nil
+}
+
+// send attempts a delivery through the sink of the current url of subscriber with its secret,
+// and updates the queue with the result. The url the delivery was queued with is used only
+// if the subscriber has none. It returns whether the next delivery to the subscriber can be sent.
+func send(ctx context.Context, delivery entry, subscriber *target, now time.Time) bool {
+	id, eventType, body := delivery.id, delivery.eventType, delivery.body
+
+	url := subscriber.url
+	if url == "" {
+		url = delivery.url
+	}
+	err := getSink(url).Send(ctx, url, Message{
+		DeliveryID:   id,
+		SubscriberID: delivery.subscriberId,
+		EventType:    eventType,
+		Secret:       subscriber.secret,
+		Timestamp:    time.Now().Unix(),
+		Body:         body,
+	})
+	if err == nil {
+		deliveryCount.Inc(eventType, RESULT_SUCCESS)
+		if err := deliveryDbExecutor.DeleteDelivery(ctx, id); err != nil {
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return false
+		}
+		resetFailures(ctx, subscriber)
+		if delivery.eventId > subscriber.delivered {
+			subscriber.delivered = delivery.eventId
+		}
+		return true
+	}
+	deliveryCount.Inc(eventType, RESULT_FAILURE)
+
+	attempts := delivery.attempts + 1
+	lastError := err.Error()
+	logger.With(ctx).Log(logger.ERROR, "delivery failed", "delivery", id, "subscriber", delivery.subscriberId,
+		"attempts", attempts, "error", lastError)
+
+	if attempts < MAX_ATTEMPTS {
+		nextAttempt := now.Add(backoff(attempts)).Unix()
+		if err := deliveryDbExecutor.UpdateDelivery(ctx, id, attempts, nextAttempt, lastError); err != nil {
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+		}
+		return false
+	}
+
+	deadLetterCount.Inc(eventType)
+	if err := deliveryDbExecutor.MoveToDeadLetter(ctx, id, attempts, lastError); err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return false
+	}
+	return addFailure(ctx, subscriber)
+}
+
+// dropDeliveries deletes deliveries queued to a subscriber which is not found.
+func dropDeliveries(ctx context.Context, subscriberId string) {
+	logger.With(ctx).Log(logger.INFO, "deliveries to removed subscriber dropped", "subscriber", subscriberId)
+	if err := deliveryDbExecutor.DeleteSubscriberDeliveries(ctx, subscriberId); err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+	}
+}
+
+// saveCursor advances the cursor of a subscriber to the latest event sent to it.
+// Ids of events are increasing, so a replayed event does not move the cursor back.
+func saveCursor(ctx context.Context, subscriber *target) {
+	if subscriber.delivered <= subscriber.cursor {
+		return
+	}
+	if err := subsDbExecutor.SetCursor(ctx, subscriber.id, subscriber.delivered); err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return
+	}
+	subscriber.cursor = subscriber.delivered
+}
+
+// resetFailures clears the failures of a subscriber after a successful delivery.
+func resetFailures(ctx context.Context, subscriber *target) {
+	if subscriber.failures == 0 {
+		return
+	}
+	if err := subsDbExecutor.SetDeliveryState(ctx, subscriber.id, 0, false); err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return
+	}
+	subscriber.failures = 0
+}
+
+// addFailure counts a delivery moved to dead letters against a subscriber, which is
+// deactivated after MAX_FAILURES of them in a row.
+// It returns whether events can still be sent to the subscriber.
+func addFailure(ctx context.Context, subscriber *target) bool {
+	failures := subscriber.failures + 1
+	deactivated := failures >= MAX_FAILURES
+	if err := subsDbExecutor.SetDeliveryState(ctx, subscriber.id, failures, deactivated); err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return false
+	}
+	if deactivated {
+		logger.With(ctx).Log(logger.ERROR, "subscriber deactivated", "subscriber", subscriber.id, "failures", failures)
+		deactivationCount.Inc()
+	}
+	subscriber.failures, subscriber.deactivated = failures, deactivated
+	return !deactivated
+}
+
+// backoff returns a delay before the next attempt after attempts failures.
+func backoff(attempts int) time.Duration {
+	delay := INITIAL_BACKOFF
+	for i := 1; i < attempts && delay < MAX_BACKOFF; i++ {
+		delay *= 2
+	}
+	if delay > MAX_BACKOFF {
+		delay = MAX_BACKOFF
+	}
+	return delay
+}
+
+// toResponses replaces the body of deliveries with the event in it,
+// and redacts credentials in their url.
+func toResponses(deliveries []map[string]interface{}) []map[string]interface{} {
+	responses := make([]map[string]interface{}, len(deliveries))
+	for i, delivery := range deliveries {
+		response := make(map[string]interface{})
+		for key, value := range delivery {
+			if key != BODY {
+				response[key] = value
+			}
+		}
+		if url, ok := delivery[URL].(string); ok {
+			response[URL] = RedactURL(url)
+		}
+
+		text, _ := delivery[BODY].(string)
+		body := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(text), &body); err == nil {
+			response[EVENT] = body[EVENT]
+		}
+		responses[i] = response
+	}
+	return responses
+}
@@ -0,0 +1,68 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package delivery
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+)
+
+const (
+	DELIVERY_HEADER  = "X-Anchor-Delivery"  // id of a delivery, the same on retries.
+	TIMESTAMP_HEADER = "X-Anchor-Timestamp" // unix time at which a delivery is sent.
+	SIGNATURE_HEADER = "X-Anchor-Signature" // HMAC-SHA256 of the timestamp and the body.
+	SIGNATURE_PREFIX = "sha256="
+	SECRET_SIZE      = 32 // bytes of a secret before hex encoding.
+)
+
+// NewSecret generates a random secret to sign events to a subscriber with.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func NewSecret() (string, error) {
+	secret := make([]byte, SECRET_SIZE)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// Sign returns the signature of body sent at timestamp, which is the hex encoded
+// HMAC-SHA256 of the timestamp, a dot and the body with the secret as the key.
+// Subscribers compute the same value from the headers and the body to verify
+// that an event comes from the anchor and is not replayed later.
+func Sign(secret string, timestamp string, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + body))
+	return SIGNATURE_PREFIX + hex.EncodeToString(mac.Sum(nil))
+}
+
+// makeHeader returns headers of a delivery sent at timestamp.
+// Deliveries to a subscriber without a secret are not signed.
+func makeHeader(deliveryId string, secret string, timestamp int64, body string) http.Header {
+	header := http.Header{}
+	header.Set(DELIVERY_HEADER, deliveryId)
+	header.Set(TIMESTAMP_HEADER, strconv.FormatInt(timestamp, 10))
+	if len(secret) != 0 {
+		header.Set(SIGNATURE_HEADER, Sign(secret, header.Get(TIMESTAMP_HEADER), body))
+	}
+	return header
+}
@@ -0,0 +1,124 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package delivery
+
+import (
+	"commons/util"
+	"context"
+	netURL "net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// REDACTED replaces credentials in urls of subscribers which are shown.
+const REDACTED = "xxxxx"
+
+// Sink sends deliveries to subscribers whose url has the scheme it is registered for.
+type Sink interface {
+	// Validate checks whether deliveries can be sent to url, when a subscriber is registered.
+	Validate(url string) error
+
+	// Send sends a delivery to url. The error is kept as the last error of the delivery.
+	Send(ctx context.Context, url string, message Message) error
+}
+
+// Message is a delivery sent to a subscriber.
+type Message struct {
+	DeliveryID   string
+	SubscriberID string
+	EventType    string
+	Secret       string // secret of the subscriber, or empty if it has none.
+	Timestamp    int64  // unix time at which the delivery is sent.
+	Body         string
+}
+
+var (
+	sinksMutex sync.RWMutex
+	sinks      = make(map[string]Sink)
+)
+
+// RegisterSink makes deliveries to urls of scheme, e.g. "mqtt", sent by sink,
+// replacing a sink registered for the scheme before.
+// Deliveries to urls of schemes without a sink are sent over HTTP.
+func RegisterSink(scheme string, sink Sink) {
+	sinksMutex.Lock()
+	defer sinksMutex.Unlock()
+	sinks[strings.ToLower(scheme)] = sink
+}
+
+// ValidateURL checks whether deliveries can be sent to url by its sink.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func ValidateURL(url string) error {
+	return getSink(url).Validate(url)
+}
+
+// RedactURL returns url with its user info redacted, such as the password of
+// an MQTT broker, so that it can be shown. A user name with a password is kept.
+func RedactURL(url string) string {
+	parsed, err := netURL.Parse(url)
+	if err != nil || parsed.User == nil {
+		return url
+	}
+	if _, exists := parsed.User.Password(); exists {
+		parsed.User = netURL.UserPassword(parsed.User.Username(), REDACTED)
+	} else {
+		parsed.User = netURL.User(REDACTED)
+	}
+	return parsed.String()
+}
+
+// getSink returns the sink registered for the scheme of url, or the HTTP sink.
+func getSink(url string) Sink {
+	if i := strings.Index(url, "://"); i > 0 {
+		sinksMutex.RLock()
+		defer sinksMutex.RUnlock()
+		if sink, exists := sinks[strings.ToLower(url[:i])]; exists {
+			return sink
+		}
+	}
+	return httpSink{}
+}
+
+// httpSink posts deliveries to subscribers with headers to verify them,
+// which fail unless subscribers answer with 2xx.
+type httpSink struct{}
+
+// httpError is the status code and the response of a failed delivery over HTTP.
+type httpError struct {
+	code     int
+	response string
+}
+
+func (e httpError) Error() string {
+	return strconv.Itoa(e.code) + " " + e.response
+}
+
+func (httpSink) Validate(url string) error {
+	return nil
+}
+
+func (httpSink) Send(ctx context.Context, url string, message Message) error {
+	header := makeHeader(message.DeliveryID, message.Secret, message.Timestamp, message.Body)
+	codes, resps := httpExecutor.SendHttpRequestWithHeader(ctx, "POST", []string{url}, header, nil, []byte(message.Body))
+	if !util.IsSuccessCode(codes[0]) {
+		return httpError{codes[0], resps[0]}
+	}
+	return nil
+}
@@ -0,0 +1,556 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package controller/notification/filter compiles filter expressions of subscriptions,
+// which decide by the fields of an event whether it is sent to a subscriber, e.g.
+//
+//	metric in ("cpu", "mem") && value >= 95
+//
+// A field is a name, or names of nested objects joined by dots. It is compared with
+// a string in double or single quotes, a number, true, false or null by ==, !=, <,
+// <=, > and >=, or with a list of them by in. Comparisons are combined by &&, || and
+// !, and grouped by parentheses. A field which is a list matches if any of its
+// elements does, and a missing field only equals null.
+package filter
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter is a compiled filter expression.
+type Filter struct {
+	expr string
+	root node
+}
+
+// SyntaxError is returned when an expression can not be compiled.
+type SyntaxError struct {
+	Offset  int // offset in bytes of the expression where the error is found.
+	Message string
+}
+
+// Error returns the message of SyntaxError with its offset.
+func (e SyntaxError) Error() string {
+	return e.Message + " at offset " + strconv.Itoa(e.Offset)
+}
+
+// Compile parses an expression into a filter.
+// If successful, this function returns an error as nil.
+// otherwise, SyntaxError will be returned.
+func Compile(expr string) (*Filter, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEnd {
+		return nil, SyntaxError{t.offset, "unexpected " + t.String()}
+	}
+	return &Filter{expr: expr, root: root}, nil
+}
+
+// Match returns whether an event satisfies the filter.
+func (f *Filter) Match(event map[string]interface{}) bool {
+	return f.root.eval(event)
+}
+
+// String returns the expression the filter is compiled from.
+func (f *Filter) String() string {
+	return f.expr
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenField
+	tokenString
+	tokenNumber
+	tokenLiteral // true, false or null.
+	tokenOperator
+	tokenIn
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	value  interface{}
+	offset int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEnd {
+		return "end of expression"
+	}
+	return "'" + t.text + "'"
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// tokenize splits an expression into tokens followed by tokenEnd.
+func tokenize(expr string) ([]token, error) {
+	tokens := make([]token, 0)
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, text: "(", offset: i})
+			i++
+			continue
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, text: ")", offset: i})
+			i++
+			continue
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", offset: i})
+			i++
+			continue
+		case strings.HasPrefix(expr[i:], "&&"):
+			tokens = append(tokens, token{kind: tokenAnd, text: "&&", offset: i})
+			i += 2
+			continue
+		case strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, token{kind: tokenOr, text: "||", offset: i})
+			i += 2
+			continue
+		case c == '"' || c == '\'':
+			t, err := scanString(expr, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, t)
+			i += len(t.text)
+			continue
+		case c == '-' || isDigit(c):
+			t, err := scanNumber(expr, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, t)
+			i += len(t.text)
+			continue
+		case isNameStart(rune(c)):
+			t := scanName(expr, i)
+			tokens = append(tokens, t)
+			i += len(t.text)
+			continue
+		}
+
+		matched := false
+		for _, op := range operators {
+			if strings.HasPrefix(expr[i:], op) {
+				tokens = append(tokens, token{kind: tokenOperator, text: op, offset: i})
+				i += len(op)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		if c == '!' {
+			tokens = append(tokens, token{kind: tokenNot, text: "!", offset: i})
+			i++
+			continue
+		}
+		return nil, SyntaxError{i, "unexpected character '" + string(c) + "'"}
+	}
+	return append(tokens, token{kind: tokenEnd, offset: len(expr)}), nil
+}
+
+// scanString scans a quoted string starting at offset, where a backslash
+// escapes the next character.
+func scanString(expr string, offset int) (token, error) {
+	quote := expr[offset]
+	var value strings.Builder
+	for i := offset + 1; i < len(expr); i++ {
+		switch expr[i] {
+		case '\\':
+			if i+1 == len(expr) {
+				return token{}, SyntaxError{offset, "unterminated string"}
+			}
+			i++
+			value.WriteByte(expr[i])
+		case quote:
+			return token{kind: tokenString, text: expr[offset : i+1], value: value.String(), offset: offset}, nil
+		default:
+			value.WriteByte(expr[i])
+		}
+	}
+	return token{}, SyntaxError{offset, "unterminated string"}
+}
+
+// scanNumber scans a number starting at offset, which is kept as float64
+// like numbers of events decoded from JSON.
+func scanNumber(expr string, offset int) (token, error) {
+	end := offset + 1
+	for end < len(expr) && (isDigit(expr[end]) || expr[end] == '.' || expr[end] == 'e' || expr[end] == 'E' ||
+		((expr[end] == '-' || expr[end] == '+') && (expr[end-1] == 'e' || expr[end-1] == 'E'))) {
+		end++
+	}
+	text := expr[offset:end]
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return token{}, SyntaxError{offset, "invalid number '" + text + "'"}
+	}
+	return token{kind: tokenNumber, text: text, value: value, offset: offset}, nil
+}
+
+// scanName scans a field, in or a literal starting at offset.
+func scanName(expr string, offset int) token {
+	end := offset
+	for end < len(expr) && (isNameStart(rune(expr[end])) || isDigit(expr[end]) || expr[end] == '.') {
+		end++
+	}
+	text := expr[offset:end]
+	switch text {
+	case "in":
+		return token{kind: tokenIn, text: text, offset: offset}
+	case "true":
+		return token{kind: tokenLiteral, text: text, value: true, offset: offset}
+	case "false":
+		return token{kind: tokenLiteral, text: text, value: false, offset: offset}
+	case "null":
+		return token{kind: tokenLiteral, text: text, value: nil, offset: offset}
+	}
+	return token{kind: tokenField, text: text, offset: offset}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isNameStart(c rune) bool {
+	return c == '_' || (c < unicode.MaxASCII && unicode.IsLetter(c))
+}
+
+// parser builds a tree of an expression by recursive descent, where && binds
+// tighter than ||, and ! tighter than both.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, SyntaxError{t.offset, "expected " + what + " but found " + t.String()}
+	}
+	return t, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	switch p.peek().kind {
+	case tokenNot:
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	case tokenLeftParen:
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err = p.expect(tokenRightParen, "')'"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	field, err := p.expect(tokenField, "a field")
+	if err != nil {
+		return nil, err
+	}
+	path := strings.Split(field.text, ".")
+	for _, name := range path {
+		if len(name) == 0 {
+			return nil, SyntaxError{field.offset, "invalid field '" + field.text + "'"}
+		}
+	}
+
+	op := p.next()
+	switch op.kind {
+	case tokenOperator:
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return compareNode{path, op.text, value}, nil
+	case tokenIn:
+		if _, err = p.expect(tokenLeftParen, "'('"); err != nil {
+			return nil, err
+		}
+		values := make([]interface{}, 0)
+		for {
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+		if _, err = p.expect(tokenRightParen, "')'"); err != nil {
+			return nil, err
+		}
+		return inNode{path, values}, nil
+	}
+	return nil, SyntaxError{op.offset, "expected an operator or 'in' but found " + op.String()}
+}
+
+func (p *parser) parseValue() (interface{}, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString, tokenNumber, tokenLiteral:
+		return t.value, nil
+	}
+	return nil, SyntaxError{t.offset, "expected a value but found " + t.String()}
+}
+
+// node is a part of an expression evaluated against an event.
+type node interface {
+	eval(event map[string]interface{}) bool
+}
+
+type orNode struct{ left, right node }
+
+func (n orNode) eval(event map[string]interface{}) bool {
+	return n.left.eval(event) || n.right.eval(event)
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) eval(event map[string]interface{}) bool {
+	return n.left.eval(event) && n.right.eval(event)
+}
+
+type notNode struct{ operand node }
+
+func (n notNode) eval(event map[string]interface{}) bool {
+	return !n.operand.eval(event)
+}
+
+type compareNode struct {
+	path  []string
+	op    string
+	value interface{}
+}
+
+func (n compareNode) eval(event map[string]interface{}) bool {
+	return anyOf(lookup(event, n.path), func(field interface{}) bool {
+		return compare(field, n.op, n.value)
+	})
+}
+
+type inNode struct {
+	path   []string
+	values []interface{}
+}
+
+func (n inNode) eval(event map[string]interface{}) bool {
+	return anyOf(lookup(event, n.path), func(field interface{}) bool {
+		for _, value := range n.values {
+			if compare(field, "==", value) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// lookup returns the value of a field in nested objects of event, or nil if it is missing.
+// Objects of events read from the database are of named map types, such as bson.M.
+func lookup(event map[string]interface{}, path []string) interface{} {
+	var value interface{} = event
+	for _, name := range path {
+		if object, ok := value.(map[string]interface{}); ok {
+			value = object[name]
+			continue
+		}
+		object := reflect.ValueOf(value)
+		if object.Kind() != reflect.Map || object.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		member := object.MapIndex(reflect.ValueOf(name).Convert(object.Type().Key()))
+		if !member.IsValid() {
+			return nil
+		}
+		value = member.Interface()
+	}
+	return value
+}
+
+// anyOf returns whether match is true for value, or for any element of value if it is a list.
+func anyOf(value interface{}, match func(interface{}) bool) bool {
+	switch list := value.(type) {
+	case []interface{}:
+		for _, element := range list {
+			if match(element) {
+				return true
+			}
+		}
+		return false
+	case []string:
+		for _, element := range list {
+			if match(element) {
+				return true
+			}
+		}
+		return false
+	}
+	return match(value)
+}
+
+// compare applies op to a field and a value. Values of different types are
+// only unequal, and only numbers and strings are ordered.
+func compare(field interface{}, op string, value interface{}) bool {
+	switch op {
+	case "==":
+		return equal(field, value)
+	case "!=":
+		return !equal(field, value)
+	}
+
+	var order int
+	switch v := value.(type) {
+	case float64:
+		f, ok := toNumber(field)
+		if !ok {
+			return false
+		}
+		switch {
+		case f < v:
+			order = -1
+		case f > v:
+			order = 1
+		}
+	case string:
+		f, ok := field.(string)
+		if !ok {
+			return false
+		}
+		order = strings.Compare(f, v)
+	default:
+		return false
+	}
+
+	switch op {
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	case ">":
+		return order > 0
+	case ">=":
+		return order >= 0
+	}
+	return false
+}
+
+func equal(field interface{}, value interface{}) bool {
+	if number, ok := value.(float64); ok {
+		f, ok := toNumber(field)
+		return ok && f == number
+	}
+	switch field.(type) {
+	case nil, bool, string:
+		return field == value
+	}
+	return false
+}
+
+// toNumber converts numbers of events, which are float64 if decoded from JSON.
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
@@ -0,0 +1,112 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const event = `{"id":"node1","status":"disconnected","cpu":85.5,"healthy":false,
+	"labels":{"site":"plant-3","zone":"a"},"apps":["app1","app2"]}`
+
+func decode(t *testing.T, body string) map[string]interface{} {
+	result := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	return result
+}
+
+func TestCalledMatch_ExpectEvaluatedAgainstEventFields(t *testing.T) {
+	testCases := map[string]bool{
+		`status in ("disconnected") && labels.site == "plant-3"`: true,
+		`status in ("connected", 'registered')`:                  false,
+		`status == "connected" || labels.zone == "a"`:            true,
+		`!(status == "disconnected")`:                            false,
+		`status != "connected" && !healthy == false`:             false,
+		`healthy == false`:                                       true,
+		`cpu >= 80 && cpu < 90.0`:                                true,
+		`cpu > 85.5`:                                             false,
+		`id <= "node1"`:                                          true,
+		`apps == "app2"`:                                         true,
+		`apps in ("app3")`:                                       false,
+		`labels.rack == null`:                                    true,
+		`labels.site.name == null`:                               true,
+		`unknown > 1`:                                            false,
+		`cpu == "85.5"`:                                          false,
+		`status == "connected" || status == "disconnected" && cpu > 90`: false,
+	}
+
+	for expr, expected := range testCases {
+		f, err := Compile(expr)
+		if err != nil {
+			t.Errorf("Unexpected err for %s: %s", expr, err.Error())
+			continue
+		}
+		if f.String() != expr {
+			t.Errorf("Expected expression: %s, actual expression: %s", expr, f.String())
+		}
+		if actual := f.Match(decode(t, event)); actual != expected {
+			t.Errorf("Expected %t for %s, actual %t", expected, expr, actual)
+		}
+	}
+}
+
+func TestCalledMatchWithNamedMapType_ExpectNestedFieldFound(t *testing.T) {
+	type object map[string]interface{}
+
+	f, _ := Compile(`labels.site == "plant-3"`)
+	if !f.Match(map[string]interface{}{"labels": object{"site": "plant-3"}}) {
+		t.Error("Expected event matched")
+	}
+}
+
+func TestCalledCompileWithInvalidExpression_ExpectSyntaxError(t *testing.T) {
+	testCases := map[string]int{
+		``:                         0,
+		`status`:                   6,
+		`status ==`:                9,
+		`status == "disconnected`:  10,
+		`status in "disconnected"`: 10,
+		`status in ("a",)`:         15,
+		`(status == "a"`:           14,
+		`status == "a" && `:        17,
+		`status == "a")`:           13,
+		`status = "a"`:             7,
+		`"a" == status`:            0,
+		`labels..site == "a"`:      0,
+		`cpu > 1.2.3`:              6,
+	}
+
+	for expr, offset := range testCases {
+		_, err := Compile(expr)
+		if err == nil {
+			t.Errorf("Expected err for %s, actual err: nil", expr)
+			continue
+		}
+		syntaxError, ok := err.(SyntaxError)
+		if !ok {
+			t.Errorf("Expected err: SyntaxError, actual err: %s", err.Error())
+			continue
+		}
+		if syntaxError.Offset != offset {
+			t.Errorf("Expected offset of %s: %d, actual offset: %d (%s)", expr, offset, syntaxError.Offset, err.Error())
+		}
+	}
+}
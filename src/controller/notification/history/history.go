@@ -0,0 +1,335 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+// Package controller/notification/history keeps events of nodes, apps and
+// resource usage for a retention period, so that they can be queried
+// whether or not anyone subscribed to them.
+package history
+
+import (
+	"commons/errors"
+	"commons/logger"
+	"commons/results"
+	"commons/util"
+	"commons/workers"
+	"context"
+	historyDB "db/mongo/event/history"
+	groupDB "db/mongo/group"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Command is an interface of event history operations.
+type Command interface {
+	// Record stores an event of eventType sent to subscribers of eventIds, which is
+	// meant for subscribers with subscriberIds, and returns the id of the stored event.
+	Record(ctx context.Context, eventType string, eventIds []string, subscriberIds []string, event map[string]interface{}) string
+
+	// GetEvents returns stored events matched with query, the latest first.
+	GetEvents(ctx context.Context, query map[string][]string) (int, map[string]interface{}, error)
+
+	// GetEventsAfter returns stored events meant for a subscriber which come after cursor, the earliest first.
+	GetEventsAfter(ctx context.Context, cursor string, eventType string, statuses []string, subscriberId string, limit int) ([]map[string]interface{}, error)
+}
+
+const (
+	ID                = "id"
+	TYPE              = "type"
+	STATUS            = "status"
+	EVENT             = "event"
+	EVENTS            = "events"
+	TIME              = "time"
+	MEMBERS           = "members"
+	NODE_ID           = "nodeid"  // query key of a node.
+	APP_ID            = "appid"   // query key of an app.
+	GROUP_ID          = "groupid" // query key of a group whose members' events are returned.
+	FROM              = "from"    // query key of the start of a period in RFC3339.
+	TO                = "to"      // query key of the end of a period in RFC3339.
+	LIMIT             = "limit"   // query key of the number of events to return.
+	APP               = "app"
+	NODE              = "node"
+	RESOURCE          = "resource"
+	GROUP             = "group"
+	DEPLOYMENT        = "deployment"
+	REGISTRY          = "registry"
+	CONFIGURATION     = "configuration"
+	DEVICE            = "device"
+	DEFAULT_LIMIT     = 100
+	MAX_LIMIT         = 1000
+	PRUNER            = "eventpruner" // name of the worker removing old events.
+	PRUNE_INTERVAL    = time.Hour
+	DEFAULT_RETENTION = 7 * 24 * time.Hour
+	RETENTION_ENV     = "ANCHOR_EVENT_RETENTION" // environment variable of the retention, e.g. 720h.
+)
+
+// types are all types of events in alphabetical order.
+var types = []string{APP, CONFIGURATION, DEPLOYMENT, DEVICE, GROUP, NODE, REGISTRY, RESOURCE}
+
+// Executor implements the Command interface.
+type Executor struct{}
+
+var historyDbExecutor historyDB.Command
+var groupDbExecutor groupDB.Command
+
+func init() {
+	historyDbExecutor = historyDB.Executor{}
+	groupDbExecutor = groupDB.Executor{}
+}
+
+// Record stores an event of eventType with the node and the app it concerns,
+// the ids of events its subscribers are registered to and the ids of subscribers
+// it is meant for, so that subscribers which missed it can find it later
+// whatever their query matches then.
+// A failure is logged and an empty id is returned, since it must not keep
+// the event from subscribers.
+func (Executor) Record(ctx context.Context, eventType string, eventIds []string, subscriberIds []string, event map[string]interface{}) string {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	status, _ := event[STATUS].(string)
+	nodeId, appId := describe(eventType, event)
+	stored, err := historyDbExecutor.AddEvent(ctx, historyDB.Event{
+		Type:          eventType,
+		Status:        status,
+		NodeID:        nodeId,
+		AppID:         appId,
+		EventIDs:      eventIds,
+		SubscriberIDs: subscriberIds,
+		Event:         event,
+		Time:          time.Now().Unix(),
+	})
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return ""
+	}
+	return stored[ID].(string)
+}
+
+// GetEvents returns at most 'limit' events, 100 by default, the latest first.
+// Events are filtered by 'type', 'status', 'nodeid', 'appid', members of 'groupid'
+// and the period between 'from' and 'to'.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetEvents(ctx context.Context, query map[string][]string) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	filter, err := parseFilter(ctx, query)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	events, err := historyDbExecutor.GetEvents(ctx, filter)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	res := make(map[string]interface{})
+	res[EVENTS] = toResponses(events)
+	return results.OK, res, nil
+}
+
+// GetEventsAfter returns at most limit events of eventType with any of statuses,
+// which come after the event of cursor and were meant for the subscriber of
+// subscriberId when they were stored, the earliest first. Events removed by the
+// retention are not returned.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetEventsAfter(ctx context.Context, cursor string, eventType string, statuses []string,
+	subscriberId string, limit int) ([]map[string]interface{}, error) {
+
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	events, err := historyDbExecutor.GetEvents(ctx, historyDB.Filter{
+		Type:          eventType,
+		Statuses:      statuses,
+		SubscriberIDs: []string{subscriberId},
+		After:         cursor,
+		Limit:         limit,
+		Ascending:     true,
+	})
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return nil, err
+	}
+	return toResponses(events), nil
+}
+
+// RetentionFromEnv returns the retention of events given by RETENTION_ENV,
+// or DEFAULT_RETENTION if it is not set or invalid.
+func RetentionFromEnv() (time.Duration, error) {
+	value := os.Getenv(RETENTION_ENV)
+	if len(value) == 0 {
+		return DEFAULT_RETENTION, nil
+	}
+
+	retention, err := time.ParseDuration(value)
+	if err != nil || retention <= 0 {
+		return DEFAULT_RETENTION, errors.InvalidParam{RETENTION_ENV + " must be a positive duration"}
+	}
+	return retention, nil
+}
+
+// StartPruner removes events older than retention every interval,
+// until a signal is sent to the returned channel.
+func StartPruner(interval time.Duration, retention time.Duration) chan bool {
+	quit := make(chan bool)
+	worker := workers.Register(PRUNER, interval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		defer worker.Stop()
+
+		ctx := context.Background()
+		prune(ctx, time.Now(), retention)
+		for {
+			select {
+			case now := <-ticker.C:
+				worker.Start()
+				prune(ctx, now, retention)
+				worker.Beat()
+			case <-quit:
+				return
+			}
+		}
+	}()
+	return quit
+}
+
+// prune removes events which occurred retention before now.
+func prune(ctx context.Context, now time.Time, retention time.Duration) {
+	removed, err := historyDbExecutor.DeleteEventsBefore(ctx, now.Add(-retention).Unix())
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return
+	}
+	if removed != 0 {
+		logger.With(ctx).Log(logger.INFO, "old events removed", "events", removed)
+	}
+}
+
+// describe returns the ids of the node and the app an event concerns.
+// Resource events name them in camel case as alert rules do.
+func describe(eventType string, event map[string]interface{}) (string, string) {
+	var nodeId, appId string
+	switch eventType {
+	case NODE:
+		nodeId, _ = event[ID].(string)
+	case RESOURCE:
+		nodeId, _ = event["nodeId"].(string)
+		appId, _ = event["appId"].(string)
+	default:
+		nodeId, _ = event[NODE_ID].(string)
+		appId, _ = event[APP_ID].(string)
+	}
+	return nodeId, appId
+}
+
+// parseFilter converts query into a filter of events.
+func parseFilter(ctx context.Context, query map[string][]string) (historyDB.Filter, error) {
+	filter := historyDB.Filter{
+		Type:  getQuery(query, TYPE, ""),
+		AppID: getQuery(query, APP_ID, ""),
+	}
+	if status := getQuery(query, STATUS, ""); len(status) != 0 {
+		filter.Statuses = []string{status}
+	}
+	if len(filter.Type) != 0 && !util.IsContainedStringInList(types, filter.Type) {
+		return filter, errors.InvalidParam{TYPE + " must be one of " + strings.Join(types, ", ")}
+	}
+
+	limit, err := strconv.Atoi(getQuery(query, LIMIT, strconv.Itoa(DEFAULT_LIMIT)))
+	if err != nil || limit <= 0 || limit > MAX_LIMIT {
+		return filter, errors.InvalidParam{LIMIT + " must be a positive integer up to " + strconv.Itoa(MAX_LIMIT)}
+	}
+	filter.Limit = limit
+
+	for _, bound := range []struct {
+		key   string
+		value *int64
+	}{{FROM, &filter.From}, {TO, &filter.To}} {
+		value := getQuery(query, bound.key, "")
+		if len(value) == 0 {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, errors.InvalidParam{bound.key + " must be RFC3339 time"}
+		}
+		*bound.value = t.Unix()
+	}
+
+	nodeId := getQuery(query, NODE_ID, "")
+	if len(nodeId) != 0 {
+		filter.NodeIDs = []string{nodeId}
+	}
+	if groupId := getQuery(query, GROUP_ID, ""); len(groupId) != 0 {
+		members, err := getMembers(ctx, groupId)
+		if err != nil {
+			return filter, err
+		}
+		filter.NodeIDs = members
+		if len(nodeId) != 0 {
+			filter.NodeIDs = []string{}
+			if util.IsContainedStringInList(members, nodeId) {
+				filter.NodeIDs = []string{nodeId}
+			}
+		}
+	}
+	return filter, nil
+}
+
+func getQuery(query map[string][]string, key string, defaultValue string) string {
+	if values, exists := query[key]; exists && len(values) != 0 && len(values[0]) != 0 {
+		return values[0]
+	}
+	return defaultValue
+}
+
+// getMembers returns ids of nodes in a group, which is empty but not nil
+// if the group has no members, so that it matches no events.
+func getMembers(ctx context.Context, groupId string) ([]string, error) {
+	group, err := groupDbExecutor.GetGroup(ctx, groupId)
+	if err != nil {
+		return nil, err
+	}
+	members, _ := group[MEMBERS].([]string)
+	if members == nil {
+		members = []string{}
+	}
+	return members, nil
+}
+
+// toResponses converts the time of events into RFC3339.
+func toResponses(events []map[string]interface{}) []map[string]interface{} {
+	responses := make([]map[string]interface{}, len(events))
+	for i, event := range events {
+		response := make(map[string]interface{})
+		for key, value := range event {
+			response[key] = value
+		}
+		if t, ok := event[TIME].(int64); ok {
+			response[TIME] = time.Unix(t, 0).UTC().Format(time.RFC3339)
+		}
+		responses[i] = response
+	}
+	return responses
+}
@@ -0,0 +1,262 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package history
+
+import (
+	"commons/errors"
+	"commons/results"
+	"context"
+	historyDB "db/mongo/event/history"
+	historyDBmocks "db/mongo/event/history/mocks"
+	groupDBmocks "db/mongo/group/mocks"
+	"github.com/golang/mock/gomock"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	now           = time.Unix(1514764800, 0)
+	dbError       = errors.DBConnectionError{"connection refused"}
+	resource      = map[string]interface{}{ID: "alertid", "nodeId": "nodeid", "appId": "appid", STATUS: "firing"}
+	eventIds      = []string{"nodeeventid"}
+	subscriberIds = []string{"subscriberid"}
+)
+
+const storedId = "5a4c6b1c9e7f1a2b3c4d5e6f"
+
+func TestCalledRecord_ExpectEventStoredWithNodeAndApp(t *testing.T) {
+	testList := []struct {
+		eventType string
+		event     map[string]interface{}
+		nodeId    string
+		appId     string
+	}{
+		{NODE, map[string]interface{}{ID: "nodeid", STATUS: "disconnected"}, "nodeid", ""},
+		{RESOURCE, resource, "nodeid", "appid"},
+		{APP, map[string]interface{}{NODE_ID: "nodeid", APP_ID: "appid", STATUS: "stop"}, "nodeid", "appid"},
+	}
+
+	for _, test := range testList {
+		t.Run(test.eventType, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dbMockObj := historyDBmocks.NewMockCommand(ctrl)
+			dbMockObj.EXPECT().AddEvent(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event historyDB.Event) (map[string]interface{}, error) {
+				if event.Type != test.eventType || event.Status != test.event[STATUS] ||
+					event.NodeID != test.nodeId || event.AppID != test.appId ||
+					!reflect.DeepEqual(event.EventIDs, eventIds) || !reflect.DeepEqual(event.SubscriberIDs, subscriberIds) ||
+					!reflect.DeepEqual(event.Event, test.event) {
+					t.Errorf("Unexpected event: %v", event)
+				}
+				return map[string]interface{}{ID: storedId}, nil
+			})
+
+			// pass mockObj to a real object.
+			historyDbExecutor = dbMockObj
+
+			id := Executor{}.Record(context.Background(), test.eventType, eventIds, subscriberIds, test.event)
+			if id != storedId {
+				t.Errorf("Unexpected id: %s", id)
+			}
+		})
+	}
+}
+
+func TestCalledRecordWhenDBFailed_ExpectNoPanic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbMockObj := historyDBmocks.NewMockCommand(ctrl)
+	dbMockObj.EXPECT().AddEvent(gomock.Any(), gomock.Any()).Return(nil, dbError)
+
+	// pass mockObj to a real object.
+	historyDbExecutor = dbMockObj
+
+	id := Executor{}.Record(context.Background(), RESOURCE, eventIds, subscriberIds, resource)
+	if id != "" {
+		t.Errorf("Unexpected id: %s", id)
+	}
+}
+
+func TestCalledGetEvents_ExpectFilteredAndTimeFormatted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	query := map[string][]string{
+		TYPE:    []string{NODE},
+		STATUS:  []string{"disconnected"},
+		FROM:    []string{"2018-01-01T00:00:00Z"},
+		LIMIT:   []string{"1"},
+		NODE_ID: []string{"nodeid"},
+	}
+	filter := historyDB.Filter{Type: NODE, Statuses: []string{"disconnected"}, NodeIDs: []string{"nodeid"}, From: now.Unix(), Limit: 1}
+	stored := map[string]interface{}{ID: "eventid", TYPE: NODE, STATUS: "disconnected", TIME: now.Unix()}
+
+	dbMockObj := historyDBmocks.NewMockCommand(ctrl)
+	dbMockObj.EXPECT().GetEvents(gomock.Any(), filter).Return([]map[string]interface{}{stored}, nil)
+
+	// pass mockObj to a real object.
+	historyDbExecutor = dbMockObj
+
+	code, res, err := Executor{}.GetEvents(context.Background(), query)
+	if code != results.OK || err != nil {
+		t.Fatalf("Unexpected result: %d, %v", code, err)
+	}
+
+	events := res[EVENTS].([]map[string]interface{})
+	if len(events) != 1 || events[0][TIME] != "2018-01-01T00:00:00Z" || events[0][ID] != "eventid" {
+		t.Errorf("Unexpected events: %v", events)
+	}
+}
+
+func TestCalledGetEventsAfter_ExpectEarliestFirstAfterCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filter := historyDB.Filter{
+		Type:          NODE,
+		Statuses:      []string{"connected", "disconnected"},
+		SubscriberIDs: subscriberIds,
+		After:         storedId,
+		Limit:         MAX_LIMIT,
+		Ascending:     true,
+	}
+	stored := map[string]interface{}{ID: "eventid", TYPE: NODE, STATUS: "disconnected", TIME: now.Unix()}
+
+	dbMockObj := historyDBmocks.NewMockCommand(ctrl)
+	dbMockObj.EXPECT().GetEvents(gomock.Any(), filter).Return([]map[string]interface{}{stored}, nil)
+
+	// pass mockObj to a real object.
+	historyDbExecutor = dbMockObj
+
+	events, err := Executor{}.GetEventsAfter(context.Background(), storedId, NODE, filter.Statuses, subscriberIds[0], MAX_LIMIT)
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	if len(events) != 1 || events[0][TIME] != "2018-01-01T00:00:00Z" {
+		t.Errorf("Unexpected events: %v", events)
+	}
+}
+
+func TestCalledGetEventsAfterWhenDBFailed_ExpectErrorReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbMockObj := historyDBmocks.NewMockCommand(ctrl)
+	dbMockObj.EXPECT().GetEvents(gomock.Any(), gomock.Any()).Return(nil, dbError)
+
+	// pass mockObj to a real object.
+	historyDbExecutor = dbMockObj
+
+	_, err := Executor{}.GetEventsAfter(context.Background(), storedId, NODE, nil, subscriberIds[0], MAX_LIMIT)
+	if err == nil {
+		t.Error("Expected err: DBConnectionError, actual err: nil")
+	}
+}
+
+func TestCalledGetEventsWithGroup_ExpectEventsOfMembers(t *testing.T) {
+	testList := []struct {
+		name    string
+		nodeId  string
+		members []string
+		nodeIds []string
+	}{
+		{"group", "", []string{"a", "b"}, []string{"a", "b"}},
+		{"member", "b", []string{"a", "b"}, []string{"b"}},
+		{"notMember", "c", []string{"a", "b"}, []string{}},
+		{"emptyGroup", "", nil, []string{}},
+	}
+
+	for _, test := range testList {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			query := map[string][]string{GROUP_ID: []string{"groupid"}, NODE_ID: []string{test.nodeId}}
+			filter := historyDB.Filter{NodeIDs: test.nodeIds, Limit: DEFAULT_LIMIT}
+
+			groupDbMockObj := groupDBmocks.NewMockCommand(ctrl)
+			dbMockObj := historyDBmocks.NewMockCommand(ctrl)
+			gomock.InOrder(
+				groupDbMockObj.EXPECT().GetGroup(gomock.Any(), "groupid").Return(map[string]interface{}{MEMBERS: test.members}, nil),
+				dbMockObj.EXPECT().GetEvents(gomock.Any(), filter).Return([]map[string]interface{}{}, nil),
+			)
+
+			// pass mockObj to a real object.
+			groupDbExecutor = groupDbMockObj
+			historyDbExecutor = dbMockObj
+
+			code, _, err := Executor{}.GetEvents(context.Background(), query)
+			if code != results.OK || err != nil {
+				t.Errorf("Unexpected result: %d, %v", code, err)
+			}
+		})
+	}
+}
+
+func TestCalledGetEventsWithInvalidQuery_ExpectInvalidParamReturn(t *testing.T) {
+	for _, query := range []map[string][]string{
+		{TYPE: []string{"unknown"}},
+		{LIMIT: []string{"0"}},
+		{LIMIT: []string{"1001"}},
+		{TO: []string{"yesterday"}},
+	} {
+		code, _, err := Executor{}.GetEvents(context.Background(), query)
+		if _, ok := err.(errors.InvalidParam); code != results.ERROR || !ok {
+			t.Errorf("Unexpected result of %v: %d, %v", query, code, err)
+		}
+	}
+}
+
+func TestCalledPrune_ExpectEventsBeforeRetentionRemoved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbMockObj := historyDBmocks.NewMockCommand(ctrl)
+	dbMockObj.EXPECT().DeleteEventsBefore(gomock.Any(), now.Add(-DEFAULT_RETENTION).Unix()).Return(2, nil)
+
+	// pass mockObj to a real object.
+	historyDbExecutor = dbMockObj
+
+	prune(context.Background(), now, DEFAULT_RETENTION)
+}
+
+func TestCalledRetentionFromEnv_ExpectDurationOrDefault(t *testing.T) {
+	defer os.Unsetenv(RETENTION_ENV)
+
+	testList := []struct {
+		value     string
+		retention time.Duration
+		valid     bool
+	}{
+		{"", DEFAULT_RETENTION, true},
+		{"720h", 720 * time.Hour, true},
+		{"-1h", DEFAULT_RETENTION, false},
+		{"week", DEFAULT_RETENTION, false},
+	}
+
+	for _, test := range testList {
+		os.Setenv(RETENTION_ENV, test.value)
+		retention, err := RetentionFromEnv()
+		if retention != test.retention || (err == nil) != test.valid {
+			t.Errorf("Unexpected result of %q: %s, %v", test.value, retention, err)
+		}
+	}
+}
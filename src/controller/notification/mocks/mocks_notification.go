@@ -22,6 +22,9 @@
 package mocks
 
 import (
+	models "commons/models"
+	context "context"
+	stream "controller/notification/stream"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -50,51 +53,169 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // Register mocks base method
-func (m *MockCommand) Register(body string, query map[string][]string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "Register", body, query)
+func (m *MockCommand) Register(ctx context.Context, req models.SubscriptionRequest, query map[string][]string) (int, models.SubscriptionResponse, error) {
+	ret := m.ctrl.Call(m, "Register", ctx, req, query)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(map[string]interface{})
+	ret1, _ := ret[1].(models.SubscriptionResponse)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // Register indicates an expected call of Register
-func (mr *MockCommandMockRecorder) Register(body, query interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockCommand)(nil).Register), body, query)
+func (mr *MockCommandMockRecorder) Register(ctx, req, query interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockCommand)(nil).Register), ctx, req, query)
 }
 
 // UnRegister mocks base method
-func (m *MockCommand) UnRegister(eventId string) (int, error) {
-	ret := m.ctrl.Call(m, "UnRegister", eventId)
+func (m *MockCommand) UnRegister(ctx context.Context, eventId string) (int, error) {
+	ret := m.ctrl.Call(m, "UnRegister", ctx, eventId)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnRegister indicates an expected call of UnRegister
-func (mr *MockCommandMockRecorder) UnRegister(eventId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnRegister", reflect.TypeOf((*MockCommand)(nil).UnRegister), eventId)
+func (mr *MockCommandMockRecorder) UnRegister(ctx, eventId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnRegister", reflect.TypeOf((*MockCommand)(nil).UnRegister), ctx, eventId)
 }
 
-// UpdateSubscriber mocks base method
-func (m *MockCommand) UpdateSubscriber() {
-	m.ctrl.Call(m, "UpdateSubscriber")
+// RotateSecret mocks base method
+func (m *MockCommand) RotateSecret(ctx context.Context, subscriberId string) (int, models.SubscriptionResponse, error) {
+	ret := m.ctrl.Call(m, "RotateSecret", ctx, subscriberId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(models.SubscriptionResponse)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RotateSecret indicates an expected call of RotateSecret
+func (mr *MockCommandMockRecorder) RotateSecret(ctx, subscriberId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSecret", reflect.TypeOf((*MockCommand)(nil).RotateSecret), ctx, subscriberId)
 }
 
-// UpdateSubscriber indicates an expected call of UpdateSubscriber
-func (mr *MockCommandMockRecorder) UpdateSubscriber() *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriber", reflect.TypeOf((*MockCommand)(nil).UpdateSubscriber))
+// GetSubscriptions mocks base method
+func (m *MockCommand) GetSubscriptions(ctx context.Context) (int, models.SubscriberList, error) {
+	ret := m.ctrl.Call(m, "GetSubscriptions", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(models.SubscriberList)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSubscriptions indicates an expected call of GetSubscriptions
+func (mr *MockCommandMockRecorder) GetSubscriptions(ctx interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptions", reflect.TypeOf((*MockCommand)(nil).GetSubscriptions), ctx)
+}
+
+// GetSubscription mocks base method
+func (m *MockCommand) GetSubscription(ctx context.Context, subscriberId string) (int, models.Subscriber, error) {
+	ret := m.ctrl.Call(m, "GetSubscription", ctx, subscriberId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(models.Subscriber)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSubscription indicates an expected call of GetSubscription
+func (mr *MockCommandMockRecorder) GetSubscription(ctx, subscriberId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockCommand)(nil).GetSubscription), ctx, subscriberId)
+}
+
+// UpdateSubscription mocks base method
+func (m *MockCommand) UpdateSubscription(ctx context.Context, subscriberId string, req models.SubscriptionUpdate) (int, models.Subscriber, error) {
+	ret := m.ctrl.Call(m, "UpdateSubscription", ctx, subscriberId, req)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(models.Subscriber)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription
+func (mr *MockCommandMockRecorder) UpdateSubscription(ctx, subscriberId, req interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockCommand)(nil).UpdateSubscription), ctx, subscriberId, req)
+}
+
+// GetMissedEvents mocks base method
+func (m *MockCommand) GetMissedEvents(ctx context.Context, subscriberId string, query map[string][]string) (int, models.MissedEvents, error) {
+	ret := m.ctrl.Call(m, "GetMissedEvents", ctx, subscriberId, query)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(models.MissedEvents)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMissedEvents indicates an expected call of GetMissedEvents
+func (mr *MockCommandMockRecorder) GetMissedEvents(ctx, subscriberId, query interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissedEvents", reflect.TypeOf((*MockCommand)(nil).GetMissedEvents), ctx, subscriberId, query)
+}
+
+// ReplayMissedEvents mocks base method
+func (m *MockCommand) ReplayMissedEvents(ctx context.Context, subscriberId string, query map[string][]string) (int, models.ReplayResponse, error) {
+	ret := m.ctrl.Call(m, "ReplayMissedEvents", ctx, subscriberId, query)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(models.ReplayResponse)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReplayMissedEvents indicates an expected call of ReplayMissedEvents
+func (mr *MockCommandMockRecorder) ReplayMissedEvents(ctx, subscriberId, query interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayMissedEvents", reflect.TypeOf((*MockCommand)(nil).ReplayMissedEvents), ctx, subscriberId, query)
+}
+
+// OpenStream mocks base method
+func (m *MockCommand) OpenStream(ctx context.Context, query map[string][]string) (int, *stream.Stream, error) {
+	ret := m.ctrl.Call(m, "OpenStream", ctx, query)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(*stream.Stream)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenStream indicates an expected call of OpenStream
+func (mr *MockCommandMockRecorder) OpenStream(ctx, query interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenStream", reflect.TypeOf((*MockCommand)(nil).OpenStream), ctx, query)
+}
+
+// CloseStream mocks base method
+func (m *MockCommand) CloseStream(ctx context.Context, s *stream.Stream) {
+	m.ctrl.Call(m, "CloseStream", ctx, s)
+}
+
+// CloseStream indicates an expected call of CloseStream
+func (mr *MockCommandMockRecorder) CloseStream(ctx, s interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseStream", reflect.TypeOf((*MockCommand)(nil).CloseStream), ctx, s)
+}
+
+// ResyncSubscribers mocks base method
+func (m *MockCommand) ResyncSubscribers(ctx context.Context, nodeIds []string) {
+	m.ctrl.Call(m, "ResyncSubscribers", ctx, nodeIds)
+}
+
+// ResyncSubscribers indicates an expected call of ResyncSubscribers
+func (mr *MockCommandMockRecorder) ResyncSubscribers(ctx, nodeIds interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResyncSubscribers", reflect.TypeOf((*MockCommand)(nil).ResyncSubscribers), ctx, nodeIds)
 }
 
 // NotificationHandler mocks base method
-func (m *MockCommand) NotificationHandler(eventType, body string) (int, error) {
-	ret := m.ctrl.Call(m, "NotificationHandler", eventType, body)
+func (m *MockCommand) NotificationHandler(ctx context.Context, eventType, body string) (int, error) {
+	ret := m.ctrl.Call(m, "NotificationHandler", ctx, eventType, body)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // NotificationHandler indicates an expected call of NotificationHandler
-func (mr *MockCommandMockRecorder) NotificationHandler(eventType, body interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationHandler", reflect.TypeOf((*MockCommand)(nil).NotificationHandler), eventType, body)
+func (mr *MockCommandMockRecorder) NotificationHandler(ctx, eventType, body interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationHandler", reflect.TypeOf((*MockCommand)(nil).NotificationHandler), ctx, eventType, body)
+}
+
+// Publish mocks base method
+func (m *MockCommand) Publish(ctx context.Context, eventType string, nodeIds []string, event map[string]interface{}) {
+	m.ctrl.Call(m, "Publish", ctx, eventType, nodeIds, event)
+}
+
+// Publish indicates an expected call of Publish
+func (mr *MockCommandMockRecorder) Publish(ctx, eventType, nodeIds, event interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockCommand)(nil).Publish), ctx, eventType, nodeIds, event)
 }
@@ -0,0 +1,157 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package notification
+
+import (
+	"commons/util"
+	"context"
+)
+
+// nodeIndex keeps nodes and groups read once, so that queries of subscribers
+// are matched with nodes in memory rather than by searching for nodes with each query.
+// Images of apps are read the first time a query with an image name is matched.
+type nodeIndex struct {
+	nodes   []map[string]interface{}
+	byId    map[string]map[string]interface{}
+	members map[string][]string // members of groups by group id.
+	images  map[string][]string // images of apps by app id, or nil until read.
+}
+
+// lazyNodeIndex reads nodes and groups the first time they are needed,
+// and keeps them or the error reading them for later calls.
+type lazyNodeIndex struct {
+	index  *nodeIndex
+	err    error
+	loaded bool
+}
+
+// get returns the nodes and groups, reading them if they have not been read yet.
+func (l *lazyNodeIndex) get(ctx context.Context) (*nodeIndex, error) {
+	if !l.loaded {
+		l.index, l.err = loadNodeIndex(ctx)
+		l.loaded = true
+	}
+	return l.index, l.err
+}
+
+// loadNodeIndex reads all nodes and groups.
+func loadNodeIndex(ctx context.Context) (*nodeIndex, error) {
+	nodes, err := nodeDbExecutor.GetNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := groupDbExecutor.GetGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &nodeIndex{
+		nodes:   nodes,
+		byId:    make(map[string]map[string]interface{}, len(nodes)),
+		members: make(map[string][]string, len(groups)),
+	}
+	for _, node := range nodes {
+		id, _ := node[ID].(string)
+		index.byId[id] = node
+	}
+	for _, group := range groups {
+		id, _ := group[ID].(string)
+		index.members[id], _ = group[MEMBERS].([]string)
+	}
+	return index, nil
+}
+
+// matchedNodeIds returns ids of nodes matched with query, or an empty list if there are none.
+func (index *nodeIndex) matchedNodeIds(ctx context.Context, query map[string][]string) ([]string, error) {
+	nodeIds := make([]string, 0)
+	for _, node := range index.nodes {
+		matched, err := index.match(ctx, query, node)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			nodeIds = append(nodeIds, node[ID].(string))
+		}
+	}
+	return nodeIds, nil
+}
+
+// matchAny returns whether any of nodes with nodeIds is matched with query.
+// Nodes which are not registered match no query.
+func (index *nodeIndex) matchAny(ctx context.Context, query map[string][]string, nodeIds []string) (bool, error) {
+	for _, nodeId := range nodeIds {
+		node, exists := index.byId[nodeId]
+		if !exists {
+			continue
+		}
+		matched, err := index.match(ctx, query, node)
+		if err != nil || matched {
+			return matched, err
+		}
+	}
+	return false, nil
+}
+
+// match returns whether node is matched with all keys of query, as nodes are searched:
+// its id, a group it is a member of, an app deployed to it and an image of such an app.
+func (index *nodeIndex) match(ctx context.Context, query map[string][]string, node map[string]interface{}) (bool, error) {
+	nodeId, _ := node[ID].(string)
+	apps, _ := node["apps"].([]string)
+
+	if values, exists := query[NODE_ID]; exists && (len(values) == 0 || values[0] != nodeId) {
+		return false, nil
+	}
+	if values, exists := query[GROUP_ID]; exists && (len(values) == 0 ||
+		!util.IsContainedStringInList(index.members[values[0]], nodeId)) {
+		return false, nil
+	}
+	if values, exists := query[APP_ID]; exists && (len(values) == 0 || !util.IsContainedStringInList(apps, values[0])) {
+		return false, nil
+	}
+	if values, exists := query[IMAGE_NAME]; exists {
+		if len(values) == 0 {
+			return false, nil
+		}
+		if index.images == nil {
+			if err := index.loadImages(ctx); err != nil {
+				return false, err
+			}
+		}
+		for _, appId := range apps {
+			if util.IsContainedStringInList(index.images[appId], values[0]) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// loadImages reads images of all apps.
+func (index *nodeIndex) loadImages(ctx context.Context) error {
+	apps, err := appDbExecutor.GetApps(ctx)
+	if err != nil {
+		return err
+	}
+	index.images = make(map[string][]string, len(apps))
+	for _, app := range apps {
+		id, _ := app[ID].(string)
+		index.images[id], _ = app["images"].([]string)
+	}
+	return nil
+}
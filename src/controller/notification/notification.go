@@ -20,27 +20,49 @@ package notification
 import (
 	"commons/errors"
 	"commons/logger"
+	"commons/models"
 	"commons/results"
 	URL "commons/url"
 	"commons/util"
+	"commons/validate"
+	"commons/workers"
+	"context"
+	"controller/notification/delivery"
+	"controller/notification/filter"
+	"controller/notification/history"
+	"controller/notification/stream"
 	nodeSearch "controller/search/node"
 	"crypto/sha1"
+	appDB "db/mongo/app"
 	appEventDB "db/mongo/event/app"
 	nodeEventDB "db/mongo/event/node"
 	subsDB "db/mongo/event/subscriber"
+	groupDB "db/mongo/group"
 	nodeDB "db/mongo/node"
 	"encoding/hex"
 	"encoding/json"
 	"messenger"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Command is an interface of notification operations.
 type Command interface {
-	Register(body string, query map[string][]string) (int, map[string]interface{}, error)
-	UnRegister(eventId string) (int, error)
-	UpdateSubscriber()
-	NotificationHandler(eventType string, body string) (int, error)
+	Register(ctx context.Context, req models.SubscriptionRequest, query map[string][]string) (int, models.SubscriptionResponse, error)
+	UnRegister(ctx context.Context, eventId string) (int, error)
+	RotateSecret(ctx context.Context, subscriberId string) (int, models.SubscriptionResponse, error)
+	GetSubscriptions(ctx context.Context) (int, models.SubscriberList, error)
+	GetSubscription(ctx context.Context, subscriberId string) (int, models.Subscriber, error)
+	UpdateSubscription(ctx context.Context, subscriberId string, req models.SubscriptionUpdate) (int, models.Subscriber, error)
+	GetMissedEvents(ctx context.Context, subscriberId string, query map[string][]string) (int, models.MissedEvents, error)
+	ReplayMissedEvents(ctx context.Context, subscriberId string, query map[string][]string) (int, models.ReplayResponse, error)
+	OpenStream(ctx context.Context, query map[string][]string) (int, *stream.Stream, error)
+	CloseStream(ctx context.Context, s *stream.Stream)
+	ResyncSubscribers(ctx context.Context, nodeIds []string)
+	NotificationHandler(ctx context.Context, eventType string, body string) (int, error)
+	Publish(ctx context.Context, eventType string, nodeIds []string, event map[string]interface{})
 }
 
 const (
@@ -51,18 +73,65 @@ const (
 	IMAGE_NAME        = "imagename"
 	APP               = "app"
 	NODE              = "node"
+	RESOURCE          = "resource"
 	NODES             = "nodes"
+	MEMBERS           = "members"
 	SUBS              = "subscriber"
 	EVENT             = "event"
 	EVENT_ID          = "eventid"
-	RESPONSES         = "response"
 	DEFAULT_NODE_PORT = "48098"
-	RESPONSE_CODE     = "code"
 	ERROR_MESSAGE     = "message"
 	TYPE              = "type"
 	STATUS            = "status"
+	SECRET            = "secret"
+	STATUS_FIRING     = "firing"   // status of a resource event when an alert is raised.
+	STATUS_RESOLVED   = "resolved" // status of a resource event when an alert is cleared.
 )
 
+const (
+	URL_FIELD             = "url"
+	TTL                   = "ttl"
+	EXPIRES_AT            = "expiresat"
+	DEACTIVATED           = "deactivated"
+	CURSOR                = "cursor"             // id of the latest event delivered to a subscriber.
+	SINCE                 = "since"              // query key of a cursor which missed events come after.
+	FILTER                = "filter"             // expression over fields of events a subscriber receives.
+	EXPIRY_CHECKER        = "subscriptionexpiry" // name of the worker removing expired subscriptions.
+	EXPIRY_CHECK_INTERVAL = time.Minute
+)
+
+// Types and status of events the anchor raises about nodes. Like resource events,
+// they are subscribed to with the same query as node events.
+const (
+	GROUP           = "group"
+	DEPLOYMENT      = "deployment"
+	REGISTRY        = "registry"
+	CONFIGURATION   = "configuration"
+	DEVICE          = "device"
+	TIMESTAMP       = "timestamp"
+	STATUS_CREATED  = "created"  // status of a group event when a group is created.
+	STATUS_DELETED  = "deleted"  // status of group and deployment events when a group or an app is deleted.
+	STATUS_JOINED   = "joined"   // status of a group event when nodes join a group.
+	STATUS_LEFT     = "left"     // status of a group event when nodes leave a group.
+	STATUS_DEPLOYED = "deployed" // status of a deployment event when an app is deployed to a node.
+	STATUS_UPDATED  = "updated"  // status of deployment and configuration events when an app or configuration is updated.
+	STATUS_ADDED    = "added"    // status of a registry event when a docker registry is added.
+	STATUS_REMOVED  = "removed"  // status of a registry event when a docker registry is removed.
+	STATUS_REBOOTED = "rebooted" // status of a device event when a node is requested to reboot.
+	STATUS_RESTORED = "restored" // status of a device event when a node is requested to restore.
+)
+
+// nodeEventStatus has the status of each type of events subscribed to with
+// the query of node events, except node events whose status nodes decide.
+var nodeEventStatus = map[string][]string{
+	RESOURCE:      {STATUS_FIRING, STATUS_RESOLVED},
+	GROUP:         {STATUS_CREATED, STATUS_DELETED, STATUS_JOINED, STATUS_LEFT},
+	DEPLOYMENT:    {STATUS_DEPLOYED, STATUS_UPDATED, STATUS_DELETED},
+	REGISTRY:      {STATUS_ADDED, STATUS_REMOVED},
+	CONFIGURATION: {STATUS_UPDATED},
+	DEVICE:        {STATUS_REBOOTED, STATUS_RESTORED},
+}
+
 // Executor implements the Command interface.
 type Executor struct{}
 
@@ -72,6 +141,17 @@ var nodeEventDbExecutor nodeEventDB.Command
 var nodeSearchExecutor nodeSearch.Command
 var httpExecutor messenger.Command
 var nodeDbExecutor nodeDB.Command
+var groupDbExecutor groupDB.Command
+var appDbExecutor appDB.Command
+var deliveryExecutor delivery.Command
+var historyExecutor history.Command
+
+// filters holds compiled filters by ids of subscribers, so that a filter is
+// compiled again only when the expression of its subscriber changes.
+var (
+	filtersMutex sync.Mutex
+	filters      = make(map[string]*filter.Filter)
+)
 
 func init() {
 	subsDbExecutor = subsDB.Executor{}
@@ -80,237 +160,912 @@ func init() {
 	nodeSearchExecutor = nodeSearch.Executor{}
 	httpExecutor = messenger.NewExecutor()
 	nodeDbExecutor = nodeDB.Executor{}
+	groupDbExecutor = groupDB.Executor{}
+	appDbExecutor = appDB.Executor{}
+	deliveryExecutor = delivery.Executor{}
+	historyExecutor = history.Executor{}
 }
 
-func (Executor) Register(body string,
-	query map[string][]string) (int, map[string]interface{}, error) {
+func (Executor) Register(ctx context.Context, req models.SubscriptionRequest,
+	query map[string][]string) (int, models.SubscriptionResponse, error) {
 
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	bodyMap, err := convertJsonToMap(body)
-	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		return results.ERROR, nil, err
-	}
 	// Check whether 'URL' is included.
-	url, exists := bodyMap["url"].(string)
-	if !exists {
-		return results.ERROR, nil, errors.InvalidJSON{"url field is required"}
+	if len(req.URL) == 0 {
+		return results.ERROR, models.SubscriptionResponse{}, errors.InvalidJSON{"url field is required"}
+	}
+	// Events are sent by the sink of the scheme of the url, e.g. to an MQTT broker.
+	if err := delivery.ValidateURL(req.URL); err != nil {
+		return results.ERROR, models.SubscriptionResponse{}, err
 	}
 
 	// Check whether 'event' is included.
-	event, exists := bodyMap["event"]
-	if !exists {
-		return results.ERROR, nil, errors.InvalidJSON{"event field is required"}
+	event := req.Event
+	if err := validateEvent(event); err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.SubscriptionResponse{}, err
+	}
+
+	// A subscription with 'ttl' is removed the given seconds later.
+	if req.TTL < 0 {
+		return results.ERROR, models.SubscriptionResponse{}, errors.InvalidField{TTL, "must be non-negative integer"}
 	}
+	expiresAt := expiry(req.TTL)
 
-	switch parseEventType(event.(map[string]interface{})) {
+	// Events to the subscriber are signed with the secret.
+	secret, err := delivery.NewSecret()
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.SubscriptionResponse{}, errors.InternalServerError{"Secret Generation Failed"}
+	}
+
+	var result int
+	var resp models.SubscriptionResponse
+	switch {
 	default:
-		return results.ERROR, nil, err
-	case APP:
-		result, resp, err := registerAppEvent(url, event.(map[string]interface{}), query)
-		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
-			return results.ERROR, nil, err
+		return results.ERROR, models.SubscriptionResponse{}, errors.InvalidField{validate.Member(EVENT, TYPE), "must be " + strings.Join(eventTypes(), ", ")}
+	case event.Type == APP:
+		result, resp, err = registerAppEvent(ctx, req.URL, secret, event, expiresAt, query)
+	case isNodeEventType(event.Type):
+		if err = validateStatus(event.Type, event.Status); err != nil {
+			return results.ERROR, models.SubscriptionResponse{}, err
 		}
-		return result, resp, err
-	case NODE:
-		result, resp, err := registerNodeEvent(url, event.(map[string]interface{}), query)
-		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
-			return results.ERROR, nil, err
-		}
-		return result, resp, err
+		result, resp, err = registerNodeEvent(ctx, req.URL, secret, event, expiresAt, query)
 	}
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.SubscriptionResponse{}, err
+	}
+	return result, resp, err
 }
 
-func (Executor) UpdateSubscriber() {
-	subscribers, err := subsDbExecutor.GetSubscribers()
+// ResyncSubscribers brings app events subscribed to up to date with changes of
+// nodes with nodeIds, such as a node registered, joining or leaving a group, or
+// an app deployed to or deleted from it. Only nodes which start or stop matching
+// the query of an app event are requested to watch or unwatch it.
+// Subscribers of events about nodes are not affected, since their query is
+// matched when events are dispatched. App events of a group are not searched
+// unless any of the nodes is or was a member of the group.
+func (Executor) ResyncSubscribers(ctx context.Context, nodeIds []string) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	if len(nodeIds) == 0 {
+		return
+	}
+
+	subscribers, err := subsDbExecutor.GetSubscribers(ctx)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return
 	}
 
-	for _, subscriber := range subscribers {
-		status := make([]interface{}, len(subscriber["status"].([]string)))
-		for i, v := range subscriber["status"].([]string) {
-			status[i] = v
+	// Subscribers of app events with the same query share the app event.
+	queries := make(map[string]map[string][]string)
+	for _, subs := range subscribers {
+		if subs[TYPE] != APP {
+			continue
 		}
-		subscriber["status"] = status
+		query, _ := subs["query"].(map[string][]string)
+		eventIds, _ := subs[EVENT_ID].([]string)
+		for _, eventId := range eventIds {
+			queries[eventId] = query
+		}
+	}
 
-		switch subscriber["type"] {
-		case NODE:
-			registerNodeEvent(subscriber["url"].(string), subscriber, subscriber["query"].(map[string][]string))
-		case APP:
-			registerAppEvent(subscriber["url"].(string), subscriber, subscriber["query"].(map[string][]string))
+	members := make(map[string][]string) // members of groups in queries, read once.
+	for eventId, query := range queries {
+		if values, exists := query[NODE_ID]; exists && !util.IsContainedStringInList(nodeIds, values[0]) {
+			// Changes of other nodes do not affect an app event of a node.
+			continue
+		}
+		if err = resyncAppEvent(ctx, eventId, query, nodeIds, members); err != nil {
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
 		}
 	}
 }
 
-func (Executor) UnRegister(eventId string) (int, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) UnRegister(ctx context.Context, eventId string) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	subs, err := subsDbExecutor.GetSubscriber(eventId)
+	subs, err := subsDbExecutor.GetSubscriber(ctx, eventId)
 	if err != nil {
 		return results.ERROR, err
 	}
 
-	switch subs[TYPE] {
-	case APP:
+	switch eventType := subs[TYPE].(string); {
+	case eventType == APP:
 		for _, appEventId := range subs[EVENT_ID].([]string) {
-			err = appEventDbExecutor.UnRegisterEvent(appEventId, subs[ID].(string))
+			err = appEventDbExecutor.UnRegisterEvent(ctx, appEventId, subs[ID].(string))
 			if err != nil {
-				logger.Logging(logger.ERROR, err.Error())
+				logger.With(ctx).Logging(logger.ERROR, err.Error())
 				return results.ERROR, err
 			}
-			appEvent, err := appEventDbExecutor.GetEvent(appEventId)
+			appEvent, err := appEventDbExecutor.GetEvent(ctx, appEventId)
 			if err != nil {
-				logger.Logging(logger.ERROR, err.Error())
+				logger.With(ctx).Logging(logger.ERROR, err.Error())
 				return results.ERROR, err
 			}
 
 			if len(appEvent[SUBS].([]string)) == 0 {
-				requestUnRegisterAppEvent(appEvent[NODES].([]string), appEventId)
-				err = appEventDbExecutor.DeleteEvent(appEventId)
+				requestUnRegisterAppEvent(ctx, appEvent[NODES].([]string), appEventId)
+				err = appEventDbExecutor.DeleteEvent(ctx, appEventId)
 				if err != nil {
-					logger.Logging(logger.ERROR, err.Error())
+					logger.With(ctx).Logging(logger.ERROR, err.Error())
 					return results.ERROR, err
 				}
 			}
 		}
-	case NODE:
+	case isNodeEventType(eventType):
+		// Only subscribers registered to events of each node have ids of events.
 		for _, nodeEventId := range subs[EVENT_ID].([]string) {
-			err = nodeEventDbExecutor.UnRegisterEvent(nodeEventId, subs[ID].(string))
+			err = nodeEventDbExecutor.UnRegisterEvent(ctx, nodeEventId, subs[ID].(string))
 			if err != nil {
-				logger.Logging(logger.ERROR, err.Error())
+				logger.With(ctx).Logging(logger.ERROR, err.Error())
 				return results.ERROR, err
 			}
-			nodeEvent, err := nodeEventDbExecutor.GetEvent(nodeEventId)
+			nodeEvent, err := nodeEventDbExecutor.GetEvent(ctx, nodeEventId)
 			if err != nil {
-				logger.Logging(logger.ERROR, err.Error())
+				logger.With(ctx).Logging(logger.ERROR, err.Error())
 				return results.ERROR, err
 			}
 
 			if len(nodeEvent[SUBS].([]string)) == 0 {
-				err = nodeEventDbExecutor.DeleteEvent(nodeEventId)
+				err = nodeEventDbExecutor.DeleteEvent(ctx, nodeEventId)
 				if err != nil {
-					logger.Logging(logger.ERROR, err.Error())
+					logger.With(ctx).Logging(logger.ERROR, err.Error())
 					return results.ERROR, err
 				}
 			}
 		}
 	}
-	err = subsDbExecutor.DeleteSubscriber(eventId)
+	err = subsDbExecutor.DeleteSubscriber(ctx, eventId)
 	if err != nil {
 		return results.ERROR, err
 	}
+	forgetFilter(eventId)
+
+	// Deliveries left in the queue are dropped by the dispatcher as well,
+	// since their subscriber is not found.
+	err = deliveryExecutor.DeleteSubscriberDeliveries(ctx, eventId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+	}
 
 	return results.OK, nil
 }
 
-func (Executor) NotificationHandler(eventType string, body string) (int, error) {
+// RotateSecret replaces the secret of a subscriber with a new one and returns it.
+// Events sent after the rotation, including retries of queued ones, are signed
+// with the new secret.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) RotateSecret(ctx context.Context, subscriberId string) (int, models.SubscriptionResponse, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	secret, err := delivery.NewSecret()
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.SubscriptionResponse{}, errors.InternalServerError{"Secret Generation Failed"}
+	}
+
+	err = subsDbExecutor.UpdateSecret(ctx, subscriberId, secret)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.SubscriptionResponse{}, err
+	}
+
+	return results.OK, models.SubscriptionResponse{ID: subscriberId, Secret: secret}, nil
+}
+
+// GetSubscriptions returns all subscriptions with the nodes they receive events of.
+// Nodes and groups are read once for all subscriptions, and a subscription whose
+// nodes can not be found has the error as 'message' field instead of failing the list.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetSubscriptions(ctx context.Context) (int, models.SubscriberList, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	subscribers, err := subsDbExecutor.GetSubscribers(ctx)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.SubscriberList{}, err
+	}
+
+	nodes := &lazyNodeIndex{}
+	res := models.SubscriberList{Subscriptions: make([]models.Subscriber, len(subscribers))}
+	for i, subs := range subscribers {
+		subscription, err := toSubscription(ctx, subs, nodes)
+		if err != nil {
+			logger.With(ctx).Log(logger.ERROR, "failed to find nodes of subscription", "subscriber", subs[ID], "error", err)
+			subscription.Message = err.Error()
+		}
+		res.Subscriptions[i] = subscription
+	}
+
+	return results.OK, res, nil
+}
+
+// GetSubscription returns a subscription with the nodes it receives events of.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetSubscription(ctx context.Context, subscriberId string) (int, models.Subscriber, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	subs, err := subsDbExecutor.GetSubscriber(ctx, subscriberId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.Subscriber{}, err
+	}
+
+	subscription, err := toSubscription(ctx, subs, &lazyNodeIndex{})
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.Subscriber{}, err
+	}
+	return results.OK, subscription, nil
+}
+
+// UpdateSubscription changes the url, the status and the filter of events, the ttl
+// and whether events are sent to a subscription, which are given as 'url',
+// 'event.status', 'event.filter', 'ttl' and 'active' fields of req. The id of the subscription is kept, and
+// activating it resets the count of failed deliveries.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) UpdateSubscription(ctx context.Context, subscriberId string, req models.SubscriptionUpdate) (int, models.Subscriber, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	subs, err := subsDbExecutor.GetSubscriber(ctx, subscriberId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.Subscriber{}, err
+	}
+	if stream.IsStreamURL(subs[URL_FIELD].(string)) {
+		return results.ERROR, models.Subscriber{}, errors.InvalidParam{"subscription of a stream can not be updated"}
+	}
+
+	url := subs[URL_FIELD].(string)
+	if req.URL != nil {
+		if url = *req.URL; len(url) == 0 {
+			return results.ERROR, models.Subscriber{}, errors.InvalidField{URL_FIELD, "must not be empty"}
+		}
+		if err = delivery.ValidateURL(url); err != nil {
+			return results.ERROR, models.Subscriber{}, err
+		}
+	}
+
+	status := subs[STATUS].([]string)
+	expr, _ := subs[FILTER].(string)
+	if event := req.Event; event != nil {
+		if len(event.Type) != 0 && event.Type != subs[TYPE] {
+			return results.ERROR, models.Subscriber{}, errors.InvalidField{validate.Member(EVENT, TYPE), "can not be changed"}
+		}
+		if event.Status != nil {
+			status = event.Status
+			if err = validateStatus(subs[TYPE].(string), status); err != nil {
+				return results.ERROR, models.Subscriber{}, err
+			}
+		}
+		if event.Filter != nil {
+			// An empty filter removes the filter of the subscription.
+			expr = *event.Filter
+			if err = validateFilter(expr); err != nil {
+				return results.ERROR, models.Subscriber{}, err
+			}
+		}
+	}
+
+	expiresAt, _ := subs[EXPIRES_AT].(int64)
+	if req.TTL != nil {
+		if *req.TTL < 0 {
+			return results.ERROR, models.Subscriber{}, errors.InvalidField{TTL, "must be non-negative integer"}
+		}
+		expiresAt = expiry(*req.TTL)
+	}
+
+	err = subsDbExecutor.UpdateSubscriber(ctx, subscriberId, url, status, expiresAt)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.Subscriber{}, err
+	}
+	if previous, _ := subs[FILTER].(string); expr != previous {
+		err = subsDbExecutor.SetFilter(ctx, subscriberId, expr)
+		if err != nil {
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return results.ERROR, models.Subscriber{}, err
+		}
+	}
+	if req.Active != nil {
+		err = subsDbExecutor.SetDeliveryState(ctx, subscriberId, 0, !*req.Active)
+		if err != nil {
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return results.ERROR, models.Subscriber{}, err
+		}
+	}
+
+	return Executor{}.GetSubscription(ctx, subscriberId)
+}
+
+// GetMissedEvents returns events a subscriber is interested in, which come after
+// the cursor given as 'since' or, if it is not given, the cursor of the subscriber,
+// the earliest first. At most history.MAX_LIMIT events are returned along with
+// the cursor to pass as 'since' for the next ones.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetMissedEvents(ctx context.Context, subscriberId string, query map[string][]string) (int, models.MissedEvents, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	_, events, cursor, err := getMissedEvents(ctx, subscriberId, query)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.MissedEvents{}, err
+	}
+
+	return results.OK, models.MissedEvents{Events: events, Cursor: cursor}, nil
+}
+
+// ReplayMissedEvents queues events a subscriber missed again in the order they
+// occurred, as GetMissedEvents returns them. Events still queued for the subscriber
+// are skipped, so that they are not delivered twice.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) ReplayMissedEvents(ctx context.Context, subscriberId string, query map[string][]string) (int, models.ReplayResponse, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	subs, events, cursor, err := getMissedEvents(ctx, subscriberId, query)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.ReplayResponse{}, err
+	}
+
+	queued, err := deliveryExecutor.GetQueuedEventIds(ctx, subscriberId)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, models.ReplayResponse{}, err
+	}
+	pending := make(map[string]bool)
+	for _, eventId := range queued {
+		pending[eventId] = true
+	}
+
+	replayed := 0
+	for _, event := range events {
+		eventId := event[ID].(string)
+		if pending[eventId] {
+			continue
+		}
+
+		body, err := convertMapToJson(map[string]interface{}{EVENT: event[EVENT]})
+		if err != nil {
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return results.ERROR, models.ReplayResponse{}, err
+		}
+		err = deliveryExecutor.Enqueue(ctx, subscriberId, subs[URL_FIELD].(string), subs[TYPE].(string), eventId, body)
+		if err != nil {
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+			return results.ERROR, models.ReplayResponse{}, err
+		}
+		replayed++
+	}
+
+	return results.OK, models.ReplayResponse{Replayed: replayed, Cursor: cursor}, nil
+}
+
+// getMissedEvents returns a subscriber, the events it is interested in after its cursor
+// and the cursor to read the next ones after. Events which do not match the filter
+// of the subscriber are skipped, but still move the cursor.
+// Events sent to streams are not kept for them, so streams have no missed events.
+func getMissedEvents(ctx context.Context, subscriberId string, query map[string][]string) (map[string]interface{}, []map[string]interface{}, string, error) {
+	subs, err := subsDbExecutor.GetSubscriber(ctx, subscriberId)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if stream.IsStreamURL(subs[URL_FIELD].(string)) {
+		return nil, nil, "", errors.InvalidParam{"missed events of a stream are not kept"}
+	}
+
+	cursor := getCursor(query, subs)
+	if len(cursor) == 0 {
+		return nil, nil, "", errors.InvalidParam{SINCE + " is required until an event is delivered"}
+	}
+
+	// Events are kept with ids of subscribers whose query matched them when
+	// they were raised, so nodes which left a group since are still covered.
+	events, err := historyExecutor.GetEventsAfter(ctx, cursor, subs[TYPE].(string),
+		subs[STATUS].([]string), subscriberId, history.MAX_LIMIT)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	matched := make([]map[string]interface{}, 0, len(events))
+	for _, event := range events {
+		if body, _ := event[EVENT].(map[string]interface{}); matchFilter(subs, body) {
+			matched = append(matched, event)
+		}
+	}
+	return subs, matched, lastEventId(events, cursor), nil
+}
+
+// getCursor returns the cursor given as 'since' in query, or the cursor of subs.
+func getCursor(query map[string][]string, subs map[string]interface{}) string {
+	if values, exists := query[SINCE]; exists && len(values) != 0 && len(values[0]) != 0 {
+		return values[0]
+	}
+	cursor, _ := subs[CURSOR].(string)
+	return cursor
+}
+
+// lastEventId returns the id of the last of events, or cursor if there are none.
+func lastEventId(events []map[string]interface{}, cursor string) string {
+	if len(events) == 0 {
+		return cursor
+	}
+	return events[len(events)-1][ID].(string)
+}
+
+// StartExpiryChecker removes subscriptions whose ttl has passed every interval,
+// until a signal is sent to the returned channel.
+func StartExpiryChecker(interval time.Duration) chan bool {
+	quit := make(chan bool)
+	worker := workers.Register(EXPIRY_CHECKER, interval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		defer worker.Stop()
+
+		ctx := context.Background()
+		removeExpired(ctx, time.Now())
+		for {
+			select {
+			case now := <-ticker.C:
+				worker.Start()
+				removeExpired(ctx, now)
+				worker.Beat()
+			case <-quit:
+				return
+			}
+		}
+	}()
+	return quit
+}
+
+// removeExpired unregisters subscriptions which expired by now.
+func removeExpired(ctx context.Context, now time.Time) {
+	subscribers, err := subsDbExecutor.GetSubscribers(ctx)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return
+	}
+
+	for _, subs := range subscribers {
+		if !isExpired(subs, now) {
+			continue
+		}
+		logger.With(ctx).Log(logger.INFO, "subscription expired", "subscriber", subs[ID])
+		if _, err := (Executor{}).UnRegister(ctx, subs[ID].(string)); err != nil {
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
+		}
+	}
+}
+
+// RemoveStaleStreams unregisters subscribers of streams which are not open,
+// e.g. ones left when the anchor crashed or restarted while clients were
+// connected, so that nodes stop watching app events for them.
+func RemoveStaleStreams() {
 	logger.Logging(logger.DEBUG, "IN")
 	defer logger.Logging(logger.DEBUG, "OUT")
 
-	bodyMap, err := convertJsonToMap(body)
+	ctx := context.Background()
+	subscribers, err := subsDbExecutor.GetSubscribers(ctx)
 	if err != nil {
 		logger.Logging(logger.ERROR, err.Error())
+		return
+	}
+
+	for _, subs := range subscribers {
+		url, _ := subs[URL_FIELD].(string)
+		if !stream.IsStreamURL(url) || stream.IsOpen(url) {
+			continue
+		}
+		logger.Log(logger.INFO, "stale stream removed", "subscriber", subs[ID])
+		if _, err := (Executor{}).UnRegister(ctx, subs[ID].(string)); err != nil {
+			logger.Logging(logger.ERROR, err.Error())
+		}
+	}
+}
+
+// OpenStream registers a stream as a subscriber of events matched with query,
+// which has the type and the list of status of events as well as the query
+// of a subscription. Events are published to the stream until it is closed
+// by CloseStream.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) OpenStream(ctx context.Context, query map[string][]string) (int, *stream.Stream, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	if len(query[TYPE]) != 1 {
+		return results.ERROR, nil, errors.InvalidParam{"a type of events is required"}
+	}
+	if len(query[STATUS]) == 0 {
+		return results.ERROR, nil, errors.InvalidParam{"status of events is required"}
+	}
+
+	s, err := stream.New()
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, nil, err
+	}
+
+	req := models.SubscriptionRequest{
+		URL:   s.URL(),
+		Event: models.Event{Type: query[TYPE][0], Status: query[STATUS]},
+	}
+	if values, exists := query[FILTER]; exists && len(values) != 0 {
+		req.Event.Filter = values[0]
+	}
+
+	filters := make(map[string][]string)
+	for _, key := range []string{GROUP_ID, NODE_ID, APP_ID, IMAGE_NAME} {
+		if values, exists := query[key]; exists {
+			filters[key] = values
+		}
+	}
+
+	result, resp, err := Executor{}.Register(ctx, req, filters)
+	if err != nil {
+		s.Close()
+		return result, nil, err
+	}
+	s.SubscriberID = resp.ID
+	return result, s, nil
+}
+
+// CloseStream closes a stream and removes its subscriber.
+func (Executor) CloseStream(ctx context.Context, s *stream.Stream) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	s.Close()
+	if _, err := (Executor{}).UnRegister(ctx, s.SubscriberID); err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+	}
+}
+
+// NotificationHandler stores an event in the history and delivers it to all subscribers
+// of the events in eventid field, which are interested in the type and the status of the event.
+// The event is queued for each subscriber concurrently, and if queueing fails for
+// some of subscribers, MULTI_STATUS is returned.
+func (Executor) NotificationHandler(ctx context.Context, eventType string, body string) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	bodyMap, err := convertJsonToMap(body)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, err
 	}
 	// Check whether 'EventId' is included.
-	eventIds, exists := bodyMap[EVENT_ID]
+	eventIds, exists := bodyMap[EVENT_ID].([]interface{})
 	if !exists {
-		logger.Logging(logger.ERROR, "eventid field is required")
+		logger.With(ctx).Logging(logger.ERROR, "eventid field is required")
 		return results.ERROR, nil
 	}
 
 	// Check whether 'Event' is included.
-	event, exists := bodyMap[EVENT]
+	event, exists := bodyMap[EVENT].(map[string]interface{})
 	if !exists {
-		logger.Logging(logger.ERROR, "event field is required")
+		logger.With(ctx).Logging(logger.ERROR, "event field is required")
 		return results.ERROR, nil
 	}
 
-	switch eventType {
-	case APP:
-		for _, eventId := range eventIds.([]interface{}) {
-			appEvent, err := appEventDbExecutor.GetEvent(eventId.(string))
-			if err != nil {
-				logger.Logging(logger.ERROR, err.Error())
-				return results.ERROR, err
-			}
-			for _, subscriberId := range appEvent[SUBS].([]string) {
-				subs, err := subsDbExecutor.GetSubscriber(subscriberId)
-				if err != nil {
-					logger.Logging(logger.ERROR, err.Error())
-					return results.ERROR, err
-				}
+	if eventType != APP && !isNodeEventType(eventType) {
+		return results.ERROR, nil
+	}
 
-				for _, status := range subs[STATUS].([]string) {
-					if strings.Compare(status, event.(map[string]interface{})[STATUS].(string)) == 0 {
-						urls := make([]string, 0)
-						urls = append(urls, subs["url"].(string))
-						reqBody := make(map[string]interface{})
-						reqBody[EVENT] = event
-						body, err := convertMapToJson(reqBody)
-						if err != nil {
-							logger.Logging(logger.ERROR, err.Error())
-							return results.ERROR, err
-						}
-						httpExecutor.SendHttpRequest("POST", urls, nil, []byte(body))
-						return results.OK, nil
-					}
-				}
+	ids := make([]string, 0, len(eventIds))
+	for _, eventId := range eventIds {
+		if id, ok := eventId.(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	// Events are kept whether or not anyone subscribes to them, with ids of
+	// subscribers they are meant for even if those are inactive, so that they
+	// can read the events they missed. The id of a kept event becomes the
+	// cursor of subscribers it is delivered to.
+	subscribers, err := findSubscribers(ctx, eventType, ids, event)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		historyExecutor.Record(ctx, eventType, ids, nil, event)
+		return results.ERROR, err
+	}
+	historyId := historyExecutor.Record(ctx, eventType, ids, getSubscriberIds(subscribers), event)
+
+	subscribers = getInterested(subscribers, event)
+	if len(subscribers) == 0 {
+		return results.ERROR, nil
+	}
+
+	reqBody := make(map[string]interface{})
+	reqBody[EVENT] = event
+	reqStr, err := convertMapToJson(reqBody)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return results.ERROR, err
+	}
+	return notifySubscribers(ctx, eventType, historyId, subscribers, reqStr)
+}
+
+// Publish sends an event of eventType the anchor raises about nodes with nodeIds
+// to subscribers of the nodes, and stores it in the history as NotificationHandler does.
+// An event about no nodes is sent to subscribers without a query and those of its group.
+// The time the event is raised at is added as 'timestamp' field unless it is given.
+// A failure is logged, since it must not fail the operation raising the event.
+func (Executor) Publish(ctx context.Context, eventType string, nodeIds []string, event map[string]interface{}) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	if _, exists := event[TIMESTAMP]; !exists {
+		event[TIMESTAMP] = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	if nodeIds == nil {
+		// Events which concern no nodes are still kept in the history.
+		nodeIds = []string{}
+	}
+
+	notification := make(map[string]interface{})
+	notification[EVENT_ID] = nodeIds
+	notification[EVENT] = event
+	body, err := convertMapToJson(notification)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		return
+	}
+
+	_, err = Executor{}.NotificationHandler(ctx, eventType, body)
+	if err != nil {
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+	}
+}
+
+// PublishDeployment sends an event of an app on a node with status through publisher
+// to subscribers of the node, for controllers which deploy apps.
+func PublishDeployment(ctx context.Context, publisher Command, nodeId string, appId string, status string) {
+	publisher.Publish(ctx, DEPLOYMENT, []string{nodeId}, map[string]interface{}{
+		NODE_ID: nodeId,
+		APP_ID:  appId,
+		STATUS:  status,
+	})
+}
+
+// findSubscribers returns subscribers of eventType registered to any of eventIds,
+// whether or not they are active or interested in the status of the event.
+// eventIds are ids of app events for app events, and ids of nodes otherwise.
+func findSubscribers(ctx context.Context, eventType string, eventIds []string, event map[string]interface{}) ([]map[string]interface{}, error) {
+	if eventType == APP {
+		return findAppSubscribers(ctx, eventIds)
+	}
+	return findNodeSubscribers(ctx, eventType, eventIds, event)
+}
+
+// getSubscriberIds returns ids of subscribers except streams, whose events are not kept.
+func getSubscriberIds(subscribers []map[string]interface{}) []string {
+	ids := make([]string, 0, len(subscribers))
+	for _, subs := range subscribers {
+		if !stream.IsStreamURL(subs[URL_FIELD].(string)) {
+			ids = append(ids, subs[ID].(string))
+		}
+	}
+	return ids
+}
+
+// getInterested returns subscribers among subscribers which are interested in event.
+func getInterested(subscribers []map[string]interface{}, event map[string]interface{}) []map[string]interface{} {
+	interested := make([]map[string]interface{}, 0, len(subscribers))
+	for _, subs := range subscribers {
+		if isInterested(subs, event) {
+			interested = append(interested, subs)
+		}
+	}
+	return interested
+}
+
+// findAppSubscribers returns subscribers of app events registered to any of eventIds.
+// A subscriber registered to several of eventIds is returned once.
+// Events and subscribers removed in the meantime are skipped.
+func findAppSubscribers(ctx context.Context, eventIds []string) ([]map[string]interface{}, error) {
+	subscribers := make([]map[string]interface{}, 0)
+	found := make(map[string]bool)
+
+	for _, eventId := range eventIds {
+		registered, err := appEventDbExecutor.GetEvent(ctx, eventId)
+		if err != nil {
+			switch err.(type) {
+			default:
+				return nil, err
+			case errors.NotFound:
+				// Nobody subscribes to the event.
+				continue
 			}
 		}
-	case NODE:
-		for _, eventId := range eventIds.([]interface{}) {
-			nodeEvent, err := nodeEventDbExecutor.GetEvent(eventId.(string))
-			if err != nil {
-				logger.Logging(logger.ERROR, err.Error())
-				return results.ERROR, err
+
+		for _, subscriberId := range registered[SUBS].([]string) {
+			if found[subscriberId] {
+				continue
 			}
+			found[subscriberId] = true
 
-			for _, subscriberId := range nodeEvent[SUBS].([]string) {
-				subs, err := subsDbExecutor.GetSubscriber(subscriberId)
-				if err != nil {
-					logger.Logging(logger.ERROR, err.Error())
-					return results.ERROR, err
+			subs, err := subsDbExecutor.GetSubscriber(ctx, subscriberId)
+			if err != nil {
+				switch err.(type) {
+				default:
+					return nil, err
+				case errors.NotFound:
+					continue
 				}
+			}
+
+			subscribers = append(subscribers, subs)
+		}
+	}
+	return subscribers, nil
+}
+
+// findNodeSubscribers returns subscribers of eventType whose query matches any of nodeIds.
+// Queries are matched with the nodes as they are when the event is dispatched, so
+// that nodes which joined a group or had an app deployed after a subscriber was
+// registered are covered. A subscriber whose query can not be matched is skipped,
+// so that the event still reaches the others.
+func findNodeSubscribers(ctx context.Context, eventType string, nodeIds []string, event map[string]interface{}) ([]map[string]interface{}, error) {
+	subscribers := make([]map[string]interface{}, 0)
+	candidates, err := subsDbExecutor.GetSubscribersByType(ctx, eventType)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher := newNodeMatcher(eventType, nodeIds, event)
+	for _, subs := range candidates {
+		query, _ := subs["query"].(map[string][]string)
+		matched, err := matcher.match(ctx, query)
+		if err != nil {
+			logger.With(ctx).Log(logger.ERROR, "failed to match query of subscriber", "subscriber", subs[ID], "error", err)
+			continue
+		}
+		if matched {
+			subscribers = append(subscribers, subs)
+		}
+	}
+	return subscribers, nil
+}
+
+// isInterested returns whether a subscriber is active, subscribes to
+// the status of event, and has a filter event matches.
+func isInterested(subs map[string]interface{}, event map[string]interface{}) bool {
+	status, _ := event[STATUS].(string)
+	statusList, _ := subs[STATUS].([]string)
+	if !util.IsContainedStringInList(statusList, status) {
+		return false
+	}
+	if deactivated, _ := subs[DEACTIVATED].(bool); deactivated || isExpired(subs, time.Now()) {
+		return false
+	}
+	return matchFilter(subs, event)
+}
+
+// nodeMatcher matches queries of subscribers with the nodes an event concerns.
+// Nodes and groups are read once per event, and only if a query needs them.
+type nodeMatcher struct {
+	eventType string
+	nodeIds   []string
+	event     map[string]interface{}
+	nodes     lazyNodeIndex
+}
+
+func newNodeMatcher(eventType string, nodeIds []string, event map[string]interface{}) *nodeMatcher {
+	return &nodeMatcher{
+		eventType: eventType,
+		nodeIds:   nodeIds,
+		event:     event,
+	}
+}
+
+// match returns whether query matches any of the nodes of the event.
+// An empty query matches all nodes, and a query of a node is matched by its id.
+// An event about the group or the app a query consists of matches it as well,
+// since the node may have just left the group or had the app deleted.
+// Queries with other keys are matched with the nodes in memory, unless the event
+// concerns no nodes, such as a group created without members.
+func (m *nodeMatcher) match(ctx context.Context, query map[string][]string) (bool, error) {
+	if len(query) == 0 {
+		return true, nil
+	}
+	if values, exists := query[NODE_ID]; exists {
+		if !util.IsContainedStringInList(m.nodeIds, values[0]) {
+			return false, nil
+		}
+		if len(query) == 1 {
+			return true, nil
+		}
+	}
+	if len(query) == 1 {
+		if values, exists := query[GROUP_ID]; exists && m.eventType == GROUP && m.event[ID] == values[0] {
+			return true, nil
+		}
+		if values, exists := query[APP_ID]; exists && m.event[APP_ID] == values[0] {
+			return true, nil
+		}
+	}
+	if len(m.nodeIds) == 0 {
+		return false, nil
+	}
+
+	// Reading nodes and groups which failed is not retried for each query.
+	index, err := m.nodes.get(ctx)
+	if err != nil {
+		return false, err
+	}
+	return index.matchAny(ctx, query, m.nodeIds)
+}
 
-				for _, status := range subs[STATUS].([]string) {
-					if strings.Compare(status, event.(map[string]interface{})[STATUS].(string)) == 0 {
-						urls := make([]string, 0)
-						urls = append(urls, subs["url"].(string))
-						reqBody := make(map[string]interface{})
-						reqBody[EVENT] = event
-						body, err := convertMapToJson(reqBody)
-						if err != nil {
-							return results.ERROR, err
-						}
-						httpExecutor.SendHttpRequest("POST", urls, nil, []byte(body))
-						return results.OK, nil
-					}
+// notifySubscribers queues body to each of subscribers concurrently.
+// It returns OK if the event is queued for all subscribers, MULTI_STATUS if for
+// some of them, and ERROR with the first error if for none of them.
+func notifySubscribers(ctx context.Context, eventType string, eventId string, subscribers []map[string]interface{}, body string) (int, error) {
+	codes := make([]int, len(subscribers))
+	errs := make([]error, len(subscribers))
+
+	var wg sync.WaitGroup
+	wg.Add(len(subscribers))
+	for i, subs := range subscribers {
+		go func(i int, subs map[string]interface{}) {
+			defer wg.Done()
+
+			codes[i] = results.OK
+			if url := subs["url"].(string); stream.IsStreamURL(url) {
+				if !stream.Publish(url, eventType, body) {
+					// The stream was closed without removing its subscriber.
+					Executor{}.UnRegister(ctx, subs[ID].(string))
 				}
+				return
 			}
-		}
+
+			errs[i] = deliveryExecutor.Enqueue(ctx, subs[ID].(string), subs["url"].(string), eventType, eventId, body)
+			if errs[i] != nil {
+				logger.With(ctx).Log(logger.ERROR, "failed to queue event", "subscriber", subs[ID], "error", errs[i].Error())
+				codes[i] = results.ERROR
+			}
+		}(i, subs)
 	}
-	return results.ERROR, nil
+	wg.Wait()
+
+	result := decideResultCode(codes)
+	if result == results.ERROR {
+		return result, errs[0]
+	}
+	return result, nil
 }
 
-func registerAppEvent(url string, event map[string]interface{},
-	query map[string][]string) (int, map[string]interface{}, error) {
+func registerAppEvent(ctx context.Context, url string, secret string, event models.Event, expiresAt int64,
+	query map[string][]string) (int, models.SubscriptionResponse, error) {
 
 	eventId := make([]string, 0)
 	eventId = append(eventId, generateEventId(query))
 
-	nodes, err := getTargetNodes(query)
+	nodes, err := getTargetNodes(ctx, query)
 	if err != nil {
 		switch err.(type) {
 		default:
-			return results.ERROR, nil, err
+			return results.ERROR, models.SubscriptionResponse{}, err
 		case errors.NotFound:
 			break
 		}
@@ -324,11 +1079,11 @@ func registerAppEvent(url string, event map[string]interface{},
 	addedNodes := make([]map[string]interface{}, 0)
 	removedNodes := make([]map[string]interface{}, 0)
 
-	eventInfo, err := appEventDbExecutor.GetEvent(eventId[0])
+	eventInfo, err := appEventDbExecutor.GetEvent(ctx, eventId[0])
 	if err != nil {
 		switch err.(type) {
 		default:
-			return results.ERROR, nil, err
+			return results.ERROR, models.SubscriptionResponse{}, err
 		case errors.NotFound:
 			addedNodes = nodes[NODES].([]map[string]interface{})
 		}
@@ -341,9 +1096,9 @@ func registerAppEvent(url string, event map[string]interface{},
 
 		for _, id := range eventInfo["nodes"].([]string) {
 			if !util.IsContainedStringInList(nodeIds, id) {
-				node, err := nodeDbExecutor.GetNode(id)
+				node, err := nodeDbExecutor.GetNode(ctx, id)
 				if err != nil {
-					logger.Logging(logger.ERROR, err.Error())
+					logger.With(ctx).Logging(logger.ERROR, err.Error())
 				} else {
 					removedNodes = append(removedNodes, node)
 				}
@@ -354,36 +1109,35 @@ func registerAppEvent(url string, event map[string]interface{},
 	// Request unregister event target application.
 	removedNodeAddress := getNodesAddress(removedNodes)
 	urls := util.MakeRequestUrl(removedNodeAddress, URL.Notification(), URL.Apps(), URL.Watch())
-	requestUnRegisterAppEvent(urls, eventId[0])
+	requestUnRegisterAppEvent(ctx, urls, eventId[0])
 
 	// Request register event target application.
 	addedNodeAddress := getNodesAddress(addedNodes)
 	urls = util.MakeRequestUrl(addedNodeAddress, URL.Notification(), URL.Apps(), URL.Watch())
-	codes, respStr := requestRegisterAppEvent(urls, query, eventId[0])
+	codes, respStr := requestRegisterAppEvent(ctx, urls, query, eventId[0])
 
 	// Convert the received response from string to map.
 	respMap, err := convertRespToMap(respStr)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
-		requestUnRegisterAppEvent(urls, eventId[0])
-		return results.ERROR, nil, err
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
+		requestUnRegisterAppEvent(ctx, urls, eventId[0])
+		return results.ERROR, models.SubscriptionResponse{}, err
 	}
 
-	eventStatus := parseEventStatus(event)
-	subsId := generateSubsId(eventId[0], url, eventStatus)
-	resp := make(map[string]interface{})
+	subsId := generateSubsId(eventId[0], url, event.Status, event.Filter)
+	resp := models.SubscriptionResponse{}
 
 	result := decideResultCode(codes)
 	if result == results.ERROR {
-		return results.ERROR, nil, err
+		return results.ERROR, models.SubscriptionResponse{}, err
 	} else if result == results.MULTI_STATUS {
 		// Make separate responses to represent partial failure case.
-		resp[RESPONSES] = makeSeparateResponses(nodes[NODES].([]map[string]interface{}), codes, respMap)
+		resp.Responses = makeSeparateResponses(nodes[NODES].([]map[string]interface{}), codes, respMap)
 	}
 
-	err = subsDbExecutor.AddSubscriber(subsId, APP, url, eventStatus, eventId, query)
+	err = subsDbExecutor.AddSubscriber(ctx, subsId, APP, url, secret, event.Filter, event.Status, eventId, query, expiresAt)
 	if err != nil {
-		return results.ERROR, nil, err
+		return results.ERROR, models.SubscriptionResponse{}, err
 	}
 
 	for i, node := range addedNodes {
@@ -396,49 +1150,146 @@ func registerAppEvent(url string, event map[string]interface{},
 		}
 	}
 
-	err = appEventDbExecutor.AddEvent(eventId[0], subsId, nodeIds)
+	err = appEventDbExecutor.AddEvent(ctx, eventId[0], subsId, nodeIds)
 	if err != nil {
-		return results.ERROR, nil, err
+		return results.ERROR, models.SubscriptionResponse{}, err
 	}
 
-	resp[ID] = subsId
+	resp.ID = subsId
+	resp.Secret = secret
+	resp.Filter = event.Filter
+	resp.ExpiresAt = expiresAt
 
 	return result, resp, err
 }
 
-func registerNodeEvent(url string, event map[string]interface{},
-	query map[string][]string) (int, map[string]interface{}, error) {
+// registerNodeEvent registers a subscriber to events of nodes matched with query.
+// The type of event is node, resource or one of the types of events the anchor raises.
+// The query is matched with nodes when events are dispatched, so the subscriber
+// is not registered to events of each node.
+// Like subscribers of app events, the subscriber is stored with the filter of
+// events and its expiry, so that it never receives events which do not match
+// the filter nor outlives its ttl.
+func registerNodeEvent(ctx context.Context, url string, secret string, event models.Event, expiresAt int64,
+	query map[string][]string) (int, models.SubscriptionResponse, error) {
+
+	// Check whether nodes can be searched with the query.
+	_, err := getTargetNodes(ctx, query)
+	if err != nil {
+		switch err.(type) {
+		default:
+			return results.ERROR, models.SubscriptionResponse{}, err
+		case errors.NotFound:
+			break
+		}
+	}
+
+	eventId := generateEventId(query)
+	if event.Type != NODE {
+		// Keep ids of node subscribers, and separate other types with the same target.
+		eventId = makeHash(event.Type + eventId)
+	}
 
-	nodes, err := getTargetNodes(query)
+	subsId := generateSubsId(eventId, url, event.Status, event.Filter)
+	err = subsDbExecutor.AddSubscriber(ctx, subsId, event.Type, url, secret, event.Filter, event.Status, []string{}, query, expiresAt)
 	if err != nil {
-		return results.ERROR, nil, err
+		return results.ERROR, models.SubscriptionResponse{}, err
 	}
 
-	nodeIds := make([]string, 0)
-	for _, node := range nodes[NODES].([]map[string]interface{}) {
-		nodeIds = append(nodeIds, node["id"].(string))
+	return results.OK, models.SubscriptionResponse{ID: subsId, Secret: secret, Filter: event.Filter, ExpiresAt: expiresAt}, err
+}
+
+// resyncAppEvent requests nodes of nodeIds which have started matching query
+// to watch an app event, and those which have stopped matching it to unwatch it.
+// The nodes watching the app event are updated unless no node has changed.
+// members caches members of groups read for queries of a group.
+func resyncAppEvent(ctx context.Context, eventId string, query map[string][]string, nodeIds []string, members map[string][]string) error {
+	appEvent, err := appEventDbExecutor.GetEvent(ctx, eventId)
+	if err != nil {
+		switch err.(type) {
+		default:
+			return err
+		case errors.NotFound:
+			// The app event has been removed in the meantime.
+			return nil
+		}
 	}
+	watching := appEvent[NODES].([]string)
 
-	eventStatus := parseEventStatus(event)
-	subsId := generateSubsId(generateEventId(query), url, eventStatus)
-	err = subsDbExecutor.AddSubscriber(subsId, NODE, url, eventStatus, nodeIds, query)
+	if values, exists := query[GROUP_ID]; exists && !containsAny(watching, nodeIds) {
+		// Nodes which neither are nor were members of the group match the query in neither case.
+		isMember, err := hasMember(ctx, values[0], nodeIds, members)
+		if err != nil || !isMember {
+			return err
+		}
+	}
+
+	matched := make([]map[string]interface{}, 0)
+	nodes, err := getTargetNodes(ctx, query)
 	if err != nil {
-		return results.ERROR, nil, err
+		switch err.(type) {
+		default:
+			return err
+		case errors.NotFound:
+			break
+		}
+	} else {
+		matched = nodes[NODES].([]map[string]interface{})
 	}
 
-	for _, nodeId := range nodeIds {
-		err = nodeEventDbExecutor.AddEvent(nodeId, subsId)
+	addedNodes := make([]map[string]interface{}, 0)
+	matchedIds := make([]string, 0)
+	for _, node := range matched {
+		id := node[ID].(string)
+		matchedIds = append(matchedIds, id)
+		if util.IsContainedStringInList(nodeIds, id) && !util.IsContainedStringInList(watching, id) {
+			addedNodes = append(addedNodes, node)
+		}
+	}
+
+	removedIds := make([]string, 0)
+	removedNodes := make([]map[string]interface{}, 0)
+	for _, id := range nodeIds {
+		if !util.IsContainedStringInList(watching, id) || util.IsContainedStringInList(matchedIds, id) {
+			continue
+		}
+		removedIds = append(removedIds, id)
+		node, err := nodeDbExecutor.GetNode(ctx, id)
 		if err != nil {
-			return results.ERROR, nil, err
+			// A node which has been unregistered no longer watches anything.
+			logger.With(ctx).Logging(logger.DEBUG, err.Error())
+			continue
 		}
+		removedNodes = append(removedNodes, node)
+	}
+
+	if len(addedNodes) == 0 && len(removedIds) == 0 {
+		return nil
 	}
-	resp := make(map[string]interface{})
-	resp[ID] = subsId
 
-	return results.OK, resp, err
+	// Request unregister event of nodes which no longer match the query.
+	urls := util.MakeRequestUrl(getNodesAddress(removedNodes), URL.Notification(), URL.Apps(), URL.Watch())
+	requestUnRegisterAppEvent(ctx, urls, eventId)
+
+	// Request register event of nodes which have started matching the query.
+	urls = util.MakeRequestUrl(getNodesAddress(addedNodes), URL.Notification(), URL.Apps(), URL.Watch())
+	codes, _ := requestRegisterAppEvent(ctx, urls, query, eventId)
+
+	updated := make([]string, 0, len(watching)+len(addedNodes))
+	for _, id := range watching {
+		if !util.IsContainedStringInList(removedIds, id) {
+			updated = append(updated, id)
+		}
+	}
+	for i, node := range addedNodes {
+		if i < len(codes) && util.IsSuccessCode(codes[i]) {
+			updated = append(updated, node[ID].(string))
+		}
+	}
+	return appEventDbExecutor.SetNodes(ctx, eventId, updated)
 }
 
-func requestRegisterAppEvent(urls []string, query map[string][]string, eventId string) ([]int, []string) {
+func requestRegisterAppEvent(ctx context.Context, urls []string, query map[string][]string, eventId string) ([]int, []string) {
 	if len(urls) == 0 {
 		return nil, nil
 	}
@@ -448,10 +1299,10 @@ func requestRegisterAppEvent(urls []string, query map[string][]string, eventId s
 		return nil, nil
 	}
 	// Request register event target application.
-	return httpExecutor.SendHttpRequest("POST", urls, nil, []byte(body))
+	return httpExecutor.SendHttpRequest(ctx, "POST", urls, nil, []byte(body))
 }
 
-func requestUnRegisterAppEvent(urls []string, eventId string) {
+func requestUnRegisterAppEvent(ctx context.Context, urls []string, eventId string) {
 	if len(urls) == 0 {
 		return
 	}
@@ -459,22 +1310,51 @@ func requestUnRegisterAppEvent(urls []string, eventId string) {
 	body, _ := convertMapToJson(reqBody)
 
 	// Request unregister event target nodes.
-	httpExecutor.SendHttpRequest("DELETE", urls, nil, []byte(body))
+	httpExecutor.SendHttpRequest(ctx, "DELETE", urls, nil, []byte(body))
 }
 
-func getTargetNodes(query map[string][]string) (map[string]interface{}, error) {
-	_, nodes, err := nodeSearchExecutor.SearchNodes(query)
+func getTargetNodes(ctx context.Context, query map[string][]string) (map[string]interface{}, error) {
+	_, nodes, err := nodeSearchExecutor.SearchNodes(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 	if nodes == nil {
-		logger.Logging(logger.DEBUG, "No matched nodes with query")
+		logger.With(ctx).Logging(logger.DEBUG, "No matched nodes with query")
 		return nil, errors.NotFound{"No matched nodes with query"}
 	}
 
 	return nodes, err
 }
 
+// hasMember returns whether any of nodeIds is a member of the group, whose members
+// are read once and kept in members. A group which is not found has no members.
+func hasMember(ctx context.Context, groupId string, nodeIds []string, members map[string][]string) (bool, error) {
+	if _, exists := members[groupId]; !exists {
+		group, err := groupDbExecutor.GetGroup(ctx, groupId)
+		if err != nil {
+			switch err.(type) {
+			default:
+				return false, err
+			case errors.NotFound:
+				members[groupId] = []string{}
+				return false, nil
+			}
+		}
+		members[groupId], _ = group[MEMBERS].([]string)
+	}
+	return containsAny(members[groupId], nodeIds), nil
+}
+
+// containsAny returns whether any of values is in list.
+func containsAny(list []string, values []string) bool {
+	for _, value := range values {
+		if util.IsContainedStringInList(list, value) {
+			return true
+		}
+	}
+	return false
+}
+
 func getSucceedNodesId(nodes []map[string]interface{}, codes []int) []string {
 	nodeId := make([]string, 0)
 	for i, node := range nodes {
@@ -496,25 +1376,190 @@ func getNodesAddress(nodes []map[string]interface{}) []map[string]interface{} {
 	return result
 }
 
-func parseEventStatus(event map[string]interface{}) []string {
-	statusList := make([]string, 0)
-	for _, status := range event["status"].([]interface{}) {
-		statusList = append(statusList, status.(string))
+// validateEvent checks whether the 'event' field of a subscription request
+// has a 'type', a list of 'status' and an optional 'filter' which can be compiled.
+// An event without a type is taken as not given.
+func validateEvent(event models.Event) error {
+	if len(event.Type) == 0 {
+		return errors.InvalidJSON{"event field is required"}
+	}
+	if event.Status == nil {
+		return errors.InvalidField{validate.Member(EVENT, STATUS), "must be array"}
+	}
+	return validateFilter(event.Filter)
+}
+
+// validateFilter checks whether a filter of events can be compiled.
+// An empty filter matches all events.
+func validateFilter(expr string) error {
+	if len(expr) == 0 {
+		return nil
+	}
+	if _, err := filter.Compile(expr); err != nil {
+		return errors.InvalidField{validate.Member(EVENT, FILTER), err.Error()}
+	}
+	return nil
+}
+
+// matchFilter returns whether an event matches the filter of a subscriber,
+// which matches all events if it has none.
+func matchFilter(subs map[string]interface{}, event map[string]interface{}) bool {
+	expr, _ := subs[FILTER].(string)
+	if len(expr) == 0 {
+		return true
+	}
+	subscriberId, _ := subs[ID].(string)
+	f, err := compileFilter(subscriberId, expr)
+	if err != nil {
+		// Filters are validated when they are set, so it is only logged.
+		logger.Logging(logger.ERROR, err.Error())
+		return false
+	}
+	return f.Match(event)
+}
+
+// compileFilter returns the filter of a subscriber compiled from expr,
+// which is compiled only if it is not compiled from expr yet.
+func compileFilter(subscriberId string, expr string) (*filter.Filter, error) {
+	filtersMutex.Lock()
+	defer filtersMutex.Unlock()
+
+	if f, exists := filters[subscriberId]; exists && f.String() == expr {
+		return f, nil
+	}
+	f, err := filter.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	filters[subscriberId] = f
+	return f, nil
+}
+
+// forgetFilter removes the compiled filter of a removed subscriber.
+func forgetFilter(subscriberId string) {
+	filtersMutex.Lock()
+	defer filtersMutex.Unlock()
+	delete(filters, subscriberId)
+}
+
+// validateStatus checks whether status of events of eventType are ones the anchor raises.
+// Status of node and app events is decided by nodes, so it is not checked.
+func validateStatus(eventType string, statusList []string) error {
+	available, exists := nodeEventStatus[eventType]
+	if !exists {
+		return nil
+	}
+	for _, status := range statusList {
+		if !util.IsContainedStringInList(available, status) {
+			return errors.InvalidField{validate.Member(EVENT, STATUS),
+				"must be one of " + strings.Join(available, ", ")}
+		}
+	}
+	return nil
+}
+
+// isNodeEventType returns whether subscribers of eventType are registered to node events.
+func isNodeEventType(eventType string) bool {
+	_, exists := nodeEventStatus[eventType]
+	return eventType == NODE || exists
+}
+
+// eventTypes returns all types of events in alphabetical order.
+func eventTypes() []string {
+	types := []string{APP, NODE}
+	for eventType := range nodeEventStatus {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// toSubscription converts a subscriber into a response without its secret and with
+// credentials in its url redacted, which has the ids of nodes the subscriber receives events of. Queries of
+// subscribers of events about nodes are matched with nodes.
+// If the nodes can not be found, the response without them is returned with the error.
+func toSubscription(ctx context.Context, subs map[string]interface{}, nodes *lazyNodeIndex) (models.Subscriber, error) {
+	subscription := convertToSubscriber(subs)
+	subscription.URL = delivery.RedactURL(subscription.URL)
+
+	if subscription.Type != APP {
+		// Subscribers of events about nodes receive events of nodes matched with their query now.
+		index, err := nodes.get(ctx)
+		if err != nil {
+			return subscription, err
+		}
+		nodeIds, err := index.matchedNodeIds(ctx, subscription.Query)
+		if err != nil {
+			return subscription, err
+		}
+		subscription.Nodes = nodeIds
+		return subscription, nil
+	}
+
+	appNodes := make([]string, 0)
+	for _, eventId := range subscription.EventID {
+		appEvent, err := appEventDbExecutor.GetEvent(ctx, eventId)
+		if err != nil {
+			switch err.(type) {
+			default:
+				return subscription, err
+			case errors.NotFound:
+				continue
+			}
+		}
+		for _, nodeId := range appEvent[NODES].([]string) {
+			if !util.IsContainedStringInList(appNodes, nodeId) {
+				appNodes = append(appNodes, nodeId)
+			}
+		}
+	}
+	subscription.Nodes = appNodes
+	return subscription, nil
+}
+
+// convertToSubscriber converts a subscriber read from the database, leaving out its secret.
+func convertToSubscriber(subs map[string]interface{}) models.Subscriber {
+	subscriber := models.Subscriber{}
+	subscriber.ID, _ = subs[ID].(string)
+	subscriber.Type, _ = subs[TYPE].(string)
+	subscriber.URL, _ = subs[URL_FIELD].(string)
+	subscriber.Status, _ = subs[STATUS].([]string)
+	subscriber.EventID, _ = subs[EVENT_ID].([]string)
+	subscriber.Query, _ = subs["query"].(map[string][]string)
+	subscriber.ExpiresAt, _ = subs[EXPIRES_AT].(int64)
+	subscriber.Failures, _ = subs["failures"].(int)
+	subscriber.Deactivated, _ = subs[DEACTIVATED].(bool)
+	subscriber.Cursor, _ = subs[CURSOR].(string)
+	subscriber.Filter, _ = subs[FILTER].(string)
+	return subscriber
+}
+
+// expiry returns the time ttl seconds later as unix time, or 0 if ttl is 0.
+func expiry(ttl int64) int64 {
+	if ttl == 0 {
+		return 0
 	}
-	return statusList
+	return time.Now().Unix() + ttl
 }
 
-func parseEventType(event map[string]interface{}) string {
-	return event["type"].(string)
+// isExpired returns whether the ttl of a subscriber has passed by now.
+func isExpired(subs map[string]interface{}, now time.Time) bool {
+	expiresAt, _ := subs[EXPIRES_AT].(int64)
+	return expiresAt != 0 && expiresAt <= now.Unix()
 }
 
-func generateSubsId(eventId string, url string, eventStatus []string) string {
+// generateSubsId makes the id of a subscriber, which is kept the same for
+// subscribers without a filter as before filters were introduced.
+func generateSubsId(eventId string, url string, eventStatus []string, expr string) string {
 	var source string
 	source += eventId
 	source += url
 	for _, status := range eventStatus {
 		source += status
 	}
+	if len(expr) != 0 {
+		source += FILTER + expr
+	}
 	return makeHash(source)
 }
 
@@ -610,7 +1655,9 @@ func convertRespToMap(respStr []string) ([]map[string]interface{}, error) {
 // decideResultCode returns a result of group operations.
 // OK: Returned when all members of the group send a success response.
 // MULTI_STATUS: Partial success for multiple requests. Some requests succeeded
-//               but at least one failed.
+//
+//	but at least one failed.
+//
 // ERROR: Returned when all members of the gorup send an error response.
 func decideResultCode(codes []int) int {
 	successCounts := 0
@@ -634,17 +1681,16 @@ func decideResultCode(codes []int) int {
 
 // makeSeparateResponses used to make a separate response.
 func makeSeparateResponses(nodes []map[string]interface{}, codes []int,
-	respMap []map[string]interface{}) []map[string]interface{} {
+	respMap []map[string]interface{}) []models.NodeResponse {
 
-	respValue := make([]map[string]interface{}, len(nodes))
+	respValue := make([]models.NodeResponse, len(nodes))
 
 	for i, node := range nodes {
-		respValue[i] = make(map[string]interface{})
-		respValue[i][ID] = node[ID].(string)
-		respValue[i][RESPONSE_CODE] = codes[i]
+		respValue[i].ID = node[ID].(string)
+		respValue[i].Code = models.StatusCode(codes[i])
 
 		if !util.IsSuccessCode(codes[i]) {
-			respValue[i][ERROR_MESSAGE] = respMap[i][ERROR_MESSAGE]
+			respValue[i].Message, _ = respMap[i][ERROR_MESSAGE].(string)
 		}
 	}
 
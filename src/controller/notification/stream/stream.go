@@ -0,0 +1,142 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+// Package controller/notification/stream keeps event streams of clients connected
+// to the anchor, which are registered as subscribers with a url of the stream
+// scheme and receive events matched for them without a delivery queue.
+package stream
+
+import (
+	"commons/logger"
+	"github.com/satori/go.uuid"
+	"sync"
+)
+
+const (
+	URL_SCHEME  = "stream://"
+	BUFFER_SIZE = 64 // events kept for a slow client before the stream is closed.
+)
+
+// Event is an event sent to a stream.
+type Event struct {
+	Type string
+	Data string
+}
+
+// Stream is a live connection of a client to receive events.
+type Stream struct {
+	// SubscriberID is the id of the subscriber registered for the stream.
+	SubscriberID string
+
+	url       string
+	events    chan Event
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var (
+	streamsMutex sync.RWMutex
+	streams      = make(map[string]*Stream)
+)
+
+// New creates a stream with a unique url to register as a subscriber.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func New() (*Stream, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Stream{
+		url:    URL_SCHEME + id.String(),
+		events: make(chan Event, BUFFER_SIZE),
+		done:   make(chan struct{}),
+	}
+
+	streamsMutex.Lock()
+	streams[s.url] = s
+	streamsMutex.Unlock()
+	return s, nil
+}
+
+// URL returns the url of the stream.
+func (s *Stream) URL() string {
+	return s.url
+}
+
+// Events returns a channel of events published to the stream.
+func (s *Stream) Events() <-chan Event {
+	return s.events
+}
+
+// Done returns a channel which is closed when the stream is closed.
+func (s *Stream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close removes the stream, after which events are no longer published to it.
+func (s *Stream) Close() {
+	s.closeOnce.Do(func() {
+		streamsMutex.Lock()
+		delete(streams, s.url)
+		streamsMutex.Unlock()
+		close(s.done)
+	})
+}
+
+// IsStreamURL returns whether url is a url of a stream.
+func IsStreamURL(url string) bool {
+	return len(url) > len(URL_SCHEME) && url[:len(URL_SCHEME)] == URL_SCHEME
+}
+
+// IsOpen returns whether the stream with url is open in this anchor.
+func IsOpen(url string) bool {
+	streamsMutex.RLock()
+	defer streamsMutex.RUnlock()
+	_, exists := streams[url]
+	return exists
+}
+
+// Publish sends an event to a stream with url. It returns false if the stream
+// does not exist, such as when it was opened before the anchor restarted.
+// A stream of a client which does not keep up with events is closed rather
+// than blocking other subscribers.
+func Publish(url string, eventType string, data string) bool {
+	streamsMutex.RLock()
+	s, exists := streams[url]
+	streamsMutex.RUnlock()
+	if !exists {
+		return false
+	}
+
+	select {
+	case s.events <- Event{Type: eventType, Data: data}:
+	case <-s.done:
+	default:
+		logger.Log(logger.ERROR, "stream is too slow to receive events", "subscriber", s.SubscriberID)
+		s.Close()
+	}
+	return true
+}
+
+// Count returns the number of open streams.
+func Count() int {
+	streamsMutex.RLock()
+	defer streamsMutex.RUnlock()
+	return len(streams)
+}
@@ -0,0 +1,88 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package stream
+
+import (
+	"testing"
+)
+
+func TestCalledPublish_ExpectEventReceivedByStream(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	defer s.Close()
+
+	if !IsStreamURL(s.URL()) {
+		t.Errorf("Expected url of stream, actual url: %s", s.URL())
+	}
+
+	if !Publish(s.URL(), "node", "data") {
+		t.Error("Expected event published")
+	}
+
+	event := <-s.Events()
+	if event.Type != "node" || event.Data != "data" {
+		t.Errorf("Unexpected event: %v", event)
+	}
+}
+
+func TestCalledPublishToClosedStream_ExpectFalseReturn(t *testing.T) {
+	s, _ := New()
+	s.Close()
+
+	if Publish(s.URL(), "node", "data") {
+		t.Error("Expected event not published to closed stream")
+	}
+	if Publish("http://subscriber", "node", "data") {
+		t.Error("Expected event not published to unknown stream")
+	}
+}
+
+func TestCalledPublishToSlowStream_ExpectStreamClosed(t *testing.T) {
+	s, _ := New()
+	count := Count()
+
+	for i := 0; i <= BUFFER_SIZE; i++ {
+		Publish(s.URL(), "node", "data")
+	}
+
+	select {
+	case <-s.Done():
+	default:
+		t.Error("Expected slow stream closed")
+	}
+	if Count() != count-1 {
+		t.Errorf("Expected stream removed, actual count: %d", Count())
+	}
+}
+
+func TestCalledIsOpen_ExpectTrueOnlyUntilClosed(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+
+	if !IsOpen(s.URL()) {
+		t.Error("Expected open stream")
+	}
+	s.Close()
+	if IsOpen(s.URL()) {
+		t.Error("Expected closed stream")
+	}
+}
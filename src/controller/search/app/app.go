@@ -22,6 +22,7 @@ import (
 	"commons/logger"
 	"commons/results"
 	"commons/util"
+	"context"
 	appDB "db/mongo/app"
 	groupDB "db/mongo/group"
 	nodeDB "db/mongo/node"
@@ -40,7 +41,7 @@ const (
 
 // Command is an interface of apps operations.
 type Command interface {
-	Search(query map[string]interface{}) (int, map[string]interface{}, error)
+	Search(ctx context.Context, query map[string]interface{}) (int, map[string]interface{}, error)
 }
 
 // Executor implements the Command interface.
@@ -56,23 +57,23 @@ func init() {
 	groupDbExecutor = groupDB.Executor{}
 }
 
-func (Executor) Search(query map[string]interface{}) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) Search(ctx context.Context, query map[string]interface{}) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Validate query parameters.
 	err := checkQueryParam(query)
 	if err != nil {
-		logger.Logging(logger.DEBUG, err.Error())
+		logger.With(ctx).Logging(logger.DEBUG, err.Error())
 		return results.ERROR, nil, err
 	}
 
 	// Checks for 'appId' query parameter existence.
 	appList := make([]map[string]interface{}, 0)
 	if appId, exists := query[APPID]; exists {
-		appList = filterByAppId(appId.([]string)[0])
+		appList = filterByAppId(ctx, appId.([]string)[0])
 	} else {
-		appList = filterByAppId()
+		appList = filterByAppId(ctx)
 	}
 
 	// Checks for 'imageName' query parameter existence.
@@ -82,12 +83,12 @@ func (Executor) Search(query map[string]interface{}) (int, map[string]interface{
 
 	// Checks for 'nodeId' query parameter existence.
 	if nodeId, exists := query[NODEID]; exists {
-		appList = filterByNodeId(appList, nodeId.([]string)[0])
+		appList = filterByNodeId(ctx, appList, nodeId.([]string)[0])
 	}
 
 	// Checks for 'gourId' query parameter existence.
 	if groupId, exists := query[GROUPID]; exists {
-		appList = filterByGroupId(appList, groupId.([]string)[0])
+		appList = filterByGroupId(ctx, appList, groupId.([]string)[0])
 	}
 
 	res := make(map[string]interface{})
@@ -95,20 +96,20 @@ func (Executor) Search(query map[string]interface{}) (int, map[string]interface{
 	return results.OK, res, nil
 }
 
-func filterByGroupId(apps []map[string]interface{}, groupId string) []map[string]interface{} {
+func filterByGroupId(ctx context.Context, apps []map[string]interface{}, groupId string) []map[string]interface{} {
 	filteredApps := make([]map[string]interface{}, 0)
 
-	group, err := groupDbExecutor.GetGroup(groupId)
+	group, err := groupDbExecutor.GetGroup(ctx, groupId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return nil
 	}
 
 	members := group[MEMBERS].([]string)
 	for _, member := range members {
-		node, err := nodeDbExecutor.GetNode(member)
+		node, err := nodeDbExecutor.GetNode(ctx, member)
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
 			return nil
 		}
 
@@ -123,12 +124,12 @@ func filterByGroupId(apps []map[string]interface{}, groupId string) []map[string
 	return filteredApps
 }
 
-func filterByNodeId(apps []map[string]interface{}, nodeId string) []map[string]interface{} {
+func filterByNodeId(ctx context.Context, apps []map[string]interface{}, nodeId string) []map[string]interface{} {
 	filteredApps := make([]map[string]interface{}, 0)
 
-	node, err := nodeDbExecutor.GetNode(nodeId)
+	node, err := nodeDbExecutor.GetNode(ctx, nodeId)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return nil
 	}
 
@@ -153,21 +154,21 @@ func filterByImageName(apps []map[string]interface{}, imageName string) []map[st
 	return filteredApps
 }
 
-func filterByAppId(appId ...string) []map[string]interface{} {
+func filterByAppId(ctx context.Context, appId ...string) []map[string]interface{} {
 	filteredApps := make([]map[string]interface{}, 0)
 
 	switch len(appId) {
 	case 1:
-		app, err := appDbExecutor.GetApp(appId[0])
+		app, err := appDbExecutor.GetApp(ctx, appId[0])
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
 			return nil
 		}
 		filteredApps = append(filteredApps, app)
 	case 0:
-		apps, err := appDbExecutor.GetApps()
+		apps, err := appDbExecutor.GetApps(ctx)
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
 			return nil
 		}
 		filteredApps = apps
@@ -19,6 +19,7 @@ package app
 import (
 	"commons/errors"
 	"commons/results"
+	"context"
 	appDbmocks "db/mongo/app/mocks"
 	nodeDbmocks "db/mongo/node/mocks"
 	groupDbmocks "db/mongo/group/mocks"
@@ -112,7 +113,7 @@ func TestCalledSearchAppsWithInvalidQuery_ExpectErrorReturn(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	code, _, err := executor.Search(invalidQuery)
+	code, _, err := executor.Search(context.Background(), invalidQuery)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFoundURL", "nil")
@@ -132,17 +133,17 @@ func TestCalledSearchAppsWithAllQuery_ExpectErrorReturn(t *testing.T) {
 	groupExecutorMockObj := groupDbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		appExecutorMockObj.EXPECT().GetApp(appId1).Return(app1, nil),
-		nodeExecutorMockObj.EXPECT().GetNode(nodeId1).Return(node1, nil),
-		groupExecutorMockObj.EXPECT().GetGroup(groupId1).Return(group1, nil),
-		nodeExecutorMockObj.EXPECT().GetNode(nodeId1).Return(node1, nil),
+		appExecutorMockObj.EXPECT().GetApp(gomock.Any(), appId1).Return(app1, nil),
+		nodeExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId1).Return(node1, nil),
+		groupExecutorMockObj.EXPECT().GetGroup(gomock.Any(), groupId1).Return(group1, nil),
+		nodeExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId1).Return(node1, nil),
 	)
 	// pass mockObj to a real object
 	appDbExecutor = appExecutorMockObj
 	nodeDbExecutor = nodeExecutorMockObj
 	groupDbExecutor = groupExecutorMockObj
 
-	code, res, err := executor.Search(allQuery)
+	code, res, err := executor.Search(context.Background(), allQuery)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -174,17 +175,17 @@ func TestCalledSearchAppsWithoutAppId_ExpectErrorReturn(t *testing.T) {
 	apps[1] = app2
 
 	gomock.InOrder(
-		appExecutorMockObj.EXPECT().GetApps().Return(apps, nil),
-		nodeExecutorMockObj.EXPECT().GetNode(nodeId1).Return(node1, nil),
-		groupExecutorMockObj.EXPECT().GetGroup(groupId1).Return(group1, nil),
-		nodeExecutorMockObj.EXPECT().GetNode(nodeId1).Return(node1, nil),
+		appExecutorMockObj.EXPECT().GetApps(gomock.Any()).Return(apps, nil),
+		nodeExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId1).Return(node1, nil),
+		groupExecutorMockObj.EXPECT().GetGroup(gomock.Any(), groupId1).Return(group1, nil),
+		nodeExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId1).Return(node1, nil),
 	)
 	// pass mockObj to a real object
 	appDbExecutor = appExecutorMockObj
 	nodeDbExecutor = nodeExecutorMockObj
 	groupDbExecutor = groupExecutorMockObj
 
-	code, res, err := executor.Search(queryWithoutAppId)
+	code, res, err := executor.Search(context.Background(), queryWithoutAppId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -211,14 +212,14 @@ func TestCalledSearchAppsWithoutGroupId_ExpectErrorReturn(t *testing.T) {
 	nodeExecutorMockObj := nodeDbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		appExecutorMockObj.EXPECT().GetApp(appId1).Return(app1, nil),
-		nodeExecutorMockObj.EXPECT().GetNode(nodeId1).Return(node1, nil),
+		appExecutorMockObj.EXPECT().GetApp(gomock.Any(), appId1).Return(app1, nil),
+		nodeExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId1).Return(node1, nil),
 	)
 	// pass mockObj to a real object
 	appDbExecutor = appExecutorMockObj
 	nodeDbExecutor = nodeExecutorMockObj
 
-	code, res, err := executor.Search(queryWithoutGroupId)
+	code, res, err := executor.Search(context.Background(), queryWithoutGroupId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -22,6 +22,7 @@
 package mocks
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -50,8 +51,8 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // Search mocks base method
-func (m *MockCommand) Search(query map[string]interface{}) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "Search", query)
+func (m *MockCommand) Search(ctx context.Context, query map[string]interface{}) (int, map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "Search", ctx, query)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(map[string]interface{})
 	ret2, _ := ret[2].(error)
@@ -59,6 +60,6 @@ func (m *MockCommand) Search(query map[string]interface{}) (int, map[string]inte
 }
 
 // Search indicates an expected call of Search
-func (mr *MockCommandMockRecorder) Search(query interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockCommand)(nil).Search), query)
+func (mr *MockCommandMockRecorder) Search(ctx, query interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockCommand)(nil).Search), ctx, query)
 }
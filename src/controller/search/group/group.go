@@ -22,13 +22,14 @@ import (
 	"commons/logger"
 	"commons/results"
 	"commons/util"
+	"context"
 	appDB "db/mongo/app"
 	groupDB "db/mongo/group"
 	nodeDB "db/mongo/node"
 )
 
 type Command interface {
-	SearchGroups(query map[string]interface{}) (int, map[string]interface{}, error)
+	SearchGroups(ctx context.Context, query map[string]interface{}) (int, map[string]interface{}, error)
 }
 
 const (
@@ -50,33 +51,33 @@ func init() {
 	groupDBExecutor = groupDB.Executor{}
 }
 
-func (Executor) SearchGroups(query map[string]interface{}) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) SearchGroups(ctx context.Context, query map[string]interface{}) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Validate query parameters.
 	err := checkQueryParam(query)
 	if err != nil {
-		logger.Logging(logger.DEBUG, err.Error())
+		logger.With(ctx).Logging(logger.DEBUG, err.Error())
 		return results.ERROR, nil, err
 	}
 
 	// Checks for 'groupId' query parameter existence.
 	groupList := make([]map[string]interface{}, 0)
 	if groupId, exists := query[GROUPID]; exists {
-		groupList = filterByGroupId(groupId.([]string)[0])
+		groupList = filterByGroupId(ctx, groupId.([]string)[0])
 	} else {
-		groupList = filterByGroupId()
+		groupList = filterByGroupId(ctx)
 	}
 
 	// Checks for 'imageName' query parameter existence.
 	if imageName, exists := query[IMAGENAME]; exists {
-		groupList = filterByImageName(groupList, imageName.([]string)[0])
+		groupList = filterByImageName(ctx, groupList, imageName.([]string)[0])
 	}
 
 	// Checks for 'appId' query parameter existence.
 	if appId, exists := query[APPID]; exists {
-		groupList = filterByAppId(groupList, appId.([]string)[0])
+		groupList = filterByAppId(ctx, groupList, appId.([]string)[0])
 	}
 
 	// Checks for 'nodeId' query parameter existence.
@@ -89,22 +90,22 @@ func (Executor) SearchGroups(query map[string]interface{}) (int, map[string]inte
 	return results.OK, res, nil
 }
 
-func filterByImageName(groups []map[string]interface{}, imageName string) []map[string]interface{} {
+func filterByImageName(ctx context.Context, groups []map[string]interface{}, imageName string) []map[string]interface{} {
 	filteredGroups := make([]map[string]interface{}, 0)
 
 	for _, group := range groups {
 		members := group["members"].([]string)
 		for _, member := range members {
-			node, err := nodeDBExecutor.GetNode(member)
+			node, err := nodeDBExecutor.GetNode(ctx, member)
 			if err != nil {
-				logger.Logging(logger.ERROR, err.Error())
+				logger.With(ctx).Logging(logger.ERROR, err.Error())
 				return nil
 			}
 
 			for _, appId := range node["apps"].([]string) {
-				app, err := appDBExecutor.GetApp(appId)
+				app, err := appDBExecutor.GetApp(ctx, appId)
 				if err != nil {
-					logger.Logging(logger.ERROR, err.Error())
+					logger.With(ctx).Logging(logger.ERROR, err.Error())
 					return nil
 				}
 
@@ -118,15 +119,15 @@ func filterByImageName(groups []map[string]interface{}, imageName string) []map[
 	return filteredGroups
 }
 
-func filterByAppId(groups []map[string]interface{}, appId string) []map[string]interface{} {
+func filterByAppId(ctx context.Context, groups []map[string]interface{}, appId string) []map[string]interface{} {
 	filteredGroups := make([]map[string]interface{}, 0)
 
 	for _, group := range groups {
 		members := group["members"].([]string)
 		for _, member := range members {
-			node, err := nodeDBExecutor.GetNode(member)
+			node, err := nodeDBExecutor.GetNode(ctx, member)
 			if err != nil {
-				logger.Logging(logger.ERROR, err.Error())
+				logger.With(ctx).Logging(logger.ERROR, err.Error())
 				return nil
 			}
 
@@ -150,21 +151,21 @@ func filterByNodeId(groups []map[string]interface{}, nodeId string) []map[string
 	return filteredGroups
 }
 
-func filterByGroupId(groupId ...string) []map[string]interface{} {
+func filterByGroupId(ctx context.Context, groupId ...string) []map[string]interface{} {
 	filteredGroups := make([]map[string]interface{}, 0)
 
 	switch len(groupId) {
 	case 1:
-		group, err := groupDBExecutor.GetGroup(groupId[0])
+		group, err := groupDBExecutor.GetGroup(ctx, groupId[0])
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
 			return nil
 		}
 		filteredGroups = append(filteredGroups, group)
 	case 0:
-		groups, err := groupDBExecutor.GetGroups()
+		groups, err := groupDBExecutor.GetGroups(ctx)
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
 			return nil
 		}
 		filteredGroups = groups
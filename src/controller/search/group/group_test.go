@@ -19,6 +19,7 @@ package group
 import (
 	"commons/errors"
 	"commons/results"
+	"context"
 	appmocks "db/mongo/app/mocks"
 	groupmocks "db/mongo/group/mocks"
 	nodemocks "db/mongo/node/mocks"
@@ -104,7 +105,7 @@ func TestCalledSearchGroupsWithInvalidQuery_ExpectErrorReturn(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	code, _, err := executor.SearchGroups(invalidQuery)
+	code, _, err := executor.SearchGroups(context.Background(), invalidQuery)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFoundURL", "nil")
@@ -124,17 +125,17 @@ func TestCalledSearchGroupsWithAllQuery_ExpectErrorReturn(t *testing.T) {
 	groupExecutorMockObj := groupmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupExecutorMockObj.EXPECT().GetGroup(groupId1).Return(group1, nil),
-		nodeExecutorMockObj.EXPECT().GetNode(nodeId1).Return(node1, nil),
-		appExecutorMockObj.EXPECT().GetApp(appId1).Return(app1, nil),
-		nodeExecutorMockObj.EXPECT().GetNode(nodeId1).Return(node1, nil),
+		groupExecutorMockObj.EXPECT().GetGroup(gomock.Any(), groupId1).Return(group1, nil),
+		nodeExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId1).Return(node1, nil),
+		appExecutorMockObj.EXPECT().GetApp(gomock.Any(), appId1).Return(app1, nil),
+		nodeExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId1).Return(node1, nil),
 	)
 	// pass mockObj to a real object
 	appDBExecutor = appExecutorMockObj
 	nodeDBExecutor = nodeExecutorMockObj
 	groupDBExecutor = groupExecutorMockObj
 
-	code, res, err := executor.SearchGroups(allQuery)
+	code, res, err := executor.SearchGroups(context.Background(), allQuery)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -162,19 +163,19 @@ func TestCalledSearchGroupsWithoutGroupId_ExpectErrorReturn(t *testing.T) {
 	groupExecutorMockObj := groupmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupExecutorMockObj.EXPECT().GetGroups().Return(groups["groups"], nil),
-		nodeExecutorMockObj.EXPECT().GetNode(nodeId1).Return(node1, nil),
-		appExecutorMockObj.EXPECT().GetApp(appId1).Return(app1, nil),
-		nodeExecutorMockObj.EXPECT().GetNode(nodeId2).Return(node2, nil),
-		appExecutorMockObj.EXPECT().GetApp(appId2).Return(app2, nil),
-		nodeExecutorMockObj.EXPECT().GetNode(nodeId1).Return(node1, nil),
+		groupExecutorMockObj.EXPECT().GetGroups(gomock.Any()).Return(groups["groups"], nil),
+		nodeExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId1).Return(node1, nil),
+		appExecutorMockObj.EXPECT().GetApp(gomock.Any(), appId1).Return(app1, nil),
+		nodeExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId2).Return(node2, nil),
+		appExecutorMockObj.EXPECT().GetApp(gomock.Any(), appId2).Return(app2, nil),
+		nodeExecutorMockObj.EXPECT().GetNode(gomock.Any(), nodeId1).Return(node1, nil),
 	)
 	// pass mockObj to a real object
 	appDBExecutor = appExecutorMockObj
 	nodeDBExecutor = nodeExecutorMockObj
 	groupDBExecutor = groupExecutorMockObj
 
-	code, res, err := executor.SearchGroups(queryWithoutGroupId)
+	code, res, err := executor.SearchGroups(context.Background(), queryWithoutGroupId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -22,6 +22,7 @@
 package mocks
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -50,8 +51,8 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // SearchGroups mocks base method
-func (m *MockCommand) SearchGroups(query map[string]interface{}) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "SearchGroups", query)
+func (m *MockCommand) SearchGroups(ctx context.Context, query map[string]interface{}) (int, map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "SearchGroups", ctx, query)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(map[string]interface{})
 	ret2, _ := ret[2].(error)
@@ -59,6 +60,6 @@ func (m *MockCommand) SearchGroups(query map[string]interface{}) (int, map[strin
 }
 
 // SearchGroups indicates an expected call of SearchGroups
-func (mr *MockCommandMockRecorder) SearchGroups(query interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchGroups", reflect.TypeOf((*MockCommand)(nil).SearchGroups), query)
+func (mr *MockCommandMockRecorder) SearchGroups(ctx, query interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchGroups", reflect.TypeOf((*MockCommand)(nil).SearchGroups), ctx, query)
 }
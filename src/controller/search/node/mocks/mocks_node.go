@@ -4,6 +4,7 @@
 package mocks
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -32,8 +33,8 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // SearchNodes mocks base method
-func (m *MockCommand) SearchNodes(query map[string][]string) (int, map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "SearchNodes", query)
+func (m *MockCommand) SearchNodes(ctx context.Context, query map[string][]string) (int, map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "SearchNodes", ctx, query)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(map[string]interface{})
 	ret2, _ := ret[2].(error)
@@ -41,6 +42,6 @@ func (m *MockCommand) SearchNodes(query map[string][]string) (int, map[string]in
 }
 
 // SearchNodes indicates an expected call of SearchNodes
-func (mr *MockCommandMockRecorder) SearchNodes(query interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNodes", reflect.TypeOf((*MockCommand)(nil).SearchNodes), query)
+func (mr *MockCommandMockRecorder) SearchNodes(ctx, query interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNodes", reflect.TypeOf((*MockCommand)(nil).SearchNodes), ctx, query)
 }
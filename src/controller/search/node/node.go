@@ -21,13 +21,14 @@ import (
 	"commons/logger"
 	"commons/results"
 	"commons/util"
+	"context"
 	appDB "db/mongo/app"
 	groupDB "db/mongo/group"
 	nodeDB "db/mongo/node"
 )
 
 type Command interface {
-	SearchNodes(query map[string][]string) (int, map[string]interface{}, error)
+	SearchNodes(ctx context.Context, query map[string][]string) (int, map[string]interface{}, error)
 }
 
 const (
@@ -52,18 +53,18 @@ func init() {
 	groupDbExecutor = groupDB.Executor{}
 }
 
-func (Executor) SearchNodes(query map[string][]string) (int, map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) SearchNodes(ctx context.Context, query map[string][]string) (int, map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	if doesContainInvalidQuery(query) {
-		logger.Logging(logger.DEBUG, "Url contains invalid query")
+		logger.With(ctx).Logging(logger.DEBUG, "Url contains invalid query")
 		return results.ERROR, nil, errors.InvalidParam{"Url contains invalid query"}
 	}
 
-	nodes, err := nodeDbExecutor.GetNodes()
+	nodes, err := nodeDbExecutor.GetNodes(ctx)
 	if err != nil {
-		logger.Logging(logger.ERROR, err.Error())
+		logger.With(ctx).Logging(logger.ERROR, err.Error())
 		return results.ERROR, nil, err
 	}
 
@@ -82,17 +83,17 @@ func (Executor) SearchNodes(query map[string][]string) (int, map[string]interfac
 	}
 
 	if groupIds, ok := query[GROUP_ID]; ok {
-		nodes, err = filterByGroupId(nodes, groupIds[0])
+		nodes, err = filterByGroupId(ctx, nodes, groupIds[0])
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
 			return results.ERROR, nil, errors.Unknown{"failed to filter by group ID"}
 		}
 	}
 
 	if imageNames, ok := query[IMAGE_NAME]; ok {
-		nodes, err = filterByImageName(nodes, imageNames[0])
+		nodes, err = filterByImageName(ctx, nodes, imageNames[0])
 		if err != nil {
-			logger.Logging(logger.ERROR, err.Error())
+			logger.With(ctx).Logging(logger.ERROR, err.Error())
 			return results.ERROR, nil, errors.Unknown{"failed to filter image name"}
 		}
 	}
@@ -102,12 +103,12 @@ func (Executor) SearchNodes(query map[string][]string) (int, map[string]interfac
 	return results.OK, result, nil
 }
 
-func filterByGroupId(nodes []map[string]interface{}, groupId string) ([]map[string]interface{}, error) {
+func filterByGroupId(ctx context.Context, nodes []map[string]interface{}, groupId string) ([]map[string]interface{}, error) {
 	filteredNodes := make([]map[string]interface{}, 0)
 
-	groups, err := groupDbExecutor.GetGroups()
+	groups, err := groupDbExecutor.GetGroups(ctx)
 	if err != nil {
-		logger.Logging(logger.DEBUG, err.Error())
+		logger.With(ctx).Logging(logger.DEBUG, err.Error())
 		return nil, err
 	}
 
@@ -137,15 +138,15 @@ func filterByAppId(nodes []map[string]interface{}, appId string) []map[string]in
 	return filteredNodes
 }
 
-func filterByImageName(nodes []map[string]interface{}, imageName string) ([]map[string]interface{}, error) {
+func filterByImageName(ctx context.Context, nodes []map[string]interface{}, imageName string) ([]map[string]interface{}, error) {
 	filteredNodes := make([]map[string]interface{}, 0)
 
 	for _, node := range nodes {
 		nodeApps := node["apps"].([]string)
 		for _, nodeApp := range nodeApps {
-			app, err := appDbExecutor.GetApp(nodeApp)
+			app, err := appDbExecutor.GetApp(ctx, nodeApp)
 			if err != nil {
-				logger.Logging(logger.ERROR, err.Error())
+				logger.With(ctx).Logging(logger.ERROR, err.Error())
 				return nil, err
 			}
 			if util.IsContainedStringInList(app["images"].([]string), imageName) {
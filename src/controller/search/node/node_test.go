@@ -3,6 +3,7 @@ package node
 import (
 	"commons/errors"
 	"commons/results"
+	"context"
 	appDbmocks "db/mongo/app/mocks"
 	groupDbmocks "db/mongo/group/mocks"
 	nodeDbmocks "db/mongo/node/mocks"
@@ -109,9 +110,9 @@ func TestSearchNodesWithAllQuery_ExpectSuccess(t *testing.T) {
 	nodeDbExecutorMockObj := nodeDbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodeDbExecutorMockObj.EXPECT().GetNodes().Return(nodes, nil),
-		groupDbExecutorMockObj.EXPECT().GetGroups().Return(groups, nil),
-		appDbExecutorMockObj.EXPECT().GetApp(appId1).Return(app1, nil),
+		nodeDbExecutorMockObj.EXPECT().GetNodes(gomock.Any()).Return(nodes, nil),
+		groupDbExecutorMockObj.EXPECT().GetGroups(gomock.Any()).Return(groups, nil),
+		appDbExecutorMockObj.EXPECT().GetApp(gomock.Any(), appId1).Return(app1, nil),
 	)
 
 	// pass mockObj to a real object.
@@ -119,7 +120,7 @@ func TestSearchNodesWithAllQuery_ExpectSuccess(t *testing.T) {
 	groupDbExecutor = groupDbExecutorMockObj
 	nodeDbExecutor = nodeDbExecutorMockObj
 
-	code, res, err := searchExecutor.SearchNodes(allQuery)
+	code, res, err := searchExecutor.SearchNodes(context.Background(), allQuery)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -145,13 +146,13 @@ func TestSearchNodesWithAllQueryWhenGetNodesFailed_ExpectRetrunError(t *testing.
 	nodeDbExecutorMockObj := nodeDbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodeDbExecutorMockObj.EXPECT().GetNodes().Return(nil, errors.DBOperationError{}),
+		nodeDbExecutorMockObj.EXPECT().GetNodes(gomock.Any()).Return(nil, errors.DBOperationError{}),
 	)
 
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodeDbExecutorMockObj
 
-	code, _, err := searchExecutor.SearchNodes(allQuery)
+	code, _, err := searchExecutor.SearchNodes(context.Background(), allQuery)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "DBOperationError", "nil")
@@ -171,15 +172,15 @@ func TestSearchNodesWithAllQueryWhenGetGroupsFailed_ExpectRetrunError(t *testing
 	groupDbExecutorMockObj := groupDbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodeDbExecutorMockObj.EXPECT().GetNodes().Return(nodes, nil),
-		groupDbExecutorMockObj.EXPECT().GetGroups().Return(nil, errors.DBOperationError{}),
+		nodeDbExecutorMockObj.EXPECT().GetNodes(gomock.Any()).Return(nodes, nil),
+		groupDbExecutorMockObj.EXPECT().GetGroups(gomock.Any()).Return(nil, errors.DBOperationError{}),
 	)
 
 	// pass mockObj to a real object.
 	nodeDbExecutor = nodeDbExecutorMockObj
 	groupDbExecutor = groupDbExecutorMockObj
 
-	code, _, err := searchExecutor.SearchNodes(allQuery)
+	code, _, err := searchExecutor.SearchNodes(context.Background(), allQuery)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "DBOperationError", "nil")
@@ -200,9 +201,9 @@ func TestSearchNodesWithAllQueryWhenGetAppFailed_ExpectRetrunError(t *testing.T)
 	appDbExecutorMockObj := appDbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		nodeDbExecutorMockObj.EXPECT().GetNodes().Return(nodes, nil),
-		groupDbExecutorMockObj.EXPECT().GetGroups().Return(groups, nil),
-		appDbExecutorMockObj.EXPECT().GetApp(gomock.Any()).Return(nil, errors.DBOperationError{}),
+		nodeDbExecutorMockObj.EXPECT().GetNodes(gomock.Any()).Return(nodes, nil),
+		groupDbExecutorMockObj.EXPECT().GetGroups(gomock.Any()).Return(groups, nil),
+		appDbExecutorMockObj.EXPECT().GetApp(gomock.Any(), gomock.Any()).Return(nil, errors.DBOperationError{}),
 	)
 
 	// pass mockObj to a real object.
@@ -210,7 +211,7 @@ func TestSearchNodesWithAllQueryWhenGetAppFailed_ExpectRetrunError(t *testing.T)
 	groupDbExecutor = groupDbExecutorMockObj
 	appDbExecutor = appDbExecutorMockObj
 
-	code, _, err := searchExecutor.SearchNodes(allQuery)
+	code, _, err := searchExecutor.SearchNodes(context.Background(), allQuery)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "DBOperationError", "nil")
@@ -229,7 +230,7 @@ func TestFilterByGroupId_ExpectSuccess(t *testing.T) {
 	groupDbExecutorMockObj := groupDbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroups().Return(groups, nil),
+		groupDbExecutorMockObj.EXPECT().GetGroups(gomock.Any()).Return(groups, nil),
 	)
 
 	// pass mockObj to a real object.
@@ -240,7 +241,7 @@ func TestFilterByGroupId_ExpectSuccess(t *testing.T) {
 	nodes[1] = node2
 	nodes[2] = node3
 
-	res, err := filterByGroupId(nodes, groupId1)
+	res, err := filterByGroupId(context.Background(), nodes, groupId1)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -262,13 +263,13 @@ func TestFilterByGroupIdWhenGetGroupsFailed_ExpectReturnError(t *testing.T) {
 	groupDbExecutorMockObj := groupDbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		groupDbExecutorMockObj.EXPECT().GetGroups().Return(nil, errors.DBOperationError{}),
+		groupDbExecutorMockObj.EXPECT().GetGroups(gomock.Any()).Return(nil, errors.DBOperationError{}),
 	)
 
 	// pass mockObj to a real object.
 	groupDbExecutor = groupDbExecutorMockObj
 
-	_, err := filterByGroupId(nodes, groupId1)
+	_, err := filterByGroupId(context.Background(), nodes, groupId1)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "DBOperationError", "nil")
@@ -301,9 +302,9 @@ func TestFilterByImageName_ExpectSuccess(t *testing.T) {
 	appDbExecutorMockObj := appDbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		appDbExecutorMockObj.EXPECT().GetApp(gomock.Any()).Return(app1, nil),
-		appDbExecutorMockObj.EXPECT().GetApp(gomock.Any()).Return(app2, nil),
-		appDbExecutorMockObj.EXPECT().GetApp(gomock.Any()).Return(app2, nil),
+		appDbExecutorMockObj.EXPECT().GetApp(gomock.Any(), gomock.Any()).Return(app1, nil),
+		appDbExecutorMockObj.EXPECT().GetApp(gomock.Any(), gomock.Any()).Return(app2, nil),
+		appDbExecutorMockObj.EXPECT().GetApp(gomock.Any(), gomock.Any()).Return(app2, nil),
 	)
 
 	// pass mockObj to a real object.
@@ -314,7 +315,7 @@ func TestFilterByImageName_ExpectSuccess(t *testing.T) {
 	nodes[1] = node2
 	nodes[2] = node3
 
-	res, err := filterByImageName(nodes, commonImage)
+	res, err := filterByImageName(context.Background(), nodes, commonImage)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -337,7 +338,7 @@ func TestFilterByImageNameWhenGetAppFailed_ExpectReturnError(t *testing.T) {
 	appDbExecutorMockObj := appDbmocks.NewMockCommand(ctrl)
 
 	gomock.InOrder(
-		appDbExecutorMockObj.EXPECT().GetApp(gomock.Any()).Return(nil, errors.DBOperationError{}),
+		appDbExecutorMockObj.EXPECT().GetApp(gomock.Any(), gomock.Any()).Return(nil, errors.DBOperationError{}),
 	)
 
 	// pass mockObj to a real object.
@@ -348,7 +349,7 @@ func TestFilterByImageNameWhenGetAppFailed_ExpectReturnError(t *testing.T) {
 	nodes[1] = node2
 	nodes[2] = node3
 
-	_, err := filterByImageName(nodes, commonImage)
+	_, err := filterByImageName(context.Background(), nodes, commonImage)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "DBOperationError", "nil")
@@ -0,0 +1,325 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package alert
+
+import (
+	"commons/errors"
+	"commons/logger"
+	"context"
+	. "db/mongo/wrapper"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type Command interface {
+	// AddRule inserts a new alert rule.
+	AddRule(ctx context.Context, rule Rule) (map[string]interface{}, error)
+
+	// GetRule returns an alert rule.
+	GetRule(ctx context.Context, ruleId string) (map[string]interface{}, error)
+
+	// GetRules returns all alert rules.
+	GetRules(ctx context.Context) ([]map[string]interface{}, error)
+
+	// DeleteRule deletes an alert rule and its alerts.
+	DeleteRule(ctx context.Context, ruleId string) error
+
+	// SetAlert inserts or replaces the state of an alert.
+	SetAlert(ctx context.Context, alert Alert) error
+
+	// GetAlerts returns the states of all alerts.
+	GetAlerts(ctx context.Context) ([]map[string]interface{}, error)
+}
+
+const (
+	DB_NAME          = "DeploymentManagerDB"
+	RULE_COLLECTION  = "ALERT_RULE"
+	ALERT_COLLECTION = "ALERT"
+	DB_URL           = "127.0.0.1:27017"
+)
+
+type Rule struct {
+	ID        bson.ObjectId `bson:"_id,omitempty"`
+	Name      string
+	Target    string
+	Metric    string
+	Operator  string
+	Threshold float64
+	Duration  int
+	NodeID    string
+	GroupID   string
+	AppID     string
+}
+
+type Alert struct {
+	ID         string `bson:"_id,omitempty"`
+	RuleID     string
+	NodeID     string
+	AppID      string
+	Status     string
+	Value      float64
+	FiredAt    string
+	ResolvedAt string
+}
+
+type Executor struct{}
+
+var mgoDial Connection
+
+func init() {
+	mgoDial = MongoDial{}
+}
+
+// Try to connect with mongo db server.
+// if succeed to connect with mongo db server, return error as nil,
+// otherwise, return error.
+func connect(ctx context.Context, url string) (Session, error) {
+	// Create a MongoDB Session
+	session, err := mgoDial.Dial(ctx, url)
+
+	if err != nil {
+		return nil, ConvertMongoError(err, "")
+	}
+
+	return session, err
+}
+
+// close of mongodb session.
+func close(mgoSession Session) {
+	mgoSession.Close()
+}
+
+// Getting collection by name.
+// return mongodb Collection
+func getCollection(mgoSession Session, dbname string, collectionName string) Collection {
+	return mgoSession.DB(dbname).C(collectionName)
+}
+
+// convertToMap converts Rule object into a map.
+func (rule Rule) convertToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        rule.ID.Hex(),
+		"name":      rule.Name,
+		"target":    rule.Target,
+		"metric":    rule.Metric,
+		"operator":  rule.Operator,
+		"threshold": rule.Threshold,
+		"duration":  rule.Duration,
+		"nodeId":    rule.NodeID,
+		"groupId":   rule.GroupID,
+		"appId":     rule.AppID,
+	}
+}
+
+// convertToMap converts Alert object into a map.
+func (alert Alert) convertToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":         alert.ID,
+		"ruleId":     alert.RuleID,
+		"nodeId":     alert.NodeID,
+		"appId":      alert.AppID,
+		"status":     alert.Status,
+		"value":      alert.Value,
+		"firedAt":    alert.FiredAt,
+		"resolvedAt": alert.ResolvedAt,
+	}
+}
+
+// AddRule inserts a new rule to 'alert rule' collection with a new id.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) AddRule(ctx context.Context, rule Rule) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return nil, err
+	}
+	defer close(session)
+
+	rule.ID = bson.NewObjectId()
+	err = getCollection(session, DB_NAME, RULE_COLLECTION).Insert(rule)
+	if err != nil {
+		return nil, ConvertMongoError(err)
+	}
+
+	result := rule.convertToMap()
+	return result, err
+}
+
+// GetRule returns a single document specified by ruleId parameter.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetRule(ctx context.Context, ruleId string) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	// Verify id is ObjectId, otherwise fail
+	if !bson.IsObjectIdHex(ruleId) {
+		return nil, errors.InvalidObjectId{ruleId}
+	}
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return nil, err
+	}
+	defer close(session)
+
+	rule := Rule{}
+	query := bson.M{"_id": bson.ObjectIdHex(ruleId)}
+	err = getCollection(session, DB_NAME, RULE_COLLECTION).Find(query).One(&rule)
+	if err != nil {
+		return nil, ConvertMongoError(err, ruleId)
+	}
+
+	result := rule.convertToMap()
+	return result, err
+}
+
+// GetRules returns all documents from 'alert rule' collection.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetRules(ctx context.Context) ([]map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return nil, err
+	}
+	defer close(session)
+
+	rules := []Rule{}
+	err = getCollection(session, DB_NAME, RULE_COLLECTION).Find(nil).All(&rules)
+	if err != nil {
+		return nil, ConvertMongoError(err)
+	}
+
+	result := make([]map[string]interface{}, len(rules))
+	for i, rule := range rules {
+		result[i] = rule.convertToMap()
+	}
+	return result, err
+}
+
+// DeleteRule deletes a single document specified by ruleId parameter
+// and all alerts raised by the rule.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) DeleteRule(ctx context.Context, ruleId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	// Verify id is ObjectId, otherwise fail
+	if !bson.IsObjectIdHex(ruleId) {
+		return errors.InvalidObjectId{ruleId}
+	}
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return err
+	}
+	defer close(session)
+
+	err = getCollection(session, DB_NAME, RULE_COLLECTION).Remove(bson.M{"_id": bson.ObjectIdHex(ruleId)})
+	if err != nil {
+		return ConvertMongoError(err, ruleId)
+	}
+
+	alerts := []Alert{}
+	err = getCollection(session, DB_NAME, ALERT_COLLECTION).Find(bson.M{"ruleid": ruleId}).All(&alerts)
+	if err != nil {
+		return ConvertMongoError(err)
+	}
+
+	for _, alert := range alerts {
+		err = getCollection(session, DB_NAME, ALERT_COLLECTION).Remove(bson.M{"_id": alert.ID})
+		if err != nil {
+			return ConvertMongoError(err, alert.ID)
+		}
+	}
+	return nil
+}
+
+// SetAlert inserts an alert to 'alert' collection,
+// or replaces it if an alert with the same id exists.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) SetAlert(ctx context.Context, alert Alert) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	if len(alert.ID) == 0 {
+		return errors.InvalidParam{"Invalid param error : alertId is empty."}
+	}
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return err
+	}
+	defer close(session)
+
+	stored := Alert{}
+	query := bson.M{"_id": alert.ID}
+	err = getCollection(session, DB_NAME, ALERT_COLLECTION).Find(query).One(&stored)
+	if err != nil {
+		err = ConvertMongoError(err)
+		switch err.(type) {
+		default:
+			return err
+		case errors.NotFound:
+			err = getCollection(session, DB_NAME, ALERT_COLLECTION).Insert(alert)
+			if err != nil {
+				return ConvertMongoError(err)
+			}
+			return nil
+		}
+	}
+
+	err = getCollection(session, DB_NAME, ALERT_COLLECTION).Update(query, alert)
+	if err != nil {
+		return ConvertMongoError(err, alert.ID)
+	}
+	return nil
+}
+
+// GetAlerts returns all documents from 'alert' collection.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetAlerts(ctx context.Context) ([]map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return nil, err
+	}
+	defer close(session)
+
+	alerts := []Alert{}
+	err = getCollection(session, DB_NAME, ALERT_COLLECTION).Find(nil).All(&alerts)
+	if err != nil {
+		return nil, ConvertMongoError(err)
+	}
+
+	result := make([]map[string]interface{}, len(alerts))
+	for i, alert := range alerts {
+		result[i] = alert.convertToMap()
+	}
+	return result, err
+}
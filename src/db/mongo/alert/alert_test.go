@@ -0,0 +1,279 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+
+package alert
+
+import (
+	"commons/errors"
+	"context"
+	mgomocks "db/mongo/wrapper/mocks"
+	"github.com/golang/mock/gomock"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"reflect"
+	"testing"
+)
+
+const (
+	validUrl = "127.0.0.1:27017"
+	ruleId   = "000000000000000000000001"
+	alertId  = "000000000000000000000001/node"
+)
+
+var (
+	rule = Rule{
+		ID:        bson.ObjectIdHex(ruleId),
+		Name:      "cpu",
+		Target:    "node",
+		Metric:    "cpu",
+		Operator:  ">",
+		Threshold: 90,
+		Duration:  300,
+	}
+	state = Alert{
+		ID:      alertId,
+		RuleID:  ruleId,
+		NodeID:  "node",
+		Status:  "firing",
+		Value:   95,
+		FiredAt: "2018-01-01T00:00:00Z",
+	}
+)
+
+func TestCalledAddRule_ExpectInsertedWithNewId(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(RULE_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Insert(gomock.Any()).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	newRule := rule
+	newRule.ID = ""
+	res, err := Executor{}.AddRule(context.Background(), newRule)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if !bson.IsObjectIdHex(res["id"].(string)) || res["metric"] != rule.Metric {
+		t.Errorf("Unexpected res: %v", res)
+	}
+}
+
+func TestCalledGetRuleWithInvalidObjectId_ExpectErrorReturn(t *testing.T) {
+	_, err := Executor{}.GetRule(context.Background(), "invalid")
+
+	switch err.(type) {
+	default:
+		t.Errorf("Expected err: %s, actual err: %v", "InvalidObjectId", err)
+	case errors.InvalidObjectId:
+	}
+}
+
+func TestCalledGetRules_ExpectSuccess(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
+
+	args := []Rule{rule}
+	expectedRes := []map[string]interface{}{rule.convertToMap()}
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(RULE_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Find(nil).Return(queryMockObj),
+		queryMockObj.EXPECT().All(gomock.Any()).SetArg(0, args).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	res, err := Executor{}.GetRules(context.Background())
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(expectedRes, res) {
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
+	}
+}
+
+func TestCalledDeleteRule_ExpectAlertsOfRuleDeleted(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(RULE_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Remove(bson.M{"_id": bson.ObjectIdHex(ruleId)}).Return(nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(ALERT_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Find(bson.M{"ruleid": ruleId}).Return(queryMockObj),
+		queryMockObj.EXPECT().All(gomock.Any()).SetArg(0, []Alert{state}).Return(nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(ALERT_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Remove(bson.M{"_id": alertId}).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	err := Executor{}.DeleteRule(context.Background(), ruleId)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+}
+
+func TestCalledSetAlertWhenDBHasNotMatchedAlert_ExpectInserted(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
+
+	query := bson.M{"_id": alertId}
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(ALERT_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
+		queryMockObj.EXPECT().One(gomock.Any()).Return(mgo.ErrNotFound),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(ALERT_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Insert(state).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	err := Executor{}.SetAlert(context.Background(), state)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+}
+
+func TestCalledSetAlertWhenDBHasMatchedAlert_ExpectUpdated(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
+
+	query := bson.M{"_id": alertId}
+	resolved := state
+	resolved.Status = "resolved"
+	resolved.ResolvedAt = "2018-01-01T00:10:00Z"
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(ALERT_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
+		queryMockObj.EXPECT().One(gomock.Any()).SetArg(0, state).Return(nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(ALERT_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Update(query, resolved).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	err := Executor{}.SetAlert(context.Background(), resolved)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+}
+
+func TestCalledSetAlertWithEmptyId_ExpectErrorReturn(t *testing.T) {
+	err := Executor{}.SetAlert(context.Background(), Alert{})
+
+	switch err.(type) {
+	default:
+		t.Errorf("Expected err: %s, actual err: %v", "InvalidParam", err)
+	case errors.InvalidParam:
+	}
+}
+
+func TestCalledGetAlerts_ExpectSuccess(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
+
+	expectedRes := []map[string]interface{}{state.convertToMap()}
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(ALERT_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Find(nil).Return(queryMockObj),
+		queryMockObj.EXPECT().All(gomock.Any()).SetArg(0, []Alert{state}).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	res, err := Executor{}.GetAlerts(context.Background())
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(expectedRes, res) {
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
+	}
+}
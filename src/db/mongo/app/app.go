@@ -20,6 +20,7 @@ package app
 import (
 	"commons/errors"
 	"commons/logger"
+	"context"
 	. "db/mongo/wrapper"
 	"encoding/json"
 	"gopkg.in/mgo.v2/bson"
@@ -30,16 +31,16 @@ import (
 // Interface of App model's operations.
 type Command interface {
 	// AddApp insert a deployed application information.
-	AddApp(appId string, description []byte) error
+	AddApp(ctx context.Context, appId string, description []byte) error
 
 	// GetApp returns single document from db related to app.
-	GetApp(appId string) (map[string]interface{}, error)
+	GetApp(ctx context.Context, appId string) (map[string]interface{}, error)
 
 	// GetApps returns all matches for the query-string which is passed in call to function.
-	GetApps(queryOptional ...map[string]interface{}) ([]map[string]interface{}, error)
+	GetApps(ctx context.Context, queryOptional ...map[string]interface{}) ([]map[string]interface{}, error)
 
 	// DeleteApp delete a deployed application information.
-	DeleteApp(appId string) error
+	DeleteApp(ctx context.Context, appId string) error
 }
 
 const (
@@ -69,9 +70,9 @@ func init() {
 // Try to connect with mongo db server.
 // if succeed to connect with mongo db server, return error as nil,
 // otherwise, return error.
-func connect(url string) (Session, error) {
+func connect(ctx context.Context, url string) (Session, error) {
 	// Create a MongoDB Session
-	session, err := mgoDial.Dial(url)
+	session, err := mgoDial.Dial(ctx, url)
 
 	if err != nil {
 		return nil, ConvertMongoError(err, "")
@@ -104,16 +105,16 @@ func (app App) convertToMap() map[string]interface{} {
 // AddApp insert a deployed application information.
 // if succeed to add, return app information as map.
 // otherwise, return error.
-func (Executor) AddApp(appId string, description []byte) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) AddApp(ctx context.Context, appId string, description []byte) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	if len(appId) == 0 {
 		err := errors.InvalidParam{"Invalid param error : app_id is empty."}
 		return err
 	}
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -163,11 +164,11 @@ func (Executor) AddApp(appId string, description []byte) error {
 // GetApp returns single document specified by appId parameter.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) GetApp(appId string) (map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetApp(ctx context.Context, appId string) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -187,11 +188,11 @@ func (Executor) GetApp(appId string) (map[string]interface{}, error) {
 // GetApps returns all matches for the query-string which is passed in call to function.
 // if succeed to get, return list of all app information as slice.
 // otherwise, return error.
-func (Executor) GetApps(queryOptional ...map[string]interface{}) ([]map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetApps(ctx context.Context, queryOptional ...map[string]interface{}) ([]map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -223,16 +224,16 @@ func (Executor) GetApps(queryOptional ...map[string]interface{}) ([]map[string]i
 // DeleteApp delete a deployed application information.
 // if succeed to delete, return error as nil.
 // otherwise, return error.
-func (Executor) DeleteApp(appId string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) DeleteApp(ctx context.Context, appId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	if len(appId) == 0 {
 		err := errors.InvalidParam{"Invalid param error : appId is empty."}
 		return err
 	}
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
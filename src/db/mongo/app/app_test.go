@@ -18,6 +18,7 @@ package app
 
 import (
 	errors "commons/errors"
+	"context"
 	mgomocks "db/mongo/wrapper/mocks"
 	"github.com/golang/mock/gomock"
 	"gopkg.in/mgo.v2"
@@ -55,11 +56,11 @@ func TestCalledConnectWithEmptyURL_ExpectErrorReturn(t *testing.T) {
 	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(invalidUrl).Return(&dummySession, connectionError),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), invalidUrl).Return(&dummySession, connectionError),
 	)
 	mgoDial = connectionMockObj
 
-	_, err := connect(invalidUrl)
+	_, err := connect(context.Background(), invalidUrl)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "UnknownError", "nil")
@@ -79,11 +80,11 @@ func TestCalledConnectWithValidURL_ExpectSuccess(t *testing.T) {
 	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(&dummySession, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(&dummySession, nil),
 	)
 	mgoDial = connectionMockObj
 
-	_, err := connect(validUrl)
+	_, err := connect(context.Background(), validUrl)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -135,7 +136,7 @@ func TestCalledAddApp_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(dbName).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -149,7 +150,7 @@ func TestCalledAddApp_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	err := executor.AddApp(appId, []byte(description))
+	err := executor.AddApp(context.Background(), appId, []byte(description))
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -171,7 +172,7 @@ func TestCalledAddAppWhenAlreadyExistsInDB_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(dbName).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -185,7 +186,7 @@ func TestCalledAddAppWhenAlreadyExistsInDB_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	err := executor.AddApp(appId, []byte(description))
+	err := executor.AddApp(context.Background(), appId, []byte(description))
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -194,7 +195,7 @@ func TestCalledAddAppWhenAlreadyExistsInDB_ExpectSuccess(t *testing.T) {
 
 func TestCalledAddAppWithInvalidAppID_ExpectErrorReturn(t *testing.T) {
 	executor := Executor{}
-	err := executor.AddApp(invalidAppId, []byte(description))
+	err := executor.AddApp(context.Background(), invalidAppId, []byte(description))
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "InvalidParamError", "nil")
@@ -220,7 +221,7 @@ func TestCalledAddAppWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(dbName).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -231,7 +232,7 @@ func TestCalledAddAppWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	err := executor.AddApp(appId, []byte(description))
+	err := executor.AddApp(context.Background(), appId, []byte(description))
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "DBOperationError", "nil")
@@ -262,7 +263,7 @@ func TestCalledGetAppsWithoutQuery_ExpectSuccess(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(nil).Return(queryMockObj),
@@ -272,7 +273,7 @@ func TestCalledGetAppsWithoutQuery_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	res, err := executor.GetApps()
+	res, err := executor.GetApps(context.Background())
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -302,7 +303,7 @@ func TestCalledGetAppsWithQuery_ExpectSuccess(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -316,7 +317,7 @@ func TestCalledGetAppsWithQuery_ExpectSuccess(t *testing.T) {
 	queryParam := make(map[string]interface{})
 	queryParam["services"] = "name"
 
-	res, err := executor.GetApps(queryParam)
+	res, err := executor.GetApps(context.Background(), queryParam)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -338,7 +339,7 @@ func TestCalledGetAppsWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(nil).Return(queryMockObj),
@@ -348,7 +349,7 @@ func TestCalledGetAppsWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	_, err := executor.GetApps()
+	_, err := executor.GetApps(context.Background())
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -375,7 +376,7 @@ func TestCalledDeleteApp_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(dbName).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -389,7 +390,7 @@ func TestCalledDeleteApp_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	err := executor.DeleteApp(appId)
+	err := executor.DeleteApp(context.Background(), appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -398,7 +399,7 @@ func TestCalledDeleteApp_ExpectSuccess(t *testing.T) {
 
 func TestCalledDeleteAppWithInvalidAppID_ExpectErrorReturn(t *testing.T) {
 	executor := Executor{}
-	err := executor.DeleteApp(invalidAppId)
+	err := executor.DeleteApp(context.Background(), invalidAppId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "InvalidParamError", "nil")
@@ -426,7 +427,7 @@ func TestCalledDeleteAppWhenReferenceCountIsNotZero_ExpectSuccess(t *testing.T)
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(dbName).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -440,7 +441,7 @@ func TestCalledDeleteAppWhenReferenceCountIsNotZero_ExpectSuccess(t *testing.T)
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	err := executor.DeleteApp(appId)
+	err := executor.DeleteApp(context.Background(), appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -460,7 +461,7 @@ func TestCalledDeleteAppWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(dbName).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -471,7 +472,7 @@ func TestCalledDeleteAppWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	err := executor.DeleteApp(appId)
+	err := executor.DeleteApp(context.Background(), appId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "DBOperationError", "nil")
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -33,33 +34,33 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // AddApp mocks base method
-func (m *MockCommand) AddApp(appId string, description []byte) error {
-	ret := m.ctrl.Call(m, "AddApp", appId, description)
+func (m *MockCommand) AddApp(ctx context.Context, appId string, description []byte) error {
+	ret := m.ctrl.Call(m, "AddApp", ctx, appId, description)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddApp indicates an expected call of AddApp
-func (mr *MockCommandMockRecorder) AddApp(appId, description interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddApp", reflect.TypeOf((*MockCommand)(nil).AddApp), appId, description)
+func (mr *MockCommandMockRecorder) AddApp(ctx, appId, description interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddApp", reflect.TypeOf((*MockCommand)(nil).AddApp), ctx, appId, description)
 }
 
 // GetApp mocks base method
-func (m *MockCommand) GetApp(appId string) (map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetApp", appId)
+func (m *MockCommand) GetApp(ctx context.Context, appId string) (map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetApp", ctx, appId)
 	ret0, _ := ret[0].(map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetApp indicates an expected call of GetApp
-func (mr *MockCommandMockRecorder) GetApp(appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApp", reflect.TypeOf((*MockCommand)(nil).GetApp), appId)
+func (mr *MockCommandMockRecorder) GetApp(ctx, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApp", reflect.TypeOf((*MockCommand)(nil).GetApp), ctx, appId)
 }
 
 // GetApps mocks base method
-func (m *MockCommand) GetApps(queryOptional ...map[string]interface{}) ([]map[string]interface{}, error) {
-	varargs := []interface{}{}
+func (m *MockCommand) GetApps(ctx context.Context, queryOptional ...map[string]interface{}) ([]map[string]interface{}, error) {
+	varargs := []interface{}{ctx}
 	for _, a := range queryOptional {
 		varargs = append(varargs, a)
 	}
@@ -70,18 +71,19 @@ func (m *MockCommand) GetApps(queryOptional ...map[string]interface{}) ([]map[st
 }
 
 // GetApps indicates an expected call of GetApps
-func (mr *MockCommandMockRecorder) GetApps(queryOptional ...interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApps", reflect.TypeOf((*MockCommand)(nil).GetApps), queryOptional...)
+func (mr *MockCommandMockRecorder) GetApps(ctx interface{}, queryOptional ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{ctx}, queryOptional...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApps", reflect.TypeOf((*MockCommand)(nil).GetApps), varargs...)
 }
 
 // DeleteApp mocks base method
-func (m *MockCommand) DeleteApp(appId string) error {
-	ret := m.ctrl.Call(m, "DeleteApp", appId)
+func (m *MockCommand) DeleteApp(ctx context.Context, appId string) error {
+	ret := m.ctrl.Call(m, "DeleteApp", ctx, appId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteApp indicates an expected call of DeleteApp
-func (mr *MockCommandMockRecorder) DeleteApp(appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApp", reflect.TypeOf((*MockCommand)(nil).DeleteApp), appId)
+func (mr *MockCommandMockRecorder) DeleteApp(ctx, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApp", reflect.TypeOf((*MockCommand)(nil).DeleteApp), ctx, appId)
 }
@@ -0,0 +1,211 @@
+/*******************************************************************************
+ * Copyright 2018 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package drift
+
+import (
+	"commons/errors"
+	"commons/logger"
+	"context"
+	. "db/mongo/wrapper"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type Command interface {
+	// SetDrift inserts or replaces the result of the latest drift check of a node.
+	SetDrift(ctx context.Context, nodeId string, differences []map[string]interface{}, checkedAt string) error
+
+	// GetDrift returns the result of the latest drift check of a node.
+	GetDrift(ctx context.Context, nodeId string) (map[string]interface{}, error)
+
+	// GetDrifts returns the results of the latest drift check of all nodes.
+	GetDrifts(ctx context.Context) ([]map[string]interface{}, error)
+
+	// DeleteDrift deletes the result of drift check of a node.
+	DeleteDrift(ctx context.Context, nodeId string) error
+}
+
+const (
+	DB_NAME          = "DeploymentManagerDB"
+	DRIFT_COLLECTION = "DRIFT"
+	DB_URL           = "127.0.0.1:27017"
+)
+
+type Drift struct {
+	ID          string `bson:"_id,omitempty"`
+	Differences []map[string]interface{}
+	CheckedAt   string
+}
+
+type Executor struct{}
+
+var mgoDial Connection
+
+func init() {
+	mgoDial = MongoDial{}
+}
+
+// Try to connect with mongo db server.
+// if succeed to connect with mongo db server, return error as nil,
+// otherwise, return error.
+func connect(ctx context.Context, url string) (Session, error) {
+	// Create a MongoDB Session
+	session, err := mgoDial.Dial(ctx, url)
+
+	if err != nil {
+		return nil, ConvertMongoError(err, "")
+	}
+
+	return session, err
+}
+
+// close of mongodb session.
+func close(mgoSession Session) {
+	mgoSession.Close()
+}
+
+// Getting collection by name.
+// return mongodb Collection
+func getCollection(mgoSession Session, dbname string, collectionName string) Collection {
+	return mgoSession.DB(dbname).C(collectionName)
+}
+
+// convertToMap converts Drift object into a map.
+func (drift Drift) convertToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          drift.ID,
+		"differences": drift.Differences,
+		"checkedat":   drift.CheckedAt,
+	}
+}
+
+// SetDrift inserts the result of drift check to 'drift' collection,
+// or replaces it if the node has been checked before.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) SetDrift(ctx context.Context, nodeId string, differences []map[string]interface{}, checkedAt string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	if len(nodeId) == 0 {
+		return errors.InvalidParam{"Invalid param error : nodeId is empty."}
+	}
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return err
+	}
+	defer close(session)
+
+	drift := Drift{}
+	query := bson.M{"_id": nodeId}
+	err = getCollection(session, DB_NAME, DRIFT_COLLECTION).Find(query).One(&drift)
+	if err != nil {
+		err = ConvertMongoError(err)
+		switch err.(type) {
+		default:
+			return err
+		case errors.NotFound:
+			drift = Drift{
+				ID:          nodeId,
+				Differences: differences,
+				CheckedAt:   checkedAt,
+			}
+			err = getCollection(session, DB_NAME, DRIFT_COLLECTION).Insert(drift)
+			if err != nil {
+				return ConvertMongoError(err)
+			}
+			return nil
+		}
+	}
+
+	update := bson.M{"$set": bson.M{"differences": differences, "checkedat": checkedAt}}
+	err = getCollection(session, DB_NAME, DRIFT_COLLECTION).Update(query, update)
+	if err != nil {
+		return ConvertMongoError(err, nodeId)
+	}
+	return nil
+}
+
+// GetDrift returns a single document specified by nodeId parameter.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetDrift(ctx context.Context, nodeId string) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return nil, err
+	}
+	defer close(session)
+
+	drift := Drift{}
+	query := bson.M{"_id": nodeId}
+	err = getCollection(session, DB_NAME, DRIFT_COLLECTION).Find(query).One(&drift)
+	if err != nil {
+		return nil, ConvertMongoError(err, nodeId)
+	}
+
+	result := drift.convertToMap()
+	return result, err
+}
+
+// GetDrifts returns all documents from 'drift' collection.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetDrifts(ctx context.Context) ([]map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return nil, err
+	}
+	defer close(session)
+
+	drifts := []Drift{}
+	err = getCollection(session, DB_NAME, DRIFT_COLLECTION).Find(nil).All(&drifts)
+	if err != nil {
+		return nil, ConvertMongoError(err)
+	}
+
+	result := make([]map[string]interface{}, len(drifts))
+	for i, drift := range drifts {
+		result[i] = drift.convertToMap()
+	}
+	return result, err
+}
+
+// DeleteDrift deletes a single document specified by nodeId parameter.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) DeleteDrift(ctx context.Context, nodeId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return err
+	}
+	defer close(session)
+
+	err = getCollection(session, DB_NAME, DRIFT_COLLECTION).Remove(bson.M{"_id": nodeId})
+	if err != nil {
+		return ConvertMongoError(err, nodeId)
+	}
+	return nil
+}
@@ -20,15 +20,19 @@ package app
 import (
 	"commons/errors"
 	"commons/logger"
+	"context"
 	. "db/mongo/wrapper"
 	"gopkg.in/mgo.v2/bson"
 )
 
 type Command interface {
-	AddEvent(id string, subscriberId string, nodeId []string) error
-	GetEvent(id string) (map[string]interface{}, error)
-	DeleteEvent(id string) error
-	UnRegisterEvent(id string, subscriberId string) error
+	AddEvent(ctx context.Context, id string, subscriberId string, nodeId []string) error
+	GetEvent(ctx context.Context, id string) (map[string]interface{}, error)
+	DeleteEvent(ctx context.Context, id string) error
+	UnRegisterEvent(ctx context.Context, id string, subscriberId string) error
+
+	// SetNodes sets the ids of nodes watching an app event.
+	SetNodes(ctx context.Context, id string, nodeIds []string) error
 }
 
 const (
@@ -55,9 +59,9 @@ func init() {
 // Try to connect with mongo db server.
 // if succeed to connect with mongo db server, return error as nil,
 // otherwise, return error.
-func connect(url string) (Session, error) {
+func connect(ctx context.Context, url string) (Session, error) {
 	// Create a MongoDB Session
-	session, err := mgoDial.Dial(url)
+	session, err := mgoDial.Dial(ctx, url)
 
 	if err != nil {
 		return nil, ConvertMongoError(err, "")
@@ -87,16 +91,16 @@ func (event AppEvent) convertToMap() map[string]interface{} {
 	}
 }
 
-func (Executor) AddEvent(id string, subscriberId string, nodeId []string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) AddEvent(ctx context.Context, id string, subscriberId string, nodeId []string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	if len(id) == 0 {
 		err := errors.InvalidParam{"Invalid param error : id is empty."}
 		return err
 	}
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -140,11 +144,11 @@ func (Executor) AddEvent(id string, subscriberId string, nodeId []string) error
 	return nil
 }
 
-func (Executor) GetEvent(id string) (map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetEvent(ctx context.Context, id string) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -161,16 +165,16 @@ func (Executor) GetEvent(id string) (map[string]interface{}, error) {
 	return result, err
 }
 
-func (Executor) DeleteEvent(id string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) DeleteEvent(ctx context.Context, id string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	if len(id) == 0 {
 		err := errors.InvalidParam{"Invalid param error : appEventId is empty."}
 		return err
 	}
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -185,11 +189,11 @@ func (Executor) DeleteEvent(id string) error {
 	return err
 }
 
-func (Executor) UnRegisterEvent(id string, subscriberId string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) UnRegisterEvent(ctx context.Context, id string, subscriberId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -214,3 +218,25 @@ func (Executor) UnRegisterEvent(id string, subscriberId string) error {
 
 	return nil
 }
+
+// SetNodes sets the ids of nodes which watch an app event specified by id parameter.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) SetNodes(ctx context.Context, id string, nodeIds []string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return err
+	}
+	defer close(session)
+
+	query := bson.M{"_id": id}
+	update := bson.M{"$set": bson.M{"nodes": nodeIds}}
+	err = getCollection(session, DB_NAME, APP_EVENT_COLLECTION).Update(query, update)
+	if err != nil {
+		return ConvertMongoError(err, id)
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ package app
 
 import (
 	errors "commons/errors"
+	"context"
 	mgomocks "db/mongo/wrapper/mocks"
 	"github.com/golang/mock/gomock"
 	"gopkg.in/mgo.v2"
@@ -51,11 +52,11 @@ func TestCalledConnectWithEmptyURL_ExpectErrorReturn(t *testing.T) {
 	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(inDB_URL).Return(&dummySession, connectionError),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), inDB_URL).Return(&dummySession, connectionError),
 	)
 	mgoDial = connectionMockObj
 
-	_, err := connect(inDB_URL)
+	_, err := connect(context.Background(), inDB_URL)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "UnknownError", "nil")
@@ -75,11 +76,11 @@ func TestCalledConnectWithDB_URL_ExpectSuccess(t *testing.T) {
 	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(DB_URL).Return(&dummySession, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), DB_URL).Return(&dummySession, nil),
 	)
 	mgoDial = connectionMockObj
 
-	_, err := connect(DB_URL)
+	_, err := connect(context.Background(), DB_URL)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -131,7 +132,7 @@ func TestCalledAddEvent_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(DB_URL).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), DB_URL).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(DB_NAME).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -145,7 +146,7 @@ func TestCalledAddEvent_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	err := executor.AddEvent(eventId, subsId1, nodeIds)
+	err := executor.AddEvent(context.Background(), eventId, subsId1, nodeIds)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -165,7 +166,7 @@ func TestCalledAddEventWhenAlreadyExistsInDB_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(DB_URL).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), DB_URL).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(DB_NAME).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -179,7 +180,7 @@ func TestCalledAddEventWhenAlreadyExistsInDB_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	err := executor.AddEvent(eventId, subsId1, nodeIds)
+	err := executor.AddEvent(context.Background(), eventId, subsId1, nodeIds)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -205,7 +206,7 @@ func TestCalledGetEvent_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(DB_URL).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), DB_URL).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(DB_NAME).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -216,7 +217,7 @@ func TestCalledGetEvent_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	res, err := executor.GetEvent(eventId)
+	res, err := executor.GetEvent(context.Background(), eventId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -239,7 +240,7 @@ func TestCalledDeleteEvent_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(DB_URL).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), DB_URL).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(DB_NAME).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Remove(query).Return(nil),
@@ -249,7 +250,7 @@ func TestCalledDeleteEvent_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	err := executor.DeleteEvent(eventId)
+	err := executor.DeleteEvent(context.Background(), eventId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -276,7 +277,7 @@ func TestCalledUnRegisterEvent_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(DB_URL).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), DB_URL).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(DB_NAME).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -290,7 +291,37 @@ func TestCalledUnRegisterEvent_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	err := executor.UnRegisterEvent(eventId, subsId1)
+	err := executor.UnRegisterEvent(context.Background(), eventId, subsId1)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+}
+
+func TestCalledSetNodes_ExpectNodesUpdated(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	query := bson.M{"_id": eventId}
+	update := bson.M{"$set": bson.M{"nodes": []string{"nodeid"}}}
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), DB_URL).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(DB_NAME).Return(dbMockObj),
+		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Update(query, update).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+	executor := Executor{}
+
+	err := executor.SetNodes(context.Background(), eventId, []string{"nodeid"})
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
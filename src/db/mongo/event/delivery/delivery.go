@@ -0,0 +1,410 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package delivery
+
+import (
+	"commons/errors"
+	"commons/logger"
+	"context"
+	. "db/mongo/wrapper"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type Command interface {
+	// AddDelivery queues a new delivery of an event to a subscriber.
+	AddDelivery(ctx context.Context, delivery Delivery) (map[string]interface{}, error)
+
+	// GetDeliveries returns all queued deliveries in the order they were queued.
+	GetDeliveries(ctx context.Context) ([]map[string]interface{}, error)
+
+	// GetDueSubscriberIds returns ids of subscribers which have deliveries to be attempted by now.
+	GetDueSubscriberIds(ctx context.Context, now int64) ([]string, error)
+
+	// GetSubscriberDeliveries returns the first deliveries queued to a subscriber in the order they were queued.
+	GetSubscriberDeliveries(ctx context.Context, subscriberId string, limit int) ([]map[string]interface{}, error)
+
+	// UpdateDelivery records a failed attempt of a queued delivery.
+	UpdateDelivery(ctx context.Context, deliveryId string, attempts int, nextAttempt int64, lastError string) error
+
+	// DeleteDelivery removes a delivery from the queue.
+	DeleteDelivery(ctx context.Context, deliveryId string) error
+
+	// DeleteSubscriberDeliveries removes all queued deliveries to a subscriber.
+	DeleteSubscriberDeliveries(ctx context.Context, subscriberId string) error
+
+	// MoveToDeadLetter moves a queued delivery which has failed too many times to dead letters.
+	MoveToDeadLetter(ctx context.Context, deliveryId string, attempts int, lastError string) error
+
+	// GetDeadLetter returns a delivery which has failed too many times.
+	GetDeadLetter(ctx context.Context, deliveryId string) (map[string]interface{}, error)
+
+	// GetDeadLetters returns all deliveries which have failed too many times.
+	GetDeadLetters(ctx context.Context) ([]map[string]interface{}, error)
+
+	// DeleteDeadLetter removes a delivery which has failed too many times.
+	DeleteDeadLetter(ctx context.Context, deliveryId string) error
+}
+
+const (
+	DB_NAME                = "DeploymentManagerDB"
+	DELIVERY_COLLECTION    = "DELIVERY"
+	DEAD_LETTER_COLLECTION = "DEAD_LETTER"
+	DB_URL                 = "127.0.0.1:27017"
+)
+
+// Delivery is an event to be sent to a subscriber. EventID is the id of the
+// event in the event history, and NextAttempt and CreatedAt are unix times in seconds.
+type Delivery struct {
+	ID           bson.ObjectId `bson:"_id,omitempty"`
+	SubscriberID string
+	URL          string
+	EventType    string
+	EventID      string
+	Body         string
+	Attempts     int
+	NextAttempt  int64
+	LastError    string
+	CreatedAt    int64
+}
+
+type Executor struct{}
+
+var mgoDial Connection
+
+func init() {
+	mgoDial = MongoDial{}
+}
+
+// Try to connect with mongo db server.
+// if succeed to connect with mongo db server, return error as nil,
+// otherwise, return error.
+func connect(ctx context.Context, url string) (Session, error) {
+	// Create a MongoDB Session
+	session, err := mgoDial.Dial(ctx, url)
+
+	if err != nil {
+		return nil, ConvertMongoError(err, "")
+	}
+
+	return session, err
+}
+
+// close of mongodb session.
+func close(mgoSession Session) {
+	mgoSession.Close()
+}
+
+// Getting collection by name.
+// return mongodb Collection
+func getCollection(mgoSession Session, dbname string, collectionName string) Collection {
+	return mgoSession.DB(dbname).C(collectionName)
+}
+
+// convertToMap converts Delivery object into a map.
+func (delivery Delivery) convertToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":           delivery.ID.Hex(),
+		"subscriberId": delivery.SubscriberID,
+		"url":          delivery.URL,
+		"eventType":    delivery.EventType,
+		"eventId":      delivery.EventID,
+		"body":         delivery.Body,
+		"attempts":     delivery.Attempts,
+		"nextAttempt":  delivery.NextAttempt,
+		"lastError":    delivery.LastError,
+		"createdAt":    delivery.CreatedAt,
+	}
+}
+
+// AddDelivery inserts a new delivery to 'delivery' collection with a new id.
+// Ids are increasing, so that deliveries can be sent in the order they were queued.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) AddDelivery(ctx context.Context, delivery Delivery) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return nil, err
+	}
+	defer close(session)
+
+	delivery.ID = bson.NewObjectId()
+	err = getCollection(session, DB_NAME, DELIVERY_COLLECTION).Insert(delivery)
+	if err != nil {
+		return nil, ConvertMongoError(err)
+	}
+
+	result := delivery.convertToMap()
+	return result, err
+}
+
+// GetDeliveries returns all documents from 'delivery' collection sorted by id.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetDeliveries(ctx context.Context) ([]map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	return getDeliveries(ctx, DELIVERY_COLLECTION)
+}
+
+// GetDueSubscriberIds returns distinct subscribers of documents of 'delivery'
+// collection whose next attempt is not later than now, so that the queue is
+// read only for subscribers which have something to be sent.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetDueSubscriberIds(ctx context.Context, now int64) ([]string, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return nil, err
+	}
+	defer close(session)
+
+	subscriberIds := []string{}
+	query := bson.M{"nextattempt": bson.M{"$lte": now}}
+	err = getCollection(session, DB_NAME, DELIVERY_COLLECTION).Find(query).Distinct("subscriberid", &subscriberIds)
+	if err != nil {
+		return nil, ConvertMongoError(err)
+	}
+	return subscriberIds, err
+}
+
+// GetSubscriberDeliveries returns at most limit documents of 'delivery' collection
+// whose subscriber is specified by subscriberId parameter, sorted by id.
+// A limit of 0 returns all of them.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetSubscriberDeliveries(ctx context.Context, subscriberId string, limit int) ([]map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return nil, err
+	}
+	defer close(session)
+
+	deliveries := []Delivery{}
+	query := bson.M{"subscriberid": subscriberId}
+	err = getCollection(session, DB_NAME, DELIVERY_COLLECTION).Find(query).Sort("_id").Limit(limit).All(&deliveries)
+	if err != nil {
+		return nil, ConvertMongoError(err, subscriberId)
+	}
+
+	result := make([]map[string]interface{}, len(deliveries))
+	for i, delivery := range deliveries {
+		result[i] = delivery.convertToMap()
+	}
+	return result, err
+}
+
+// UpdateDelivery updates the number of attempts, the time of the next attempt
+// and the last error of a document of 'delivery' collection specified by deliveryId parameter.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) UpdateDelivery(ctx context.Context, deliveryId string, attempts int, nextAttempt int64, lastError string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	// Verify id is ObjectId, otherwise fail
+	if !bson.IsObjectIdHex(deliveryId) {
+		return errors.InvalidObjectId{deliveryId}
+	}
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return err
+	}
+	defer close(session)
+
+	query := bson.M{"_id": bson.ObjectIdHex(deliveryId)}
+	update := bson.M{"$set": bson.M{"attempts": attempts, "nextattempt": nextAttempt, "lasterror": lastError}}
+	err = getCollection(session, DB_NAME, DELIVERY_COLLECTION).Update(query, update)
+	if err != nil {
+		return ConvertMongoError(err, deliveryId)
+	}
+	return nil
+}
+
+// DeleteDelivery deletes a single document specified by deliveryId parameter
+// from 'delivery' collection.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) DeleteDelivery(ctx context.Context, deliveryId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	return deleteDelivery(ctx, DELIVERY_COLLECTION, deliveryId)
+}
+
+// DeleteSubscriberDeliveries deletes all documents of 'delivery' collection
+// whose subscriber is specified by subscriberId parameter.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) DeleteSubscriberDeliveries(ctx context.Context, subscriberId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return err
+	}
+	defer close(session)
+
+	_, err = getCollection(session, DB_NAME, DELIVERY_COLLECTION).RemoveAll(bson.M{"subscriberid": subscriberId})
+	if err != nil {
+		return ConvertMongoError(err, subscriberId)
+	}
+	return nil
+}
+
+// MoveToDeadLetter inserts a document of 'delivery' collection specified by deliveryId
+// parameter to 'dead letter' collection keeping its id, with the number of attempts
+// and the last error updated, and deletes it from 'delivery' collection.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) MoveToDeadLetter(ctx context.Context, deliveryId string, attempts int, lastError string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	// Verify id is ObjectId, otherwise fail
+	if !bson.IsObjectIdHex(deliveryId) {
+		return errors.InvalidObjectId{deliveryId}
+	}
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return err
+	}
+	defer close(session)
+
+	delivery := Delivery{}
+	query := bson.M{"_id": bson.ObjectIdHex(deliveryId)}
+	err = getCollection(session, DB_NAME, DELIVERY_COLLECTION).Find(query).One(&delivery)
+	if err != nil {
+		return ConvertMongoError(err, deliveryId)
+	}
+
+	delivery.Attempts, delivery.LastError = attempts, lastError
+	err = getCollection(session, DB_NAME, DEAD_LETTER_COLLECTION).Insert(delivery)
+	if err != nil {
+		return ConvertMongoError(err)
+	}
+
+	err = getCollection(session, DB_NAME, DELIVERY_COLLECTION).Remove(query)
+	if err != nil {
+		return ConvertMongoError(err, deliveryId)
+	}
+	return nil
+}
+
+// GetDeadLetter returns a single document specified by deliveryId parameter
+// from 'dead letter' collection.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetDeadLetter(ctx context.Context, deliveryId string) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	// Verify id is ObjectId, otherwise fail
+	if !bson.IsObjectIdHex(deliveryId) {
+		return nil, errors.InvalidObjectId{deliveryId}
+	}
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return nil, err
+	}
+	defer close(session)
+
+	delivery := Delivery{}
+	query := bson.M{"_id": bson.ObjectIdHex(deliveryId)}
+	err = getCollection(session, DB_NAME, DEAD_LETTER_COLLECTION).Find(query).One(&delivery)
+	if err != nil {
+		return nil, ConvertMongoError(err, deliveryId)
+	}
+
+	result := delivery.convertToMap()
+	return result, err
+}
+
+// GetDeadLetters returns all documents from 'dead letter' collection sorted by id.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetDeadLetters(ctx context.Context) ([]map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	return getDeliveries(ctx, DEAD_LETTER_COLLECTION)
+}
+
+// DeleteDeadLetter deletes a single document specified by deliveryId parameter
+// from 'dead letter' collection.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) DeleteDeadLetter(ctx context.Context, deliveryId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	return deleteDelivery(ctx, DEAD_LETTER_COLLECTION, deliveryId)
+}
+
+func getDeliveries(ctx context.Context, collection string) ([]map[string]interface{}, error) {
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return nil, err
+	}
+	defer close(session)
+
+	// An ObjectId starts with its creation time and ends with a counter,
+	// so that the order of ids is the order of insertions.
+	deliveries := []Delivery{}
+	err = getCollection(session, DB_NAME, collection).Find(nil).Sort("_id").All(&deliveries)
+	if err != nil {
+		return nil, ConvertMongoError(err)
+	}
+
+	result := make([]map[string]interface{}, len(deliveries))
+	for i, delivery := range deliveries {
+		result[i] = delivery.convertToMap()
+	}
+	return result, err
+}
+
+func deleteDelivery(ctx context.Context, collection string, deliveryId string) error {
+	// Verify id is ObjectId, otherwise fail
+	if !bson.IsObjectIdHex(deliveryId) {
+		return errors.InvalidObjectId{deliveryId}
+	}
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return err
+	}
+	defer close(session)
+
+	err = getCollection(session, DB_NAME, collection).Remove(bson.M{"_id": bson.ObjectIdHex(deliveryId)})
+	if err != nil {
+		return ConvertMongoError(err, deliveryId)
+	}
+	return nil
+}
@@ -0,0 +1,316 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package delivery
+
+import (
+	"commons/errors"
+	"context"
+	mgomocks "db/mongo/wrapper/mocks"
+	"github.com/golang/mock/gomock"
+	"gopkg.in/mgo.v2/bson"
+	"reflect"
+	"testing"
+)
+
+const (
+	validUrl   = "127.0.0.1:27017"
+	deliveryId = "000000000000000000000002"
+)
+
+var delivery = Delivery{
+	ID:           bson.ObjectIdHex(deliveryId),
+	SubscriberID: "subscriber",
+	URL:          "http://subscriber/events",
+	EventType:    "node",
+	Body:         `{"event":{}}`,
+	CreatedAt:    1514764800,
+}
+
+func TestCalledAddDelivery_ExpectInsertedWithNewId(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(DELIVERY_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Insert(gomock.Any()).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	newDelivery := delivery
+	newDelivery.ID = ""
+	res, err := Executor{}.AddDelivery(context.Background(), newDelivery)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if !bson.IsObjectIdHex(res["id"].(string)) || res["subscriberId"] != delivery.SubscriberID {
+		t.Errorf("Unexpected res: %v", res)
+	}
+}
+
+func TestCalledGetDeliveries_ExpectSortedById(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
+
+	older := delivery
+	older.ID = bson.ObjectIdHex("000000000000000000000001")
+	args := []Delivery{older, delivery}
+	expectedRes := []map[string]interface{}{older.convertToMap(), delivery.convertToMap()}
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(DELIVERY_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Find(nil).Return(queryMockObj),
+		queryMockObj.EXPECT().Sort("_id").Return(queryMockObj),
+		queryMockObj.EXPECT().All(gomock.Any()).SetArg(0, args).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	res, err := Executor{}.GetDeliveries(context.Background())
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(expectedRes, res) {
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
+	}
+}
+
+func TestCalledGetDueSubscriberIds_ExpectDistinctSubscribersOfDueDeliveries(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
+
+	args := []string{"subscriber"}
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(DELIVERY_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Find(bson.M{"nextattempt": bson.M{"$lte": int64(100)}}).Return(queryMockObj),
+		queryMockObj.EXPECT().Distinct("subscriberid", gomock.Any()).SetArg(1, args).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	res, err := Executor{}.GetDueSubscriberIds(context.Background(), 100)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(args, res) {
+		t.Errorf("Expected res: %v, actual res: %v", args, res)
+	}
+}
+
+func TestCalledGetSubscriberDeliveries_ExpectQueriedBySubscriberSortedById(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
+
+	args := []Delivery{delivery}
+	expectedRes := []map[string]interface{}{delivery.convertToMap()}
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(DELIVERY_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Find(bson.M{"subscriberid": "subscriber"}).Return(queryMockObj),
+		queryMockObj.EXPECT().Sort("_id").Return(queryMockObj),
+		queryMockObj.EXPECT().Limit(10).Return(queryMockObj),
+		queryMockObj.EXPECT().All(gomock.Any()).SetArg(0, args).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	res, err := Executor{}.GetSubscriberDeliveries(context.Background(), "subscriber", 10)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(expectedRes, res) {
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
+	}
+}
+
+func TestCalledUpdateDelivery_ExpectAttemptRecorded(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+
+	query := bson.M{"_id": delivery.ID}
+	update := bson.M{"$set": bson.M{"attempts": 2, "nextattempt": int64(1514764810), "lasterror": "500"}}
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(DELIVERY_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Update(query, update).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	err := Executor{}.UpdateDelivery(context.Background(), deliveryId, 2, 1514764810, "500")
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+}
+
+func TestCalledDeleteSubscriberDeliveries_ExpectAllOfSubscriberRemoved(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(DELIVERY_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().RemoveAll(bson.M{"subscriberid": "subscriber"}).Return(2, nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	err := Executor{}.DeleteSubscriberDeliveries(context.Background(), "subscriber")
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+}
+
+func TestCalledMoveToDeadLetter_ExpectMovedWithSameId(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
+
+	query := bson.M{"_id": delivery.ID}
+	deadLetter := delivery
+	deadLetter.Attempts, deadLetter.LastError = 8, "500"
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(DELIVERY_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
+		queryMockObj.EXPECT().One(gomock.Any()).SetArg(0, delivery).Return(nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(DEAD_LETTER_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Insert(deadLetter).Return(nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(DELIVERY_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Remove(query).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	err := Executor{}.MoveToDeadLetter(context.Background(), deliveryId, 8, "500")
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+}
+
+func TestCalledDeleteDeadLetter_ExpectRemovedById(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(DEAD_LETTER_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Remove(bson.M{"_id": delivery.ID}).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	err := Executor{}.DeleteDeadLetter(context.Background(), deliveryId)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+}
+
+func TestCalledWithInvalidObjectId_ExpectErrorReturn(t *testing.T) {
+	_, getErr := Executor{}.GetDeadLetter(context.Background(), "invalid")
+	deleteErr := Executor{}.DeleteDelivery(context.Background(), "invalid")
+	updateErr := Executor{}.UpdateDelivery(context.Background(), "invalid", 1, 0, "")
+	moveErr := Executor{}.MoveToDeadLetter(context.Background(), "invalid", 1, "")
+
+	for _, err := range []error{getErr, deleteErr, updateErr, moveErr} {
+		switch err.(type) {
+		default:
+			t.Errorf("Expected err: %s, actual err: %v", "InvalidObjectId", err)
+		case errors.InvalidObjectId:
+		}
+	}
+}
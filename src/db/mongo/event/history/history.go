@@ -0,0 +1,251 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package history
+
+import (
+	"commons/errors"
+	"commons/logger"
+	"context"
+	. "db/mongo/wrapper"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type Command interface {
+	// AddEvent stores an event sent to subscribers.
+	AddEvent(ctx context.Context, event Event) (map[string]interface{}, error)
+
+	// GetEvents returns stored events matched with filter, the latest first by default.
+	GetEvents(ctx context.Context, filter Filter) ([]map[string]interface{}, error)
+
+	// DeleteEventsBefore removes events which occurred before t and returns the number of them.
+	DeleteEventsBefore(ctx context.Context, t int64) (int, error)
+}
+
+const (
+	DB_NAME          = "DeploymentManagerDB"
+	EVENT_COLLECTION = "EVENT"
+	DB_URL           = "127.0.0.1:27017"
+)
+
+// Event is an event of a node, an app or resource usage. NodeID and AppID are
+// empty if the event does not concern a node or an app, EventIDs are ids of
+// node or app events which subscribers are registered to, SubscriberIDs are ids
+// of subscribers the event was meant for when it was stored, and Time is unix
+// time in seconds.
+type Event struct {
+	ID            bson.ObjectId `bson:"_id,omitempty"`
+	Type          string
+	Status        string
+	NodeID        string
+	AppID         string
+	EventIDs      []string
+	SubscriberIDs []string
+	Event         map[string]interface{}
+	Time          int64
+}
+
+// Filter selects events. Empty fields match all events, and lists which are
+// empty but not nil match no event. NodeIDs match NodeID or any of EventIDs.
+type Filter struct {
+	Type          string
+	Statuses      []string
+	NodeIDs       []string
+	AppID         string
+	EventIDs      []string
+	SubscriberIDs []string
+	From          int64  // unix time of the earliest event, or 0.
+	To            int64  // unix time of the latest event, or 0.
+	After         string // id of an event which returned events come after.
+	Limit         int    // maximum number of events, or 0.
+	Ascending     bool   // whether the earliest events come first.
+}
+
+type Executor struct{}
+
+var mgoDial Connection
+
+func init() {
+	mgoDial = MongoDial{}
+}
+
+// Try to connect with mongo db server.
+// if succeed to connect with mongo db server, return error as nil,
+// otherwise, return error.
+func connect(ctx context.Context, url string) (Session, error) {
+	// Create a MongoDB Session
+	session, err := mgoDial.Dial(ctx, url)
+
+	if err != nil {
+		return nil, ConvertMongoError(err, "")
+	}
+
+	return session, err
+}
+
+// close of mongodb session.
+func close(mgoSession Session) {
+	mgoSession.Close()
+}
+
+// Getting collection by name.
+// return mongodb Collection
+func getCollection(mgoSession Session, dbname string, collectionName string) Collection {
+	return mgoSession.DB(dbname).C(collectionName)
+}
+
+// convertToMap converts Event object into a map.
+func (event Event) convertToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"id":            event.ID.Hex(),
+		"type":          event.Type,
+		"status":        event.Status,
+		"nodeid":        event.NodeID,
+		"appid":         event.AppID,
+		"eventids":      event.EventIDs,
+		"subscriberids": event.SubscriberIDs,
+		"event":         event.Event,
+		"time":          event.Time,
+	}
+}
+
+// toQuery converts a filter into a query of 'event' collection.
+func (filter Filter) toQuery() (bson.M, error) {
+	query := bson.M{}
+	for key, value := range map[string]string{"type": filter.Type, "appid": filter.AppID} {
+		if len(value) != 0 {
+			query[key] = value
+		}
+	}
+	for key, values := range map[string][]string{"status": filter.Statuses, "eventids": filter.EventIDs,
+		"subscriberids": filter.SubscriberIDs} {
+		if values != nil {
+			query[key] = bson.M{"$in": values}
+		}
+	}
+	if filter.NodeIDs != nil {
+		// Events about several nodes, such as those of a group, keep ids of the nodes as ids of events.
+		query["$or"] = []bson.M{
+			{"nodeid": bson.M{"$in": filter.NodeIDs}},
+			{"eventids": bson.M{"$in": filter.NodeIDs}},
+		}
+	}
+	if len(filter.After) != 0 {
+		// Verify id is ObjectId, otherwise fail
+		if !bson.IsObjectIdHex(filter.After) {
+			return nil, errors.InvalidObjectId{filter.After}
+		}
+		query["_id"] = bson.M{"$gt": bson.ObjectIdHex(filter.After)}
+	}
+
+	period := bson.M{}
+	if filter.From != 0 {
+		period["$gte"] = filter.From
+	}
+	if filter.To != 0 {
+		period["$lte"] = filter.To
+	}
+	if len(period) != 0 {
+		query["time"] = period
+	}
+	return query, nil
+}
+
+// AddEvent inserts a new event to 'event' collection with a new id.
+// Ids are increasing, so that events are ordered as they occurred.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) AddEvent(ctx context.Context, event Event) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return nil, err
+	}
+	defer close(session)
+
+	event.ID = bson.NewObjectId()
+	err = getCollection(session, DB_NAME, EVENT_COLLECTION).Insert(event)
+	if err != nil {
+		return nil, ConvertMongoError(err)
+	}
+
+	result := event.convertToMap()
+	return result, err
+}
+
+// GetEvents returns documents of 'event' collection matched with filter
+// in descending order of id, or ascending order if filter is Ascending.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetEvents(ctx context.Context, filter Filter) ([]map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	selector, err := filter.toQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return nil, err
+	}
+	defer close(session)
+
+	order := "-_id"
+	if filter.Ascending {
+		order = "_id"
+	}
+
+	events := []Event{}
+	query := getCollection(session, DB_NAME, EVENT_COLLECTION).Find(selector).Sort(order)
+	if filter.Limit != 0 {
+		query = query.Limit(filter.Limit)
+	}
+	err = query.All(&events)
+	if err != nil {
+		return nil, ConvertMongoError(err)
+	}
+
+	result := make([]map[string]interface{}, len(events))
+	for i, event := range events {
+		result[i] = event.convertToMap()
+	}
+	return result, err
+}
+
+// DeleteEventsBefore deletes documents of 'event' collection which occurred before t.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) DeleteEventsBefore(ctx context.Context, t int64) (int, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return 0, err
+	}
+	defer close(session)
+
+	query := bson.M{"time": bson.M{"$lt": t}}
+	removed, err := getCollection(session, DB_NAME, EVENT_COLLECTION).RemoveAll(query)
+	if err != nil {
+		return 0, ConvertMongoError(err)
+	}
+	return removed, nil
+}
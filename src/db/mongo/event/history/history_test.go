@@ -0,0 +1,206 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package history
+
+import (
+	"commons/errors"
+	"context"
+	mgomocks "db/mongo/wrapper/mocks"
+	"github.com/golang/mock/gomock"
+	"gopkg.in/mgo.v2/bson"
+	"reflect"
+	"testing"
+)
+
+const (
+	validUrl = "127.0.0.1:27017"
+	eventId  = "000000000000000000000002"
+)
+
+var event = Event{
+	ID:     bson.ObjectIdHex(eventId),
+	Type:   "node",
+	Status: "disconnected",
+	NodeID: "nodeid",
+	Event:  map[string]interface{}{"id": "nodeid", "status": "disconnected"},
+	Time:   1514764800,
+}
+
+func TestCalledAddEvent_ExpectInsertedWithNewId(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(EVENT_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Insert(gomock.Any()).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	newEvent := event
+	newEvent.ID = ""
+	res, err := Executor{}.AddEvent(context.Background(), newEvent)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if !bson.IsObjectIdHex(res["id"].(string)) || res["nodeid"] != event.NodeID {
+		t.Errorf("Unexpected res: %v", res)
+	}
+}
+
+func TestCalledGetEvents_ExpectFilterConvertedToQuery(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
+
+	filter := Filter{Type: "node", Statuses: []string{"disconnected"}, NodeIDs: []string{"nodeid"}, To: 1514764800, Limit: 1}
+	query := bson.M{
+		"type":   "node",
+		"status": bson.M{"$in": []string{"disconnected"}},
+		"$or": []bson.M{
+			{"nodeid": bson.M{"$in": []string{"nodeid"}}},
+			{"eventids": bson.M{"$in": []string{"nodeid"}}},
+		},
+		"time": bson.M{"$lte": int64(1514764800)},
+	}
+	expectedRes := []map[string]interface{}{event.convertToMap()}
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(EVENT_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
+		queryMockObj.EXPECT().Sort("-_id").Return(queryMockObj),
+		queryMockObj.EXPECT().Limit(1).Return(queryMockObj),
+		queryMockObj.EXPECT().All(gomock.Any()).SetArg(0, []Event{event}).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	res, err := Executor{}.GetEvents(context.Background(), filter)
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(expectedRes, res) {
+		t.Errorf("Expected res: %v, actual res: %v", expectedRes, res)
+	}
+}
+
+func TestCalledGetEventsAfterCursor_ExpectLaterEventsInAscendingOrder(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
+
+	filter := Filter{SubscriberIDs: []string{"subscriberid"}, After: "000000000000000000000001", Ascending: true}
+	query := bson.M{
+		"subscriberids": bson.M{"$in": []string{"subscriberid"}},
+		"_id":           bson.M{"$gt": bson.ObjectIdHex("000000000000000000000001")},
+	}
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(EVENT_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
+		queryMockObj.EXPECT().Sort("_id").Return(queryMockObj),
+		queryMockObj.EXPECT().All(gomock.Any()).SetArg(0, []Event{event}).Return(nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	res, err := Executor{}.GetEvents(context.Background(), filter)
+
+	if err != nil || len(res) != 1 {
+		t.Errorf("Unexpected result: %v, %v", res, err)
+	}
+}
+
+func TestCalledGetEventsAfterInvalidCursor_ExpectInvalidObjectIdReturn(t *testing.T) {
+	_, err := Executor{}.GetEvents(context.Background(), Filter{After: "cursor"})
+
+	if _, ok := err.(errors.InvalidObjectId); !ok {
+		t.Errorf("Expected err: InvalidObjectId, actual err: %v", err)
+	}
+}
+
+func TestCalledDeleteEventsBefore_ExpectOlderEventsRemoved(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+	dbMockObj := mgomocks.NewMockDatabase(mockCtrl)
+	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
+
+	query := bson.M{"time": bson.M{"$lt": int64(1514764800)}}
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
+		dbMockObj.EXPECT().C(EVENT_COLLECTION).Return(collectionMockObj),
+		collectionMockObj.EXPECT().RemoveAll(query).Return(3, nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	removed, err := Executor{}.DeleteEventsBefore(context.Background(), 1514764800)
+
+	if removed != 3 || err != nil {
+		t.Errorf("Unexpected result: %d, %v", removed, err)
+	}
+}
+
+func TestCalledGetEventsWhenDBFailed_ExpectErrorReturn(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(nil, errors.DBConnectionError{})
+
+	mgoDial = connectionMockObj
+
+	res, err := Executor{}.GetEvents(context.Background(), Filter{})
+
+	if res != nil || err == nil {
+		t.Errorf("Unexpected result: %v, %v", res, err)
+	}
+}
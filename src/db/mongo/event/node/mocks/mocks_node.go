@@ -22,6 +22,7 @@
 package mocks
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -50,50 +51,50 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // AddEvent mocks base method
-func (m *MockCommand) AddEvent(id, subscriberId string) error {
-	ret := m.ctrl.Call(m, "AddEvent", id, subscriberId)
+func (m *MockCommand) AddEvent(ctx context.Context, id, subscriberId string) error {
+	ret := m.ctrl.Call(m, "AddEvent", ctx, id, subscriberId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddEvent indicates an expected call of AddEvent
-func (mr *MockCommandMockRecorder) AddEvent(id, subscriberId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvent", reflect.TypeOf((*MockCommand)(nil).AddEvent), id, subscriberId)
+func (mr *MockCommandMockRecorder) AddEvent(ctx, id, subscriberId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvent", reflect.TypeOf((*MockCommand)(nil).AddEvent), ctx, id, subscriberId)
 }
 
 // GetEvent mocks base method
-func (m *MockCommand) GetEvent(id string) (map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetEvent", id)
+func (m *MockCommand) GetEvent(ctx context.Context, id string) (map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetEvent", ctx, id)
 	ret0, _ := ret[0].(map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetEvent indicates an expected call of GetEvent
-func (mr *MockCommandMockRecorder) GetEvent(id interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvent", reflect.TypeOf((*MockCommand)(nil).GetEvent), id)
+func (mr *MockCommandMockRecorder) GetEvent(ctx, id interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvent", reflect.TypeOf((*MockCommand)(nil).GetEvent), ctx, id)
 }
 
 // DeleteEvent mocks base method
-func (m *MockCommand) DeleteEvent(id string) error {
-	ret := m.ctrl.Call(m, "DeleteEvent", id)
+func (m *MockCommand) DeleteEvent(ctx context.Context, id string) error {
+	ret := m.ctrl.Call(m, "DeleteEvent", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteEvent indicates an expected call of DeleteEvent
-func (mr *MockCommandMockRecorder) DeleteEvent(id interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockCommand)(nil).DeleteEvent), id)
+func (mr *MockCommandMockRecorder) DeleteEvent(ctx, id interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockCommand)(nil).DeleteEvent), ctx, id)
 }
 
 // UnRegisterEvent mocks base method
-func (m *MockCommand) UnRegisterEvent(id, subscriberId string) error {
-	ret := m.ctrl.Call(m, "UnRegisterEvent", id, subscriberId)
+func (m *MockCommand) UnRegisterEvent(ctx context.Context, id, subscriberId string) error {
+	ret := m.ctrl.Call(m, "UnRegisterEvent", ctx, id, subscriberId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UnRegisterEvent indicates an expected call of UnRegisterEvent
-func (mr *MockCommandMockRecorder) UnRegisterEvent(id, subscriberId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnRegisterEvent", reflect.TypeOf((*MockCommand)(nil).UnRegisterEvent), id, subscriberId)
+func (mr *MockCommandMockRecorder) UnRegisterEvent(ctx, id, subscriberId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnRegisterEvent", reflect.TypeOf((*MockCommand)(nil).UnRegisterEvent), ctx, id, subscriberId)
 }
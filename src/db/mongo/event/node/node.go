@@ -20,15 +20,16 @@ package node
 import (
 	"commons/errors"
 	"commons/logger"
+	"context"
 	. "db/mongo/wrapper"
 	"gopkg.in/mgo.v2/bson"
 )
 
 type Command interface {
-	AddEvent(id string, subscriberId string) error
-	GetEvent(id string) (map[string]interface{}, error)
-	DeleteEvent(id string) error
-	UnRegisterEvent(id string, subscriberId string) error
+	AddEvent(ctx context.Context, id string, subscriberId string) error
+	GetEvent(ctx context.Context, id string) (map[string]interface{}, error)
+	DeleteEvent(ctx context.Context, id string) error
+	UnRegisterEvent(ctx context.Context, id string, subscriberId string) error
 }
 
 const (
@@ -54,9 +55,9 @@ func init() {
 // Try to connect with mongo db server.
 // if succeed to connect with mongo db server, return error as nil,
 // otherwise, return error.
-func connect(url string) (Session, error) {
+func connect(ctx context.Context, url string) (Session, error) {
 	// Create a MongoDB Session
-	session, err := mgoDial.Dial(url)
+	session, err := mgoDial.Dial(ctx, url)
 
 	if err != nil {
 		return nil, ConvertMongoError(err, "")
@@ -85,16 +86,16 @@ func (event NodeEvent) convertToMap() map[string]interface{} {
 	}
 }
 
-func (Executor) AddEvent(eventId string, subscriberId string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) AddEvent(ctx context.Context, eventId string, subscriberId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	if len(eventId) == 0 {
 		err := errors.InvalidParam{"Invalid param error : eventId is empty."}
 		return err
 	}
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -136,11 +137,11 @@ func (Executor) AddEvent(eventId string, subscriberId string) error {
 	return nil
 }
 
-func (Executor) GetEvent(id string) (map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetEvent(ctx context.Context, id string) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -157,16 +158,16 @@ func (Executor) GetEvent(id string) (map[string]interface{}, error) {
 	return result, err
 }
 
-func (Executor) DeleteEvent(id string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) DeleteEvent(ctx context.Context, id string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	if len(id) == 0 {
 		err := errors.InvalidParam{"Invalid param error : nodeEventId is empty."}
 		return err
 	}
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -181,11 +182,11 @@ func (Executor) DeleteEvent(id string) error {
 	return err
 }
 
-func (Executor) UnRegisterEvent(id string, subscriberId string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) UnRegisterEvent(ctx context.Context, id string, subscriberId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -18,6 +18,7 @@ package node
 
 import (
 	errors "commons/errors"
+	"context"
 	mgomocks "db/mongo/wrapper/mocks"
 	"github.com/golang/mock/gomock"
 	"gopkg.in/mgo.v2"
@@ -49,11 +50,11 @@ func TestCalledConnectWithEmptyURL_ExpectErrorReturn(t *testing.T) {
 	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(inDB_URL).Return(&dummySession, connectionError),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), inDB_URL).Return(&dummySession, connectionError),
 	)
 	mgoDial = connectionMockObj
 
-	_, err := connect(inDB_URL)
+	_, err := connect(context.Background(), inDB_URL)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "UnknownError", "nil")
@@ -73,11 +74,11 @@ func TestCalledConnectWithDB_URL_ExpectSuccess(t *testing.T) {
 	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(DB_URL).Return(&dummySession, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), DB_URL).Return(&dummySession, nil),
 	)
 	mgoDial = connectionMockObj
 
-	_, err := connect(DB_URL)
+	_, err := connect(context.Background(), DB_URL)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -129,7 +130,7 @@ func TestCalledAddEvent_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(DB_URL).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), DB_URL).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(DB_NAME).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -143,7 +144,7 @@ func TestCalledAddEvent_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	err := executor.AddEvent(eventId, subsId1)
+	err := executor.AddEvent(context.Background(), eventId, subsId1)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -163,7 +164,7 @@ func TestCalledAddEventWhenAlreadyExistsInDB_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(DB_URL).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), DB_URL).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(DB_NAME).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -177,7 +178,7 @@ func TestCalledAddEventWhenAlreadyExistsInDB_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	err := executor.AddEvent(eventId, subsId1)
+	err := executor.AddEvent(context.Background(), eventId, subsId1)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -202,7 +203,7 @@ func TestCalledGetEvent_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(DB_URL).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), DB_URL).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(DB_NAME).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -213,7 +214,7 @@ func TestCalledGetEvent_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	res, err := executor.GetEvent(eventId)
+	res, err := executor.GetEvent(context.Background(), eventId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -236,7 +237,7 @@ func TestCalledDeleteEvent_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(DB_URL).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), DB_URL).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(DB_NAME).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Remove(query).Return(nil),
@@ -246,7 +247,7 @@ func TestCalledDeleteEvent_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	err := executor.DeleteEvent(eventId)
+	err := executor.DeleteEvent(context.Background(), eventId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -272,7 +273,7 @@ func TestCalledUnRegisterEvent_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(DB_URL).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), DB_URL).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(DB_NAME).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -286,7 +287,7 @@ func TestCalledUnRegisterEvent_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	err := executor.UnRegisterEvent(eventId, subsId1)
+	err := executor.UnRegisterEvent(context.Background(), eventId, subsId1)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -22,6 +22,7 @@
 package mocks
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -50,51 +51,124 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // AddSubscriber mocks base method
-func (m *MockCommand) AddSubscriber(id, eventType, url string, status, eventId []string, queries map[string][]string) error {
-	ret := m.ctrl.Call(m, "AddSubscriber", id, eventType, url, status, eventId, queries)
+func (m *MockCommand) AddSubscriber(ctx context.Context, id, eventType, url, secret, filter string, status, eventId []string, queries map[string][]string, expiresAt int64) error {
+	ret := m.ctrl.Call(m, "AddSubscriber", ctx, id, eventType, url, secret, filter, status, eventId, queries, expiresAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddSubscriber indicates an expected call of AddSubscriber
-func (mr *MockCommandMockRecorder) AddSubscriber(id, eventType, url, status, eventId, queries interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscriber", reflect.TypeOf((*MockCommand)(nil).AddSubscriber), id, eventType, url, status, eventId, queries)
+func (mr *MockCommandMockRecorder) AddSubscriber(ctx, id, eventType, url, secret, filter, status, eventId, queries, expiresAt interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscriber", reflect.TypeOf((*MockCommand)(nil).AddSubscriber), ctx, id, eventType, url, secret, filter, status, eventId, queries, expiresAt)
 }
 
 // GetSubscribers mocks base method
-func (m *MockCommand) GetSubscribers() ([]map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetSubscribers")
+func (m *MockCommand) GetSubscribers(ctx context.Context) ([]map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetSubscribers", ctx)
 	ret0, _ := ret[0].([]map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSubscribers indicates an expected call of GetSubscribers
-func (mr *MockCommandMockRecorder) GetSubscribers() *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribers", reflect.TypeOf((*MockCommand)(nil).GetSubscribers))
+func (mr *MockCommandMockRecorder) GetSubscribers(ctx interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribers", reflect.TypeOf((*MockCommand)(nil).GetSubscribers), ctx)
+}
+
+// GetSubscribersByType mocks base method
+func (m *MockCommand) GetSubscribersByType(ctx context.Context, eventType string) ([]map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetSubscribersByType", ctx, eventType)
+	ret0, _ := ret[0].([]map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscribersByType indicates an expected call of GetSubscribersByType
+func (mr *MockCommandMockRecorder) GetSubscribersByType(ctx, eventType interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribersByType", reflect.TypeOf((*MockCommand)(nil).GetSubscribersByType), ctx, eventType)
 }
 
 // GetSubscriber mocks base method
-func (m *MockCommand) GetSubscriber(id string) (map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetSubscriber", id)
+func (m *MockCommand) GetSubscriber(ctx context.Context, id string) (map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetSubscriber", ctx, id)
 	ret0, _ := ret[0].(map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSubscriber indicates an expected call of GetSubscriber
-func (mr *MockCommandMockRecorder) GetSubscriber(id interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriber", reflect.TypeOf((*MockCommand)(nil).GetSubscriber), id)
+func (mr *MockCommandMockRecorder) GetSubscriber(ctx, id interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriber", reflect.TypeOf((*MockCommand)(nil).GetSubscriber), ctx, id)
 }
 
 // DeleteSubscriber mocks base method
-func (m *MockCommand) DeleteSubscriber(id string) error {
-	ret := m.ctrl.Call(m, "DeleteSubscriber", id)
+func (m *MockCommand) DeleteSubscriber(ctx context.Context, id string) error {
+	ret := m.ctrl.Call(m, "DeleteSubscriber", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteSubscriber indicates an expected call of DeleteSubscriber
-func (mr *MockCommandMockRecorder) DeleteSubscriber(id interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscriber", reflect.TypeOf((*MockCommand)(nil).DeleteSubscriber), id)
+func (mr *MockCommandMockRecorder) DeleteSubscriber(ctx, id interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscriber", reflect.TypeOf((*MockCommand)(nil).DeleteSubscriber), ctx, id)
+}
+
+// UpdateSecret mocks base method
+func (m *MockCommand) UpdateSecret(ctx context.Context, id, secret string) error {
+	ret := m.ctrl.Call(m, "UpdateSecret", ctx, id, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecret indicates an expected call of UpdateSecret
+func (mr *MockCommandMockRecorder) UpdateSecret(ctx, id, secret interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockCommand)(nil).UpdateSecret), ctx, id, secret)
+}
+
+// UpdateSubscriber mocks base method
+func (m *MockCommand) UpdateSubscriber(ctx context.Context, id, url string, status []string, expiresAt int64) error {
+	ret := m.ctrl.Call(m, "UpdateSubscriber", ctx, id, url, status, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubscriber indicates an expected call of UpdateSubscriber
+func (mr *MockCommandMockRecorder) UpdateSubscriber(ctx, id, url, status, expiresAt interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriber", reflect.TypeOf((*MockCommand)(nil).UpdateSubscriber), ctx, id, url, status, expiresAt)
+}
+
+// SetDeliveryState mocks base method
+func (m *MockCommand) SetDeliveryState(ctx context.Context, id string, failures int, deactivated bool) error {
+	ret := m.ctrl.Call(m, "SetDeliveryState", ctx, id, failures, deactivated)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDeliveryState indicates an expected call of SetDeliveryState
+func (mr *MockCommandMockRecorder) SetDeliveryState(ctx, id, failures, deactivated interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeliveryState", reflect.TypeOf((*MockCommand)(nil).SetDeliveryState), ctx, id, failures, deactivated)
+}
+
+// SetCursor mocks base method
+func (m *MockCommand) SetCursor(ctx context.Context, id, cursor string) error {
+	ret := m.ctrl.Call(m, "SetCursor", ctx, id, cursor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCursor indicates an expected call of SetCursor
+func (mr *MockCommandMockRecorder) SetCursor(ctx, id, cursor interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCursor", reflect.TypeOf((*MockCommand)(nil).SetCursor), ctx, id, cursor)
+}
+
+// SetFilter mocks base method
+func (m *MockCommand) SetFilter(ctx context.Context, id, filter string) error {
+	ret := m.ctrl.Call(m, "SetFilter", ctx, id, filter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFilter indicates an expected call of SetFilter
+func (mr *MockCommandMockRecorder) SetFilter(ctx, id, filter interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFilter", reflect.TypeOf((*MockCommand)(nil).SetFilter), ctx, id, filter)
 }
@@ -20,16 +20,36 @@ package subscriber
 import (
 	"commons/errors"
 	"commons/logger"
+	"context"
 	. "db/mongo/wrapper"
 	"gopkg.in/mgo.v2/bson"
 )
 
 type Command interface {
 	// AddSubscriber insert new Subscriber.
-	AddSubscriber(id, eventType, url string, status, eventId []string, queries map[string][]string) error
-	GetSubscribers() ([]map[string]interface{}, error)
-	GetSubscriber(id string) (map[string]interface{}, error)
-	DeleteSubscriber(id string) error
+	AddSubscriber(ctx context.Context, id, eventType, url, secret, filter string, status, eventId []string, queries map[string][]string, expiresAt int64) error
+	GetSubscribers(ctx context.Context) ([]map[string]interface{}, error)
+
+	// GetSubscribersByType returns subscribers of events of eventType.
+	GetSubscribersByType(ctx context.Context, eventType string) ([]map[string]interface{}, error)
+	GetSubscriber(ctx context.Context, id string) (map[string]interface{}, error)
+	DeleteSubscriber(ctx context.Context, id string) error
+
+	// UpdateSecret replaces the secret which events to a subscriber are signed with.
+	UpdateSecret(ctx context.Context, id, secret string) error
+
+	// UpdateSubscriber replaces the url, the status filter and the expiry of a subscriber.
+	UpdateSubscriber(ctx context.Context, id, url string, status []string, expiresAt int64) error
+
+	// SetDeliveryState sets the number of consecutive failed deliveries to a subscriber
+	// and whether events are no longer sent to it.
+	SetDeliveryState(ctx context.Context, id string, failures int, deactivated bool) error
+
+	// SetCursor records the id of the latest event delivered to a subscriber.
+	SetCursor(ctx context.Context, id, cursor string) error
+
+	// SetFilter sets the filter expression events to a subscriber must match.
+	SetFilter(ctx context.Context, id, filter string) error
 }
 
 const (
@@ -39,12 +59,18 @@ const (
 )
 
 type Subscriber struct {
-	ID      string
-	Type    string
-	URL     string
-	Status  []string
-	EventId []string
-	Query   map[string][]string
+	ID          string
+	Type        string
+	URL         string
+	Secret      string
+	Status      []string
+	EventId     []string
+	Query       map[string][]string
+	ExpiresAt   int64 // unix time after which the subscriber is removed, or 0.
+	Failures    int   // consecutive deliveries moved to dead letters.
+	Deactivated bool
+	Cursor      string // id of the latest event delivered in the event history.
+	Filter      string // expression over fields of events, or empty to match all.
 }
 
 type Executor struct {
@@ -59,9 +85,9 @@ func init() {
 // Try to connect with mongo db server.
 // if succeed to connect with mongo db server, return error as nil,
 // otherwise, return error.
-func connect(url string) (Session, error) {
+func connect(ctx context.Context, url string) (Session, error) {
 	// Create a MongoDB Session
-	session, err := mgoDial.Dial(url)
+	session, err := mgoDial.Dial(ctx, url)
 
 	if err != nil {
 		return nil, ConvertMongoError(err, "")
@@ -84,20 +110,30 @@ func getCollection(mgoSession Session, dbname string, collectionName string) Col
 // convertToMap converts Subscriber object into a map.
 func (subscriber Subscriber) convertToMap() map[string]interface{} {
 	return map[string]interface{}{
-		"id":      subscriber.ID,
-		"type":    subscriber.Type,
-		"url":     subscriber.URL,
-		"status":  subscriber.Status,
-		"eventid": subscriber.EventId,
-		"query":   subscriber.Query,
+		"id":          subscriber.ID,
+		"type":        subscriber.Type,
+		"url":         subscriber.URL,
+		"secret":      subscriber.Secret,
+		"status":      subscriber.Status,
+		"eventid":     subscriber.EventId,
+		"query":       subscriber.Query,
+		"expiresat":   subscriber.ExpiresAt,
+		"failures":    subscriber.Failures,
+		"deactivated": subscriber.Deactivated,
+		"cursor":      subscriber.Cursor,
+		"filter":      subscriber.Filter,
 	}
 }
 
-func (Executor) AddSubscriber(id, eventType, url string, status, eventId []string, queries map[string][]string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+// AddSubscriber inserts a new subscriber with the filter of its events and its expiry,
+// or updates event ids, the secret and the filter of a subscriber specified by id
+// parameter if it already exists. The expiry of an existing subscriber is replaced
+// unless expiresAt is 0.
+func (Executor) AddSubscriber(ctx context.Context, id, eventType, url, secret, filter string, status, eventId []string, queries map[string][]string, expiresAt int64) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -113,12 +149,15 @@ func (Executor) AddSubscriber(id, eventType, url string, status, eventId []strin
 			return err
 		case errors.NotFound:
 			subscriber = Subscriber{
-				ID:      id,
-				Type:    eventType,
-				URL:     url,
-				Status:  status,
-				EventId: eventId,
-				Query:   queries,
+				ID:        id,
+				Type:      eventType,
+				URL:       url,
+				Secret:    secret,
+				Status:    status,
+				EventId:   eventId,
+				Query:     queries,
+				ExpiresAt: expiresAt,
+				Filter:    filter,
 			}
 
 			err = getCollection(session, DB_NAME, SUBSCRIBER_COLLECTION).Insert(subscriber)
@@ -129,7 +168,11 @@ func (Executor) AddSubscriber(id, eventType, url string, status, eventId []strin
 		}
 	}
 
-	update := bson.M{"$set": bson.M{"eventid": eventId}}
+	fields := bson.M{"eventid": eventId, "secret": secret, "filter": filter}
+	if expiresAt != 0 {
+		fields["expiresat"] = expiresAt
+	}
+	update := bson.M{"$set": fields}
 	err = getCollection(session, DB_NAME, SUBSCRIBER_COLLECTION).Update(query, update)
 	if err != nil {
 		return ConvertMongoError(err, "")
@@ -137,11 +180,11 @@ func (Executor) AddSubscriber(id, eventType, url string, status, eventId []strin
 	return nil
 }
 
-func (Executor) GetSubscribers() ([]map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetSubscribers(ctx context.Context) ([]map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -160,11 +203,37 @@ func (Executor) GetSubscribers() ([]map[string]interface{}, error) {
 	return result, err
 }
 
-func (Executor) GetSubscriber(id string) (map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+// GetSubscribersByType returns subscribers of events of eventType.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetSubscribersByType(ctx context.Context, eventType string) ([]map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return nil, err
+	}
+	defer close(session)
+
+	subscribers := []Subscriber{}
+	err = getCollection(session, DB_NAME, SUBSCRIBER_COLLECTION).Find(bson.M{"type": eventType}).All(&subscribers)
+	if err != nil {
+		return nil, ConvertMongoError(err)
+	}
+
+	result := make([]map[string]interface{}, len(subscribers))
+	for i, subscriber := range subscribers {
+		result[i] = subscriber.convertToMap()
+	}
+	return result, err
+}
+
+func (Executor) GetSubscriber(ctx context.Context, id string) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -181,16 +250,16 @@ func (Executor) GetSubscriber(id string) (map[string]interface{}, error) {
 	return result, err
 }
 
-func (Executor) DeleteSubscriber(id string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) DeleteSubscriber(ctx context.Context, id string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	if len(id) == 0 {
 		err := errors.InvalidParam{"Invalid param error : subscriberId is empty."}
 		return err
 	}
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -204,3 +273,116 @@ func (Executor) DeleteSubscriber(id string) error {
 
 	return err
 }
+
+// UpdateSecret replaces the secret of a subscriber specified by id parameter.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) UpdateSecret(ctx context.Context, id, secret string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return err
+	}
+	defer close(session)
+
+	query := bson.M{"id": id}
+	update := bson.M{"$set": bson.M{"secret": secret}}
+	err = getCollection(session, DB_NAME, SUBSCRIBER_COLLECTION).Update(query, update)
+	if err != nil {
+		return ConvertMongoError(err, id)
+	}
+	return nil
+}
+
+// UpdateSubscriber replaces the url, the status filter and the expiry of a subscriber
+// specified by id parameter.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) UpdateSubscriber(ctx context.Context, id, url string, status []string, expiresAt int64) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return err
+	}
+	defer close(session)
+
+	query := bson.M{"id": id}
+	update := bson.M{"$set": bson.M{"url": url, "status": status, "expiresat": expiresAt}}
+	err = getCollection(session, DB_NAME, SUBSCRIBER_COLLECTION).Update(query, update)
+	if err != nil {
+		return ConvertMongoError(err, id)
+	}
+	return nil
+}
+
+// SetDeliveryState sets the number of consecutive failed deliveries and whether
+// a subscriber specified by id parameter is deactivated.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) SetDeliveryState(ctx context.Context, id string, failures int, deactivated bool) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return err
+	}
+	defer close(session)
+
+	query := bson.M{"id": id}
+	update := bson.M{"$set": bson.M{"failures": failures, "deactivated": deactivated}}
+	err = getCollection(session, DB_NAME, SUBSCRIBER_COLLECTION).Update(query, update)
+	if err != nil {
+		return ConvertMongoError(err, id)
+	}
+	return nil
+}
+
+// SetCursor sets the id of the latest event delivered to a subscriber
+// specified by id parameter.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) SetCursor(ctx context.Context, id, cursor string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return err
+	}
+	defer close(session)
+
+	query := bson.M{"id": id}
+	update := bson.M{"$set": bson.M{"cursor": cursor}}
+	err = getCollection(session, DB_NAME, SUBSCRIBER_COLLECTION).Update(query, update)
+	if err != nil {
+		return ConvertMongoError(err, id)
+	}
+	return nil
+}
+
+// SetFilter sets the filter expression of a subscriber specified by id parameter.
+// If successful, this function returns an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) SetFilter(ctx context.Context, id, filter string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := connect(ctx, DB_URL)
+	if err != nil {
+		return err
+	}
+	defer close(session)
+
+	query := bson.M{"id": id}
+	update := bson.M{"$set": bson.M{"filter": filter}}
+	err = getCollection(session, DB_NAME, SUBSCRIBER_COLLECTION).Update(query, update)
+	if err != nil {
+		return ConvertMongoError(err, id)
+	}
+	return nil
+}
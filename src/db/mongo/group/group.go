@@ -19,6 +19,7 @@ package group
 import (
 	"commons/errors"
 	"commons/logger"
+	"context"
 	nodeDB "db/mongo/node"
 	. "db/mongo/wrapper"
 
@@ -27,28 +28,28 @@ import (
 
 type Command interface {
 	// CreateGroup insert new Group.
-	CreateGroup(name string) (map[string]interface{}, error)
+	CreateGroup(ctx context.Context, name string) (map[string]interface{}, error)
 
 	// GetGroup returns single document from db related to group.
-	GetGroup(groupId string) (map[string]interface{}, error)
+	GetGroup(ctx context.Context, groupId string) (map[string]interface{}, error)
 
 	// GetGroups returns all documents from db related to group.
-	GetGroups() ([]map[string]interface{}, error)
+	GetGroups(ctx context.Context) ([]map[string]interface{}, error)
 
 	// GetGroupMembers returns all nodes who belong to the target group.
-	GetGroupMembers(groupId string) ([]map[string]interface{}, error)
+	GetGroupMembers(ctx context.Context, groupId string) ([]map[string]interface{}, error)
 
 	// GetGroupMembersByAppID returns all nodes including specific app on the target group.
-	GetGroupMembersByAppID(groupId string, appId string) ([]map[string]interface{}, error)
+	GetGroupMembersByAppID(ctx context.Context, groupId string, appId string) ([]map[string]interface{}, error)
 
 	// JoinGroup add specific node to the target group.
-	JoinGroup(groupId string, nodeId string) error
+	JoinGroup(ctx context.Context, groupId string, nodeId string) error
 
 	// LeaveGroup delete specific node from the target group.
-	LeaveGroup(groupId string, nodeId string) error
+	LeaveGroup(ctx context.Context, groupId string, nodeId string) error
 
 	// DeleteGroup delete single document from db related to group.
-	DeleteGroup(groupId string) error
+	DeleteGroup(ctx context.Context, groupId string) error
 }
 
 const (
@@ -76,9 +77,9 @@ func init() {
 // Try to connect with mongo db server.
 // if succeed to connect with mongo db server, return error as nil,
 // otherwise, return error.
-func connect(url string) (Session, error) {
+func connect(ctx context.Context, url string) (Session, error) {
 	// Create a MongoDB Session
-	session, err := mgoDial.Dial(url)
+	session, err := mgoDial.Dial(ctx, url)
 
 	if err != nil {
 		return nil, ConvertMongoError(err, "")
@@ -110,11 +111,11 @@ func (group Group) convertToMap() map[string]interface{} {
 // CreateGroup inserts new Group to 'group' collection.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) CreateGroup(name string) (map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) CreateGroup(ctx context.Context, name string) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -138,11 +139,11 @@ func (Executor) CreateGroup(name string) (map[string]interface{}, error) {
 // GetGroup returns single document specified by groupId parameter.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) GetGroup(groupId string) (map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetGroup(ctx context.Context, groupId string) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -168,11 +169,11 @@ func (Executor) GetGroup(groupId string) (map[string]interface{}, error) {
 // GetGroups returns all documents from 'group' collection.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) GetGroups() ([]map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetGroups(ctx context.Context) ([]map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -194,11 +195,11 @@ func (Executor) GetGroups() ([]map[string]interface{}, error) {
 // JoinGroup adds the specific node to a list of group members.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) JoinGroup(groupId string, nodeId string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) JoinGroup(ctx context.Context, groupId string, nodeId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -222,11 +223,11 @@ func (Executor) JoinGroup(groupId string, nodeId string) error {
 // LeaveGroup deletes the specific node from a list of group members.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) LeaveGroup(groupId string, nodeId string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) LeaveGroup(ctx context.Context, groupId string, nodeId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -250,9 +251,9 @@ func (Executor) LeaveGroup(groupId string, nodeId string) error {
 // GetGroupMembers returns all nodes who belong to the target group.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (client Executor) GetGroupMembers(groupId string) ([]map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (client Executor) GetGroupMembers(ctx context.Context, groupId string) ([]map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Verify id is ObjectId, otherwise fail
 	if !bson.IsObjectIdHex(groupId) {
@@ -260,7 +261,7 @@ func (client Executor) GetGroupMembers(groupId string) ([]map[string]interface{}
 		return nil, err
 	}
 
-	group, err := client.GetGroup(groupId)
+	group, err := client.GetGroup(ctx, groupId)
 	if err != nil {
 		return nil, err
 	}
@@ -268,7 +269,7 @@ func (client Executor) GetGroupMembers(groupId string) ([]map[string]interface{}
 	result := make([]map[string]interface{}, len(group["members"].([]string)))
 	for i, nodeId := range group["members"].([]string) {
 		var node map[string]interface{}
-		node, err := nodeExecutor.GetNode(nodeId)
+		node, err := nodeExecutor.GetNode(ctx, nodeId)
 		if err != nil {
 			return nil, err
 		}
@@ -281,9 +282,9 @@ func (client Executor) GetGroupMembers(groupId string) ([]map[string]interface{}
 // by the given appid on the target group.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (client Executor) GetGroupMembersByAppID(groupId string, appId string) ([]map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (client Executor) GetGroupMembersByAppID(ctx context.Context, groupId string, appId string) ([]map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
 	// Verify id is ObjectId, otherwise fail
 	if !bson.IsObjectIdHex(groupId) {
@@ -291,7 +292,7 @@ func (client Executor) GetGroupMembersByAppID(groupId string, appId string) ([]m
 		return nil, err
 	}
 
-	group, err := client.GetGroup(groupId)
+	group, err := client.GetGroup(ctx, groupId)
 	if err != nil {
 		return nil, err
 	}
@@ -299,7 +300,7 @@ func (client Executor) GetGroupMembersByAppID(groupId string, appId string) ([]m
 	result := make([]map[string]interface{}, 0)
 	for _, nodeId := range group["members"].([]string) {
 		var node map[string]interface{}
-		node, err := nodeExecutor.GetNodeByAppID(nodeId, appId)
+		node, err := nodeExecutor.GetNodeByAppID(ctx, nodeId, appId)
 		if err == nil {
 			result = append(result, node)
 		}
@@ -311,11 +312,11 @@ func (client Executor) GetGroupMembersByAppID(groupId string, appId string) ([]m
 // DeleteGroup deletes single document specified by groupId parameter.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) DeleteGroup(groupId string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) DeleteGroup(ctx context.Context, groupId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
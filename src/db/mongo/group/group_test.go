@@ -2,6 +2,7 @@ package group
 
 import (
 	errors "commons/errors"
+	"context"
 	nodedbmocks "db/mongo/node/mocks"
 	mgomocks "db/mongo/wrapper/mocks"
 	"github.com/golang/mock/gomock"
@@ -38,11 +39,11 @@ func TestCalledConnectWithEmptyURL_ExpectErrorReturn(t *testing.T) {
 	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(invalidUrl).Return(&dummySession, connectionError),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), invalidUrl).Return(&dummySession, connectionError),
 	)
 	mgoDial = connectionMockObj
 
-	_, err := connect(invalidUrl)
+	_, err := connect(context.Background(), invalidUrl)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "UnknownError", "nil")
@@ -62,11 +63,11 @@ func TestCalledConnectWithValidURL_ExpectSuccess(t *testing.T) {
 	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(&dummySession, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(&dummySession, nil),
 	)
 	mgoDial = connectionMockObj
 
-	_, err := connect(validUrl)
+	_, err := connect(context.Background(), validUrl)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -115,7 +116,7 @@ func TestCalledCreateGroup_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Insert(gomock.Any()).Return(nil),
@@ -124,7 +125,7 @@ func TestCalledCreateGroup_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	_, err := executor.CreateGroup(groupName)
+	_, err := executor.CreateGroup(context.Background(), groupName)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -141,7 +142,7 @@ func TestCalledCreateGroupWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Insert(gomock.Any()).Return(mgo.ErrNotFound),
@@ -150,7 +151,7 @@ func TestCalledCreateGroupWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	_, err := executor.CreateGroup(groupName)
+	_, err := executor.CreateGroup(context.Background(), groupName)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -182,7 +183,7 @@ func TestCalledGetGroup_ExpectSuccess(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -192,7 +193,7 @@ func TestCalledGetGroup_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	res, err := executor.GetGroup(groupId)
+	res, err := executor.GetGroup(context.Background(), groupId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -211,13 +212,13 @@ func TestCalledGetGroupWithInvalidObjectId_ExpectErrorReturn(t *testing.T) {
 	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().Close(),
 	)
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	_, err := executor.GetGroup(invalidObjectId)
+	_, err := executor.GetGroup(context.Background(), invalidObjectId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", invalidObjectError.Error(), "nil")
@@ -241,7 +242,7 @@ func TestCalledGetGroupWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -251,7 +252,7 @@ func TestCalledGetGroupWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	_, err := executor.GetGroup(groupId)
+	_, err := executor.GetGroup(context.Background(), groupId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -282,7 +283,7 @@ func TestCalledGetGroups_ExpectSuccess(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(nil).Return(queryMockObj),
@@ -292,7 +293,7 @@ func TestCalledGetGroups_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	res, err := executor.GetGroups()
+	res, err := executor.GetGroups(context.Background())
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -314,7 +315,7 @@ func TestCalledGetGroupsWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(nil).Return(queryMockObj),
@@ -324,7 +325,7 @@ func TestCalledGetGroupsWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	_, err := executor.GetGroups()
+	_, err := executor.GetGroups(context.Background())
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -350,7 +351,7 @@ func TestCalledJoinGroup_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Update(query, update).Return(nil),
@@ -359,7 +360,7 @@ func TestCalledJoinGroup_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.JoinGroup(groupId, nodeId)
+	err := executor.JoinGroup(context.Background(), groupId, nodeId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -374,13 +375,13 @@ func TestCalledJoinGroupWithInvalidObjectIdAboutGroup_ExpectErrorReturn(t *testi
 	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().Close(),
 	)
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.JoinGroup(invalidObjectId, nodeId)
+	err := executor.JoinGroup(context.Background(), invalidObjectId, nodeId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", invalidObjectError.Error(), "nil")
@@ -404,7 +405,7 @@ func TestCalledJoinGroupWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Update(query, update).Return(mgo.ErrNotFound),
@@ -413,7 +414,7 @@ func TestCalledJoinGroupWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.JoinGroup(groupId, nodeId)
+	err := executor.JoinGroup(context.Background(), groupId, nodeId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -439,7 +440,7 @@ func TestCalledLeaveGroup_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Update(query, update).Return(nil),
@@ -448,7 +449,7 @@ func TestCalledLeaveGroup_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.LeaveGroup(groupId, nodeId)
+	err := executor.LeaveGroup(context.Background(), groupId, nodeId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -463,13 +464,13 @@ func TestCalledLeaveGroupWithInvalidObjectIdAboutGroup_ExpectErrorReturn(t *test
 	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().Close(),
 	)
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.LeaveGroup(invalidObjectId, nodeId)
+	err := executor.LeaveGroup(context.Background(), invalidObjectId, nodeId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", invalidObjectError.Error(), "nil")
@@ -493,7 +494,7 @@ func TestCalledLeaveGroupWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Update(query, update).Return(mgo.ErrNotFound),
@@ -502,7 +503,7 @@ func TestCalledLeaveGroupWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.LeaveGroup(groupId, nodeId)
+	err := executor.LeaveGroup(context.Background(), groupId, nodeId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -545,20 +546,20 @@ func TestCalledGetGroupMembers_ExpectSuccess(t *testing.T) {
 	nodeMockObj := nodedbmocks.NewMockCommand(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(groupQuery).Return(queryMockObj),
 		queryMockObj.EXPECT().One(gomock.Any()).SetArg(0, groupArg).Return(nil),
 		sessionMockObj.EXPECT().Close(),
 
-		nodeMockObj.EXPECT().GetNode(nodeId).Return(expectedNodeRes, nil),
+		nodeMockObj.EXPECT().GetNode(gomock.Any(), nodeId).Return(expectedNodeRes, nil),
 	)
 
 	nodeExecutor = nodeMockObj
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	res, err := executor.GetGroupMembers(groupId)
+	res, err := executor.GetGroupMembers(context.Background(), groupId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -578,7 +579,7 @@ func TestCalledGetGroupMembersWithInvalidObjectId_ExpectErrorReturn(t *testing.T
 	defer mockCtrl.Finish()
 
 	executor := Executor{}
-	_, err := executor.GetGroupMembers(invalidObjectId)
+	_, err := executor.GetGroupMembers(context.Background(), invalidObjectId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", invalidObjectError.Error(), "nil")
@@ -619,21 +620,21 @@ func TestCalledGetGroupMembersByAppID_ExpectSuccess(t *testing.T) {
 	nodeMockObj := nodedbmocks.NewMockCommand(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(groupQuery).Return(queryMockObj),
 		queryMockObj.EXPECT().One(gomock.Any()).SetArg(0, groupArg).Return(nil),
 		sessionMockObj.EXPECT().Close(),
 
-		nodeMockObj.EXPECT().GetNodeByAppID(nodeId, appId).Return(expectedNodeRes, nil),
+		nodeMockObj.EXPECT().GetNodeByAppID(gomock.Any(), nodeId, appId).Return(expectedNodeRes, nil),
 	)
 
 	mgoDial = connectionMockObj
 	nodeExecutor = nodeMockObj
 
 	executor := Executor{}
-	res, err := executor.GetGroupMembersByAppID(groupId, appId)
+	res, err := executor.GetGroupMembersByAppID(context.Background(), groupId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -649,7 +650,7 @@ func TestCalledGetGroupMembersByAppIDWithInvalidObjectId_ExpectErrorReturn(t *te
 	defer mockCtrl.Finish()
 
 	executor := Executor{}
-	_, err := executor.GetGroupMembersByAppID(invalidObjectId, appId)
+	_, err := executor.GetGroupMembersByAppID(context.Background(), invalidObjectId, appId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "invalidObjectError", "nil")
@@ -674,7 +675,7 @@ func TestCalledDeleteGroup_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Remove(query).Return(nil),
@@ -683,7 +684,7 @@ func TestCalledDeleteGroup_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.DeleteGroup(groupId)
+	err := executor.DeleteGroup(context.Background(), groupId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -698,13 +699,13 @@ func TestCalledDeleteGroupWithInvalidObjectId_ExpectErrorReturn(t *testing.T) {
 	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().Close(),
 	)
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.DeleteGroup(invalidObjectId)
+	err := executor.DeleteGroup(context.Background(), invalidObjectId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "invalidObjectError", "nil")
@@ -729,7 +730,7 @@ func TestCalledDeleteGroupWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Remove(query).Return(mgo.ErrNotFound),
@@ -738,7 +739,7 @@ func TestCalledDeleteGroupWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.DeleteGroup(groupId)
+	err := executor.DeleteGroup(context.Background(), groupId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -4,6 +4,7 @@
 package mocks
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -32,102 +33,102 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // CreateGroup mocks base method
-func (m *MockCommand) CreateGroup(name string) (map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "CreateGroup", name)
+func (m *MockCommand) CreateGroup(ctx context.Context, name string) (map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "CreateGroup", ctx, name)
 	ret0, _ := ret[0].(map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateGroup indicates an expected call of CreateGroup
-func (mr *MockCommandMockRecorder) CreateGroup(name interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockCommand)(nil).CreateGroup), name)
+func (mr *MockCommandMockRecorder) CreateGroup(ctx, name interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockCommand)(nil).CreateGroup), ctx, name)
 }
 
 // GetGroup mocks base method
-func (m *MockCommand) GetGroup(groupId string) (map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetGroup", groupId)
+func (m *MockCommand) GetGroup(ctx context.Context, groupId string) (map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetGroup", ctx, groupId)
 	ret0, _ := ret[0].(map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetGroup indicates an expected call of GetGroup
-func (mr *MockCommandMockRecorder) GetGroup(groupId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockCommand)(nil).GetGroup), groupId)
+func (mr *MockCommandMockRecorder) GetGroup(ctx, groupId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockCommand)(nil).GetGroup), ctx, groupId)
 }
 
 // GetGroups mocks base method
-func (m *MockCommand) GetGroups() ([]map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetGroups")
+func (m *MockCommand) GetGroups(ctx context.Context) ([]map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetGroups", ctx)
 	ret0, _ := ret[0].([]map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetGroups indicates an expected call of GetGroups
-func (mr *MockCommandMockRecorder) GetGroups() *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroups", reflect.TypeOf((*MockCommand)(nil).GetGroups))
+func (mr *MockCommandMockRecorder) GetGroups(ctx interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroups", reflect.TypeOf((*MockCommand)(nil).GetGroups), ctx)
 }
 
 // GetGroupMembers mocks base method
-func (m *MockCommand) GetGroupMembers(groupId string) ([]map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetGroupMembers", groupId)
+func (m *MockCommand) GetGroupMembers(ctx context.Context, groupId string) ([]map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetGroupMembers", ctx, groupId)
 	ret0, _ := ret[0].([]map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetGroupMembers indicates an expected call of GetGroupMembers
-func (mr *MockCommandMockRecorder) GetGroupMembers(groupId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupMembers", reflect.TypeOf((*MockCommand)(nil).GetGroupMembers), groupId)
+func (mr *MockCommandMockRecorder) GetGroupMembers(ctx, groupId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupMembers", reflect.TypeOf((*MockCommand)(nil).GetGroupMembers), ctx, groupId)
 }
 
 // GetGroupMembersByAppID mocks base method
-func (m *MockCommand) GetGroupMembersByAppID(groupId, appId string) ([]map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetGroupMembersByAppID", groupId, appId)
+func (m *MockCommand) GetGroupMembersByAppID(ctx context.Context, groupId, appId string) ([]map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetGroupMembersByAppID", ctx, groupId, appId)
 	ret0, _ := ret[0].([]map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetGroupMembersByAppID indicates an expected call of GetGroupMembersByAppID
-func (mr *MockCommandMockRecorder) GetGroupMembersByAppID(groupId, appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupMembersByAppID", reflect.TypeOf((*MockCommand)(nil).GetGroupMembersByAppID), groupId, appId)
+func (mr *MockCommandMockRecorder) GetGroupMembersByAppID(ctx, groupId, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupMembersByAppID", reflect.TypeOf((*MockCommand)(nil).GetGroupMembersByAppID), ctx, groupId, appId)
 }
 
 // JoinGroup mocks base method
-func (m *MockCommand) JoinGroup(groupId, nodeId string) error {
-	ret := m.ctrl.Call(m, "JoinGroup", groupId, nodeId)
+func (m *MockCommand) JoinGroup(ctx context.Context, groupId, nodeId string) error {
+	ret := m.ctrl.Call(m, "JoinGroup", ctx, groupId, nodeId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // JoinGroup indicates an expected call of JoinGroup
-func (mr *MockCommandMockRecorder) JoinGroup(groupId, nodeId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinGroup", reflect.TypeOf((*MockCommand)(nil).JoinGroup), groupId, nodeId)
+func (mr *MockCommandMockRecorder) JoinGroup(ctx, groupId, nodeId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinGroup", reflect.TypeOf((*MockCommand)(nil).JoinGroup), ctx, groupId, nodeId)
 }
 
 // LeaveGroup mocks base method
-func (m *MockCommand) LeaveGroup(groupId, nodeId string) error {
-	ret := m.ctrl.Call(m, "LeaveGroup", groupId, nodeId)
+func (m *MockCommand) LeaveGroup(ctx context.Context, groupId, nodeId string) error {
+	ret := m.ctrl.Call(m, "LeaveGroup", ctx, groupId, nodeId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // LeaveGroup indicates an expected call of LeaveGroup
-func (mr *MockCommandMockRecorder) LeaveGroup(groupId, nodeId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveGroup", reflect.TypeOf((*MockCommand)(nil).LeaveGroup), groupId, nodeId)
+func (mr *MockCommandMockRecorder) LeaveGroup(ctx, groupId, nodeId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveGroup", reflect.TypeOf((*MockCommand)(nil).LeaveGroup), ctx, groupId, nodeId)
 }
 
 // DeleteGroup mocks base method
-func (m *MockCommand) DeleteGroup(groupId string) error {
-	ret := m.ctrl.Call(m, "DeleteGroup", groupId)
+func (m *MockCommand) DeleteGroup(ctx context.Context, groupId string) error {
+	ret := m.ctrl.Call(m, "DeleteGroup", ctx, groupId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteGroup indicates an expected call of DeleteGroup
-func (mr *MockCommandMockRecorder) DeleteGroup(groupId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockCommand)(nil).DeleteGroup), groupId)
+func (mr *MockCommandMockRecorder) DeleteGroup(ctx, groupId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockCommand)(nil).DeleteGroup), ctx, groupId)
 }
@@ -0,0 +1,64 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package health
+
+import (
+	"commons/errors"
+	"commons/logger"
+	"context"
+	. "db/mongo/wrapper"
+	"time"
+)
+
+type Command interface {
+	// GetVersion connects to the database server and returns its version.
+	GetVersion(ctx context.Context) (string, error)
+}
+
+const (
+	DB_URL          = "127.0.0.1:27017"
+	CONNECT_TIMEOUT = 2 * time.Second
+)
+
+type Executor struct{}
+
+var mgoDial Connection
+
+func init() {
+	mgoDial = MongoDial{}
+}
+
+// GetVersion checks connectivity to the database server within CONNECT_TIMEOUT,
+// so that a readiness check does not hang while the server is down.
+// If successful, this function returns the version of the server and an error as nil.
+// otherwise, an appropriate error will be returned.
+func (Executor) GetVersion(ctx context.Context) (string, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
+
+	session, err := mgoDial.DialWithTimeout(ctx, DB_URL, CONNECT_TIMEOUT)
+	if err != nil {
+		return "", errors.DBConnectionError{err.Error()}
+	}
+	defer session.Close()
+
+	version, err := session.Version()
+	if err != nil {
+		return "", ConvertMongoError(err)
+	}
+	return version, nil
+}
@@ -0,0 +1,75 @@
+/*******************************************************************************
+ * Copyright 2017 Samsung Electronics All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ *******************************************************************************/
+package health
+
+import (
+	"commons/errors"
+	"context"
+	mgomocks "db/mongo/wrapper/mocks"
+	"github.com/golang/mock/gomock"
+	"reflect"
+	"testing"
+)
+
+const validUrl = "127.0.0.1:27017"
+
+func TestCalledGetVersion_ExpectVersionReturned(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().DialWithTimeout(gomock.Any(), validUrl, CONNECT_TIMEOUT).Return(sessionMockObj, nil),
+		sessionMockObj.EXPECT().Version().Return("3.4.4", nil),
+		sessionMockObj.EXPECT().Close(),
+	)
+
+	mgoDial = connectionMockObj
+
+	version, err := Executor{}.GetVersion(context.Background())
+
+	if err != nil {
+		t.Errorf("Unexpected err: %s", err.Error())
+	}
+	if version != "3.4.4" {
+		t.Errorf("Expected version: 3.4.4, actual version: %s", version)
+	}
+}
+
+func TestCalledGetVersionWhenDBConnectionFailed_ExpectErrorReturn(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
+
+	gomock.InOrder(
+		connectionMockObj.EXPECT().DialWithTimeout(gomock.Any(), validUrl, CONNECT_TIMEOUT).Return(nil, errors.DBConnectionError{}),
+	)
+
+	mgoDial = connectionMockObj
+
+	_, err := Executor{}.GetVersion(context.Background())
+
+	if err == nil {
+		t.Fatal("Expected error, actual nil")
+	}
+	if reflect.TypeOf(err).Name() != "DBConnectionError" {
+		t.Errorf("Expected err: DBConnectionError, actual err: %s", reflect.TypeOf(err).Name())
+	}
+}
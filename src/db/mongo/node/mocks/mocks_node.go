@@ -4,6 +4,7 @@
 package mocks
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -32,70 +33,70 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // AddNode mocks base method
-func (m *MockCommand) AddNode(id, ip, status string, config map[string]interface{}, apps []string) (map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "AddNode", id, ip, status, config, apps)
+func (m *MockCommand) AddNode(ctx context.Context, id, ip, status string, config map[string]interface{}, apps []string) (map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "AddNode", ctx, id, ip, status, config, apps)
 	ret0, _ := ret[0].(map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddNode indicates an expected call of AddNode
-func (mr *MockCommandMockRecorder) AddNode(id, ip, status, config, apps interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNode", reflect.TypeOf((*MockCommand)(nil).AddNode), id, ip, status, config, apps)
+func (mr *MockCommandMockRecorder) AddNode(ctx, id, ip, status, config, apps interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNode", reflect.TypeOf((*MockCommand)(nil).AddNode), ctx, id, ip, status, config, apps)
 }
 
 // UpdateNodeAddress mocks base method
-func (m *MockCommand) UpdateNodeAddress(nodeId, host, port string) error {
-	ret := m.ctrl.Call(m, "UpdateNodeAddress", nodeId, host, port)
+func (m *MockCommand) UpdateNodeAddress(ctx context.Context, nodeId, host, port string) error {
+	ret := m.ctrl.Call(m, "UpdateNodeAddress", ctx, nodeId, host, port)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateNodeAddress indicates an expected call of UpdateNodeAddress
-func (mr *MockCommandMockRecorder) UpdateNodeAddress(nodeId, host, port interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodeAddress", reflect.TypeOf((*MockCommand)(nil).UpdateNodeAddress), nodeId, host, port)
+func (mr *MockCommandMockRecorder) UpdateNodeAddress(ctx, nodeId, host, port interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodeAddress", reflect.TypeOf((*MockCommand)(nil).UpdateNodeAddress), ctx, nodeId, host, port)
 }
 
 // UpdateNodeStatus mocks base method
-func (m *MockCommand) UpdateNodeStatus(nodeId, status string) error {
-	ret := m.ctrl.Call(m, "UpdateNodeStatus", nodeId, status)
+func (m *MockCommand) UpdateNodeStatus(ctx context.Context, nodeId, status string) error {
+	ret := m.ctrl.Call(m, "UpdateNodeStatus", ctx, nodeId, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateNodeStatus indicates an expected call of UpdateNodeStatus
-func (mr *MockCommandMockRecorder) UpdateNodeStatus(nodeId, status interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodeStatus", reflect.TypeOf((*MockCommand)(nil).UpdateNodeStatus), nodeId, status)
+func (mr *MockCommandMockRecorder) UpdateNodeStatus(ctx, nodeId, status interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodeStatus", reflect.TypeOf((*MockCommand)(nil).UpdateNodeStatus), ctx, nodeId, status)
 }
 
 // UpdateNodeConfiguration mocks base method
-func (m *MockCommand) UpdateNodeConfiguration(nodeId string, config map[string]interface{}) error {
-	ret := m.ctrl.Call(m, "UpdateNodeConfiguration", nodeId, config)
+func (m *MockCommand) UpdateNodeConfiguration(ctx context.Context, nodeId string, config map[string]interface{}) error {
+	ret := m.ctrl.Call(m, "UpdateNodeConfiguration", ctx, nodeId, config)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateNodeConfiguration indicates an expected call of UpdateNodeConfiguration
-func (mr *MockCommandMockRecorder) UpdateNodeConfiguration(nodeId, config interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodeConfiguration", reflect.TypeOf((*MockCommand)(nil).UpdateNodeConfiguration), nodeId, config)
+func (mr *MockCommandMockRecorder) UpdateNodeConfiguration(ctx, nodeId, config interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodeConfiguration", reflect.TypeOf((*MockCommand)(nil).UpdateNodeConfiguration), ctx, nodeId, config)
 }
 
 // GetNode mocks base method
-func (m *MockCommand) GetNode(nodeId string) (map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetNode", nodeId)
+func (m *MockCommand) GetNode(ctx context.Context, nodeId string) (map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetNode", ctx, nodeId)
 	ret0, _ := ret[0].(map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetNode indicates an expected call of GetNode
-func (mr *MockCommandMockRecorder) GetNode(nodeId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNode", reflect.TypeOf((*MockCommand)(nil).GetNode), nodeId)
+func (mr *MockCommandMockRecorder) GetNode(ctx, nodeId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNode", reflect.TypeOf((*MockCommand)(nil).GetNode), ctx, nodeId)
 }
 
 // GetNodes mocks base method
-func (m *MockCommand) GetNodes(queryOptional ...map[string]interface{}) ([]map[string]interface{}, error) {
-	varargs := []interface{}{}
+func (m *MockCommand) GetNodes(ctx context.Context, queryOptional ...map[string]interface{}) ([]map[string]interface{}, error) {
+	varargs := []interface{}{ctx}
 	for _, a := range queryOptional {
 		varargs = append(varargs, a)
 	}
@@ -106,68 +107,69 @@ func (m *MockCommand) GetNodes(queryOptional ...map[string]interface{}) ([]map[s
 }
 
 // GetNodes indicates an expected call of GetNodes
-func (mr *MockCommandMockRecorder) GetNodes(queryOptional ...interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodes", reflect.TypeOf((*MockCommand)(nil).GetNodes), queryOptional...)
+func (mr *MockCommandMockRecorder) GetNodes(ctx interface{}, queryOptional ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{ctx}, queryOptional...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodes", reflect.TypeOf((*MockCommand)(nil).GetNodes), varargs...)
 }
 
 // GetNodeByAppID mocks base method
-func (m *MockCommand) GetNodeByAppID(nodeId, appId string) (map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetNodeByAppID", nodeId, appId)
+func (m *MockCommand) GetNodeByAppID(ctx context.Context, nodeId, appId string) (map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetNodeByAppID", ctx, nodeId, appId)
 	ret0, _ := ret[0].(map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetNodeByAppID indicates an expected call of GetNodeByAppID
-func (mr *MockCommandMockRecorder) GetNodeByAppID(nodeId, appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeByAppID", reflect.TypeOf((*MockCommand)(nil).GetNodeByAppID), nodeId, appId)
+func (mr *MockCommandMockRecorder) GetNodeByAppID(ctx, nodeId, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeByAppID", reflect.TypeOf((*MockCommand)(nil).GetNodeByAppID), ctx, nodeId, appId)
 }
 
 // GetNodeByIP mocks base method
-func (m *MockCommand) GetNodeByIP(ip string) (map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetNodeByIP", ip)
+func (m *MockCommand) GetNodeByIP(ctx context.Context, ip string) (map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetNodeByIP", ctx, ip)
 	ret0, _ := ret[0].(map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetNodeByIP indicates an expected call of GetNodeByIP
-func (mr *MockCommandMockRecorder) GetNodeByIP(ip interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeByIP", reflect.TypeOf((*MockCommand)(nil).GetNodeByIP), ip)
+func (mr *MockCommandMockRecorder) GetNodeByIP(ctx, ip interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeByIP", reflect.TypeOf((*MockCommand)(nil).GetNodeByIP), ctx, ip)
 }
 
 // AddAppToNode mocks base method
-func (m *MockCommand) AddAppToNode(nodeId, appId string) error {
-	ret := m.ctrl.Call(m, "AddAppToNode", nodeId, appId)
+func (m *MockCommand) AddAppToNode(ctx context.Context, nodeId, appId string) error {
+	ret := m.ctrl.Call(m, "AddAppToNode", ctx, nodeId, appId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddAppToNode indicates an expected call of AddAppToNode
-func (mr *MockCommandMockRecorder) AddAppToNode(nodeId, appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAppToNode", reflect.TypeOf((*MockCommand)(nil).AddAppToNode), nodeId, appId)
+func (mr *MockCommandMockRecorder) AddAppToNode(ctx, nodeId, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAppToNode", reflect.TypeOf((*MockCommand)(nil).AddAppToNode), ctx, nodeId, appId)
 }
 
 // DeleteAppFromNode mocks base method
-func (m *MockCommand) DeleteAppFromNode(nodeId, appId string) error {
-	ret := m.ctrl.Call(m, "DeleteAppFromNode", nodeId, appId)
+func (m *MockCommand) DeleteAppFromNode(ctx context.Context, nodeId, appId string) error {
+	ret := m.ctrl.Call(m, "DeleteAppFromNode", ctx, nodeId, appId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteAppFromNode indicates an expected call of DeleteAppFromNode
-func (mr *MockCommandMockRecorder) DeleteAppFromNode(nodeId, appId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAppFromNode", reflect.TypeOf((*MockCommand)(nil).DeleteAppFromNode), nodeId, appId)
+func (mr *MockCommandMockRecorder) DeleteAppFromNode(ctx, nodeId, appId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAppFromNode", reflect.TypeOf((*MockCommand)(nil).DeleteAppFromNode), ctx, nodeId, appId)
 }
 
 // DeleteNode mocks base method
-func (m *MockCommand) DeleteNode(nodeId string) error {
-	ret := m.ctrl.Call(m, "DeleteNode", nodeId)
+func (m *MockCommand) DeleteNode(ctx context.Context, nodeId string) error {
+	ret := m.ctrl.Call(m, "DeleteNode", ctx, nodeId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteNode indicates an expected call of DeleteNode
-func (mr *MockCommandMockRecorder) DeleteNode(nodeId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNode", reflect.TypeOf((*MockCommand)(nil).DeleteNode), nodeId)
+func (mr *MockCommandMockRecorder) DeleteNode(ctx, nodeId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNode", reflect.TypeOf((*MockCommand)(nil).DeleteNode), ctx, nodeId)
 }
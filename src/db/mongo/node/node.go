@@ -19,43 +19,44 @@ package node
 import (
 	"commons/errors"
 	"commons/logger"
+	"context"
 	. "db/mongo/wrapper"
 	"gopkg.in/mgo.v2/bson"
 )
 
 type Command interface {
 	// AddNode insert new Node.
-	AddNode(id, ip, status string, config map[string]interface{}, apps []string) (map[string]interface{}, error)
+	AddNode(ctx context.Context, id, ip, status string, config map[string]interface{}, apps []string) (map[string]interface{}, error)
 
 	// UpdateNodeAddress updates ip,port of node from db related to node.
-	UpdateNodeAddress(nodeId string, host string, port string) error
+	UpdateNodeAddress(ctx context.Context, nodeId string, host string, port string) error
 
 	// UpdateNodeStatus updates status of node from db related to node.
-	UpdateNodeStatus(nodeId string, status string) error
+	UpdateNodeStatus(ctx context.Context, nodeId string, status string) error
 
 	// UpdateNodeConfiguration updates configuration information of node from db related to node.
-	UpdateNodeConfiguration(nodeId string, config map[string]interface{}) error
+	UpdateNodeConfiguration(ctx context.Context, nodeId string, config map[string]interface{}) error
 
 	// GetNode returns single document from db related to node.
-	GetNode(nodeId string) (map[string]interface{}, error)
+	GetNode(ctx context.Context, nodeId string) (map[string]interface{}, error)
 
 	// GetNodes returns all matches for the query-string which is passed in call to function.
-	GetNodes(queryOptional ...map[string]interface{}) ([]map[string]interface{}, error)
+	GetNodes(ctx context.Context, queryOptional ...map[string]interface{}) ([]map[string]interface{}, error)
 
 	// GetNodeByAppID returns single document including specific app.
-	GetNodeByAppID(nodeId string, appId string) (map[string]interface{}, error)
+	GetNodeByAppID(ctx context.Context, nodeId string, appId string) (map[string]interface{}, error)
 
 	// GetNodeByIP returns single document from db related to node.
-	GetNodeByIP(ip string) (map[string]interface{}, error)
+	GetNodeByIP(ctx context.Context, ip string) (map[string]interface{}, error)
 
 	// AddAppToNode add specific app to the target node.
-	AddAppToNode(nodeId string, appId string) error
+	AddAppToNode(ctx context.Context, nodeId string, appId string) error
 
 	// DeleteAppFromNode delete specific app from the target node.
-	DeleteAppFromNode(nodeId string, appId string) error
+	DeleteAppFromNode(ctx context.Context, nodeId string, appId string) error
 
 	// DeleteNode delete single document from db related to node.
-	DeleteNode(nodeId string) error
+	DeleteNode(ctx context.Context, nodeId string) error
 }
 
 const (
@@ -83,9 +84,9 @@ func init() {
 // Try to connect with mongo db server.
 // if succeed to connect with mongo db server, return error as nil,
 // otherwise, return error.
-func connect(url string) (Session, error) {
+func connect(ctx context.Context, url string) (Session, error) {
 	// Create a MongoDB Session
-	session, err := mgoDial.Dial(url)
+	session, err := mgoDial.Dial(ctx, url)
 
 	if err != nil {
 		return nil, ConvertMongoError(err, "")
@@ -119,11 +120,11 @@ func (node Node) convertToMap() map[string]interface{} {
 // AddNode inserts new node to 'node' collection.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) AddNode(id, ip, status string, config map[string]interface{}, apps []string) (map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) AddNode(ctx context.Context, id, ip, status string, config map[string]interface{}, apps []string) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -169,11 +170,11 @@ func (Executor) AddNode(id, ip, status string, config map[string]interface{}, ap
 // UpdateNodeAddress updates ip,port of node specified by nodeId parameter.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) UpdateNodeAddress(nodeId string, host string, port string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) UpdateNodeAddress(ctx context.Context, nodeId string, host string, port string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -191,11 +192,11 @@ func (Executor) UpdateNodeAddress(nodeId string, host string, port string) error
 // UpdateNodeStatus updates status of node specified by nodeId parameter.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) UpdateNodeStatus(nodeId string, status string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) UpdateNodeStatus(ctx context.Context, nodeId string, status string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -210,11 +211,11 @@ func (Executor) UpdateNodeStatus(nodeId string, status string) error {
 	return err
 }
 
-func (Executor) UpdateNodeConfiguration(nodeId string, config map[string]interface{}) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) UpdateNodeConfiguration(ctx context.Context, nodeId string, config map[string]interface{}) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -232,11 +233,11 @@ func (Executor) UpdateNodeConfiguration(nodeId string, config map[string]interfa
 // GetNode returns single document specified by nodeId parameter.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) GetNode(nodeId string) (map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetNode(ctx context.Context, nodeId string) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -256,11 +257,11 @@ func (Executor) GetNode(nodeId string) (map[string]interface{}, error) {
 // GetNodes returns all documents from 'node' collection.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) GetNodes(queryOptional ...map[string]interface{}) ([]map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetNodes(ctx context.Context, queryOptional ...map[string]interface{}) ([]map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -291,11 +292,11 @@ func (Executor) GetNodes(queryOptional ...map[string]interface{}) ([]map[string]
 // If successful, this function returns an error as nil.
 // But if the target node does not include the given appId,
 // an appropriate error will be returned.
-func (Executor) GetNodeByAppID(nodeId string, appId string) (map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetNodeByAppID(ctx context.Context, nodeId string, appId string) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -316,11 +317,11 @@ func (Executor) GetNodeByAppID(nodeId string, appId string) (map[string]interfac
 // If successful, this function returns an error as nil.
 // But if the target node does not include the given appId,
 // an appropriate error will be returned.
-func (Executor) GetNodeByIP(ip string) (map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetNodeByIP(ctx context.Context, ip string) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -340,11 +341,11 @@ func (Executor) GetNodeByIP(ip string) (map[string]interface{}, error) {
 // AddAppToNode adds the specific app to the target node.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) AddAppToNode(nodeId string, appId string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) AddAppToNode(ctx context.Context, nodeId string, appId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -362,11 +363,11 @@ func (Executor) AddAppToNode(nodeId string, appId string) error {
 // DeleteAppFromNode deletes the specific app from the target node.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) DeleteAppFromNode(nodeId string, appId string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) DeleteAppFromNode(ctx context.Context, nodeId string, appId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -384,11 +385,11 @@ func (Executor) DeleteAppFromNode(nodeId string, appId string) error {
 // DeleteNode deletes single document from 'node' collection.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) DeleteNode(nodeId string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) DeleteNode(ctx context.Context, nodeId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
@@ -18,6 +18,7 @@ package node
 
 import (
 	errors "commons/errors"
+	"context"
 	mgomocks "db/mongo/wrapper/mocks"
 	"github.com/golang/mock/gomock"
 	"gopkg.in/mgo.v2"
@@ -62,11 +63,11 @@ func TestCalledConnectWithEmptyURL_ExpectErrorReturn(t *testing.T) {
 	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(invalidUrl).Return(&dummySession, connectionError),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), invalidUrl).Return(&dummySession, connectionError),
 	)
 	mgoDial = connectionMockObj
 
-	_, err := connect(invalidUrl)
+	_, err := connect(context.Background(), invalidUrl)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "UnknownError", "nil")
@@ -86,11 +87,11 @@ func TestCalledConnectWithValidURL_ExpectSuccess(t *testing.T) {
 	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(&dummySession, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(&dummySession, nil),
 	)
 	mgoDial = connectionMockObj
 
-	_, err := connect(validUrl)
+	_, err := connect(context.Background(), validUrl)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -143,7 +144,7 @@ func TestCalledAddNode_ExpectSuccess(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(dbName).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -157,7 +158,7 @@ func TestCalledAddNode_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 	executor := Executor{}
 
-	_, err := executor.AddNode(nodeId, ip, status, configuration, []string{})
+	_, err := executor.AddNode(context.Background(), nodeId, ip, status, configuration, []string{})
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -178,7 +179,7 @@ func TestCalledAddNodeWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(dbName).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -191,7 +192,7 @@ func TestCalledAddNodeWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	_, err := executor.AddNode(nodeId, ip, status, configuration, []string{})
+	_, err := executor.AddNode(context.Background(), nodeId, ip, status, configuration, []string{})
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -217,7 +218,7 @@ func TestCalledUpdateNodeAddress_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Update(query, update).Return(nil),
@@ -226,7 +227,7 @@ func TestCalledUpdateNodeAddress_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.UpdateNodeAddress(nodeId, "192.168.0.1", "48098")
+	err := executor.UpdateNodeAddress(context.Background(), nodeId, "192.168.0.1", "48098")
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -246,7 +247,7 @@ func TestCalledUpdateNodeAddressWhenDBReturnsError_ExpectErrorReturn(t *testing.
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Update(query, update).Return(mgo.ErrNotFound),
@@ -255,7 +256,7 @@ func TestCalledUpdateNodeAddressWhenDBReturnsError_ExpectErrorReturn(t *testing.
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.UpdateNodeAddress(nodeId, "192.168.0.1", "48098")
+	err := executor.UpdateNodeAddress(context.Background(), nodeId, "192.168.0.1", "48098")
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -281,7 +282,7 @@ func TestCalledUpdateNodeStatus_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Update(query, update).Return(nil),
@@ -290,7 +291,7 @@ func TestCalledUpdateNodeStatus_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.UpdateNodeStatus(nodeId, "connected")
+	err := executor.UpdateNodeStatus(context.Background(), nodeId, "connected")
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -310,7 +311,7 @@ func TestCalledUpdateNodeStatusWhenDBReturnsError_ExpectErrorReturn(t *testing.T
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Update(query, update).Return(mgo.ErrNotFound),
@@ -319,7 +320,7 @@ func TestCalledUpdateNodeStatusWhenDBReturnsError_ExpectErrorReturn(t *testing.T
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.UpdateNodeStatus(nodeId, "connected")
+	err := executor.UpdateNodeStatus(context.Background(), nodeId, "connected")
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -353,7 +354,7 @@ func TestCalledGetNode_ExpectSuccess(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -363,7 +364,7 @@ func TestCalledGetNode_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	res, err := executor.GetNode(nodeId)
+	res, err := executor.GetNode(context.Background(), nodeId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -387,7 +388,7 @@ func TestCalledGetNodeWhenDBHasNotMatchedNode_ExpectErrorReturn(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -397,7 +398,7 @@ func TestCalledGetNodeWhenDBHasNotMatchedNode_ExpectErrorReturn(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	_, err := executor.GetNode(nodeId)
+	_, err := executor.GetNode(context.Background(), nodeId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -430,7 +431,7 @@ func TestCalledGetNodes_ExpectSuccess(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(nil).Return(queryMockObj),
@@ -440,7 +441,7 @@ func TestCalledGetNodes_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	res, err := executor.GetNodes()
+	res, err := executor.GetNodes(context.Background())
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -462,7 +463,7 @@ func TestCalledGetNodesWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(nil).Return(queryMockObj),
@@ -472,7 +473,7 @@ func TestCalledGetNodesWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	_, err := executor.GetNodes()
+	_, err := executor.GetNodes(context.Background())
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -506,7 +507,7 @@ func TestCalledGetNodeByAppID_ExpectSuccess(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -516,7 +517,7 @@ func TestCalledGetNodeByAppID_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	res, err := executor.GetNodeByAppID(nodeId, appId)
+	res, err := executor.GetNodeByAppID(context.Background(), nodeId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -540,7 +541,7 @@ func TestCalledGetNodeByAppIDWhenDBHasNotMatchedNode_ExpectErrorReturn(t *testin
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -550,7 +551,7 @@ func TestCalledGetNodeByAppIDWhenDBHasNotMatchedNode_ExpectErrorReturn(t *testin
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	_, err := executor.GetNodeByAppID(nodeId, appId)
+	_, err := executor.GetNodeByAppID(context.Background(), nodeId, appId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -577,7 +578,7 @@ func TestCalledGetNodeByIPWhenDBHasMatchedNode_ExpectSuccess(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -587,7 +588,7 @@ func TestCalledGetNodeByIPWhenDBHasMatchedNode_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	_, err := executor.GetNodeByIP(ip)
+	_, err := executor.GetNodeByIP(context.Background(), ip)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -607,7 +608,7 @@ func TestCalledAddAppToNode_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Update(query, update).Return(nil),
@@ -616,7 +617,7 @@ func TestCalledAddAppToNode_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.AddAppToNode(nodeId, appId)
+	err := executor.AddAppToNode(context.Background(), nodeId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -636,7 +637,7 @@ func TestCalledAddAppToNodeWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Update(query, update).Return(mgo.ErrNotFound),
@@ -645,7 +646,7 @@ func TestCalledAddAppToNodeWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.AddAppToNode(nodeId, appId)
+	err := executor.AddAppToNode(context.Background(), nodeId, appId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -671,7 +672,7 @@ func TestCalledDeleteAppFromNode_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Update(query, update).Return(nil),
@@ -680,7 +681,7 @@ func TestCalledDeleteAppFromNode_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.DeleteAppFromNode(nodeId, appId)
+	err := executor.DeleteAppFromNode(context.Background(), nodeId, appId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -700,7 +701,7 @@ func TestCalledDeleteAppFromNodeWhenDBReturnsError_ExpectErrorReturn(t *testing.
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Update(query, update).Return(mgo.ErrNotFound),
@@ -709,7 +710,7 @@ func TestCalledDeleteAppFromNodeWhenDBReturnsError_ExpectErrorReturn(t *testing.
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.DeleteAppFromNode(nodeId, appId)
+	err := executor.DeleteAppFromNode(context.Background(), nodeId, appId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -734,7 +735,7 @@ func TestCalledDeleteNode_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Remove(query).Return(nil),
@@ -743,7 +744,7 @@ func TestCalledDeleteNode_ExpectSuccess(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.DeleteNode(nodeId)
+	err := executor.DeleteNode(context.Background(), nodeId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -762,7 +763,7 @@ func TestCalledDeleteNodeWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Remove(query).Return(mgo.ErrNotFound),
@@ -771,7 +772,7 @@ func TestCalledDeleteNodeWhenDBReturnsError_ExpectErrorReturn(t *testing.T) {
 
 	mgoDial = connectionMockObj
 	executor := Executor{}
-	err := executor.DeleteNode(nodeId)
+	err := executor.DeleteNode(context.Background(), nodeId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -4,6 +4,7 @@
 package mocks
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -32,39 +33,39 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // AddDockerRegistry mocks base method
-func (m *MockCommand) AddDockerRegistry(url string) (map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "AddDockerRegistry", url)
+func (m *MockCommand) AddDockerRegistry(ctx context.Context, url string) (map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "AddDockerRegistry", ctx, url)
 	ret0, _ := ret[0].(map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddDockerRegistry indicates an expected call of AddDockerRegistry
-func (mr *MockCommandMockRecorder) AddDockerRegistry(url interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDockerRegistry", reflect.TypeOf((*MockCommand)(nil).AddDockerRegistry), url)
+func (mr *MockCommandMockRecorder) AddDockerRegistry(ctx, url interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDockerRegistry", reflect.TypeOf((*MockCommand)(nil).AddDockerRegistry), ctx, url)
 }
 
 // GetDockerRegistries mocks base method
-func (m *MockCommand) GetDockerRegistries() ([]map[string]interface{}, error) {
-	ret := m.ctrl.Call(m, "GetDockerRegistries")
+func (m *MockCommand) GetDockerRegistries(ctx context.Context) ([]map[string]interface{}, error) {
+	ret := m.ctrl.Call(m, "GetDockerRegistries", ctx)
 	ret0, _ := ret[0].([]map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDockerRegistries indicates an expected call of GetDockerRegistries
-func (mr *MockCommandMockRecorder) GetDockerRegistries() *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDockerRegistries", reflect.TypeOf((*MockCommand)(nil).GetDockerRegistries))
+func (mr *MockCommandMockRecorder) GetDockerRegistries(ctx interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDockerRegistries", reflect.TypeOf((*MockCommand)(nil).GetDockerRegistries), ctx)
 }
 
 // DeleteDockerRegistry mocks base method
-func (m *MockCommand) DeleteDockerRegistry(registryId string) error {
-	ret := m.ctrl.Call(m, "DeleteDockerRegistry", registryId)
+func (m *MockCommand) DeleteDockerRegistry(ctx context.Context, registryId string) error {
+	ret := m.ctrl.Call(m, "DeleteDockerRegistry", ctx, registryId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteDockerRegistry indicates an expected call of DeleteDockerRegistry
-func (mr *MockCommandMockRecorder) DeleteDockerRegistry(registryId interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDockerRegistry", reflect.TypeOf((*MockCommand)(nil).DeleteDockerRegistry), registryId)
+func (mr *MockCommandMockRecorder) DeleteDockerRegistry(ctx, registryId interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDockerRegistry", reflect.TypeOf((*MockCommand)(nil).DeleteDockerRegistry), ctx, registryId)
 }
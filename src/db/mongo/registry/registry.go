@@ -19,19 +19,20 @@ package registry
 import (
 	"commons/errors"
 	"commons/logger"
+	"context"
 	. "db/mongo/wrapper"
 	"gopkg.in/mgo.v2/bson"
 )
 
 type Command interface {
 	// AddDockerRegistry insert a new docker registry information.
-	AddDockerRegistry(url string) (map[string]interface{}, error)
+	AddDockerRegistry(ctx context.Context, url string) (map[string]interface{}, error)
 
 	// GetDockerRegistries returns all documents from db related to docker registry.
-	GetDockerRegistries() ([]map[string]interface{}, error)
+	GetDockerRegistries(ctx context.Context) ([]map[string]interface{}, error)
 
 	// DeleteDockerRegistry delete a specific docker registry information from db related to registry.
-	DeleteDockerRegistry(registryId string) error
+	DeleteDockerRegistry(ctx context.Context, registryId string) error
 }
 
 const (
@@ -56,9 +57,9 @@ func init() {
 // Try to connect with mongo db server.
 // if succeed to connect with mongo db server, return error as nil,
 // otherwise, return error.
-func connect(url string) (Session, error) {
+func connect(ctx context.Context, url string) (Session, error) {
 	// Create a MongoDB Session
-	session, err := mgoDial.Dial(url)
+	session, err := mgoDial.Dial(ctx, url)
 
 	if err != nil {
 		return nil, ConvertMongoError(err, "")
@@ -89,11 +90,11 @@ func (registry Registry) convertToMap() map[string]interface{} {
 // AddDockerRegistry insert a new docker registry information to 'registry' collection.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) AddDockerRegistry(url string) (map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) AddDockerRegistry(ctx context.Context, url string) (map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -117,11 +118,11 @@ func (Executor) AddDockerRegistry(url string) (map[string]interface{}, error) {
 // GetDockerRegistries returns all documents.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) GetDockerRegistries() ([]map[string]interface{}, error) {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) GetDockerRegistries(ctx context.Context) ([]map[string]interface{}, error) {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return nil, err
 	}
@@ -145,11 +146,11 @@ func (Executor) GetDockerRegistries() ([]map[string]interface{}, error) {
 // DeleteDockerRegistry delete a single document from 'registry' collection.
 // If successful, this function returns an error as nil.
 // otherwise, an appropriate error will be returned.
-func (Executor) DeleteDockerRegistry(registryId string) error {
-	logger.Logging(logger.DEBUG, "IN")
-	defer logger.Logging(logger.DEBUG, "OUT")
+func (Executor) DeleteDockerRegistry(ctx context.Context, registryId string) error {
+	logger.With(ctx).Logging(logger.DEBUG, "IN")
+	defer logger.With(ctx).Logging(logger.DEBUG, "OUT")
 
-	session, err := connect(DB_URL)
+	session, err := connect(ctx, DB_URL)
 	if err != nil {
 		return err
 	}
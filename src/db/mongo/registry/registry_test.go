@@ -18,6 +18,7 @@ package registry
 
 import (
 	errors "commons/errors"
+	"context"
 	mgomocks "db/mongo/wrapper/mocks"
 	"github.com/golang/mock/gomock"
 	"gopkg.in/mgo.v2"
@@ -59,11 +60,11 @@ func TestCalledConnectWithEmptyURL_ExpectErrorReturn(t *testing.T) {
 	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(invalidUrl).Return(&dummySession, connectionError),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), invalidUrl).Return(&dummySession, connectionError),
 	)
 	mgoDial = connectionMockObj
 
-	_, err := connect(invalidUrl)
+	_, err := connect(context.Background(), invalidUrl)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "UnknownError", "nil")
@@ -83,11 +84,11 @@ func TestCalledConnectWithValidURL_ExpectSuccess(t *testing.T) {
 	connectionMockObj := mgomocks.NewMockConnection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(&dummySession, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(&dummySession, nil),
 	)
 	mgoDial = connectionMockObj
 
-	_, err := connect(validUrl)
+	_, err := connect(context.Background(), validUrl)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -136,7 +137,7 @@ func TestCalledAddDockerRegistry_ExpectSuccess(t *testing.T) {
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(dbName).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Insert(gomock.Any()).Return(nil),
@@ -146,7 +147,7 @@ func TestCalledAddDockerRegistry_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 	Executor := Executor{}
 
-	_, err := Executor.AddDockerRegistry(registryUrl)
+	_, err := Executor.AddDockerRegistry(context.Background(), registryUrl)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -163,7 +164,7 @@ func TestCalledAddDockerRegistryWhenDBReturnsError_ExpectErrorReturn(t *testing.
 	collectionMockObj := mgomocks.NewMockCollection(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Insert(gomock.Any()).Return(mgo.ErrNotFound),
@@ -173,7 +174,7 @@ func TestCalledAddDockerRegistryWhenDBReturnsError_ExpectErrorReturn(t *testing.
 	mgoDial = connectionMockObj
 	Executor := Executor{}
 
-	_, err := Executor.AddDockerRegistry(registryUrl)
+	_, err := Executor.AddDockerRegistry(context.Background(), registryUrl)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -203,7 +204,7 @@ func TestCalledGetDockerRegistries_ExpectSuccess(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(nil).Return(queryMockObj),
@@ -214,7 +215,7 @@ func TestCalledGetDockerRegistries_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 
 	Executor := Executor{}
-	res, err := Executor.GetDockerRegistries()
+	res, err := Executor.GetDockerRegistries(context.Background())
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -236,7 +237,7 @@ func TestCalledGetDockerRegistriesWhenDBReturnsError_ExpectErrorReturn(t *testin
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(nil).Return(queryMockObj),
@@ -247,7 +248,7 @@ func TestCalledGetDockerRegistriesWhenDBReturnsError_ExpectErrorReturn(t *testin
 	mgoDial = connectionMockObj
 
 	Executor := Executor{}
-	_, err := Executor.GetDockerRegistries()
+	_, err := Executor.GetDockerRegistries(context.Background())
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
@@ -274,7 +275,7 @@ func TestCalledDeleteDockerRegistry_ExpectSuccess(t *testing.T) {
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -288,7 +289,7 @@ func TestCalledDeleteDockerRegistry_ExpectSuccess(t *testing.T) {
 	mgoDial = connectionMockObj
 
 	Executor := Executor{}
-	err := Executor.DeleteDockerRegistry(registryId)
+	err := Executor.DeleteDockerRegistry(context.Background(), registryId)
 
 	if err != nil {
 		t.Errorf("Unexpected err: %s", err.Error())
@@ -303,14 +304,14 @@ func TestCalledDeleteDockerRegistryWithInvalidObjectId_ExpectErrorReturn(t *test
 	sessionMockObj := mgomocks.NewMockSession(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().Close(),
 	)
 
 	mgoDial = connectionMockObj
 
 	Executor := Executor{}
-	err := Executor.DeleteDockerRegistry(invalidObjectId)
+	err := Executor.DeleteDockerRegistry(context.Background(), invalidObjectId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", invalidObjectError.Error(), "nil")
@@ -334,7 +335,7 @@ func TestCalledDeleteDockerRegistryWhenDBReturnsError_ExpectErrorReturn(t *testi
 	queryMockObj := mgomocks.NewMockQuery(mockCtrl)
 
 	gomock.InOrder(
-		connectionMockObj.EXPECT().Dial(validUrl).Return(sessionMockObj, nil),
+		connectionMockObj.EXPECT().Dial(gomock.Any(), validUrl).Return(sessionMockObj, nil),
 		sessionMockObj.EXPECT().DB(gomock.Any()).Return(dbMockObj),
 		dbMockObj.EXPECT().C(gomock.Any()).Return(collectionMockObj),
 		collectionMockObj.EXPECT().Find(query).Return(queryMockObj),
@@ -345,7 +346,7 @@ func TestCalledDeleteDockerRegistryWhenDBReturnsError_ExpectErrorReturn(t *testi
 	mgoDial = connectionMockObj
 
 	Executor := Executor{}
-	err := Executor.DeleteDockerRegistry(registryId)
+	err := Executor.DeleteDockerRegistry(context.Background(), registryId)
 
 	if err == nil {
 		t.Errorf("Expected err: %s, actual err: %s", "NotFound", "nil")
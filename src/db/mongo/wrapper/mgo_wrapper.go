@@ -18,21 +18,31 @@ package wrapper
 
 import (
 	"commons/errors"
+	"commons/metrics"
+	"commons/tracing"
+	"context"
 	"gopkg.in/mgo.v2"
+	"time"
 )
 
+var operationDuration = metrics.NewHistogram("anchor_db_operation_duration_seconds",
+	"Latency of database operations.", nil, "collection", "operation")
+
 type (
 	Session interface {
 		DB(name string) Database
+		Version() (string, error)
 		Close()
 	}
 
 	MongoSession struct {
 		Session *mgo.Session
+		ctx     context.Context // context of operations, whose spans are traced as children of its span.
 	}
 
 	Connection interface {
-		Dial(url string) (Session, error)
+		Dial(ctx context.Context, url string) (Session, error)
+		DialWithTimeout(ctx context.Context, url string, timeout time.Duration) (Session, error)
 	}
 
 	MongoDial struct{}
@@ -43,31 +53,48 @@ type (
 
 	MongoDatabase struct {
 		Database *mgo.Database
+		ctx      context.Context
 	}
 
 	Collection interface {
 		Find(query interface{}) Query
 		Insert(docs ...interface{}) error
 		Remove(selector interface{}) error
+		RemoveAll(selector interface{}) (int, error)
 		Update(selector interface{}, update interface{}) error
 	}
 
 	MongoCollection struct {
 		Collection *mgo.Collection
+		ctx        context.Context
 	}
 
 	Query interface {
 		All(result interface{}) error
 		One(result interface{}) error
+		Sort(fields ...string) Query
+		Limit(n int) Query
+		Distinct(key string, result interface{}) error
 	}
 
 	MongoQuery struct {
-		Query *mgo.Query
+		Query      *mgo.Query
+		collection string
+		ctx        context.Context
 	}
 )
 
 func (s MongoSession) DB(name string) Database {
-	return &MongoDatabase{Database: s.Session.DB(name)}
+	return &MongoDatabase{Database: s.Session.DB(name), ctx: s.ctx}
+}
+
+// Version returns the version of the database server.
+func (s MongoSession) Version() (string, error) {
+	info, err := s.Session.BuildInfo()
+	if err != nil {
+		return "", err
+	}
+	return info.Version, nil
 }
 
 func (s MongoSession) Close() {
@@ -75,44 +102,124 @@ func (s MongoSession) Close() {
 }
 
 // Dial is a wrapper function used to abstract mgo Dial function.
-func (MongoDial) Dial(url string) (Session, error) {
+// Operations of the session are traced with the span carried by ctx.
+func (MongoDial) Dial(ctx context.Context, url string) (Session, error) {
 	session, err := mgo.Dial(url)
-	return MongoSession{Session: session}, err
+	return MongoSession{Session: session, ctx: ctx}, err
+}
+
+// DialWithTimeout is a wrapper function used to abstract mgo DialWithTimeout function.
+// Operations of the session are traced with the span carried by ctx.
+func (MongoDial) DialWithTimeout(ctx context.Context, url string, timeout time.Duration) (Session, error) {
+	session, err := mgo.DialWithTimeout(url, timeout)
+	return MongoSession{Session: session, ctx: ctx}, err
 }
 
 // C is a wrapper function used to abstract mgo C function.
 func (d MongoDatabase) C(name string) Collection {
-	return &MongoCollection{Collection: d.Database.C(name)}
+	return &MongoCollection{Collection: d.Database.C(name), ctx: d.ctx}
 }
 
 // Find is a wrapper function used to abstract mgo Find function.
 func (c MongoCollection) Find(query interface{}) Query {
-	return MongoQuery{Query: c.Collection.Find(query)}
+	return MongoQuery{Query: c.Collection.Find(query), collection: c.Collection.Name, ctx: c.ctx}
 }
 
 // Insert is a wrapper function used to abstract mgo Insert function.
 func (c MongoCollection) Insert(docs ...interface{}) error {
-	return c.Collection.Insert(docs...)
+	defer operationDuration.ObserveSince(time.Now(), c.Collection.Name, "insert")
+	span := startSpan(c.ctx, c.Collection.Name, "insert")
+	err := c.Collection.Insert(docs...)
+	finishSpan(span, err)
+	return err
 }
 
 // Remove is a wrapper function used to abstract mgo Remove function.
 func (c MongoCollection) Remove(selector interface{}) error {
-	return c.Collection.Remove(selector)
+	defer operationDuration.ObserveSince(time.Now(), c.Collection.Name, "remove")
+	span := startSpan(c.ctx, c.Collection.Name, "remove")
+	err := c.Collection.Remove(selector)
+	finishSpan(span, err)
+	return err
+}
+
+// RemoveAll is a wrapper function used to abstract mgo RemoveAll function.
+// It returns the number of removed documents.
+func (c MongoCollection) RemoveAll(selector interface{}) (int, error) {
+	defer operationDuration.ObserveSince(time.Now(), c.Collection.Name, "remove")
+	span := startSpan(c.ctx, c.Collection.Name, "remove")
+	info, err := c.Collection.RemoveAll(selector)
+	finishSpan(span, err)
+	if err != nil {
+		return 0, err
+	}
+	return info.Removed, nil
 }
 
 // Update is a wrapper function used to abstract mgo Update function.
 func (c MongoCollection) Update(selector interface{}, update interface{}) error {
-	return c.Collection.Update(selector, update)
+	defer operationDuration.ObserveSince(time.Now(), c.Collection.Name, "update")
+	span := startSpan(c.ctx, c.Collection.Name, "update")
+	err := c.Collection.Update(selector, update)
+	finishSpan(span, err)
+	return err
 }
 
 // All is a wrapper function used to abstract mgo All function.
+// Queries are sent to the database when the results are read, so find
+// operations are measured and traced here rather than in Find.
 func (q MongoQuery) All(result interface{}) error {
-	return q.Query.All(result)
+	defer operationDuration.ObserveSince(time.Now(), q.collection, "find")
+	span := startSpan(q.ctx, q.collection, "find")
+	err := q.Query.All(result)
+	finishSpan(span, err)
+	return err
+}
+
+// Sort is a wrapper function used to abstract mgo Sort function.
+func (q MongoQuery) Sort(fields ...string) Query {
+	return MongoQuery{Query: q.Query.Sort(fields...), collection: q.collection, ctx: q.ctx}
+}
+
+// Limit is a wrapper function used to abstract mgo Limit function.
+func (q MongoQuery) Limit(n int) Query {
+	return MongoQuery{Query: q.Query.Limit(n), collection: q.collection, ctx: q.ctx}
+}
+
+// Distinct is a wrapper function used to abstract mgo Distinct function.
+func (q MongoQuery) Distinct(key string, result interface{}) error {
+	defer operationDuration.ObserveSince(time.Now(), q.collection, "distinct")
+	span := startSpan(q.ctx, q.collection, "distinct")
+	err := q.Query.Distinct(key, result)
+	finishSpan(span, err)
+	return err
 }
 
 // One is a wrapper function used to abstract mgo One function.
 func (q MongoQuery) One(result interface{}) error {
-	return q.Query.One(result)
+	defer operationDuration.ObserveSince(time.Now(), q.collection, "find")
+	span := startSpan(q.ctx, q.collection, "find")
+	err := q.Query.One(result)
+	finishSpan(span, err)
+	return err
+}
+
+// startSpan starts a span of an operation on collection
+// if ctx is of a traced request.
+func startSpan(ctx context.Context, collection string, operation string) *tracing.Span {
+	span := tracing.Start(tracing.FromContext(ctx), "mongo "+collection+"."+operation, tracing.KIND_CLIENT)
+	span.SetAttribute("db.system", "mongodb")
+	span.SetAttribute("db.mongodb.collection", collection)
+	span.SetAttribute("db.operation", operation)
+	return span
+}
+
+// finishSpan ends span, which is marked as failed if err is not ErrNotFound.
+func finishSpan(span *tracing.Span, err error) {
+	if err != nil && err != mgo.ErrNotFound {
+		span.SetError(err)
+	}
+	span.Finish()
 }
 
 // ConvertMongoError converts a mongo error into an error defined in errors package.
@@ -4,8 +4,10 @@
 package mock_wrapper
 
 import (
+	context "context"
 	. "db/mongo/wrapper"
 	gomock "github.com/golang/mock/gomock"
+	time "time"
 )
 
 // Mock of Session interface
@@ -39,6 +41,17 @@ func (_mr *_MockSessionRecorder) DB(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DB", arg0)
 }
 
+func (_m *MockSession) Version() (string, error) {
+	ret := _m.ctrl.Call(_m, "Version")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockSessionRecorder) Version() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Version")
+}
+
 func (_m *MockSession) Close() {
 	_m.ctrl.Call(_m, "Close")
 }
@@ -68,15 +81,26 @@ func (_m *MockConnection) EXPECT() *_MockConnectionRecorder {
 	return _m.recorder
 }
 
-func (_m *MockConnection) Dial(url string) (Session, error) {
-	ret := _m.ctrl.Call(_m, "Dial", url)
+func (_m *MockConnection) Dial(ctx context.Context, url string) (Session, error) {
+	ret := _m.ctrl.Call(_m, "Dial", ctx, url)
+	ret0, _ := ret[0].(Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockConnectionRecorder) Dial(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Dial", arg0, arg1)
+}
+
+func (_m *MockConnection) DialWithTimeout(ctx context.Context, url string, timeout time.Duration) (Session, error) {
+	ret := _m.ctrl.Call(_m, "DialWithTimeout", ctx, url, timeout)
 	ret0, _ := ret[0].(Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (_mr *_MockConnectionRecorder) Dial(arg0 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "Dial", arg0)
+func (_mr *_MockConnectionRecorder) DialWithTimeout(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DialWithTimeout", arg0, arg1, arg2)
 }
 
 // Mock of Database interface
@@ -165,6 +189,17 @@ func (_mr *_MockCollectionRecorder) Remove(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Remove", arg0)
 }
 
+func (_m *MockCollection) RemoveAll(selector interface{}) (int, error) {
+	ret := _m.ctrl.Call(_m, "RemoveAll", selector)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCollectionRecorder) RemoveAll(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveAll", arg0)
+}
+
 func (_m *MockCollection) Update(selector interface{}, update interface{}) error {
 	ret := _m.ctrl.Call(_m, "Update", selector, update)
 	ret0, _ := ret[0].(error)
@@ -215,3 +250,37 @@ func (_m *MockQuery) One(result interface{}) error {
 func (_mr *_MockQueryRecorder) One(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "One", arg0)
 }
+
+func (_m *MockQuery) Sort(fields ...string) Query {
+	_s := []interface{}{}
+	for _, _x := range fields {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "Sort", _s...)
+	ret0, _ := ret[0].(Query)
+	return ret0
+}
+
+func (_mr *_MockQueryRecorder) Sort(arg0 ...interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Sort", arg0...)
+}
+
+func (_m *MockQuery) Limit(n int) Query {
+	ret := _m.ctrl.Call(_m, "Limit", n)
+	ret0, _ := ret[0].(Query)
+	return ret0
+}
+
+func (_mr *_MockQueryRecorder) Limit(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Limit", arg0)
+}
+
+func (_m *MockQuery) Distinct(key string, result interface{}) error {
+	ret := _m.ctrl.Call(_m, "Distinct", key, result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockQueryRecorder) Distinct(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Distinct", arg0, arg1)
+}
@@ -19,10 +19,33 @@ package main
 import (
 	"api"
 	"commons/logger"
+	"commons/tracing"
+	nodemanager "controller/management/node"
+	"controller/monitoring/resource/history"
+	"controller/notification"
+	"controller/notification/delivery"
+	eventHistory "controller/notification/history"
 )
 
 func main() {
-	logger.Logging(logger.DEBUG, "Start Pharos Anchor")
+	if err := logger.ConfigureFromEnv(); err != nil {
+		logger.Logging(logger.ERROR, err.Error())
+	}
+	if err := tracing.ConfigureFromEnv(); err != nil {
+		logger.Logging(logger.ERROR, err.Error())
+	}
+	logger.Logging(logger.INFO, "Start Pharos Anchor")
+	notification.RemoveStaleStreams()
+	tracing.StartExporter(tracing.EXPORT_INTERVAL)
+	nodemanager.StartDriftDetector(nodemanager.DRIFT_CHECK_INTERVAL, true)
+	history.StartCollector(history.COLLECT_INTERVAL)
+	delivery.StartDispatcher(delivery.DISPATCH_INTERVAL)
+	notification.StartExpiryChecker(notification.EXPIRY_CHECK_INTERVAL)
+	retention, err := eventHistory.RetentionFromEnv()
+	if err != nil {
+		logger.Logging(logger.ERROR, err.Error())
+	}
+	eventHistory.StartPruner(eventHistory.PRUNE_INTERVAL, retention)
 	api.RunWebServer("0.0.0.0", 48099)
-	logger.Logging(logger.DEBUG, "Stop Pharos Anchor")
+	logger.Logging(logger.INFO, "Stop Pharos Anchor")
 }
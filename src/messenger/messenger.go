@@ -20,24 +20,48 @@ package messenger
 import (
 	"bytes"
 	"commons/logger"
+	"commons/metrics"
+	"commons/tracing"
+	"context"
+	"errors"
 	"net/http"
+	"net/url"
 	"sort"
 	"sync"
+	"time"
+)
+
+const (
+	RESULT_SUCCESS = "success"
+	RESULT_ERROR   = "error"
+)
+
+var (
+	requestDuration = metrics.NewHistogram("anchor_messenger_request_duration_seconds",
+		"Latency of requests sent to nodes.", nil, "node")
+	requestCount = metrics.NewCounter("anchor_messenger_requests_total",
+		"Number of requests sent to nodes, where a transport error or 5xx response is an error.", "node", "result")
 )
 
 type httpWrapper interface {
 	DoWrapper(req *http.Request) (*http.Response, error)
 }
 
-type httpClient struct{}
+type httpClient struct {
+	client *http.Client // client requests are sent with, or nil for DefaultClient.
+}
 
-// DoWrapper is a wrapper around DefaultClient.Do.
-func (httpClient) DoWrapper(req *http.Request) (*http.Response, error) {
-	return http.DefaultClient.Do(req)
+// DoWrapper is a wrapper around Do of the client, or DefaultClient.Do.
+func (c httpClient) DoWrapper(req *http.Request) (*http.Response, error) {
+	if c.client == nil {
+		return http.DefaultClient.Do(req)
+	}
+	return c.client.Do(req)
 }
 
 type Command interface {
-	SendHttpRequest(method string, urls []string, queries map[string]interface{}, dataOptional ...[]byte) ([]int, []string)
+	SendHttpRequest(ctx context.Context, method string, urls []string, queries map[string]interface{}, dataOptional ...[]byte) ([]int, []string)
+	SendHttpRequestWithHeader(ctx context.Context, method string, urls []string, header http.Header, queries map[string]interface{}, dataOptional ...[]byte) ([]int, []string)
 }
 
 type Executor struct {
@@ -50,6 +74,14 @@ func NewExecutor() *Executor {
 	}
 }
 
+// NewExecutorWithTimeout returns an executor whose requests fail unless
+// they are answered within timeout.
+func NewExecutorWithTimeout(timeout time.Duration) *Executor {
+	return &Executor{
+		client: httpClient{&http.Client{Timeout: timeout}},
+	}
+}
+
 // A httpResponse represents an HTTP response received from remote device.
 type httpResponse struct {
 	index int
@@ -74,15 +106,28 @@ func (arr sortRespSlice) Swap(i, j int) {
 	arr[i], arr[j] = arr[j], arr[i]
 }
 
-// sendHttpRequest creates a new request and sends it to target device.
-func (executor Executor) SendHttpRequest(method string, urls []string, queries map[string]interface{}, dataOptional ...[]byte) ([]int, []string) {
+// SendHttpRequest creates a new request and sends it to target device.
+// If ctx carries the span of a traced request, the requests are traced as
+// children of a span of the fan-out, and the trace is propagated to devices
+// in the traceparent header.
+func (executor Executor) SendHttpRequest(ctx context.Context, method string, urls []string, queries map[string]interface{}, dataOptional ...[]byte) ([]int, []string) {
+	return executor.SendHttpRequestWithHeader(ctx, method, urls, nil, queries, dataOptional...)
+}
+
+// SendHttpRequestWithHeader sends requests as SendHttpRequest with header
+// added to each of them.
+func (executor Executor) SendHttpRequestWithHeader(ctx context.Context, method string, urls []string, header http.Header, queries map[string]interface{}, dataOptional ...[]byte) ([]int, []string) {
+	fanout := tracing.Start(tracing.FromContext(ctx), "messenger "+method, tracing.KIND_INTERNAL)
+	fanout.SetAttribute("messenger.targets", len(urls))
+	defer fanout.Finish()
+
 	var wg sync.WaitGroup
 	wg.Add(len(urls))
 
 	respChannel := make(chan httpResponse, len(urls))
 	for i := range urls {
 		go func(idx int) {
-			logger.Logging(logger.DEBUG, "sending http request:", urls[idx])
+			logger.With(ctx).Logging(logger.DEBUG, "sending http request:", urls[idx])
 
 			var err error
 			var req *http.Request
@@ -109,13 +154,28 @@ func (executor Executor) SendHttpRequest(method string, urls []string, queries m
 					}
 				}
 				req.URL.RawQuery = query.Encode()
+				for key, values := range header {
+					for _, value := range values {
+						req.Header.Add(key, value)
+					}
+				}
 
+				span := tracing.Start(fanout, method+" "+req.URL.Host, tracing.KIND_CLIENT)
+				span.SetAttribute("http.method", method)
+				span.SetAttribute("http.url", req.URL.String())
+				if span != nil {
+					req.Header.Set(tracing.TRACEPARENT_HEADER, span.TraceParent())
+				}
+
+				start := time.Now()
 				resp.resp, err = executor.client.DoWrapper(req)
 				if err != nil {
 					resp.err = err.Error()
 				} else {
 					resp.err = ""
 				}
+				observeRequest(req.URL, resp, start)
+				finishSpan(span, resp)
 				respChannel <- resp
 			}
 			defer wg.Done()
@@ -131,6 +191,32 @@ func (executor Executor) SendHttpRequest(method string, urls []string, queries m
 	return changeToReturnValue(respList)
 }
 
+// observeRequest records the latency and result of a request per node.
+func observeRequest(target *url.URL, resp httpResponse, start time.Time) {
+	requestDuration.ObserveSince(start, target.Host)
+
+	result := RESULT_SUCCESS
+	if resp.resp == nil || resp.resp.StatusCode >= http.StatusInternalServerError {
+		result = RESULT_ERROR
+	}
+	requestCount.Inc(target.Host, result)
+}
+
+// finishSpan ends the span of a request, which is marked as failed
+// on a transport error or 5xx response.
+func finishSpan(span *tracing.Span, resp httpResponse) {
+	switch {
+	case resp.resp == nil:
+		span.SetError(errors.New(resp.err))
+	case resp.resp.StatusCode >= http.StatusInternalServerError:
+		span.SetAttribute("http.status_code", resp.resp.StatusCode)
+		span.SetError(errors.New(http.StatusText(resp.resp.StatusCode)))
+	default:
+		span.SetAttribute("http.status_code", resp.resp.StatusCode)
+	}
+	span.Finish()
+}
+
 // changeToReturnValue parses a response code and body from httpResponse structure.
 func changeToReturnValue(respList []httpResponse) (respCode []int, respBody []string) {
 	var buf bytes.Buffer
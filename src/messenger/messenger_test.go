@@ -18,12 +18,18 @@ package messenger
 
 import (
 	"bytes"
+	"commons/metrics"
+	"commons/tracing"
+	"context"
 	"errors"
 	"github.com/golang/mock/gomock"
 	"io/ioutil"
 	msgmocks "messenger/mocks"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestCalledSendHttpRequestWithoutData_ExpectSuccess(t *testing.T) {
@@ -42,7 +48,7 @@ func TestCalledSendHttpRequestWithoutData_ExpectSuccess(t *testing.T) {
 	messengerObj.client = httpMockObj
 
 	testUrls := []string{"/test/url", "/test/url"}
-	messengerObj.SendHttpRequest("POST", testUrls, nil)
+	messengerObj.SendHttpRequest(context.Background(), "POST", testUrls, nil)
 }
 
 func TestCalledSendHttpRequestWithData_ExpectSuccess(t *testing.T) {
@@ -61,7 +67,7 @@ func TestCalledSendHttpRequestWithData_ExpectSuccess(t *testing.T) {
 	messengerObj.client = httpMockObj
 
 	testUrls := []string{"/test/url", "/test/url"}
-	messengerObj.SendHttpRequest("POST", testUrls, nil)
+	messengerObj.SendHttpRequest(context.Background(), "POST", testUrls, nil)
 }
 
 func TestCalledSendHttpRequestWhenFailedToSendHttpRequest_ExpectErrorReturn(t *testing.T) {
@@ -78,5 +84,124 @@ func TestCalledSendHttpRequestWhenFailedToSendHttpRequest_ExpectErrorReturn(t *t
 	messengerObj.client = httpMockObj
 
 	testUrls := []string{"/test/url", "/test/url"}
-	messengerObj.SendHttpRequest("POST", testUrls, nil)
+	messengerObj.SendHttpRequest(context.Background(), "POST", testUrls, nil)
+}
+
+func TestCalledSendHttpRequest_ExpectResultCountedPerNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	httpMockObj := msgmocks.NewMockhttpWrapper(ctrl)
+
+	gomock.InOrder(
+		httpMockObj.EXPECT().DoWrapper(gomock.Any()).Return(&http.Response{
+			StatusCode: 503,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(""))}, nil),
+	)
+
+	messengerObj := NewExecutor()
+	messengerObj.client = httpMockObj
+
+	testUrls := []string{"http://192.168.0.1:48098/api/v1/ping"}
+	messengerObj.SendHttpRequest(context.Background(), "POST", testUrls, nil)
+
+	var buf bytes.Buffer
+	metrics.Write(&buf)
+
+	expected := `anchor_messenger_requests_total{node="192.168.0.1:48098",result="error"} 1`
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("Expected metric: %s, actual metrics: %s", expected, buf.String())
+	}
+}
+
+type recordingExporter struct {
+	spans []*tracing.Span
+}
+
+func (e *recordingExporter) Export(spans []*tracing.Span) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func TestCalledSendHttpRequestInTracedRequest_ExpectTraceParentPropagated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	exporter := &recordingExporter{}
+	tracing.SetExporters(exporter)
+	defer tracing.SetExporters()
+
+	parent := tracing.StartTrace("", "request", tracing.KIND_SERVER)
+	ctx := tracing.NewContext(context.Background(), parent)
+
+	httpMockObj := msgmocks.NewMockhttpWrapper(ctrl)
+
+	var traceparent string
+	gomock.InOrder(
+		httpMockObj.EXPECT().DoWrapper(gomock.Any()).Do(func(req *http.Request) {
+			traceparent = req.Header.Get(tracing.TRACEPARENT_HEADER)
+		}).Return(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(""))}, nil),
+	)
+
+	messengerObj := NewExecutor()
+	messengerObj.client = httpMockObj
+
+	testUrls := []string{"http://192.168.0.1:48098/api/v1/ping"}
+	messengerObj.SendHttpRequest(ctx, "POST", testUrls, nil)
+	tracing.Flush()
+
+	traceId, spanId, ok := tracing.ParseTraceParent(traceparent)
+	if !ok || traceId != parent.TraceID {
+		t.Errorf("Expected traceparent of trace %s, actual %s", parent.TraceID, traceparent)
+	}
+
+	// a span of the request and a span of the fan-out.
+	if len(exporter.spans) != 2 {
+		t.Fatalf("Expected 2 spans, actual %d", len(exporter.spans))
+	}
+	request, fanout := exporter.spans[0], exporter.spans[1]
+	if request.SpanID != spanId || request.ParentID != fanout.SpanID || fanout.ParentID != parent.SpanID {
+		t.Errorf("Unexpected hierarchy of spans: %v, %v", request, fanout)
+	}
+}
+
+func TestCalledSendHttpRequestWithHeader_ExpectHeaderSent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	httpMockObj := msgmocks.NewMockhttpWrapper(ctrl)
+
+	httpMockObj.EXPECT().DoWrapper(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("X-Test") != "value" {
+			t.Errorf("Expected header X-Test: value, actual header: %v", req.Header)
+		}
+		return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewBufferString(""))}, nil
+	})
+
+	messengerObj := NewExecutor()
+	messengerObj.client = httpMockObj
+
+	header := http.Header{}
+	header.Set("X-Test", "value")
+	codes, _ := messengerObj.SendHttpRequestWithHeader(context.Background(), "POST", []string{"http://test/url"}, header, nil, []byte("data"))
+	if codes[0] != 200 {
+		t.Errorf("Expected code: 200, actual code: %d", codes[0])
+	}
+}
+
+func TestCalledSendHttpRequestWithTimeoutWhenNotAnswered_ExpectErrorReturn(t *testing.T) {
+	release := make(chan bool)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	executor := NewExecutorWithTimeout(10 * time.Millisecond)
+	codes, _ := executor.SendHttpRequest(context.Background(), "POST", []string{server.URL}, nil)
+	if codes[0] != 500 {
+		t.Errorf("Expected code: 500, actual code: %d", codes[0])
+	}
 }
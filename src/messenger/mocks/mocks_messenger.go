@@ -21,6 +21,7 @@
 package mocks
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	http "net/http"
 	reflect "reflect"
@@ -86,8 +87,8 @@ func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
 }
 
 // SendHttpRequest mocks base method
-func (m *MockCommand) SendHttpRequest(method string, urls []string, queries map[string]interface{}, dataOptional ...[]byte) ([]int, []string) {
-	varargs := []interface{}{method, urls, queries}
+func (m *MockCommand) SendHttpRequest(ctx context.Context, method string, urls []string, queries map[string]interface{}, dataOptional ...[]byte) ([]int, []string) {
+	varargs := []interface{}{ctx, method, urls, queries}
 	for _, a := range dataOptional {
 		varargs = append(varargs, a)
 	}
@@ -98,7 +99,25 @@ func (m *MockCommand) SendHttpRequest(method string, urls []string, queries map[
 }
 
 // SendHttpRequest indicates an expected call of SendHttpRequest
-func (mr *MockCommandMockRecorder) SendHttpRequest(method, urls, queries interface{}, dataOptional ...interface{}) *gomock.Call {
-	varargs := append([]interface{}{method, urls, queries}, dataOptional...)
+func (mr *MockCommandMockRecorder) SendHttpRequest(ctx, method, urls, queries interface{}, dataOptional ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{ctx, method, urls, queries}, dataOptional...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHttpRequest", reflect.TypeOf((*MockCommand)(nil).SendHttpRequest), varargs...)
 }
+
+// SendHttpRequestWithHeader mocks base method
+func (m *MockCommand) SendHttpRequestWithHeader(ctx context.Context, method string, urls []string, header http.Header, queries map[string]interface{}, dataOptional ...[]byte) ([]int, []string) {
+	varargs := []interface{}{ctx, method, urls, header, queries}
+	for _, a := range dataOptional {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SendHttpRequestWithHeader", varargs...)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].([]string)
+	return ret0, ret1
+}
+
+// SendHttpRequestWithHeader indicates an expected call of SendHttpRequestWithHeader
+func (mr *MockCommandMockRecorder) SendHttpRequestWithHeader(ctx, method, urls, header, queries interface{}, dataOptional ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{ctx, method, urls, header, queries}, dataOptional...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHttpRequestWithHeader", reflect.TypeOf((*MockCommand)(nil).SendHttpRequestWithHeader), varargs...)
+}